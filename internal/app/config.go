@@ -21,6 +21,13 @@ type Config struct {
 	// because this will give you a performance gain (due to the server will not check of packages number and them ordering).
 	// Otherwise, if your data needs to be in safe, and you cannot afford to lose it, use the TCP.
 	StreamingTransport string `env:"STREAMING_SERVER_TRANSPORT_PROTOCOL" envDefault:"tcp" opts:"tcp,udp"`
+	// StreamingMaxBufferedAhead is a max. number of seconds which the server may send ahead of the client playback
+	// position when the flow control mode is requested by the client (the client acknowledges consumed chunks
+	// or reports its buffered-ahead seconds). By default, it's 30 seconds.
+	StreamingMaxBufferedAhead float64 `env:"STREAMING_MAX_BUFFERED_AHEAD" envDefault:"30"`
+	// StreamingTokenCheckInterval is an interval of checking the token of running stream was not revoked (logout),
+	// the revoked stream is interrupted. The expiration of token doesn't interrupt the started stream.
+	StreamingTokenCheckInterval string `env:"STREAMING_TOKEN_CHECK_INTERVAL" envDefault:"30s"`
 	// >>> DATABASE <<<
 	// MongoUri is a simple MongoDb DSN string for connect to database.
 	MongoUri string `env:"MONGO_URI" envDefault:"mongodb://mongodb:27017/streaming"`
@@ -32,9 +39,8 @@ type Config struct {
 	PasswordHashCost int `env:"PASSWORD_HASH_COST" envDefault:"10"`
 	// JwtSecretSalt is a secret string which further will convert to slice of bytes and will be provided
 	// as a salt for signature the jwt tokens.
-	// If this variable an empty or omitted then will be generated random salt which is alive while application instance
-	// is running (note: you cannot get access to this value, and it will not be provided anywhere as an output. If
-	// you need access to this value, and you need to share this value, then sat up your own secret string).
+	// It's required by the HMAC algos (HS*), the application is not started without it. All instances must have
+	// the same value, otherwise the tokens which were issued by one instance are rejected by the others.
 	JwtSecretSalt string `env:"JWT_SECRET_SALT" envDefault:""`
 	// JwtTokenIssuer is an issuer of JWT token. This variable helps determine which service issued the token
 	// (commonly used for verify that token was created by service of your system, for example,
@@ -43,10 +49,31 @@ type Config struct {
 	// JwtTokenAcceptedIssuers is a string with another JwtTokenIssuer values separated by delimiter. This values will
 	// be accepted while token payload verification.
 	JwtTokenAcceptedIssuers string `env:"JWT_TOKEN_ACCEPTED_ISSUERS" envDefault:"auth_service,streaming_service"`
-	// JwtTokenExpiresAfter is a value which defined TTL of token in seconds. Default: `86400` (1 day).
-	JwtTokenExpiresAfter int64 `env:"JWT_TOKEN_EXPIRES_AFTER" envDefault:"86400"`
+	// JwtTokenExpiresAfter is a value which defined TTL of access token in seconds. Default: `900` (15 minutes).
+	// The access token is short-lived, the client takes a new one by the refresh token.
+	JwtTokenExpiresAfter int64 `env:"JWT_TOKEN_EXPIRES_AFTER" envDefault:"900"`
+	// JwtRefreshTokenExpiresAfter is a value which defined TTL of refresh token in seconds. Default: `2592000` (30 days).
+	// Each refresh rotates the token, so it's an inactivity period after which the user must log in again.
+	JwtRefreshTokenExpiresAfter int64 `env:"JWT_REFRESH_TOKEN_EXPIRES_AFTER" envDefault:"2592000"`
 	// JwtTokenEncryptAlgo is a value which will be used as encrypt algo for encode the token.
-	JwtTokenEncryptAlgo string `env:"JWT_TOKEN_ENCRYPT_ALGO" envDefault:"HS256" opts:"HS256,HS384,HS512"`
+	// The HMAC algos (HS*) sign the token by JwtSecretSalt, the asymmetric ones (RS256, ES256, EdDSA) sign it
+	// by the private key from JwtKeysDir, so the other services may verify tokens by the public keys (JWKS).
+	JwtTokenEncryptAlgo string `env:"JWT_TOKEN_ENCRYPT_ALGO" envDefault:"HS256" opts:"HS256,HS384,HS512,RS256,ES256,EdDSA"`
+	// JwtKeysDir is a directory with PEM encoded keys of the asymmetric algo, the filename without the `.pem`
+	// extension is a key ID (`kid`). The private keys sign tokens, the public ones (`<kid>.pub.pem`) only verify
+	// tokens which were signed by the removed private key, which is useful while the keys rotation.
+	JwtKeysDir string `env:"JWT_KEYS_DIR" envDefault:""`
+	// JwtSigningKeyID is an ID of the private key into JwtKeysDir which signs new tokens.
+	// If this variable an empty or omitted then the last private key by the name order will be used.
+	JwtSigningKeyID string `env:"JWT_SIGNING_KEY_ID" envDefault:""`
+	// BlockedTokensPollInterval is an interval of loading the tokens and sessions which were blocked by the other
+	// instances, it's used only when the MongoDb change streams are not available (standalone server), otherwise
+	// the blocked tokens are received by the change stream immediately.
+	BlockedTokensPollInterval string `env:"BLOCKED_TOKENS_POLL_INTERVAL" envDefault:"10s"`
+	// BlockedTokensCacheSize is a max. number of the blocked tokens and sessions which are kept in memory of instance.
+	// The least recently checked ones are evicted when the cache is full, the misses are confirmed by the database
+	// until the evicted ones are expired. Zero disables the cache.
+	BlockedTokensCacheSize int `env:"BLOCKED_TOKENS_CACHE_SIZE" envDefault:"100000"`
 	// ResourceUploadingStrategy is an uploading strategy which will be used for upload files on the server.
 	// 	1. 'muiltipart_form' is a strategy which used builtin sugar approach. It will be parsing a whole file into the
 	//		memory (if a file more than ResourceInMemoryFileSizeThreshold, it will be saved on the disk, otherwise, it will be
@@ -57,7 +84,9 @@ type Config struct {
 	//	the 'muiltipart_form' approach and increase the value of ResourceInMemoryFileSizeThreshold variable.
 	//	Otherwise, use 'muiltipart_part' because it takes a much lower RAM per file uploading.
 	//	For example: for upload the file which weight is 50mb. it will take around 10mb. of your RAM.
-	ResourceUploadingStrategy string `env:"UPLOADER_TYPE" envDefault:"multipart_part" opts:"multipart_part,multipart_form"`
+	//	3. 'tus' is a resumable uploading by the tus 1.0 protocol. The file is received by chunks which are appended
+	//		to the stored part, so the interrupted uploading may be continued, even after the server restart.
+	ResourceUploadingStrategy string `env:"UPLOADER_TYPE" envDefault:"multipart_part" opts:"multipart_part,multipart_form,tus"`
 	// ResourceFormFilename is a value which will be used for extract a file from the form by given string.
 	// *Used only with the 'muiltipart_form' strategy because the 'muiltipart_part' will search the first form file.
 	//	Be careful and don't send more than one file per request in one form.
@@ -69,8 +98,36 @@ type Config struct {
 	// which may be loaded in the RAM. If file weight is more this value, than it will be loaded on the disk (slow op.).
 	// By default, it's 100mb per file.
 	ResourceInMemoryFileSizeThreshold int64 `env:"IN_MEMORY_FILE_SIZE_THRESHOLD" envDefault:"104857600"`
+	// ResourceUploadExpiration is a duration after which the not completed tus upload will be removed with the received
+	// part of the file. Each received chunk prolongs the upload. *Used only with the 'tus' strategy.
+	ResourceUploadExpiration string `env:"UPLOAD_EXPIRATION" envDefault:"24h"`
 	// AdminContactEmail is a target administrator contact email address for takes a users errors reports.
 	AdminContactEmail string `env:"ADMIN_CONTACT_EMAIL_ADDRESS" envDefault:"glazunov2142@gmail.com"`
+	// AdminEmails is a string with emails separated by comma, the registered users with them are promoted to the admins
+	// at startup (the registration never grants the role). The roles of the other users are changed by the admins.
+	AdminEmails string `env:"ADMIN_EMAILS" envDefault:""`
+	// >>> STORAGE <<<
+	// StorageType is a backend which will be used for store the resources files:
+	//	1. 'filesystem' is a strategy which stores the files into the local resources directory. The streaming nodes
+	//		must share the directory with the uploading ones.
+	//	2. 's3' is a strategy which stores the files into the S3-compatible object storage (AWS S3, MinIO and others),
+	//		so the streaming nodes are stateless. The files are read by ranges, the 'tus' uploading is not supported.
+	StorageType string `env:"STORAGE_TYPE" envDefault:"filesystem" opts:"filesystem,s3"`
+	// S3Endpoint is a URL of the S3-compatible API with the scheme, for example: 'https://s3.eu-central-1.amazonaws.com'.
+	S3Endpoint string `env:"S3_ENDPOINT" envDefault:"http://minio:9000"`
+	// S3Region is a region of the bucket which is used for sign the requests.
+	S3Region string `env:"S3_REGION" envDefault:"us-east-1"`
+	// S3Bucket is a name of the bucket which contains the resources files, it will be created if not exists.
+	S3Bucket string `env:"S3_BUCKET" envDefault:"resources"`
+	// S3AccessKey is an access key id of the object storage.
+	S3AccessKey string `env:"S3_ACCESS_KEY" envDefault:""`
+	// S3SecretKey is a secret access key of the object storage.
+	S3SecretKey string `env:"S3_SECRET_KEY" envDefault:""`
+	// S3UsePathStyle means the bucket is addressed by the path instead of the subdomain (MinIO requires it by default).
+	S3UsePathStyle bool `env:"S3_USE_PATH_STYLE" envDefault:"true"`
+	// S3PartSize is a size of one part in bytes while uploading the file by parts, each uploading file takes it in RAM.
+	// It cannot be less than 5mb. By default, it's 16mb.
+	S3PartSize int64 `env:"S3_PART_SIZE" envDefault:"16777216"`
 	// >>> API <<<
 	// ResourcesApiVersionPrefix is a value which will be used as your RestAPI controllers version prefix.
 	// For example: {{schema}}://{{host}}:{{port}}{{ResourcesApiVersionPrefix}}/{{additionalControllerPath}}
@@ -94,4 +151,46 @@ type Config struct {
 	// StreamingChunkSize is a value which means the size of one chunk while reading the file when streaming a resource.
 	// By default, it's 1mb.
 	StreamingChunkSize int `env:"FILE_READER_CHUNK_SIZE" envDefault:"1048576"`
+	// >>> SEGMENTER <<<
+	// SegmentTargetDuration is a desired duration of one segment in seconds which will be served through the HLS/DASH.
+	// Segments are cut only on the keyframes, so the real duration may be a little bit longer.
+	// By default, it's 6 seconds.
+	SegmentTargetDuration float64 `env:"SEGMENT_TARGET_DURATION" envDefault:"6"`
+	// >>> TRANSCODER <<<
+	// TranscoderType is a strategy which will be used for produce the video renditions:
+	//	1. 'ffmpeg' is a strategy which transcodes the resource by the ffmpeg binary (it must be available in the PATH).
+	//	2. 'fake' is a strategy which copies the original file and declares it as a rendition of the requested profile.
+	//		Use it only for tests and development, it does not require the ffmpeg.
+	// The same strategy is used for the previews: the 'fake' one draws the plain images instead of the video frames.
+	TranscoderType string `env:"TRANSCODER_TYPE" envDefault:"ffmpeg" opts:"ffmpeg,fake"`
+	// RenditionLadder is a list of the renditions profiles in format 'height:kbps' separated by comma which will be
+	// produced for each uploaded video, for example: '720:2800,480:1400,360:800'. The profiles which are not lower
+	// than the original resolution are skipped. By default, it's empty and only the original resource is served.
+	RenditionLadder []string `env:"RENDITION_LADDER" envSeparator:","`
+	// >>> PREVIEWS <<<
+	// StoryboardInterval is a number of seconds between the frames of the storyboard (the seek previews) sprite sheet.
+	// The interval is increased for the long videos, so the sheet has 100 tiles at most. By default, it's 10 seconds.
+	StoryboardInterval float64 `env:"STORYBOARD_INTERVAL" envDefault:"10"`
+	// StoryboardTileWidth is a width of the storyboard tile in pixels, the height is scaled proportionally.
+	StoryboardTileWidth int `env:"STORYBOARD_TILE_WIDTH" envDefault:"160"`
+	// >>> SUBTITLES <<<
+	// SubtitleFormFilename is a value which will be used for extract a subtitle file from the form by given string.
+	SubtitleFormFilename string `env:"SUBTITLE_FORM_FILENAME" envDefault:"subtitle"`
+	// SubtitleMaxFilesizeThreshold is a threshold value which means the max. weight of uploading subtitle file in bytes.
+	// The subtitle files are always parsed in the RAM. By default, it's 5mb per file.
+	SubtitleMaxFilesizeThreshold int64 `env:"MAX_SUBTITLE_FILESIZE" envDefault:"5242880"`
+	// >>> JOBS <<<
+	// JobWorkers is a number of workers which process the background jobs (for example, fragmentation
+	// of the uploaded resources). By default, it's 2 workers per application instance.
+	JobWorkers int `env:"JOB_WORKERS" envDefault:"2"`
+	// JobMaxAttempts is a max. number of attempts of processing one job, the job is marked as failed after that.
+	JobMaxAttempts int `env:"JOB_MAX_ATTEMPTS" envDefault:"3"`
+	// JobPollInterval is an interval of polling the jobs collection when there are no pending jobs.
+	JobPollInterval string `env:"JOB_POLL_INTERVAL" envDefault:"1s"`
+	// JobRetryBackoff is a delay before the first retry of the failed job, it's doubled on each next attempt.
+	JobRetryBackoff string `env:"JOB_RETRY_BACKOFF" envDefault:"30s"`
+	// JobLeaseTimeout is a duration while the job is owned by the worker. It's prolonged on each progress report,
+	// so the job will be taken by another worker only if the owner is dead (for example, the instance was killed).
+	// The worker which lease was expired cannot update the job anymore.
+	JobLeaseTimeout string `env:"JOB_LEASE_TIMEOUT" envDefault:"5m"`
 }
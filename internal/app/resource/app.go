@@ -9,16 +9,30 @@ import (
 	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/accessor"
 	accessorinterface "github.com/Borislavv/video-streaming/internal/domain/service/accessor/interface"
+	audioservice "github.com/Borislavv/video-streaming/internal/domain/service/audio"
+	audiointerface "github.com/Borislavv/video-streaming/internal/domain/service/audio/interface"
 	authservice "github.com/Borislavv/video-streaming/internal/domain/service/authenticator"
 	authenticatorinterface "github.com/Borislavv/video-streaming/internal/domain/service/authenticator/interface"
 	cacheservice "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
 	extractorinterface "github.com/Borislavv/video-streaming/internal/domain/service/extractor/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/job"
+	jobinterface "github.com/Borislavv/video-streaming/internal/domain/service/job/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/moderator"
+	moderatorinterface "github.com/Borislavv/video-streaming/internal/domain/service/moderator/interface"
+	previewerinterface "github.com/Borislavv/video-streaming/internal/domain/service/previewer/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/rendition"
+	renditioninterface "github.com/Borislavv/video-streaming/internal/domain/service/rendition/interface"
 	resourceservice "github.com/Borislavv/video-streaming/internal/domain/service/resource"
 	resourceinterface "github.com/Borislavv/video-streaming/internal/domain/service/resource/interface"
 	securityservice "github.com/Borislavv/video-streaming/internal/domain/service/security/interface"
+	shareservice "github.com/Borislavv/video-streaming/internal/domain/service/share"
+	shareinterface "github.com/Borislavv/video-streaming/internal/domain/service/share/interface"
 	storagerinterface "github.com/Borislavv/video-streaming/internal/domain/service/storager/interface"
+	subtitleservice "github.com/Borislavv/video-streaming/internal/domain/service/subtitle"
+	subtitleinterface "github.com/Borislavv/video-streaming/internal/domain/service/subtitle/interface"
 	tokenizerinterface "github.com/Borislavv/video-streaming/internal/domain/service/tokenizer/interface"
+	transcoderinterface "github.com/Borislavv/video-streaming/internal/domain/service/transcoder/interface"
 	uploaderservice "github.com/Borislavv/video-streaming/internal/domain/service/uploader/interface"
 	userservice "github.com/Borislavv/video-streaming/internal/domain/service/user"
 	userinterface "github.com/Borislavv/video-streaming/internal/domain/service/user/interface"
@@ -28,12 +42,18 @@ import (
 	validatorinterface "github.com/Borislavv/video-streaming/internal/domain/validator/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/render"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/rest/admin"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/rest/audio"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/rest/auth"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/rest/dash"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/rest/hls"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/rest/resource"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/rest/share"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/rest/subtitle"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/rest/user"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/rest/video"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/static"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/wellknown"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/request"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response"
 	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
@@ -42,9 +62,17 @@ import (
 	mongodbinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/mongodb/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/server/http"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/cacher"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/detector"
+	detectorinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/detector/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/logger"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/manifest"
+	manifestinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/manifest/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/previewer"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/security"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/segmenter"
+	segmenterinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/segmenter/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/tokenizer"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/transcoder"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file"
 	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
@@ -55,6 +83,7 @@ import (
 	"os"
 	"os/signal"
 	"reflect"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -120,8 +149,20 @@ func (app *ResourcesApp) Run(mWg *sync.WaitGroup) {
 		return
 	}
 
+	// file storage
+	if err = app.InitFileStorageService(); err != nil {
+		loggerService.Critical(err)
+		return
+	}
+
 	// file uploader and dependencies
-	if err = app.InitUploaderServices(); err != nil {
+	if err = app.InitUploaderServices(wg); err != nil {
+		loggerService.Critical(err)
+		return
+	}
+
+	// job queue and dependencies
+	if err = app.InitJobQueueServices(); err != nil {
 		loggerService.Critical(err)
 		return
 	}
@@ -132,12 +173,48 @@ func (app *ResourcesApp) Run(mWg *sync.WaitGroup) {
 		return
 	}
 
+	// transcoder and rendition services
+	if err = app.InitRenditionServices(); err != nil {
+		loggerService.Critical(err)
+		return
+	}
+
 	// video services
 	if err = app.InitVideoServices(); err != nil {
 		loggerService.Critical(err)
 		return
 	}
 
+	// share services
+	if err = app.InitShareServices(); err != nil {
+		loggerService.Critical(err)
+		return
+	}
+
+	// subtitle services
+	if err = app.InitSubtitleServices(); err != nil {
+		loggerService.Critical(err)
+		return
+	}
+
+	// audio services
+	if err = app.InitAudioServices(); err != nil {
+		loggerService.Critical(err)
+		return
+	}
+
+	// segmenter and manifest services
+	if err = app.InitManifestServices(); err != nil {
+		loggerService.Critical(err)
+		return
+	}
+
+	// background job workers
+	if err = app.InitJobWorkers(wg); err != nil {
+		loggerService.Critical(err)
+		return
+	}
+
 	// password services
 	if err = app.InitPasswordService(); err != nil {
 		loggerService.Critical(err)
@@ -150,8 +227,20 @@ func (app *ResourcesApp) Run(mWg *sync.WaitGroup) {
 		return
 	}
 
+	// moderation services
+	if err = app.InitModerationServices(); err != nil {
+		loggerService.Critical(err)
+		return
+	}
+
+	// admins of the configuration
+	if err = app.InitAdmins(); err != nil {
+		loggerService.Critical(err)
+		return
+	}
+
 	// token services
-	if err = app.InitTokenServices(); err != nil {
+	if err = app.InitTokenServices(wg); err != nil {
 		loggerService.Critical(err)
 		return
 	}
@@ -278,74 +367,466 @@ func (app *ResourcesApp) InitPasswordService() error {
 	return nil
 }
 
-func (app *ResourcesApp) InitCacheService() error {
-	loggerService, err := app.di.GetLoggerService()
+func (app *ResourcesApp) InitCacheService() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	ctx, err := app.di.GetCtx()
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+
+	c := cacher.NewCache(
+		cacher.NewMapCacheStorage(ctx),
+		cacher.NewCacheDisplacer(ctx, time.Second*1),
+	)
+
+	app.di.
+		Set(c, reflect.TypeOf((*cacheservice.Cacher)(nil))).
+		Set(c, nil)
+
+	return nil
+}
+
+func (app *ResourcesApp) InitRenditionServices() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	if app.cfg.TranscoderType == transcoder.FFmpegTranscoderType {
+		// used the ffmpeg binary
+		t, terr := transcoder.NewFFmpegTranscoder(app.di)
+		if terr != nil {
+			return loggerService.LogPropagate(terr)
+		}
+
+		app.di.
+			Set(t, reflect.TypeOf((*transcoderinterface.Transcoder)(nil))).
+			Set(t, nil)
+	} else if app.cfg.TranscoderType == transcoder.FakeTranscoderType {
+		// used copying of the original file
+		t, terr := transcoder.NewFakeTranscoder(app.di)
+		if terr != nil {
+			return loggerService.LogPropagate(terr)
+		}
+
+		app.di.
+			Set(t, reflect.TypeOf((*transcoderinterface.Transcoder)(nil))).
+			Set(t, nil)
+	}
+
+	p, err := rendition.NewLadderProducer(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(p, reflect.TypeOf((*renditioninterface.Producer)(nil))).
+		Set(p, nil)
+
+	if app.cfg.TranscoderType == previewer.FFmpegPreviewerType {
+		// used the ffmpeg binary
+		v, verr := previewer.NewFFmpegPreviewer(app.di)
+		if verr != nil {
+			return loggerService.LogPropagate(verr)
+		}
+
+		app.di.
+			Set(v, reflect.TypeOf((*previewerinterface.Previewer)(nil))).
+			Set(v, nil)
+	} else if app.cfg.TranscoderType == previewer.FakePreviewerType {
+		// used drawing of the plain images
+		v, verr := previewer.NewFakePreviewer(app.di)
+		if verr != nil {
+			return loggerService.LogPropagate(verr)
+		}
+
+		app.di.
+			Set(v, reflect.TypeOf((*previewerinterface.Previewer)(nil))).
+			Set(v, nil)
+	}
+
+	return nil
+}
+
+func (app *ResourcesApp) InitVideoServices() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	r, err := mongodb.NewVideoRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(r, reflect.TypeOf((*mongodbinterface.Video)(nil))).
+		Set(r, nil)
+
+	c, err := cache.NewVideoRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(c, reflect.TypeOf((*repositoryinterface.Video)(nil))).
+		Set(c, nil)
+
+	v, err := validator.NewVideoValidator(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(v, reflect.TypeOf((*validatorinterface.Video)(nil))).
+		Set(v, nil)
+
+	b, err := builder.NewVideoBuilder(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(b, reflect.TypeOf((*builderinterface.Video)(nil))).
+		Set(b, nil)
+
+	sr, err := mongodb.NewShareRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(sr, reflect.TypeOf((*repositoryinterface.Share)(nil))).
+		Set(sr, reflect.TypeOf((*mongodbinterface.Share)(nil))).
+		Set(sr, nil)
+
+	tr, err := mongodb.NewSubtitleRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(tr, reflect.TypeOf((*repositoryinterface.Subtitle)(nil))).
+		Set(tr, reflect.TypeOf((*mongodbinterface.Subtitle)(nil))).
+		Set(tr, nil)
+
+	s, err := videoservice.NewCRUDService(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(s, reflect.TypeOf((*videointerface.CRUD)(nil))).
+		Set(s, nil)
+
+	vs, err := videoservice.NewViewService(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(vs, reflect.TypeOf((*videointerface.Viewer)(nil))).
+		Set(vs, nil)
+
+	return nil
+}
+
+func (app *ResourcesApp) InitShareServices() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	v, err := validator.NewShareValidator(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(v, reflect.TypeOf((*validatorinterface.Share)(nil))).
+		Set(v, nil)
+
+	b, err := builder.NewShareBuilder(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(b, reflect.TypeOf((*builderinterface.Share)(nil))).
+		Set(b, nil)
+
+	s, err := shareservice.NewCRUDService(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(s, reflect.TypeOf((*shareinterface.CRUD)(nil))).
+		Set(s, nil)
+
+	return nil
+}
+
+func (app *ResourcesApp) InitSubtitleServices() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	v, err := validator.NewSubtitleValidator(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(v, reflect.TypeOf((*validatorinterface.Subtitle)(nil))).
+		Set(v, nil)
+
+	b, err := builder.NewSubtitleBuilder(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(b, reflect.TypeOf((*builderinterface.Subtitle)(nil))).
+		Set(b, nil)
+
+	// the concrete type is shared with the resource uploader, so the service is registered by the interface only
+	u, err := uploader.NewSubtitleUploader(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(u, reflect.TypeOf((*uploaderservice.Subtitle)(nil)))
+
+	s, err := subtitleservice.NewCRUDService(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(s, reflect.TypeOf((*subtitleinterface.CRUD)(nil))).
+		Set(s, nil)
+
+	return nil
+}
+
+func (app *ResourcesApp) InitModerationServices() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	v, err := validator.NewModerationValidator(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(v, reflect.TypeOf((*validatorinterface.Moderation)(nil))).
+		Set(v, nil)
+
+	b, err := builder.NewModerationBuilder(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(b, reflect.TypeOf((*builderinterface.Moderation)(nil))).
+		Set(b, nil)
+
+	s, err := moderator.NewModerationService(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(s, reflect.TypeOf((*moderatorinterface.Moderator)(nil))).
+		Set(s, nil)
+
+	return nil
+}
+
+// InitAdmins - promotes the registered users of the configured emails to the admins.
+func (app *ResourcesApp) InitAdmins() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	cfg, err := app.di.GetConfig()
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+
+	var emails []string
+	for _, email := range strings.Split(cfg.AdminEmails, ",") {
+		if email = strings.TrimSpace(email); email != "" {
+			emails = append(emails, email)
+		}
+	}
+	if len(emails) == 0 {
+		return nil
+	}
+
+	moderationService, err := app.di.GetModerationService()
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+
+	return moderationService.PromoteAdmins(emails)
+}
+
+func (app *ResourcesApp) InitAudioServices() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	r, err := mongodb.NewAudioRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(r, reflect.TypeOf((*mongodbinterface.Audio)(nil))).
+		Set(r, nil)
+
+	c, err := cache.NewAudioRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(c, reflect.TypeOf((*repositoryinterface.Audio)(nil))).
+		Set(c, nil)
+
+	v, err := validator.NewAudioValidator(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(v, reflect.TypeOf((*validatorinterface.Audio)(nil))).
+		Set(v, nil)
+
+	b, err := builder.NewAudioBuilder(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(b, reflect.TypeOf((*builderinterface.Audio)(nil))).
+		Set(b, nil)
+
+	s, err := audioservice.NewCRUDService(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(s, reflect.TypeOf((*audiointerface.CRUD)(nil))).
+		Set(s, nil)
+
+	return nil
+}
+
+func (app *ResourcesApp) InitManifestServices() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	s, err := segmenter.NewResourceSegmenter(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(s, reflect.TypeOf((*segmenterinterface.Segmenter)(nil))).
+		Set(s, nil)
+
+	m, err := detector.NewResourceMediaInfo(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(m, reflect.TypeOf((*detectorinterface.MediaInfo)(nil))).
+		Set(m, nil)
+
+	c, err := detector.NewResourceCodecs(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(c, reflect.TypeOf((*detectorinterface.Codecs)(nil))).
+		Set(c, nil)
+
+	a, err := detector.NewResourceAudioTracks(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(a, reflect.TypeOf((*detectorinterface.AudioTracks)(nil))).
+		Set(a, nil)
+
+	t, err := manifest.NewSubtitleTracks(app.di)
 	if err != nil {
-		return err
+		return loggerService.LogPropagate(err)
 	}
+	app.di.
+		Set(t, reflect.TypeOf((*manifestinterface.TextTracks)(nil))).
+		Set(t, nil)
 
-	ctx, err := app.di.GetCtx()
+	h, err := manifest.NewHLSGenerator(app.di)
 	if err != nil {
 		return loggerService.LogPropagate(err)
 	}
+	app.di.
+		Set(h, reflect.TypeOf((*manifestinterface.HLS)(nil))).
+		Set(h, nil)
 
-	c := cacher.NewCache(
-		cacher.NewMapCacheStorage(ctx),
-		cacher.NewCacheDisplacer(ctx, time.Second*1),
-	)
-
+	d, err := manifest.NewDASHGenerator(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
 	app.di.
-		Set(c, reflect.TypeOf((*cacheservice.Cacher)(nil))).
-		Set(c, nil)
+		Set(d, reflect.TypeOf((*manifestinterface.DASH)(nil))).
+		Set(d, nil)
 
 	return nil
 }
 
-func (app *ResourcesApp) InitVideoServices() error {
+func (app *ResourcesApp) InitJobQueueServices() error {
 	loggerService, err := app.di.GetLoggerService()
 	if err != nil {
 		return err
 	}
 
-	r, err := mongodb.NewVideoRepository(app.di)
+	r, err := mongodb.NewJobRepository(app.di)
 	if err != nil {
 		return loggerService.LogPropagate(err)
 	}
 	app.di.
-		Set(r, reflect.TypeOf((*mongodbinterface.Video)(nil))).
+		Set(r, reflect.TypeOf((*repositoryinterface.Job)(nil))).
+		Set(r, reflect.TypeOf((*mongodbinterface.Job)(nil))).
 		Set(r, nil)
 
-	c, err := cache.NewVideoRepository(app.di)
+	q, err := job.NewQueue(app.di)
 	if err != nil {
 		return loggerService.LogPropagate(err)
 	}
 	app.di.
-		Set(c, reflect.TypeOf((*repositoryinterface.Video)(nil))).
-		Set(c, nil)
+		Set(q, reflect.TypeOf((*jobinterface.Queue)(nil))).
+		Set(q, nil)
 
-	v, err := validator.NewVideoValidator(app.di)
+	return nil
+}
+
+func (app *ResourcesApp) InitJobWorkers(wg *sync.WaitGroup) error {
+	loggerService, err := app.di.GetLoggerService()
 	if err != nil {
-		return loggerService.LogPropagate(err)
+		return err
 	}
-	app.di.
-		Set(v, reflect.TypeOf((*validatorinterface.Video)(nil))).
-		Set(v, nil)
 
-	b, err := builder.NewVideoBuilder(app.di)
+	f, err := job.NewFragmentHandler(app.di)
 	if err != nil {
 		return loggerService.LogPropagate(err)
 	}
 	app.di.
-		Set(b, reflect.TypeOf((*builderinterface.Video)(nil))).
-		Set(b, nil)
+		Set([]jobinterface.Handler{
+			f,
+		}, reflect.TypeOf((*[]jobinterface.Handler)(nil)))
 
-	s, err := videoservice.NewCRUDService(app.di)
+	p, err := job.NewWorkerPool(app.di)
 	if err != nil {
 		return loggerService.LogPropagate(err)
 	}
 	app.di.
-		Set(s, reflect.TypeOf((*videointerface.CRUD)(nil))).
-		Set(s, nil)
+		Set(p, reflect.TypeOf((*jobinterface.Pool)(nil))).
+		Set(p, nil)
+
+	p.Run(wg)
 
 	return nil
 }
@@ -396,6 +877,16 @@ func (app *ResourcesApp) InitResourceServices() error {
 		Set(s, reflect.TypeOf((*resourceinterface.CRUD)(nil))).
 		Set(s, nil)
 
+	if app.cfg.ResourceUploadingStrategy == uploader.TusUploadingType {
+		rs, rerr := resourceservice.NewResumableService(app.di)
+		if rerr != nil {
+			return loggerService.LogPropagate(rerr)
+		}
+		app.di.
+			Set(rs, reflect.TypeOf((*resourceinterface.Resumable)(nil))).
+			Set(rs, nil)
+	}
+
 	return nil
 }
 
@@ -481,7 +972,7 @@ func (app *ResourcesApp) InitAuthServices() error {
 	return nil
 }
 
-func (app *ResourcesApp) InitTokenServices() error {
+func (app *ResourcesApp) InitTokenServices(wg *sync.WaitGroup) error {
 	loggerService, err := app.di.GetLoggerService()
 	if err != nil {
 		return err
@@ -492,10 +983,29 @@ func (app *ResourcesApp) InitTokenServices() error {
 		return loggerService.LogPropagate(err)
 	}
 	app.di.
-		Set(r, reflect.TypeOf((*repositoryinterface.BlockedToken)(nil))).
 		Set(r, reflect.TypeOf((*mongodbinterface.BlockedToken)(nil))).
 		Set(r, nil)
 
+	// blocked tokens are checked on each request, so they are kept in memory
+	cr, err := cache.NewBlockedTokenRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(cr, reflect.TypeOf((*repositoryinterface.BlockedToken)(nil))).
+		Set(cr, nil)
+
+	cr.Run(wg)
+
+	rt, err := mongodb.NewRefreshTokenRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(rt, reflect.TypeOf((*repositoryinterface.RefreshToken)(nil))).
+		Set(rt, reflect.TypeOf((*mongodbinterface.RefreshToken)(nil))).
+		Set(rt, nil)
+
 	s, err := tokenizer.NewJwtService(app.di)
 	if err != nil {
 		return loggerService.LogPropagate(err)
@@ -507,27 +1017,70 @@ func (app *ResourcesApp) InitTokenServices() error {
 	return nil
 }
 
-func (app *ResourcesApp) InitUploaderServices() error {
+func (app *ResourcesApp) InitFileStorageService() error {
 	loggerService, err := app.di.GetLoggerService()
 	if err != nil {
 		return err
 	}
 
-	// filesystem storage
-	filesystemStorage, err := file.NewFilesystemStorageService(app.di)
+	var storage fileinterface.Storage
+	if app.cfg.StorageType == file.S3StorageType {
+		// objects storage which is shared by the nodes
+		if storage, err = file.NewS3StorageService(app.di); err != nil {
+			return loggerService.LogPropagate(err)
+		}
+	} else {
+		// local resources directory
+		if storage, err = file.NewFilesystemStorageService(app.di); err != nil {
+			return loggerService.LogPropagate(err)
+		}
+	}
+
+	app.di.
+		Set(storage, reflect.TypeOf((*fileinterface.Storage)(nil))).
+		Set(storage, reflect.TypeOf((*storagerinterface.Storage)(nil))).
+		Set(storage, nil)
+
+	return nil
+}
+
+func (app *ResourcesApp) InitUploaderServices(wg *sync.WaitGroup) error {
+	loggerService, err := app.di.GetLoggerService()
 	if err != nil {
-		return loggerService.LogPropagate(err)
+		return err
 	}
 
 	// filename computer
 	filenameComputer := file.NewNameComputerService()
 
+	// filetype detector by the content
+	contentSniffer := file.NewContentSnifferService()
+
 	app.di.
-		Set(filesystemStorage, reflect.TypeOf((*fileinterface.Storage)(nil))).
-		Set(filesystemStorage, reflect.TypeOf((*storagerinterface.Storage)(nil))).
 		Set(filenameComputer, reflect.TypeOf((*fileinterface.NameComputer)(nil))).
-		Set(filesystemStorage, nil).
-		Set(filenameComputer, nil)
+		Set(contentSniffer, reflect.TypeOf((*fileinterface.Sniffer)(nil))).
+		Set(filenameComputer, nil).
+		Set(contentSniffer, nil)
+
+	// content addressed storage which counts references of the files in the database
+	blobRepository, err := mongodb.NewBlobRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+
+	app.di.
+		Set(blobRepository, reflect.TypeOf((*repositoryinterface.Blob)(nil))).
+		Set(blobRepository, reflect.TypeOf((*mongodbinterface.Blob)(nil))).
+		Set(blobRepository, nil)
+
+	blobStorage, err := file.NewBlobStorageService(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+
+	app.di.
+		Set(blobStorage, reflect.TypeOf((*fileinterface.BlobStorage)(nil))).
+		Set(blobStorage, nil)
 
 	if app.cfg.ResourceUploadingStrategy == uploader.MultipartFormUploadingType {
 		// used parsing of full form into RAM
@@ -549,6 +1102,29 @@ func (app *ResourcesApp) InitUploaderServices() error {
 		app.di.
 			Set(service, reflect.TypeOf((*uploaderservice.Uploader)(nil))).
 			Set(service, nil)
+	} else if app.cfg.ResourceUploadingStrategy == uploader.TusUploadingType {
+		// used resumable uploading by chunks, the state of uploads is stored in the database
+		r, rerr := mongodb.NewUploadRepository(app.di)
+		if rerr != nil {
+			return loggerService.LogPropagate(rerr)
+		}
+
+		app.di.
+			Set(r, reflect.TypeOf((*repositoryinterface.Upload)(nil))).
+			Set(r, reflect.TypeOf((*mongodbinterface.Upload)(nil))).
+			Set(r, nil)
+
+		service, uerr := uploader.NewTusUploader(app.di)
+		if uerr != nil {
+			return loggerService.LogPropagate(uerr)
+		}
+
+		app.di.
+			Set(service, reflect.TypeOf((*uploaderservice.Uploader)(nil))).
+			Set(service, reflect.TypeOf((*uploaderservice.Resumable)(nil))).
+			Set(service, nil)
+
+		service.Run(wg)
 	}
 
 	return nil
@@ -640,8 +1216,158 @@ func (app *ResourcesApp) InitAuthedRestApiControllers() ([]controller.Controller
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
 	}
+	videoContentController, err := video.NewContentController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	videoPosterController, err := video.NewPosterController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	videoStoryboardController, err := video.NewStoryboardController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	videoStoryboardImageController, err := video.NewStoryboardImageController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
 
-	return []controller.Controller{
+	// share
+	shareCreateController, err := share.NewCreateController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	shareListController, err := share.NewListController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	shareDeleteController, err := share.NewDeleteController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	// subtitle
+	subtitleCreateController, err := subtitle.NewCreateController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	subtitleUpdateController, err := subtitle.NewUpdateController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	subtitleContentController, err := subtitle.NewContentController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	subtitleListController, err := subtitle.NewListController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	subtitleDeleteController, err := subtitle.NewDeleteController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	// auth
+	logoutController, err := auth.NewLogoutController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	logoutAllController, err := auth.NewLogoutAllController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	// admin
+	adminVideoListController, err := admin.NewVideoListController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	adminVideoGetController, err := admin.NewVideoGetController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	adminVideoDeleteController, err := admin.NewVideoDeleteController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	adminResourceListController, err := admin.NewResourceListController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	adminResourceGetController, err := admin.NewResourceGetController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	adminResourceDeleteController, err := admin.NewResourceDeleteController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	adminUserRolesController, err := admin.NewUserRolesController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	// audio
+	audioCreateController, err := audio.NewCreateController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	audioUpdateController, err := audio.NewUpdateController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	audioGetController, err := audio.NewGetController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	audioListController, err := audio.NewListController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	audioDeleteController, err := audio.NewDeleteController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	// hls
+	hlsMasterPlaylistController, err := hls.NewMasterPlaylistController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	hlsMediaPlaylistController, err := hls.NewMediaPlaylistController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	hlsInitSegmentController, err := hls.NewInitSegmentController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	hlsSegmentController, err := hls.NewSegmentController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	hlsSubtitlePlaylistController, err := hls.NewSubtitlePlaylistController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	// dash
+	dashManifestController, err := dash.NewManifestController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	dashInitSegmentController, err := dash.NewInitSegmentController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	dashSegmentController, err := dash.NewSegmentController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	controllers := []controller.Controller{
 		// resource
 		resourceUploadController,
 		// video
@@ -650,16 +1376,98 @@ func (app *ResourcesApp) InitAuthedRestApiControllers() ([]controller.Controller
 		videoGetController,
 		videoListController,
 		videoDeleteController,
+		videoContentController,
+		videoPosterController,
+		videoStoryboardController,
+		videoStoryboardImageController,
+		// share
+		shareCreateController,
+		shareListController,
+		shareDeleteController,
+		// subtitle
+		subtitleCreateController,
+		subtitleUpdateController,
+		subtitleContentController,
+		subtitleListController,
+		subtitleDeleteController,
+		// hls
+		hlsMasterPlaylistController,
+		hlsMediaPlaylistController,
+		hlsInitSegmentController,
+		hlsSegmentController,
+		hlsSubtitlePlaylistController,
+		// dash
+		dashManifestController,
+		dashInitSegmentController,
+		dashSegmentController,
 		// audio
-		audio.NewCreateController(),
-		audio.NewDeleteController(),
-		audio.NewGetController(),
-		audio.NewListController(),
-		audio.NewUpdateController(),
+		audioCreateController,
+		audioUpdateController,
+		audioGetController,
+		audioListController,
+		audioDeleteController,
 		// user
 		userUpdateController,
 		userGetController,
 		userDeleteController,
+		// auth
+		logoutController,
+		logoutAllController,
+		// admin
+		adminVideoListController,
+		adminVideoGetController,
+		adminVideoDeleteController,
+		adminResourceListController,
+		adminResourceGetController,
+		adminResourceDeleteController,
+		adminUserRolesController,
+	}
+
+	// resumable uploading
+	if app.cfg.ResourceUploadingStrategy == uploader.TusUploadingType {
+		tusControllers, terr := app.initTusControllers()
+		if terr != nil {
+			return nil, loggerService.LogPropagate(terr)
+		}
+		controllers = append(controllers, tusControllers...)
+	}
+
+	return controllers, nil
+}
+
+func (app *ResourcesApp) initTusControllers() ([]controller.Controller, error) {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	tusOptionsController, err := resource.NewTusOptionsController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	tusCreateController, err := resource.NewTusCreateController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	tusHeadController, err := resource.NewTusHeadController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	tusPatchController, err := resource.NewTusPatchController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	tusDeleteController, err := resource.NewTusDeleteController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return []controller.Controller{
+		tusOptionsController,
+		tusCreateController,
+		tusHeadController,
+		tusPatchController,
+		tusDeleteController,
 	}, nil
 }
 
@@ -679,9 +1487,21 @@ func (app *ResourcesApp) InitUnauthedRestApiControllers() ([]controller.Controll
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	refreshController, err := auth.NewRefreshController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	videoSharedController, err := video.NewSharedController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	return []controller.Controller{
 		authorizationController,
 		registrationController,
+		refreshController,
+		videoSharedController,
 	}, nil
 }
 
@@ -733,6 +1553,22 @@ func (app *ResourcesApp) InitStaticServingControllers() ([]controller.Controller
 	}, nil
 }
 
+func (app *ResourcesApp) InitWellKnownControllers() ([]controller.Controller, error) {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	jwksController, err := wellknown.NewJwksController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return []controller.Controller{
+		jwksController,
+	}, nil
+}
+
 func (app *ResourcesApp) InitHttpServer(wg *sync.WaitGroup) error {
 	loggerService, err := app.di.GetLoggerService()
 	if err != nil {
@@ -770,6 +1606,12 @@ func (app *ResourcesApp) InitHttpServer(wg *sync.WaitGroup) error {
 		return loggerService.LogPropagate(err)
 	}
 
+	// Well-known
+	wellKnownControllers, err := app.InitWellKnownControllers()
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+
 	server, err := http.NewHttpServer(
 		app.di,
 		authedRestAPIControllers,
@@ -777,6 +1619,7 @@ func (app *ResourcesApp) InitHttpServer(wg *sync.WaitGroup) error {
 		authedNativeControllers,
 		unauthedNativeControllers,
 		staticFilesControllers,
+		wellKnownControllers,
 	)
 	if err != nil {
 		return loggerService.LogPropagate(err)
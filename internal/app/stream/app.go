@@ -5,9 +5,14 @@ import (
 	"github.com/Borislavv/video-streaming/internal/app"
 	loggerservice "github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/accessor"
+	accessorinterface "github.com/Borislavv/video-streaming/internal/domain/service/accessor/interface"
 	cacheservice "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	storagerinterface "github.com/Borislavv/video-streaming/internal/domain/service/storager/interface"
 	tokenizerinterface "github.com/Borislavv/video-streaming/internal/domain/service/tokenizer/interface"
+	videoservice "github.com/Borislavv/video-streaming/internal/domain/service/video"
+	videointerface "github.com/Borislavv/video-streaming/internal/domain/service/video/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/cache"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/mongodb"
 	mongodbinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/mongodb/interface"
@@ -16,19 +21,29 @@ import (
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/detector"
 	detectorinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/detector/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/logger"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/manifest"
+	manifestinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/manifest/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/reader"
 	readerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/reader/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/segmenter"
+	segmenterinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/segmenter/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/abr"
+	abrinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/abr/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/handler"
 	handlerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/handler/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/handler/strategy"
 	strategyinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/handler/strategy/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/listener"
 	listenerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/listener/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/guard"
+	guardinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/guard/interface"
 	streamerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/interface"
 	protointerface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/ws"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/tokenizer"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file"
+	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
 	"github.com/caarlos0/env/v9"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -89,12 +104,36 @@ func (app *StreamingApp) Run(mWg *sync.WaitGroup) {
 		return
 	}
 
+	// access service
+	if err = app.InitAccessService(); err != nil {
+		loggerService.Critical(err)
+		return
+	}
+
 	// video services
 	if err = app.InitVideoServices(); err != nil {
 		loggerService.Critical(err)
 		return
 	}
 
+	// subtitle services
+	if err = app.InitSubtitleServices(); err != nil {
+		loggerService.Critical(err)
+		return
+	}
+
+	// audio services
+	if err = app.InitAudioServices(); err != nil {
+		loggerService.Critical(err)
+		return
+	}
+
+	// file storage
+	if err = app.InitFileStorageService(); err != nil {
+		loggerService.Critical(err)
+		return
+	}
+
 	// file reader service
 	if err = app.InitFileReaderService(); err != nil {
 		loggerService.Critical(err)
@@ -113,8 +152,20 @@ func (app *StreamingApp) Run(mWg *sync.WaitGroup) {
 		return
 	}
 
+	// resource segmenter service
+	if err = app.InitSegmenterService(); err != nil {
+		loggerService.Critical(err)
+		return
+	}
+
+	// adaptive streaming service
+	if err = app.InitAdaptiveStreamerService(); err != nil {
+		loggerService.Critical(err)
+		return
+	}
+
 	// token services
-	if err = app.InitTokenServices(); err != nil {
+	if err = app.InitTokenServices(wg); err != nil {
 		loggerService.Critical(err)
 		return
 	}
@@ -265,6 +316,119 @@ func (app *StreamingApp) InitVideoServices() error {
 		Set(c, reflect.TypeOf((*repositoryinterface.Video)(nil))).
 		Set(c, nil)
 
+	sr, err := mongodb.NewShareRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(sr, reflect.TypeOf((*repositoryinterface.Share)(nil))).
+		Set(sr, reflect.TypeOf((*mongodbinterface.Share)(nil))).
+		Set(sr, nil)
+
+	vs, err := videoservice.NewViewService(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(vs, reflect.TypeOf((*videointerface.Viewer)(nil))).
+		Set(vs, nil)
+
+	return nil
+}
+
+func (app *StreamingApp) InitSubtitleServices() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	r, err := mongodb.NewSubtitleRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(r, reflect.TypeOf((*repositoryinterface.Subtitle)(nil))).
+		Set(r, reflect.TypeOf((*mongodbinterface.Subtitle)(nil))).
+		Set(r, nil)
+
+	t, err := manifest.NewSubtitleTracks(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(t, reflect.TypeOf((*manifestinterface.TextTracks)(nil))).
+		Set(t, nil)
+
+	return nil
+}
+
+func (app *StreamingApp) InitAccessService() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	a, err := accessor.NewAccessService(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+
+	app.di.
+		Set(a, reflect.TypeOf((*accessorinterface.Accessor)(nil))).
+		Set(a, nil)
+
+	return nil
+}
+
+func (app *StreamingApp) InitAudioServices() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	r, err := mongodb.NewAudioRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(r, reflect.TypeOf((*mongodbinterface.Audio)(nil))).
+		Set(r, nil)
+
+	c, err := cache.NewAudioRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(c, reflect.TypeOf((*repositoryinterface.Audio)(nil))).
+		Set(c, nil)
+
+	return nil
+}
+
+func (app *StreamingApp) InitFileStorageService() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	var storage fileinterface.Storage
+	if app.cfg.StorageType == file.S3StorageType {
+		// objects storage which is shared by the nodes
+		if storage, err = file.NewS3StorageService(app.di); err != nil {
+			return loggerService.LogPropagate(err)
+		}
+	} else {
+		// local resources directory
+		if storage, err = file.NewFilesystemStorageService(app.di); err != nil {
+			return loggerService.LogPropagate(err)
+		}
+	}
+
+	app.di.
+		Set(storage, reflect.TypeOf((*fileinterface.Storage)(nil))).
+		Set(storage, reflect.TypeOf((*storagerinterface.Storage)(nil))).
+		Set(storage, nil)
+
 	return nil
 }
 
@@ -308,6 +472,14 @@ func (app *StreamingApp) InitCodecsInfoService() error {
 		return err
 	}
 
+	m, err := detector.NewResourceMediaInfo(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(m, reflect.TypeOf((*detectorinterface.MediaInfo)(nil))).
+		Set(m, nil)
+
 	c, err := detector.NewResourceCodecs(app.di)
 	if err != nil {
 		return loggerService.LogPropagate(err)
@@ -316,6 +488,64 @@ func (app *StreamingApp) InitCodecsInfoService() error {
 		Set(c, reflect.TypeOf((*detectorinterface.Codecs)(nil))).
 		Set(c, nil)
 
+	d, err := detector.NewResourceDuration(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(d, reflect.TypeOf((*detectorinterface.Duration)(nil))).
+		Set(d, nil)
+
+	a, err := detector.NewResourceAudioTracks(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(a, reflect.TypeOf((*detectorinterface.AudioTracks)(nil))).
+		Set(a, nil)
+
+	return nil
+}
+
+func (app *StreamingApp) InitSegmenterService() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	s, err := segmenter.NewResourceSegmenter(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(s, reflect.TypeOf((*segmenterinterface.Segmenter)(nil))).
+		Set(s, nil)
+
+	d, err := segmenter.NewTrackDemuxer(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(d, reflect.TypeOf((*segmenterinterface.Demuxer)(nil))).
+		Set(d, nil)
+
+	return nil
+}
+
+func (app *StreamingApp) InitAdaptiveStreamerService() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	s, err := abr.NewAdaptiveStreamer(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(s, reflect.TypeOf((*abrinterface.AdaptiveStreamer)(nil))).
+		Set(s, nil)
+
 	return nil
 }
 
@@ -348,9 +578,49 @@ func (app *StreamingApp) InitWebSocketHandler() error {
 		return loggerService.LogPropagate(err)
 	}
 	app.di.
-		Set(streamByIDStrategy, nil).
+		Set(streamByIDStrategy, nil)
+
+	streamByIDWithOffsetStrategy, err := strategy.NewStreamByIDWithOffsetActionStrategy(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(streamByIDWithOffsetStrategy, nil)
+
+	streamAudioByIDStrategy, err := strategy.NewStreamAudioByIDActionStrategy(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(streamAudioByIDStrategy, nil)
+
+	playbackControlStrategy, err := strategy.NewPlaybackControlActionStrategy(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(playbackControlStrategy, nil)
+
+	flowControlStrategy, err := strategy.NewFlowControlActionStrategy(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(flowControlStrategy, nil)
+
+	renditionStrategy, err := strategy.NewRenditionActionStrategy(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(renditionStrategy, nil).
 		Set([]strategyinterface.ActionStrategy{
 			streamByIDStrategy,
+			streamByIDWithOffsetStrategy,
+			streamAudioByIDStrategy,
+			playbackControlStrategy,
+			flowControlStrategy,
+			renditionStrategy,
 		}, reflect.TypeOf((*[]strategyinterface.ActionStrategy)(nil)))
 
 	// handler which use strategies
@@ -382,7 +652,7 @@ func (app *StreamingApp) InitStreamingService() error {
 	return nil
 }
 
-func (app *StreamingApp) InitTokenServices() error {
+func (app *StreamingApp) InitTokenServices(wg *sync.WaitGroup) error {
 	loggerService, err := app.di.GetLoggerService()
 	if err != nil {
 		return err
@@ -393,9 +663,28 @@ func (app *StreamingApp) InitTokenServices() error {
 		return loggerService.LogPropagate(err)
 	}
 	app.di.
-		Set(r, reflect.TypeOf((*repositoryinterface.BlockedToken)(nil))).
+		Set(r, reflect.TypeOf((*mongodbinterface.BlockedToken)(nil))).
 		Set(r, nil)
 
+	// blocked tokens are checked on each request, so they are kept in memory
+	cr, err := cache.NewBlockedTokenRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(cr, reflect.TypeOf((*repositoryinterface.BlockedToken)(nil))).
+		Set(cr, nil)
+
+	cr.Run(wg)
+
+	rt, err := mongodb.NewRefreshTokenRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(rt, reflect.TypeOf((*repositoryinterface.RefreshToken)(nil))).
+		Set(rt, nil)
+
 	s, err := tokenizer.NewJwtService(app.di)
 	if err != nil {
 		return loggerService.LogPropagate(err)
@@ -404,6 +693,14 @@ func (app *StreamingApp) InitTokenServices() error {
 		Set(s, reflect.TypeOf((*tokenizerinterface.Tokenizer)(nil))).
 		Set(s, nil)
 
+	g, err := guard.NewTokenGuard(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(g, reflect.TypeOf((*guardinterface.TokenGuard)(nil))).
+		Set(g, nil)
+
 	return nil
 }
 
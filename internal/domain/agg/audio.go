@@ -9,5 +9,5 @@ type Audio struct {
 	entity.Audio `bson:",inline"`
 
 	Resource  entity.Resource `json:"resource" bson:"resource"`
-	Timestamp vo.Timestamp    `bson:",inline"`
+	Timestamp vo.Timestamp    `json:"timestamp" bson:",inline"`
 }
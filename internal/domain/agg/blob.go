@@ -0,0 +1,12 @@
+package agg
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+)
+
+type Blob struct {
+	entity.Blob `bson:",inline"`
+
+	Timestamp vo.Timestamp `json:"timestamp" bson:",inline"`
+}
@@ -12,13 +12,30 @@ type BlockedToken struct {
 	Timestamp vo.Timestamp `json:"timestamp" bson:",inline"`
 }
 
-func NewBlockedToken(token string, reason string, userID vo.ID) *BlockedToken {
+func NewBlockedToken(token string, reason string, userID vo.ID, expiresAt time.Time) *BlockedToken {
 	return &BlockedToken{
 		BlockedToken: entity.BlockedToken{
 			Value:     token,
 			UserID:    userID,
 			Reason:    reason,
 			BlockedAt: time.Now(),
+			ExpiresAt: expiresAt,
+		},
+		Timestamp: vo.Timestamp{
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Time{},
+		},
+	}
+}
+
+func NewBlockedSession(session string, reason string, userID vo.ID, expiresAt time.Time) *BlockedToken {
+	return &BlockedToken{
+		BlockedToken: entity.BlockedToken{
+			Session:   session,
+			UserID:    userID,
+			Reason:    reason,
+			BlockedAt: time.Now(),
+			ExpiresAt: expiresAt,
 		},
 		Timestamp: vo.Timestamp{
 			CreatedAt: time.Now(),
@@ -0,0 +1,29 @@
+package agg
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"time"
+)
+
+type Job struct {
+	entity.Job `bson:",inline"`
+
+	Timestamp vo.Timestamp `json:"timestamp" bson:",inline"`
+}
+
+func NewJob(jobType string, resource *Resource) *Job {
+	return &Job{
+		Job: entity.Job{
+			Type:       jobType,
+			UserID:     resource.UserID,
+			ResourceID: resource.ID,
+			Status:     entity.JobPending,
+			RunAt:      time.Now(),
+		},
+		Timestamp: vo.Timestamp{
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Time{},
+		},
+	}
+}
@@ -8,6 +8,39 @@ import (
 type Video struct {
 	entity.Video `bson:",inline"`
 
-	Resource  entity.Resource `json:"resource" bson:"resource"`
-	Timestamp vo.Timestamp    `json:"timestamp" bson:",inline"`
+	Resource   entity.Resource    `json:"resource" bson:"resource"`
+	Renditions []entity.Rendition `json:"renditions" bson:"renditions,omitempty"`
+	Timestamp  vo.Timestamp       `json:"timestamp" bson:",inline"`
+
+	// PosterURL and StoryboardURL are set by the api when the resource has a preview, they are not stored.
+	PosterURL     string `json:"posterURL,omitempty" bson:"-"`
+	StoryboardURL string `json:"storyboardURL,omitempty" bson:"-"`
+}
+
+// GetRenditions - returns the rendition ladder ordered by bitrate ascending. The video without produced
+// renditions is represented by the single one, the original resource.
+func (v *Video) GetRenditions() []entity.Rendition {
+	if len(v.Renditions) == 0 {
+		return []entity.Rendition{{Resource: v.Resource}}
+	}
+	return v.Renditions
+}
+
+// GetRendition - returns the rendition by its number in the ladder.
+func (v *Video) GetRendition(number int) (entity.Rendition, bool) {
+	renditions := v.GetRenditions()
+	if number < 0 || number >= len(renditions) {
+		return entity.Rendition{}, false
+	}
+	return renditions[number], true
+}
+
+// GetOriginalRendition - returns the number of rendition which is the uploaded resource.
+func (v *Video) GetOriginalRendition() int {
+	for number, rendition := range v.Renditions {
+		if rendition.Original {
+			return number
+		}
+	}
+	return 0
 }
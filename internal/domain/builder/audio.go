@@ -0,0 +1,271 @@
+package builder
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	extractorinterface "github.com/Borislavv/video-streaming/internal/domain/service/extractor/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/helper"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type AudioBuilder struct {
+	logger             loggerinterface.Logger
+	ctx                context.Context
+	extractor          extractorinterface.RequestParams
+	audioRepository    repositoryinterface.Audio
+	resourceRepository repositoryinterface.Resource
+}
+
+// NewAudioBuilder is a constructor of AudioBuilder
+func NewAudioBuilder(serviceContainer diinterface.ServiceContainer) (*AudioBuilder, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	requestParametersExtractor, err := serviceContainer.GetRequestParametersExtractorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	audioRepository, err := serviceContainer.GetAudioRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	resourceRepository, err := serviceContainer.GetResourceRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &AudioBuilder{
+		ctx:                ctx,
+		logger:             loggerService,
+		extractor:          requestParametersExtractor,
+		audioRepository:    audioRepository,
+		resourceRepository: resourceRepository,
+	}, nil
+}
+
+// BuildCreateRequestDTOFromRequest - build a dto.CreateAudioRequest from raw *http.Request
+func (b *AudioBuilder) BuildCreateRequestDTOFromRequest(r *http.Request) (*dto.AudioCreateRequestDTO, error) {
+	audioDTO := &dto.AudioCreateRequestDTO{}
+	if err := json.NewDecoder(r.Body).Decode(audioDTO); err != nil {
+		if err == io.EOF {
+			return nil, b.logger.LogPropagate(errtype.NewRequestBodyIsEmptyError())
+		}
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	// setting up a user id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		audioDTO.UserID = userID
+	}
+
+	return audioDTO, nil
+}
+
+// BuildAggFromCreateRequestDTO - build an agg.Audio from dto.CreateAudioRequest
+func (b *AudioBuilder) BuildAggFromCreateRequestDTO(req dtointerface.CreateAudioRequest) (*agg.Audio, error) {
+	resource, err := b.resourceRepository.FindOneByID(
+		b.ctx, dto.NewResourceGetRequestDTO(req.GetResourceID(), req.GetUserID()),
+	)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	return &agg.Audio{
+		Audio: entity.Audio{
+			UserID:      req.GetUserID(),
+			Name:        req.GetName(),
+			Description: req.GetDescription(),
+		},
+		Resource: resource.Resource,
+		Timestamp: vo.Timestamp{
+			CreatedAt: time.Now(),
+		},
+	}, nil
+}
+
+// BuildUpdateRequestDTOFromRequest - build a dto.UpdateAudioRequest from raw *http.Request
+func (b *AudioBuilder) BuildUpdateRequestDTOFromRequest(r *http.Request) (*dto.AudioUpdateRequestDTO, error) {
+	audioDTO := &dto.AudioUpdateRequestDTO{}
+	if err := json.NewDecoder(r.Body).Decode(&audioDTO); err != nil {
+		if err == io.EOF {
+			return nil, b.logger.LogPropagate(errtype.NewRequestBodyIsEmptyError())
+		}
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	// setting up a user id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		audioDTO.UserID = userID
+	}
+
+	// setting up an audio id
+	hexID, err := b.extractor.GetParameter(idField, r)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+	oID, err := primitive.ObjectIDFromHex(hexID)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+	audioDTO.ID = vo.ID{Value: oID}
+
+	return audioDTO, nil
+}
+
+// BuildAggFromUpdateRequestDTO - build an agg.Audio from dto.UpdateAudioRequest
+func (b *AudioBuilder) BuildAggFromUpdateRequestDTO(req dtointerface.UpdateAudioRequest) (*agg.Audio, error) {
+	audio, err := b.audioRepository.FindOneByID(b.ctx, req)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	changes := 0
+	if audio.Name != req.GetName() {
+		audio.Name = req.GetName()
+		changes++
+	}
+	if audio.Description != req.GetDescription() {
+		audio.Description = req.GetDescription()
+		changes++
+	}
+	if !req.GetResourceID().Value.IsZero() {
+		resource, ferr := b.resourceRepository.FindOneByID(
+			b.ctx, dto.NewResourceGetRequestDTO(req.GetResourceID(), req.GetUserID()),
+		)
+		if ferr != nil {
+			return nil, b.logger.LogPropagate(ferr)
+		}
+		if audio.Resource.ID.Value != resource.Resource.ID.Value {
+			audio.Resource = resource.Resource
+			changes++
+		}
+	}
+	if changes > 0 {
+		audio.Timestamp.UpdatedAt = time.Now()
+	}
+
+	return audio, nil
+}
+
+// BuildGetRequestDTOFromRequest - build a dto.GetAudioRequest from raw *http.Request
+func (b *AudioBuilder) BuildGetRequestDTOFromRequest(r *http.Request) (*dto.AudioGetRequestDTO, error) {
+	audioDTO := &dto.AudioGetRequestDTO{}
+
+	// setting up a user id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		audioDTO.UserID = userID
+	}
+
+	hexID, err := b.extractor.GetParameter(idField, r)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+	oID, err := primitive.ObjectIDFromHex(hexID)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+	audioDTO.ID = vo.ID{Value: oID}
+
+	return audioDTO, nil
+}
+
+// BuildListRequestDTOFromRequest - build a dto.ListAudioRequest from raw *http.Request
+func (b *AudioBuilder) BuildListRequestDTOFromRequest(r *http.Request) (*dto.AudioListRequestDTO, error) {
+	audioDTO := &dto.AudioListRequestDTO{}
+
+	// setting up a user id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		audioDTO.UserID = userID
+	}
+
+	if b.extractor.HasParameter(nameField, r) {
+		if nm, err := b.extractor.GetParameter(nameField, r); err == nil {
+			audioDTO.Name = nm
+		}
+	}
+	if b.extractor.HasParameter(createdAtField, r) {
+		createdAt, _ := b.extractor.GetParameter(createdAtField, r)
+
+		parsedCreatedAt, err := helper.ParseTime(createdAt)
+		if err != nil {
+			return nil, b.logger.LogPropagate(errtype.NewTimeParsingValidationError(createdAt))
+		} else {
+			audioDTO.CreatedAt = parsedCreatedAt
+		}
+	}
+	if b.extractor.HasParameter(fromField, r) {
+		from, _ := b.extractor.GetParameter(fromField, r)
+
+		parsedFrom, err := helper.ParseTime(from)
+		if err != nil {
+			return nil, b.logger.LogPropagate(errtype.NewTimeParsingValidationError(from))
+		} else {
+			audioDTO.From = parsedFrom
+		}
+	}
+	if b.extractor.HasParameter(toField, r) {
+		to, _ := b.extractor.GetParameter(toField, r)
+
+		parsedTo, err := helper.ParseTime(to)
+		if err != nil {
+			return nil, b.logger.LogPropagate(errtype.NewTimeParsingValidationError(to))
+		} else {
+			audioDTO.To = parsedTo
+		}
+	}
+	if b.extractor.HasParameter(pageField, r) {
+		pg, _ := b.extractor.GetParameter(pageField, r)
+		pgi, atoiErr := strconv.Atoi(pg)
+		if atoiErr != nil {
+			return nil, b.logger.LogPropagate(atoiErr)
+		}
+		audioDTO.Page = pgi
+	} else {
+		audioDTO.Page = pageDefaultValue
+	}
+	if b.extractor.HasParameter(limitField, r) {
+		l, _ := b.extractor.GetParameter(limitField, r)
+		li, atoiErr := strconv.Atoi(l)
+		if atoiErr != nil {
+			return nil, b.logger.LogPropagate(atoiErr)
+		}
+		audioDTO.Limit = li
+	} else {
+		audioDTO.Limit = limitDefaultValue
+	}
+
+	return audioDTO, nil
+}
+
+// BuildDeleteRequestDTOFromRequest - build a dto.DeleteAudioRequest from raw *http.Request
+func (b *AudioBuilder) BuildDeleteRequestDTOFromRequest(r *http.Request) (*dto.AudioDeleteRequestDto, error) {
+	audioGetDTO, err := b.BuildGetRequestDTOFromRequest(r)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	return &dto.AudioDeleteRequestDto{ID: audioGetDTO.ID, UserID: audioGetDTO.UserID}, nil
+}
@@ -34,3 +34,14 @@ func (b *AuthBuilder) BuildAuthRequestDTOFromRequest(r *http.Request) (dtointerf
 	}
 	return authDTO, nil
 }
+
+func (b *AuthBuilder) BuildRefreshRequestDTOFromRequest(r *http.Request) (dtointerface.RefreshRequest, error) {
+	refreshDTO := &dto.RefreshRequestDTO{}
+	if err := json.NewDecoder(r.Body).Decode(refreshDTO); err != nil {
+		if err == io.EOF {
+			return nil, errtype.NewRequestBodyIsEmptyError()
+		}
+		return nil, b.logger.LogPropagate(err)
+	}
+	return refreshDTO, nil
+}
@@ -0,0 +1,18 @@
+package builderinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"net/http"
+)
+
+type Audio interface {
+	BuildGetRequestDTOFromRequest(r *http.Request) (*dto.AudioGetRequestDTO, error)
+	BuildListRequestDTOFromRequest(r *http.Request) (*dto.AudioListRequestDTO, error)
+	BuildCreateRequestDTOFromRequest(r *http.Request) (*dto.AudioCreateRequestDTO, error)
+	BuildAggFromCreateRequestDTO(reqDTO dtointerface.CreateAudioRequest) (*agg.Audio, error)
+	BuildUpdateRequestDTOFromRequest(r *http.Request) (*dto.AudioUpdateRequestDTO, error)
+	BuildAggFromUpdateRequestDTO(reqDTO dtointerface.UpdateAudioRequest) (*agg.Audio, error)
+	BuildDeleteRequestDTOFromRequest(r *http.Request) (*dto.AudioDeleteRequestDto, error)
+}
@@ -7,4 +7,5 @@ import (
 
 type Auth interface {
 	BuildAuthRequestDTOFromRequest(r *http.Request) (dtointerface.AuthRequest, error)
+	BuildRefreshRequestDTOFromRequest(r *http.Request) (dtointerface.RefreshRequest, error)
 }
@@ -0,0 +1,13 @@
+package builderinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"net/http"
+)
+
+type Moderation interface {
+	BuildVideoListRequestDTOFromRequest(r *http.Request) (*dto.ModerationVideoListRequestDTO, error)
+	BuildResourceListRequestDTOFromRequest(r *http.Request) (*dto.ModerationResourceListRequestDTO, error)
+	BuildByIdRequestDTOFromRequest(r *http.Request) (*dto.ModerationRequestByIdDTO, error)
+	BuildUserRolesRequestDTOFromRequest(r *http.Request) (*dto.ModerationUserRolesRequestDTO, error)
+}
@@ -10,4 +10,8 @@ import (
 type Resource interface {
 	BuildUploadRequestDTOFromRequest(r *http.Request) (*dto.ResourceUploadRequestDTO, error)
 	BuildAggFromUploadRequestDTO(reqDTO dtointerface.UploadResourceRequest) *agg.Resource
+	BuildCreateUploadRequestDTOFromRequest(r *http.Request) (*dto.UploadCreateRequestDTO, error)
+	BuildGetUploadRequestDTOFromRequest(r *http.Request) (*dto.UploadGetRequestDTO, error)
+	BuildPatchUploadRequestDTOFromRequest(r *http.Request) (*dto.UploadPatchRequestDTO, error)
+	BuildDeleteUploadRequestDTOFromRequest(r *http.Request) (*dto.UploadDeleteRequestDTO, error)
 }
@@ -0,0 +1,15 @@
+package builderinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"net/http"
+)
+
+type Share interface {
+	BuildCreateRequestDTOFromRequest(r *http.Request) (*dto.ShareCreateRequestDTO, error)
+	BuildAggFromCreateRequestDTO(reqDTO dtointerface.CreateShareRequest) (*agg.Share, error)
+	BuildListRequestDTOFromRequest(r *http.Request) (*dto.ShareListRequestDTO, error)
+	BuildDeleteRequestDTOFromRequest(r *http.Request) (*dto.ShareDeleteRequestDTO, error)
+}
@@ -0,0 +1,18 @@
+package builderinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"net/http"
+)
+
+type Subtitle interface {
+	BuildCreateRequestDTOFromRequest(r *http.Request) (*dto.SubtitleCreateRequestDTO, error)
+	BuildAggFromCreateRequestDTO(reqDTO dtointerface.CreateSubtitleRequest) *agg.Subtitle
+	BuildUpdateRequestDTOFromRequest(r *http.Request) (*dto.SubtitleUpdateRequestDTO, error)
+	BuildAggFromUpdateRequestDTO(reqDTO dtointerface.UpdateSubtitleRequest) (*agg.Subtitle, error)
+	BuildListRequestDTOFromRequest(r *http.Request) (*dto.SubtitleListRequestDTO, error)
+	BuildGetRequestDTOFromRequest(r *http.Request) (*dto.SubtitleGetRequestDTO, error)
+	BuildDeleteRequestDTOFromRequest(r *http.Request) (*dto.SubtitleDeleteRequestDTO, error)
+}
@@ -0,0 +1,143 @@
+package builder
+
+import (
+	"encoding/json"
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	extractorinterface "github.com/Borislavv/video-streaming/internal/domain/service/extractor/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"net/http"
+)
+
+const userIDField = "userID"
+
+type ModerationBuilder struct {
+	logger       loggerinterface.Logger
+	extractor    extractorinterface.RequestParams
+	videoBuilder builderinterface.Video
+}
+
+// NewModerationBuilder is a constructor of ModerationBuilder
+func NewModerationBuilder(serviceContainer diinterface.ServiceContainer) (*ModerationBuilder, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	requestParametersExtractor, err := serviceContainer.GetRequestParametersExtractorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	videoBuilder, err := serviceContainer.GetVideoBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &ModerationBuilder{
+		logger:       loggerService,
+		extractor:    requestParametersExtractor,
+		videoBuilder: videoBuilder,
+	}, nil
+}
+
+// BuildVideoListRequestDTOFromRequest - build a dto.ModerateVideoListRequest from raw *http.Request.
+// The filters are the same as for the own videos, but the user is taken from the query (all users when it's omitted).
+func (b *ModerationBuilder) BuildVideoListRequestDTOFromRequest(r *http.Request) (*dto.ModerationVideoListRequestDTO, error) {
+	videoDTO, err := b.videoBuilder.BuildListRequestDTOFromRequest(r)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	userID, err := b.extractUserID(r)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+	videoDTO.UserID = userID
+
+	return &dto.ModerationVideoListRequestDTO{
+		VideoListRequestDTO:  *videoDTO,
+		ModerationRequestDTO: b.buildModerationRequestDTO(r),
+	}, nil
+}
+
+// BuildResourceListRequestDTOFromRequest - build a dto.ModerateResourceListRequest from raw *http.Request
+func (b *ModerationBuilder) BuildResourceListRequestDTOFromRequest(r *http.Request) (*dto.ModerationResourceListRequestDTO, error) {
+	// the pagination is parsed in the same way as for the videos
+	videoDTO, err := b.videoBuilder.BuildListRequestDTOFromRequest(r)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	userID, err := b.extractUserID(r)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	return &dto.ModerationResourceListRequestDTO{
+		UserID:               userID,
+		PaginationRequestDTO: videoDTO.PaginationRequestDTO,
+		ModerationRequestDTO: b.buildModerationRequestDTO(r),
+	}, nil
+}
+
+// BuildByIdRequestDTOFromRequest - build a dto.ModerateByIdRequest from raw *http.Request
+func (b *ModerationBuilder) BuildByIdRequestDTOFromRequest(r *http.Request) (*dto.ModerationRequestByIdDTO, error) {
+	hexID, err := b.extractor.GetParameter(idField, r)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+	oID, err := primitive.ObjectIDFromHex(hexID)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	return dto.NewModerationRequestByIdDTO(vo.ID{Value: oID}, b.buildModerationRequestDTO(r).ModeratorRoles), nil
+}
+
+// BuildUserRolesRequestDTOFromRequest - build a dto.ModerateUserRolesRequest from raw *http.Request
+func (b *ModerationBuilder) BuildUserRolesRequestDTOFromRequest(r *http.Request) (*dto.ModerationUserRolesRequestDTO, error) {
+	rolesDTO := &dto.ModerationUserRolesRequestDTO{}
+	if err := json.NewDecoder(r.Body).Decode(rolesDTO); err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	byIdDTO, err := b.BuildByIdRequestDTOFromRequest(r)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+	rolesDTO.ID = byIdDTO.ID
+	rolesDTO.ModerationRequestDTO = byIdDTO.ModerationRequestDTO
+
+	return rolesDTO, nil
+}
+
+func (b *ModerationBuilder) buildModerationRequestDTO(r *http.Request) dto.ModerationRequestDTO {
+	moderationDTO := dto.ModerationRequestDTO{}
+
+	// setting up the roles of authorized user
+	if roles, ok := r.Context().Value(enum.UserRolesContextKey).([]string); ok {
+		moderationDTO.ModeratorRoles = roles
+	}
+
+	return moderationDTO
+}
+
+func (b *ModerationBuilder) extractUserID(r *http.Request) (vo.ID, error) {
+	if !b.extractor.HasParameter(userIDField, r) {
+		return vo.ID{}, nil
+	}
+	hexID, err := b.extractor.GetParameter(userIDField, r)
+	if err != nil {
+		return vo.ID{}, err
+	}
+	oID, err := primitive.ObjectIDFromHex(hexID)
+	if err != nil {
+		return vo.ID{}, err
+	}
+	return vo.ID{Value: oID}, nil
+}
@@ -1,20 +1,37 @@
 package builder
 
 import (
+	"encoding/base64"
+	"fmt"
 	"github.com/Borislavv/video-streaming/internal/domain/agg"
 	"github.com/Borislavv/video-streaming/internal/domain/dto"
 	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/entity"
 	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	extractorinterface "github.com/Borislavv/video-streaming/internal/domain/service/extractor/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"mime"
 	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 )
 
+// the keys of the upload metadata, the values are base64 encoded
+const (
+	filenameMetadataKey = "filename"
+	filetypeMetadataKey = "filetype"
+	defaultFiletype     = "application/octet-stream"
+)
+
 type ResourceBuilder struct {
 	logger                    loggerinterface.Logger
+	extractor                 extractorinterface.RequestParams
 	formFilename              string
 	inMemoryFileSizeThreshold int64
 }
@@ -30,8 +47,14 @@ func NewResourceBuilder(serviceContainer diinterface.ServiceContainer) (*Resourc
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	requestParametersExtractor, err := serviceContainer.GetRequestParametersExtractorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	return &ResourceBuilder{
 		logger:                    loggerService,
+		extractor:                 requestParametersExtractor,
 		formFilename:              cfg.ResourceFormFilename,
 		inMemoryFileSizeThreshold: cfg.ResourceInMemoryFileSizeThreshold,
 	}, nil
@@ -56,9 +79,148 @@ func (b *ResourceBuilder) BuildAggFromUploadRequestDTO(req dtointerface.UploadRe
 			Filepath: req.GetUploadedFilepath(),
 			Filesize: req.GetUploadedFilesize(),
 			Filetype: req.GetUploadedFiletype(),
+			Status:   entity.ResourcePending,
 		},
 		Timestamp: vo.Timestamp{
 			CreatedAt: time.Now(),
 		},
 	}
 }
+
+// BuildCreateUploadRequestDTOFromRequest will be parse the tus creation request and build a dto.CreateUploadRequest
+func (b *ResourceBuilder) BuildCreateUploadRequestDTOFromRequest(r *http.Request) (*dto.UploadCreateRequestDTO, error) {
+	if err := b.checkTusVersion(r); err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	uploadDTO := &dto.UploadCreateRequestDTO{}
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		uploadDTO.UserID = userID
+	}
+
+	length, err := b.parseIntHeader(r, enum.UploadLengthHeaderKey)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+	uploadDTO.Length = length
+
+	metadata, err := b.parseUploadMetadata(r.Header.Get(enum.UploadMetadataHeaderKey))
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+	uploadDTO.Name = metadata[filenameMetadataKey]
+	uploadDTO.Filetype = metadata[filetypeMetadataKey]
+
+	// the filetype is optional for the tus clients
+	if uploadDTO.Filetype == "" {
+		uploadDTO.Filetype = mime.TypeByExtension(filepath.Ext(uploadDTO.Name))
+		if uploadDTO.Filetype == "" {
+			uploadDTO.Filetype = defaultFiletype
+		}
+	}
+
+	return uploadDTO, nil
+}
+
+// BuildGetUploadRequestDTOFromRequest will be parse the tus offset request and build a dto.GetUploadRequest
+func (b *ResourceBuilder) BuildGetUploadRequestDTOFromRequest(r *http.Request) (*dto.UploadGetRequestDTO, error) {
+	if err := b.checkTusVersion(r); err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	uploadDTO := &dto.UploadGetRequestDTO{}
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		uploadDTO.UserID = userID
+	}
+
+	hexID, err := b.extractor.GetParameter(idField, r)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+	oID, err := primitive.ObjectIDFromHex(hexID)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+	uploadDTO.ID = vo.ID{Value: oID}
+
+	return uploadDTO, nil
+}
+
+// BuildPatchUploadRequestDTOFromRequest will be parse the tus chunk request and build a dto.PatchUploadRequest
+func (b *ResourceBuilder) BuildPatchUploadRequestDTOFromRequest(r *http.Request) (*dto.UploadPatchRequestDTO, error) {
+	uploadGetDTO, err := b.BuildGetUploadRequestDTOFromRequest(r)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	offset, err := b.parseIntHeader(r, enum.UploadOffsetHeaderKey)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	uploadDTO := dto.NewUploadPatchRequestDTO(r)
+	uploadDTO.ID = uploadGetDTO.ID
+	uploadDTO.UserID = uploadGetDTO.UserID
+	uploadDTO.Offset = offset
+	uploadDTO.ContentType = r.Header.Get(entity.MIMEContentTypeKey)
+
+	return uploadDTO, nil
+}
+
+// BuildDeleteUploadRequestDTOFromRequest will be parse the tus termination request and build a dto.DeleteUploadRequest
+func (b *ResourceBuilder) BuildDeleteUploadRequestDTOFromRequest(r *http.Request) (*dto.UploadDeleteRequestDTO, error) {
+	uploadGetDTO, err := b.BuildGetUploadRequestDTOFromRequest(r)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	return &dto.UploadDeleteRequestDTO{ID: uploadGetDTO.ID, UserID: uploadGetDTO.UserID}, nil
+}
+
+// checkTusVersion - each request of the protocol except the OPTIONS one must declare the used version.
+func (b *ResourceBuilder) checkTusVersion(r *http.Request) error {
+	if version := r.Header.Get(enum.TusResumableHeaderKey); version != enum.TusVersion {
+		return errtype.NewUnsupportedTusVersionError(version, enum.TusVersion)
+	}
+	return nil
+}
+
+func (b *ResourceBuilder) parseIntHeader(r *http.Request, key string) (int64, error) {
+	value := r.Header.Get(key)
+	if value == "" {
+		return 0, errtype.NewFieldCannotBeEmptyError(key)
+	}
+
+	i, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || i < 0 {
+		return 0, errtype.NewInvalidUploadedFileError(
+			fmt.Sprintf("header '%v' must be a non-negative integer, '%v' given", key, value),
+		)
+	}
+
+	return i, nil
+}
+
+// parseUploadMetadata - parses the comma separated pairs of key and base64 encoded value, the value may be omitted.
+func (b *ResourceBuilder) parseUploadMetadata(header string) (map[string]string, error) {
+	metadata := make(map[string]string)
+	for _, pair := range strings.Split(header, ",") {
+		fields := strings.Fields(pair)
+		if len(fields) == 0 {
+			continue
+		}
+
+		value := ""
+		if len(fields) > 1 {
+			decoded, err := base64.StdEncoding.DecodeString(fields[1])
+			if err != nil {
+				return nil, errtype.NewInvalidUploadedFileError(
+					fmt.Sprintf("upload metadata value of '%v' is not base64 encoded", fields[0]),
+				)
+			}
+			value = string(decoded)
+		}
+		metadata[fields[0]] = value
+	}
+	return metadata, nil
+}
@@ -0,0 +1,171 @@
+package builder
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	extractorinterface "github.com/Borislavv/video-streaming/internal/domain/service/extractor/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	shareIDField = "shareID"
+	// shareTokenLength - number of random bytes of the share token
+	shareTokenLength = 32
+)
+
+type ShareBuilder struct {
+	logger          loggerinterface.Logger
+	ctx             context.Context
+	extractor       extractorinterface.RequestParams
+	videoRepository repositoryinterface.Video
+}
+
+// NewShareBuilder is a constructor of ShareBuilder
+func NewShareBuilder(serviceContainer diinterface.ServiceContainer) (*ShareBuilder, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	requestParametersExtractor, err := serviceContainer.GetRequestParametersExtractorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	videoRepository, err := serviceContainer.GetVideoRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &ShareBuilder{
+		ctx:             ctx,
+		logger:          loggerService,
+		extractor:       requestParametersExtractor,
+		videoRepository: videoRepository,
+	}, nil
+}
+
+// BuildCreateRequestDTOFromRequest - build a dto.CreateShareRequest from raw *http.Request.
+// The body is optional, the share without expiration is made by the empty one.
+func (b *ShareBuilder) BuildCreateRequestDTOFromRequest(r *http.Request) (*dto.ShareCreateRequestDTO, error) {
+	shareDTO := &dto.ShareCreateRequestDTO{}
+	if err := json.NewDecoder(r.Body).Decode(shareDTO); err != nil && err != io.EOF {
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	// setting up a user id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		shareDTO.UserID = userID
+	}
+
+	// setting up a video id
+	videoID, err := b.extractID(idField, r)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+	shareDTO.VideoID = videoID
+
+	return shareDTO, nil
+}
+
+// BuildAggFromCreateRequestDTO - build an agg.Share from dto.CreateShareRequest, only the owner is able to share the video.
+func (b *ShareBuilder) BuildAggFromCreateRequestDTO(req dtointerface.CreateShareRequest) (*agg.Share, error) {
+	video, err := b.videoRepository.FindOneByID(
+		b.ctx, dto.NewVideoGetRequestDTO(req.GetVideoID(), "", vo.ID{}, req.GetUserID()),
+	)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	token, err := b.generateToken()
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	return &agg.Share{
+		Share: entity.Share{
+			VideoID:   video.ID,
+			UserID:    video.UserID,
+			Token:     token,
+			ExpiresAt: req.GetExpiresAt(),
+		},
+		Timestamp: vo.Timestamp{
+			CreatedAt: time.Now(),
+		},
+	}, nil
+}
+
+// BuildListRequestDTOFromRequest - build a dto.ListShareRequest from raw *http.Request
+func (b *ShareBuilder) BuildListRequestDTOFromRequest(r *http.Request) (*dto.ShareListRequestDTO, error) {
+	shareDTO := &dto.ShareListRequestDTO{}
+
+	// setting up a user id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		shareDTO.UserID = userID
+	}
+
+	// setting up a video id
+	videoID, err := b.extractID(idField, r)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+	shareDTO.VideoID = videoID
+
+	return shareDTO, nil
+}
+
+// BuildDeleteRequestDTOFromRequest - build a dto.DeleteShareRequest from raw *http.Request
+func (b *ShareBuilder) BuildDeleteRequestDTOFromRequest(r *http.Request) (*dto.ShareDeleteRequestDTO, error) {
+	listDTO, err := b.BuildListRequestDTOFromRequest(r)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	// setting up a share id
+	shareID, err := b.extractID(shareIDField, r)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	return dto.NewShareDeleteRequestDTO(shareID, listDTO.VideoID, listDTO.UserID), nil
+}
+
+func (b *ShareBuilder) extractID(field string, r *http.Request) (vo.ID, error) {
+	hexID, err := b.extractor.GetParameter(field, r)
+	if err != nil {
+		return vo.ID{}, err
+	}
+	oID, err := primitive.ObjectIDFromHex(hexID)
+	if err != nil {
+		return vo.ID{}, err
+	}
+	return vo.ID{Value: oID}, nil
+}
+
+// generateToken - makes the url safe random token, it's the only secret of the share link.
+func (b *ShareBuilder) generateToken() (string, error) {
+	p := make([]byte, shareTokenLength)
+	if _, err := rand.Read(p); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(p), nil
+}
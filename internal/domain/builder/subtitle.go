@@ -0,0 +1,214 @@
+package builder
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	extractorinterface "github.com/Borislavv/video-streaming/internal/domain/service/extractor/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"io"
+	"net/http"
+	"time"
+)
+
+const subtitleIDField = "subtitleID"
+
+type SubtitleBuilder struct {
+	logger     loggerinterface.Logger
+	ctx        context.Context
+	extractor  extractorinterface.RequestParams
+	repository repositoryinterface.Subtitle
+}
+
+// NewSubtitleBuilder is a constructor of SubtitleBuilder
+func NewSubtitleBuilder(serviceContainer diinterface.ServiceContainer) (*SubtitleBuilder, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	requestParametersExtractor, err := serviceContainer.GetRequestParametersExtractorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	subtitleRepository, err := serviceContainer.GetSubtitleRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &SubtitleBuilder{
+		ctx:        ctx,
+		logger:     loggerService,
+		extractor:  requestParametersExtractor,
+		repository: subtitleRepository,
+	}, nil
+}
+
+// BuildCreateRequestDTOFromRequest - build a dto.CreateSubtitleRequest from raw *http.Request,
+// the form is not parsed here because it's limited and parsed by the uploader.
+func (b *SubtitleBuilder) BuildCreateRequestDTOFromRequest(r *http.Request) (*dto.SubtitleCreateRequestDTO, error) {
+	// setting up a user id
+	userID, _ := r.Context().Value(enum.UserIDContextKey).(vo.ID)
+
+	// setting up a video id
+	videoID, err := b.extractID(idField, r)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	return dto.NewSubtitleCreateRequestDTO(r, videoID, userID), nil
+}
+
+// BuildAggFromCreateRequestDTO - build an agg.Subtitle from the dto.CreateSubtitleRequest with uploaded file,
+// the track is labeled by its language if the label is omitted.
+func (b *SubtitleBuilder) BuildAggFromCreateRequestDTO(req dtointerface.CreateSubtitleRequest) *agg.Subtitle {
+	label := req.GetLabel()
+	if label == "" {
+		label = req.GetLanguage()
+	}
+
+	return &agg.Subtitle{
+		Subtitle: entity.Subtitle{
+			VideoID:  req.GetVideoID(),
+			UserID:   req.GetUserID(),
+			Language: req.GetLanguage(),
+			Label:    label,
+			Kind:     req.GetKind(),
+			Default:  req.GetDefault(),
+			Filename: req.GetUploadedFilename(),
+			Filesize: req.GetUploadedFilesize(),
+		},
+		Timestamp: vo.Timestamp{
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		},
+	}
+}
+
+// BuildUpdateRequestDTOFromRequest - build a dto.UpdateSubtitleRequest from raw *http.Request.
+// The body is optional, nothing is changed by the empty one.
+func (b *SubtitleBuilder) BuildUpdateRequestDTOFromRequest(r *http.Request) (*dto.SubtitleUpdateRequestDTO, error) {
+	subtitleDTO := &dto.SubtitleUpdateRequestDTO{}
+	if err := json.NewDecoder(r.Body).Decode(subtitleDTO); err != nil && err != io.EOF {
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	deleteDTO, err := b.BuildDeleteRequestDTOFromRequest(r)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+	subtitleDTO.ID = deleteDTO.ID
+	subtitleDTO.VideoID = deleteDTO.VideoID
+	subtitleDTO.UserID = deleteDTO.UserID
+
+	return subtitleDTO, nil
+}
+
+// BuildAggFromUpdateRequestDTO - build an agg.Subtitle from dto.UpdateSubtitleRequest, the omitted fields are kept.
+func (b *SubtitleBuilder) BuildAggFromUpdateRequestDTO(req dtointerface.UpdateSubtitleRequest) (*agg.Subtitle, error) {
+	subtitle, err := b.repository.FindOneByID(b.ctx, req)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	changes := 0
+	if req.GetLanguage() != "" && subtitle.Language != req.GetLanguage() {
+		subtitle.Language = req.GetLanguage()
+		changes++
+	}
+	if req.GetLabel() != "" && subtitle.Label != req.GetLabel() {
+		subtitle.Label = req.GetLabel()
+		changes++
+	}
+	if req.GetKind() != "" && subtitle.GetKind() != req.GetKind() {
+		subtitle.Kind = req.GetKind()
+		changes++
+	}
+	if req.GetDefault() != nil && subtitle.Default != *req.GetDefault() {
+		subtitle.Default = *req.GetDefault()
+		changes++
+	}
+	if changes > 0 {
+		subtitle.Timestamp.UpdatedAt = time.Now()
+	}
+
+	return subtitle, nil
+}
+
+// BuildListRequestDTOFromRequest - build a dto.ListSubtitleRequest from raw *http.Request
+func (b *SubtitleBuilder) BuildListRequestDTOFromRequest(r *http.Request) (*dto.SubtitleListRequestDTO, error) {
+	subtitleDTO := &dto.SubtitleListRequestDTO{}
+
+	// setting up a user id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		subtitleDTO.UserID = userID
+	}
+
+	// setting up a video id
+	videoID, err := b.extractID(idField, r)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+	subtitleDTO.VideoID = videoID
+
+	// setting up a share token, the tracks are available for anyone who may view the video
+	if b.extractor.HasParameter(enum.ShareTokenQueryKey, r) {
+		if token, gerr := b.extractor.GetParameter(enum.ShareTokenQueryKey, r); gerr == nil {
+			subtitleDTO.ShareToken = token
+		}
+	}
+
+	return subtitleDTO, nil
+}
+
+// BuildGetRequestDTOFromRequest - build a dto.GetSubtitleRequest from raw *http.Request
+func (b *SubtitleBuilder) BuildGetRequestDTOFromRequest(r *http.Request) (*dto.SubtitleGetRequestDTO, error) {
+	listDTO, err := b.BuildListRequestDTOFromRequest(r)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	// setting up a subtitle id
+	subtitleID, err := b.extractID(subtitleIDField, r)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	return dto.NewSubtitleGetRequestDTO(subtitleID, listDTO.VideoID, listDTO.UserID, listDTO.ShareToken), nil
+}
+
+// BuildDeleteRequestDTOFromRequest - build a dto.DeleteSubtitleRequest from raw *http.Request
+func (b *SubtitleBuilder) BuildDeleteRequestDTOFromRequest(r *http.Request) (*dto.SubtitleDeleteRequestDTO, error) {
+	getDTO, err := b.BuildGetRequestDTOFromRequest(r)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	return dto.NewSubtitleDeleteRequestDTO(getDTO.ID, getDTO.VideoID, getDTO.UserID), nil
+}
+
+func (b *SubtitleBuilder) extractID(field string, r *http.Request) (vo.ID, error) {
+	hexID, err := b.extractor.GetParameter(field, r)
+	if err != nil {
+		return vo.ID{}, err
+	}
+	oID, err := primitive.ObjectIDFromHex(hexID)
+	if err != nil {
+		return vo.ID{}, err
+	}
+	return vo.ID{Value: oID}, nil
+}
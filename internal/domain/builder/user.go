@@ -108,6 +108,7 @@ func (b *UserBuilder) BuildAggFromCreateRequestDTO(req dtointerface.CreateUserRe
 			Username: req.GetUsername(),
 			Email:    req.GetEmail(),
 			Birthday: birthday,
+			Roles:    []string{enum.UserRole}, // the admins are promoted at startup, see ModerationService.PromoteAdmins
 		},
 		Timestamp: vo.Timestamp{
 			CreatedAt: time.Now(),
@@ -198,5 +199,6 @@ func (b *UserBuilder) BuildResponseDTO(user *agg.User) (*dto.UserResponseDTO, er
 		Username: user.Username,
 		Email:    user.Email,
 		Birthday: user.Birthday,
+		Roles:    user.GetRoles(),
 	}, nil
 }
@@ -105,11 +105,17 @@ func (b *VideoBuilder) BuildAggFromCreateRequestDTO(req dtointerface.CreateVideo
 		return nil, b.logger.LogPropagate(err)
 	}
 
+	visibility := req.GetVisibility()
+	if visibility == "" {
+		visibility = enum.PrivateVisibility
+	}
+
 	return &agg.Video{
 		Video: entity.Video{
 			UserID:      req.GetUserID(),
 			Name:        req.GetName(),
 			Description: req.GetDescription(),
+			Visibility:  visibility,
 		},
 		Resource: resource.Resource,
 		Timestamp: vo.Timestamp{
@@ -163,6 +169,10 @@ func (b *VideoBuilder) BuildAggFromUpdateRequestDTO(req dtointerface.UpdateVideo
 		video.Description = req.GetDescription()
 		changes++
 	}
+	if req.GetVisibility() != "" && video.GetVisibility() != req.GetVisibility() {
+		video.Visibility = req.GetVisibility()
+		changes++
+	}
 	if !req.GetResourceID().Value.IsZero() {
 		resource, ferr := b.resourceRepository.FindOneByID(
 			b.ctx, dto.NewResourceGetRequestDTO(req.GetResourceID(), req.GetUserID()),
@@ -201,6 +211,13 @@ func (b *VideoBuilder) BuildGetRequestDTOFromRequest(r *http.Request) (*dto.Vide
 	}
 	videoDTO.ID = vo.ID{Value: oID}
 
+	// setting up a share token, it's used only while the video is viewed
+	if b.extractor.HasParameter(enum.ShareTokenQueryKey, r) {
+		if token, gerr := b.extractor.GetParameter(enum.ShareTokenQueryKey, r); gerr == nil {
+			videoDTO.ShareToken = token
+		}
+	}
+
 	return videoDTO, nil
 }
 
@@ -1 +1,126 @@
 package dto
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"time"
+)
+
+// AudioCreateRequestDTO - used when u want to create a new one audio.
+type AudioCreateRequestDTO struct {
+	/*Required*/ Name string `json:"name"`
+	/*Required*/ UserID vo.ID
+	/*Required*/ ResourceID vo.ID `json:"resourceID"`
+	/*Optional*/ Description string `json:"description,omitempty"`
+}
+
+func (req *AudioCreateRequestDTO) GetName() string {
+	return req.Name
+}
+func (req *AudioCreateRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+func (req *AudioCreateRequestDTO) GetResourceID() vo.ID {
+	return req.ResourceID
+}
+func (req *AudioCreateRequestDTO) GetDescription() string {
+	return req.Description
+}
+
+// AudioUpdateRequestDTO - used when u want to update an audio record.
+type AudioUpdateRequestDTO struct {
+	/*Required*/ ID vo.ID `json:"id"`
+	/*Optional*/ Name string `json:"name"`
+	/*Optional*/ UserID vo.ID
+	/*Optional*/ ResourceID vo.ID `json:"resourceID"`
+	/*Optional*/ Description string `json:"description,omitempty"`
+}
+
+func (req *AudioUpdateRequestDTO) GetID() vo.ID {
+	return req.ID
+}
+func (req *AudioUpdateRequestDTO) GetName() string {
+	return req.Name
+}
+func (req *AudioUpdateRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+func (req *AudioUpdateRequestDTO) GetResourceID() vo.ID {
+	return req.ResourceID
+}
+func (req *AudioUpdateRequestDTO) GetDescription() string {
+	return req.Description
+}
+
+// AudioGetRequestDTO - used when you want to find a single audio by Name or ID, but you always must specify a UserID.
+type AudioGetRequestDTO struct {
+	/*Optional*/ ID vo.ID `json:"id"`
+	/*Optional*/ Name string
+	/*Optional*/ ResourceID vo.ID
+	/*Required*/ UserID vo.ID
+}
+
+func NewAudioGetRequestDTO(id vo.ID, name string, resourceID vo.ID, userID vo.ID) *AudioGetRequestDTO {
+	return &AudioGetRequestDTO{
+		ID:         id,
+		Name:       name,
+		ResourceID: resourceID,
+		UserID:     userID,
+	}
+}
+func (req *AudioGetRequestDTO) GetID() vo.ID {
+	return req.ID
+}
+func (req *AudioGetRequestDTO) GetName() string {
+	return req.Name
+}
+func (req *AudioGetRequestDTO) GetResourceID() vo.ID {
+	return req.ResourceID
+}
+func (req *AudioGetRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+
+// AudioListRequestDTO - used when u want to find a collection of audios.
+type AudioListRequestDTO struct {
+	/*Required*/ UserID vo.ID
+	/*Optional*/ Name string `json:"name"` // part of name
+	/*Optional*/ CreatedAt time.Time `json:"createdAt" format:"2006-01-02T15:04:05Z07:00"`
+	/*Optional*/ From time.Time `json:"from" format:"2006-01-02T15:04:05Z07:00"`
+	/*Optional*/ To time.Time `json:"to" format:"2006-01-02T15:04:05Z07:00"`
+	/*Optional*/ PaginationRequestDTO
+}
+
+func (req *AudioListRequestDTO) GetName() string {
+	return req.Name
+}
+func (req *AudioListRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+func (req *AudioListRequestDTO) GetCreatedAt() time.Time {
+	return req.CreatedAt
+}
+func (req *AudioListRequestDTO) GetFrom() time.Time {
+	return req.From
+}
+func (req *AudioListRequestDTO) GetTo() time.Time {
+	return req.To
+}
+
+// AudioDeleteRequestDto - used when you want to remove the audio.
+type AudioDeleteRequestDto struct {
+	/*Required*/ ID vo.ID `json:"id"`
+	/*Required*/ UserID vo.ID
+}
+
+func NewAudioDeleteRequestDto(id vo.ID, userID vo.ID) *AudioDeleteRequestDto {
+	return &AudioDeleteRequestDto{
+		ID:     id,
+		UserID: userID,
+	}
+}
+func (req *AudioDeleteRequestDto) GetID() vo.ID {
+	return req.ID
+}
+func (req *AudioDeleteRequestDto) GetUserID() vo.ID {
+	return req.UserID
+}
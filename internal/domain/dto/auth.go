@@ -12,3 +12,37 @@ func (r *AuthRequestDTO) GetEmail() string {
 func (r *AuthRequestDTO) GetPassword() string {
 	return r.Password
 }
+
+// RefreshRequestDTO - used when u want to exchange the refresh token to the new pair of tokens.
+type RefreshRequestDTO struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+func (r *RefreshRequestDTO) GetRefreshToken() string {
+	return r.RefreshToken
+}
+
+// TokensResponseDTO - is a pair of tokens which is issued by log in and by refresh.
+type TokensResponseDTO struct {
+	AccessToken  string `json:"accessToken"`
+	RefreshToken string `json:"refreshToken"`
+	ExpiresIn    int64  `json:"expiresIn"` // access token TTL in seconds
+}
+
+// JwkResponseDTO - is a public key which verifies the access tokens (RFC 7517), the fields depend on the key type.
+type JwkResponseDTO struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Crv string `json:"crv,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JwksResponseDTO - is a set of public keys which is served by `/.well-known/jwks.json`.
+type JwksResponseDTO struct {
+	Keys []*JwkResponseDTO `json:"keys"`
+}
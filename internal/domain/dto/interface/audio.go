@@ -7,6 +7,7 @@ import (
 
 type CreateAudioRequest interface {
 	GetName() string
+	GetUserID() vo.ID
 	GetResourceID() vo.ID
 	GetDescription() string
 }
@@ -14,15 +15,18 @@ type CreateAudioRequest interface {
 type UpdateAudioRequest interface {
 	GetID() vo.ID
 	GetName() string
+	GetUserID() vo.ID
 	GetResourceID() vo.ID
 	GetDescription() string
 }
 
 type GetAudioRequest interface {
 	GetID() vo.ID
+	GetUserID() vo.ID
 }
 type ListAudioRequest interface {
-	GetName() string         // path of name
+	GetName() string         // part of name
+	GetUserID() vo.ID        // user identifier
 	GetCreatedAt() time.Time // concrete search date point
 	GetFrom() time.Time      // search date limit from
 	GetTo() time.Time        // search date limit to
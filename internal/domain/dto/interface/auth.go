@@ -4,3 +4,7 @@ type AuthRequest interface {
 	GetEmail() string
 	GetPassword() string
 }
+
+type RefreshRequest interface {
+	GetRefreshToken() string
+}
@@ -0,0 +1,31 @@
+package dtointerface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+)
+
+type ModerationRequest interface {
+	GetModeratorRoles() []string
+}
+
+type ModerateVideoListRequest interface {
+	ListVideoRequest
+	ModerationRequest
+}
+
+type ModerateResourceListRequest interface {
+	GetUserID() vo.ID // user identifier (empty means all users)
+	PaginatedRequest
+	ModerationRequest
+}
+
+type ModerateByIdRequest interface {
+	GetID() vo.ID
+	ModerationRequest
+}
+
+type ModerateUserRolesRequest interface {
+	GetID() vo.ID
+	GetRoles() []string
+	ModerationRequest
+}
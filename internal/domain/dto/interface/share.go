@@ -0,0 +1,23 @@
+package dtointerface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"time"
+)
+
+type CreateShareRequest interface {
+	GetVideoID() vo.ID
+	GetUserID() vo.ID
+	GetExpiresAt() *time.Time
+}
+
+type ListShareRequest interface {
+	GetVideoID() vo.ID
+	GetUserID() vo.ID
+}
+
+type DeleteShareRequest interface {
+	GetID() vo.ID
+	GetVideoID() vo.ID
+	GetUserID() vo.ID
+}
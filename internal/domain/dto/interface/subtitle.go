@@ -0,0 +1,43 @@
+package dtointerface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+)
+
+type CreateSubtitleRequest interface {
+	UploadResourceRequest
+	GetVideoID() vo.ID
+	GetLanguage() string
+	GetLabel() string
+	GetKind() string
+	GetDefault() bool
+}
+
+type UpdateSubtitleRequest interface {
+	GetID() vo.ID
+	GetVideoID() vo.ID
+	GetUserID() vo.ID
+	GetLanguage() string
+	GetLabel() string
+	GetKind() string
+	GetDefault() *bool
+}
+
+type ListSubtitleRequest interface {
+	GetVideoID() vo.ID
+	GetUserID() vo.ID // empty for the anonymous viewer
+	GetShareToken() string
+}
+
+type GetSubtitleRequest interface {
+	GetID() vo.ID
+	GetVideoID() vo.ID
+	GetUserID() vo.ID // empty for the anonymous viewer
+	GetShareToken() string
+}
+
+type DeleteSubtitleRequest interface {
+	GetID() vo.ID
+	GetVideoID() vo.ID
+	GetUserID() vo.ID
+}
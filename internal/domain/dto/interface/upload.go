@@ -0,0 +1,30 @@
+package dtointerface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"io"
+	"net/http"
+)
+
+type CreateUploadRequest interface {
+	GetUserID() vo.ID
+	GetName() string
+	GetFiletype() string
+	GetLength() int64
+}
+
+type GetUploadRequest interface {
+	GetID() vo.ID
+	GetUserID() vo.ID
+}
+
+type PatchUploadRequest interface {
+	GetID() vo.ID
+	GetUserID() vo.ID
+	GetOffset() int64
+	GetContentType() string
+	GetRequest() *http.Request
+	GetBody() io.Reader
+}
+
+type DeleteUploadRequest GetUploadRequest
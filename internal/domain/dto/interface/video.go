@@ -10,6 +10,7 @@ type CreateVideoRequest interface {
 	GetUserID() vo.ID
 	GetResourceID() vo.ID
 	GetDescription() string
+	GetVisibility() string
 }
 
 type UpdateVideoRequest interface {
@@ -18,12 +19,20 @@ type UpdateVideoRequest interface {
 	GetUserID() vo.ID
 	GetResourceID() vo.ID
 	GetDescription() string
+	GetVisibility() string
 }
 
 type GetVideoRequest interface {
 	GetID() vo.ID
 	GetUserID() vo.ID
 }
+
+type ViewVideoRequest interface {
+	GetID() vo.ID
+	GetUserID() vo.ID // empty for the anonymous viewer
+	GetShareToken() string
+}
+
 type ListVideoRequest interface {
 	GetName() string         // part of name
 	GetUserID() vo.ID        // user identifier
@@ -0,0 +1,61 @@
+package dto
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+)
+
+// ModerationRequestDTO - carries the roles of the user who makes the moderation request.
+type ModerationRequestDTO struct {
+	/*Required*/ ModeratorRoles []string
+}
+
+func (req *ModerationRequestDTO) GetModeratorRoles() []string {
+	return req.ModeratorRoles
+}
+
+// ModerationVideoListRequestDTO - used when u want to find the videos of any user (of all users by empty user id).
+type ModerationVideoListRequestDTO struct {
+	VideoListRequestDTO
+	ModerationRequestDTO
+}
+
+// ModerationResourceListRequestDTO - used when u want to find the resources of any user (of all users by empty user id).
+type ModerationResourceListRequestDTO struct {
+	/*Optional*/ UserID vo.ID
+	/*Optional*/ PaginationRequestDTO
+	ModerationRequestDTO
+}
+
+func (req *ModerationResourceListRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+
+// ModerationRequestByIdDTO - used when u want to get or delete the video or resource of any user.
+type ModerationRequestByIdDTO struct {
+	/*Required*/ ID vo.ID
+	ModerationRequestDTO
+}
+
+func NewModerationRequestByIdDTO(id vo.ID, moderatorRoles []string) *ModerationRequestByIdDTO {
+	return &ModerationRequestByIdDTO{
+		ID:                   id,
+		ModerationRequestDTO: ModerationRequestDTO{ModeratorRoles: moderatorRoles},
+	}
+}
+func (req *ModerationRequestByIdDTO) GetID() vo.ID {
+	return req.ID
+}
+
+// ModerationUserRolesRequestDTO - used when u want to change the roles of the user.
+type ModerationUserRolesRequestDTO struct {
+	/*Required*/ ID vo.ID
+	/*Required*/ Roles []string `json:"roles"`
+	ModerationRequestDTO
+}
+
+func (req *ModerationUserRolesRequestDTO) GetID() vo.ID {
+	return req.ID
+}
+func (req *ModerationUserRolesRequestDTO) GetRoles() []string {
+	return req.Roles
+}
@@ -0,0 +1,66 @@
+package dto
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"time"
+)
+
+// ShareCreateRequestDTO - used when u want to make a new share link of the video.
+type ShareCreateRequestDTO struct {
+	/*Required*/ VideoID vo.ID
+	/*Required*/ UserID vo.ID
+	/*Optional*/ ExpiresAt *time.Time `json:"expiresAt,omitempty" format:"2006-01-02T15:04:05Z07:00"`
+}
+
+func (req *ShareCreateRequestDTO) GetVideoID() vo.ID {
+	return req.VideoID
+}
+func (req *ShareCreateRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+func (req *ShareCreateRequestDTO) GetExpiresAt() *time.Time {
+	return req.ExpiresAt
+}
+
+// ShareListRequestDTO - used when u want to find the share links of the video.
+type ShareListRequestDTO struct {
+	/*Required*/ VideoID vo.ID
+	/*Required*/ UserID vo.ID
+}
+
+func NewShareListRequestDTO(videoID vo.ID, userID vo.ID) *ShareListRequestDTO {
+	return &ShareListRequestDTO{
+		VideoID: videoID,
+		UserID:  userID,
+	}
+}
+func (req *ShareListRequestDTO) GetVideoID() vo.ID {
+	return req.VideoID
+}
+func (req *ShareListRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+
+// ShareDeleteRequestDTO - used when u want to revoke the share link.
+type ShareDeleteRequestDTO struct {
+	/*Required*/ ID vo.ID
+	/*Required*/ VideoID vo.ID
+	/*Required*/ UserID vo.ID
+}
+
+func NewShareDeleteRequestDTO(id vo.ID, videoID vo.ID, userID vo.ID) *ShareDeleteRequestDTO {
+	return &ShareDeleteRequestDTO{
+		ID:      id,
+		VideoID: videoID,
+		UserID:  userID,
+	}
+}
+func (req *ShareDeleteRequestDTO) GetID() vo.ID {
+	return req.ID
+}
+func (req *ShareDeleteRequestDTO) GetVideoID() vo.ID {
+	return req.VideoID
+}
+func (req *ShareDeleteRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
@@ -0,0 +1,202 @@
+package dto
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// form fields of the subtitle track which are sent together with the file
+const (
+	SubtitleLanguageFormField = "language"
+	SubtitleLabelFormField    = "label"
+	SubtitleKindFormField     = "kind"
+	SubtitleDefaultFormField  = "default"
+)
+
+// SubtitleCreateRequestDTO - used when u want to upload a new subtitle track of the video. The fields of track
+// are sent by the form together with the file, so they are available only after the form was parsed by the uploader.
+type SubtitleCreateRequestDTO struct {
+	/*Required*/ VideoID vo.ID
+	/*Required*/ UserID vo.ID
+
+	request          *http.Request
+	originFilename   string
+	uploadedFilename string
+	uploadedFilepath string
+	uploadedFiletype string
+	uploadedFilesize int64
+}
+
+func NewSubtitleCreateRequestDTO(r *http.Request, videoID vo.ID, userID vo.ID) *SubtitleCreateRequestDTO {
+	return &SubtitleCreateRequestDTO{
+		VideoID: videoID,
+		UserID:  userID,
+		request: r,
+	}
+}
+func (req *SubtitleCreateRequestDTO) GetVideoID() vo.ID {
+	return req.VideoID
+}
+func (req *SubtitleCreateRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+func (req *SubtitleCreateRequestDTO) GetLanguage() string {
+	return strings.TrimSpace(req.request.PostForm.Get(SubtitleLanguageFormField))
+}
+func (req *SubtitleCreateRequestDTO) GetLabel() string {
+	return strings.TrimSpace(req.request.PostForm.Get(SubtitleLabelFormField))
+}
+func (req *SubtitleCreateRequestDTO) GetKind() string {
+	return strings.TrimSpace(req.request.PostForm.Get(SubtitleKindFormField))
+}
+func (req *SubtitleCreateRequestDTO) GetDefault() bool {
+	isDefault, _ := strconv.ParseBool(req.request.PostForm.Get(SubtitleDefaultFormField))
+	return isDefault
+}
+func (req *SubtitleCreateRequestDTO) GetRequest() *http.Request {
+	return req.request
+}
+func (req *SubtitleCreateRequestDTO) GetOriginFilename() string {
+	return req.originFilename
+}
+func (req *SubtitleCreateRequestDTO) SetOriginFilename(filename string) {
+	req.originFilename = filename
+}
+func (req *SubtitleCreateRequestDTO) GetUploadedFilename() string {
+	return req.uploadedFilename
+}
+func (req *SubtitleCreateRequestDTO) SetUploadedFilename(filename string) {
+	req.uploadedFilename = filename
+}
+func (req *SubtitleCreateRequestDTO) GetUploadedFilepath() string {
+	return req.uploadedFilepath
+}
+func (req *SubtitleCreateRequestDTO) SetUploadedFilepath(filepath string) {
+	req.uploadedFilepath = filepath
+}
+func (req *SubtitleCreateRequestDTO) GetUploadedFilesize() int64 {
+	return req.uploadedFilesize
+}
+func (req *SubtitleCreateRequestDTO) SetUploadedFilesize(filesize int64) {
+	req.uploadedFilesize = filesize
+}
+func (req *SubtitleCreateRequestDTO) GetUploadedFiletype() string {
+	return req.uploadedFiletype
+}
+func (req *SubtitleCreateRequestDTO) SetUploadedFiletype(filetype string) {
+	req.uploadedFiletype = filetype
+}
+
+// SubtitleUpdateRequestDTO - used when u want to change the fields of subtitle track, the file is not replaceable.
+type SubtitleUpdateRequestDTO struct {
+	/*Required*/ ID vo.ID
+	/*Required*/ VideoID vo.ID
+	/*Required*/ UserID vo.ID
+	/*Optional*/ Language string `json:"language,omitempty"` // unchanged if omitted
+	/*Optional*/ Label string `json:"label,omitempty"` // unchanged if omitted
+	/*Optional*/ Kind string `json:"kind,omitempty"` // unchanged if omitted
+	/*Optional*/ Default *bool `json:"default,omitempty"` // unchanged if omitted
+}
+
+func (req *SubtitleUpdateRequestDTO) GetID() vo.ID {
+	return req.ID
+}
+func (req *SubtitleUpdateRequestDTO) GetVideoID() vo.ID {
+	return req.VideoID
+}
+func (req *SubtitleUpdateRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+func (req *SubtitleUpdateRequestDTO) GetLanguage() string {
+	return req.Language
+}
+func (req *SubtitleUpdateRequestDTO) GetLabel() string {
+	return req.Label
+}
+func (req *SubtitleUpdateRequestDTO) GetKind() string {
+	return req.Kind
+}
+func (req *SubtitleUpdateRequestDTO) GetDefault() *bool {
+	return req.Default
+}
+
+// SubtitleListRequestDTO - used when u want to find the subtitle tracks of the video. The tracks are available
+// for anyone who may view the video, so the user may be omitted and the ShareToken grants access to the private one.
+type SubtitleListRequestDTO struct {
+	/*Required*/ VideoID vo.ID
+	/*Optional*/ UserID vo.ID
+	/*Optional*/ ShareToken string
+}
+
+func NewSubtitleListRequestDTO(videoID vo.ID, userID vo.ID, shareToken string) *SubtitleListRequestDTO {
+	return &SubtitleListRequestDTO{
+		VideoID:    videoID,
+		UserID:     userID,
+		ShareToken: shareToken,
+	}
+}
+func (req *SubtitleListRequestDTO) GetVideoID() vo.ID {
+	return req.VideoID
+}
+func (req *SubtitleListRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+func (req *SubtitleListRequestDTO) GetShareToken() string {
+	return req.ShareToken
+}
+
+// SubtitleGetRequestDTO - used when u want to find a single subtitle track of the video, the access is the same
+// as of the SubtitleListRequestDTO.
+type SubtitleGetRequestDTO struct {
+	/*Required*/ ID vo.ID
+	/*Required*/ VideoID vo.ID
+	/*Optional*/ UserID vo.ID
+	/*Optional*/ ShareToken string
+}
+
+func NewSubtitleGetRequestDTO(id vo.ID, videoID vo.ID, userID vo.ID, shareToken string) *SubtitleGetRequestDTO {
+	return &SubtitleGetRequestDTO{
+		ID:         id,
+		VideoID:    videoID,
+		UserID:     userID,
+		ShareToken: shareToken,
+	}
+}
+func (req *SubtitleGetRequestDTO) GetID() vo.ID {
+	return req.ID
+}
+func (req *SubtitleGetRequestDTO) GetVideoID() vo.ID {
+	return req.VideoID
+}
+func (req *SubtitleGetRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+func (req *SubtitleGetRequestDTO) GetShareToken() string {
+	return req.ShareToken
+}
+
+// SubtitleDeleteRequestDTO - used when u want to remove the subtitle track with its file.
+type SubtitleDeleteRequestDTO struct {
+	/*Required*/ ID vo.ID
+	/*Required*/ VideoID vo.ID
+	/*Required*/ UserID vo.ID
+}
+
+func NewSubtitleDeleteRequestDTO(id vo.ID, videoID vo.ID, userID vo.ID) *SubtitleDeleteRequestDTO {
+	return &SubtitleDeleteRequestDTO{
+		ID:      id,
+		VideoID: videoID,
+		UserID:  userID,
+	}
+}
+func (req *SubtitleDeleteRequestDTO) GetID() vo.ID {
+	return req.ID
+}
+func (req *SubtitleDeleteRequestDTO) GetVideoID() vo.ID {
+	return req.VideoID
+}
+func (req *SubtitleDeleteRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
@@ -0,0 +1,87 @@
+package dto
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"io"
+	"net/http"
+)
+
+type UploadCreateRequestDTO struct {
+	UserID   vo.ID
+	Name     string
+	Filetype string
+	Length   int64
+}
+
+func (req *UploadCreateRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+func (req *UploadCreateRequestDTO) GetName() string {
+	return req.Name
+}
+func (req *UploadCreateRequestDTO) GetFiletype() string {
+	return req.Filetype
+}
+func (req *UploadCreateRequestDTO) GetLength() int64 {
+	return req.Length
+}
+
+type UploadGetRequestDTO struct {
+	ID     vo.ID `json:"id"`
+	UserID vo.ID
+}
+
+func NewUploadGetRequestDTO(id vo.ID, userID vo.ID) *UploadGetRequestDTO {
+	return &UploadGetRequestDTO{
+		ID:     id,
+		UserID: userID,
+	}
+}
+func (req *UploadGetRequestDTO) GetID() vo.ID {
+	return req.ID
+}
+func (req *UploadGetRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+
+type UploadPatchRequestDTO struct {
+	ID          vo.ID
+	UserID      vo.ID
+	Offset      int64
+	ContentType string
+	request     *http.Request
+}
+
+func NewUploadPatchRequestDTO(r *http.Request) *UploadPatchRequestDTO {
+	return &UploadPatchRequestDTO{request: r}
+}
+func (req *UploadPatchRequestDTO) GetID() vo.ID {
+	return req.ID
+}
+func (req *UploadPatchRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+func (req *UploadPatchRequestDTO) GetOffset() int64 {
+	return req.Offset
+}
+func (req *UploadPatchRequestDTO) GetContentType() string {
+	return req.ContentType
+}
+func (req *UploadPatchRequestDTO) GetRequest() *http.Request {
+	return req.request
+}
+func (req *UploadPatchRequestDTO) GetBody() io.Reader {
+	return req.request.Body
+}
+
+type UploadDeleteRequestDTO struct {
+	ID     vo.ID `json:"id"`
+	UserID vo.ID
+}
+
+func (req *UploadDeleteRequestDTO) GetID() vo.ID {
+	return req.ID
+}
+func (req *UploadDeleteRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
@@ -79,4 +79,5 @@ type UserResponseDTO struct {
 	Username string    `json:"username" bson:"username"`
 	Email    string    `json:"email" bson:"email"` // unique key
 	Birthday time.Time `json:"birthday" bson:"birthday,omitempty"`
+	Roles    []string  `json:"roles" bson:"roles,omitempty"`
 }
@@ -11,6 +11,7 @@ type VideoCreateRequestDTO struct {
 	/*Required*/ UserID vo.ID
 	/*Required*/ ResourceID vo.ID `json:"resourceID"`
 	/*Optional*/ Description string `json:"description,omitempty"`
+	/*Optional*/ Visibility string `json:"visibility,omitempty"` // private by default
 }
 
 func (req *VideoCreateRequestDTO) GetName() string {
@@ -25,6 +26,9 @@ func (req *VideoCreateRequestDTO) GetResourceID() vo.ID {
 func (req *VideoCreateRequestDTO) GetDescription() string {
 	return req.Description
 }
+func (req *VideoCreateRequestDTO) GetVisibility() string {
+	return req.Visibility
+}
 
 // VideoUpdateRequestDTO - used when u want to update a video record.
 type VideoUpdateRequestDTO struct {
@@ -33,6 +37,7 @@ type VideoUpdateRequestDTO struct {
 	/*Optional*/ UserID vo.ID
 	/*Optional*/ ResourceID vo.ID `json:"resourceID"`
 	/*Optional*/ Description string `json:"description,omitempty"`
+	/*Optional*/ Visibility string `json:"visibility,omitempty"` // unchanged if omitted
 }
 
 func (req *VideoUpdateRequestDTO) GetID() vo.ID {
@@ -50,13 +55,18 @@ func (req *VideoUpdateRequestDTO) GetResourceID() vo.ID {
 func (req *VideoUpdateRequestDTO) GetDescription() string {
 	return req.Description
 }
+func (req *VideoUpdateRequestDTO) GetVisibility() string {
+	return req.Visibility
+}
 
 // VideoGetRequestDTO - used when you want to find a single video by Name or ID, but you always must specify a UserID.
+// The video which is viewed by ID may be requested without UserID, the ShareToken grants access to the private one.
 type VideoGetRequestDTO struct {
 	/*Optional*/ ID vo.ID `json:"id"`
 	/*Optional*/ Name string
 	/*Optional*/ ResourceID vo.ID
 	/*Required*/ UserID vo.ID
+	/*Optional*/ ShareToken string
 }
 
 func NewVideoGetRequestDTO(id vo.ID, name string, resourceID vo.ID, userID vo.ID) *VideoGetRequestDTO {
@@ -67,6 +77,13 @@ func NewVideoGetRequestDTO(id vo.ID, name string, resourceID vo.ID, userID vo.ID
 		UserID:     userID,
 	}
 }
+func NewVideoViewRequestDTO(id vo.ID, userID vo.ID, shareToken string) *VideoGetRequestDTO {
+	return &VideoGetRequestDTO{
+		ID:         id,
+		UserID:     userID,
+		ShareToken: shareToken,
+	}
+}
 func (req *VideoGetRequestDTO) GetID() vo.ID {
 	return req.ID
 }
@@ -79,6 +96,9 @@ func (req *VideoGetRequestDTO) GetResourceID() vo.ID {
 func (req *VideoGetRequestDTO) GetUserID() vo.ID {
 	return req.UserID
 }
+func (req *VideoGetRequestDTO) GetShareToken() string {
+	return req.ShareToken
+}
 
 // VideoListRequestDTO - used when u want to find a collection of videos.
 type VideoListRequestDTO struct {
@@ -141,3 +161,17 @@ func (req *VideoDeleteRequestDto) GetID() vo.ID {
 func (req *VideoDeleteRequestDto) GetUserID() vo.ID {
 	return req.UserID
 }
+
+// VideoResponseDTO - is the public representation of the video which is returned to the viewers who are not
+// its owner, it has no owner and no storage details of the resource and renditions.
+type VideoResponseDTO struct {
+	ID            vo.ID           `json:"id"`
+	Name          string          `json:"name"`
+	Description   string          `json:"description"`
+	Duration      float64         `json:"duration"` // seconds, zero while the resource is not processed
+	PosterURL     string          `json:"posterURL,omitempty"`
+	StoryboardURL string          `json:"storyboardURL,omitempty"`
+	Codecs        []string        `json:"codecs"` // RFC 6381 codecs of the renditions, e.g. "avc1.64001F"
+	AudioTracks   []vo.AudioTrack `json:"audioTracks"`
+	Subtitles     []vo.TextTrack  `json:"subtitles"`
+}
@@ -3,10 +3,10 @@ package entity
 import "github.com/Borislavv/video-streaming/internal/domain/vo"
 
 type Audio struct {
-	ID          vo.ID  `json:"id,omitempty" bson:"_id,omitempty,inline"`
+	ID          vo.ID  `json:"id" bson:",inline"`
 	UserID      vo.ID  `json:"userID" bson:"user"`
-	Name        string `bson:"name"`
-	Description string `bson:"description,omitempty"`
+	Name        string `json:"name" bson:"name"`
+	Description string `json:"description" bson:"description,omitempty"`
 }
 
 func (r Audio) GetID() vo.ID {
@@ -0,0 +1,22 @@
+package entity
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+// Blob - is a stored file which is named by the SHA-256 of its content, so the identical files of the user
+// are stored once and shared by the resources which refer to it.
+type Blob struct {
+	ID         vo.ID  `json:"id" bson:",inline"`
+	UserID     vo.ID  `json:"userID" bson:"user"`           // user identifier
+	Filename   string `json:"filename" bson:"filename"`     // content addressed filename
+	References int64  `json:"references" bson:"references"` // number of resources which refer to the blob
+}
+
+func (b Blob) GetID() vo.ID {
+	return b.ID
+}
+func (b Blob) GetUserID() vo.ID {
+	return b.UserID
+}
+func (b Blob) GetFilename() string {
+	return b.Filename
+}
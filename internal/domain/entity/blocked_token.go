@@ -11,6 +11,17 @@ type BlockedToken struct {
 	Value     string    `bson:"value"`
 	Reason    string    `bson:"reason"`
 	BlockedAt time.Time `bson:"blockedAt"`
+	// ExpiresAt is an expiration of the token itself, the blocked token is removed after it (by the TTL index).
+	// The token which was blocked before the expiration was introduced takes it at startup by the blocking time.
+	ExpiresAt time.Time `bson:"expiresAt,omitempty"`
+	// Session is a family of refresh tokens which is blocked entirely, the value is empty then. All access tokens
+	// which were issued with the session are rejected until the last of them is expired.
+	Session string `bson:"session,omitempty"`
+}
+
+// IsExpired - the expired token is rejected by verification anyway, so it's no longer need to be blocked.
+func (r BlockedToken) IsExpired(now time.Time) bool {
+	return !r.ExpiresAt.IsZero() && !now.Before(r.ExpiresAt)
 }
 
 func (r BlockedToken) GetID() vo.ID {
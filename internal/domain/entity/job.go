@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"time"
+)
+
+// statuses of the background job
+const (
+	JobPending    = "pending"
+	JobProcessing = "processing"
+	JobDone       = "done"
+	JobFailed     = "failed"
+)
+
+// Job - is a background task which processes the uploaded resource.
+type Job struct {
+	ID         vo.ID     `json:"id" bson:",inline"`
+	Type       string    `json:"type" bson:"type"`
+	UserID     vo.ID     `json:"userID" bson:"user"`         // owner of the resource
+	ResourceID vo.ID     `json:"resourceID" bson:"resource"` // processed resource
+	Status     string    `json:"status" bson:"status"`
+	Attempts   int       `json:"attempts" bson:"attempts"`     // number of started attempts
+	Progress   float64   `json:"progress" bson:"progress"`     // progress of the current attempt from 0 to 1
+	Error      string    `json:"error" bson:"error"`           // reason of the last failed attempt
+	RunAt      time.Time `json:"runAt" bson:"runAt"`           // the job will not be taken before this time
+	LeasedTill time.Time `json:"leasedTill" bson:"leasedTill"` // the worker lease, the expired job is taken again
+	Lease      string    `json:"-" bson:"lease"`               // token of the worker lease, checked on updates
+}
+
+func (j Job) GetID() vo.ID {
+	return j.ID
+}
+func (j Job) GetType() string {
+	return j.Type
+}
+func (j Job) GetUserID() vo.ID {
+	return j.UserID
+}
+func (j Job) GetResourceID() vo.ID {
+	return j.ResourceID
+}
@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"time"
+)
+
+// RefreshToken - is a single use token which is exchanged to the new pair of tokens. All tokens which are
+// rotated from the same log in are joined by the family, it's revoked entirely on logout or reuse of a token.
+type RefreshToken struct {
+	ID        vo.ID      `json:"id" bson:",inline"`
+	UserID    vo.ID      `json:"userID" bson:"user"`
+	Family    string     `json:"family" bson:"family"`
+	Hash      string     `json:"-" bson:"hash"` // sha256 of the token, the token itself is known by the client only
+	ExpiresAt time.Time  `json:"expiresAt" bson:"expiresAt"`
+	UsedAt    *time.Time `json:"usedAt,omitempty" bson:"usedAt,omitempty"`
+	RevokedAt *time.Time `json:"revokedAt,omitempty" bson:"revokedAt,omitempty"`
+}
+
+func (t RefreshToken) GetID() vo.ID {
+	return t.ID
+}
+
+// IsExpired - checks whether the token is not valid anymore at the given moment.
+func (t RefreshToken) IsExpired(at time.Time) bool {
+	return !at.Before(t.ExpiresAt)
+}
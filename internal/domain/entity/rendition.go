@@ -0,0 +1,16 @@
+package entity
+
+// Rendition - is an encoding of the video resource with specified resolution and bitrate.
+type Rendition struct {
+	Resource   Resource `json:"resource" bson:"resource"`
+	Width      int      `json:"width" bson:"width"`
+	Height     int      `json:"height" bson:"height"`
+	Bitrate    int64    `json:"bitrate" bson:"bitrate"` // bits per second
+	AudioCodec string   `json:"audioCodec" bson:"audioCodec"`
+	VideoCodec string   `json:"videoCodec" bson:"videoCodec"`
+	Original   bool     `json:"original" bson:"original"` // whether the resource is the uploaded one
+}
+
+func (r Rendition) GetResource() Resource {
+	return r.Resource
+}
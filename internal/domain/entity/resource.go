@@ -9,14 +9,30 @@ const (
 	MIMEContentTypeKey        = "Content-Type"
 )
 
+// statuses of the resource processing, the resource may be streamed only when it's ready
+const (
+	ResourcePending    = "pending"
+	ResourceProcessing = "processing"
+	ResourceReady      = "ready"
+	ResourceFailed     = "failed"
+)
+
 type Resource struct {
-	ID       vo.ID  `json:"id" bson:",inline"`
-	UserID   vo.ID  `json:"userID" bson:"user"`       // user identifier
-	Name     string `json:"name" bson:"name"`         // original filename
-	Filename string `json:"filename" bson:"filename"` // uploaded filename
-	Filepath string `json:"filepath" bson:"filepath"` // path to uploaded file
-	Filetype string `json:"filetype" bson:"filetype"` // filetype
-	Filesize int64  `json:"filesize" bson:"filesize"` // size of uploaded file
+	ID       vo.ID   `json:"id" bson:",inline"`
+	UserID   vo.ID   `json:"userID" bson:"user"`                     // user identifier
+	Name     string  `json:"name" bson:"name"`                       // original filename
+	Filename string  `json:"filename" bson:"filename"`               // uploaded filename
+	Filepath string  `json:"filepath" bson:"filepath"`               // path to uploaded file
+	Filetype string  `json:"filetype" bson:"filetype"`               // filetype
+	Filesize int64   `json:"filesize" bson:"filesize"`               // size of uploaded file
+	Status   string  `json:"status" bson:"status,omitempty"`         // processing status
+	Progress float64 `json:"progress" bson:"progress"`               // processing progress from 0 to 1
+	Error    string  `json:"error,omitempty" bson:"error,omitempty"` // reason of the failed processing
+	// MediaInfo is probed once the resource was processed, the resources which were uploaded before
+	// it was introduced have no media info and are probed on demand.
+	MediaInfo *vo.MediaInfo `json:"mediaInfo,omitempty" bson:"mediaInfo,omitempty"`
+	// Preview is generated for the video resources only, the same as the media info, once it was processed.
+	Preview *vo.Preview `json:"preview,omitempty" bson:"preview,omitempty"`
 }
 
 func (r Resource) GetID() vo.ID {
@@ -40,3 +56,18 @@ func (r Resource) GetFilesize() int64 {
 func (r Resource) GetFiletype() string {
 	return r.Filetype
 }
+func (r Resource) GetStatus() string {
+	return r.Status
+}
+func (r Resource) GetMediaInfo() *vo.MediaInfo {
+	return r.MediaInfo
+}
+func (r Resource) GetPreview() *vo.Preview {
+	return r.Preview
+}
+
+// IsReady - checks whether the resource may be streamed. The resources which were uploaded before
+// the processing was introduced have no status and are considered as ready.
+func (r Resource) IsReady() bool {
+	return r.Status == "" || r.Status == ResourceReady
+}
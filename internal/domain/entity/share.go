@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"time"
+)
+
+// Share - is a revocable link to the video, the holder of token is able to watch the video without an account.
+type Share struct {
+	ID        vo.ID      `json:"id" bson:",inline"`
+	VideoID   vo.ID      `json:"videoID" bson:"video"`                           // shared video identifier
+	UserID    vo.ID      `json:"userID" bson:"user"`                             // owner of the video
+	Token     string     `json:"token" bson:"token"`                             // secret part of the link
+	ExpiresAt *time.Time `json:"expiresAt,omitempty" bson:"expiresAt,omitempty"` // the share never expires if omitted
+}
+
+func (s Share) GetID() vo.ID {
+	return s.ID
+}
+
+// IsExpired - checks whether the share is not valid anymore at the given moment.
+func (s Share) IsExpired(at time.Time) bool {
+	return s.ExpiresAt != nil && !at.Before(*s.ExpiresAt)
+}
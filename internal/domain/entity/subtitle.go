@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+)
+
+// Subtitle - is a text track of the video in WebVTT format, the SRT files are converted while uploading.
+type Subtitle struct {
+	ID       vo.ID  `json:"id" bson:",inline"`
+	VideoID  vo.ID  `json:"videoID" bson:"video"`     // video which the track belongs to
+	UserID   vo.ID  `json:"userID" bson:"user"`       // owner of the video
+	Language string `json:"language" bson:"language"` // BCP 47 language tag, e.g. 'en' or 'pt-BR'
+	Label    string `json:"label" bson:"label"`       // title of the track which is shown by the player
+	Kind     string `json:"kind" bson:"kind,omitempty"`
+	Default  bool   `json:"default" bson:"default"` // the track is enabled by the player at start, one per video
+	Filename string `json:"-" bson:"filename"`
+	Filesize int64  `json:"filesize" bson:"filesize"`
+}
+
+func (s Subtitle) GetID() vo.ID {
+	return s.ID
+}
+
+// GetKind - returns the kind of track, the tracks are subtitles if the kind was not specified.
+func (s Subtitle) GetKind() string {
+	if s.Kind == "" {
+		return enum.SubtitlesKind
+	}
+	return s.Kind
+}
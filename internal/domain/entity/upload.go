@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"time"
+)
+
+// Upload - is a resumable uploading of the file which is received by chunks. The resource will be created
+// only when the whole file is received.
+type Upload struct {
+	ID         vo.ID     `json:"id" bson:",inline"`
+	UserID     vo.ID     `json:"userID" bson:"user"`         // user identifier
+	Name       string    `json:"name" bson:"name"`           // original filename
+	Filename   string    `json:"filename" bson:"filename"`   // uploaded filename
+	Filepath   string    `json:"filepath" bson:"filepath"`   // path to uploaded file
+	Filetype   string    `json:"filetype" bson:"filetype"`   // filetype
+	Length     int64     `json:"length" bson:"length"`       // declared size of the file
+	Offset     int64     `json:"offset" bson:"offset"`       // number of received bytes
+	ResourceID vo.ID     `json:"resourceID" bson:"resource"` // created resource of the completed upload
+	ExpiresAt  time.Time `json:"expiresAt" bson:"expiresAt"` // the not completed upload will be removed after
+	// Adopted - whether the received file was moved under the content addressed name, the upload holds
+	// a reference of the blob until the resource is created
+	Adopted bool `json:"-" bson:"adopted"`
+}
+
+func (u Upload) GetID() vo.ID {
+	return u.ID
+}
+func (u Upload) GetUserID() vo.ID {
+	return u.UserID
+}
+
+// IsCompleted - checks whether the whole file was received.
+func (u Upload) IsCompleted() bool {
+	return u.Offset == u.Length
+}
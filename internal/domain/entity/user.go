@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
 	"github.com/Borislavv/video-streaming/internal/domain/vo"
 	"time"
 )
@@ -11,6 +12,7 @@ type User struct {
 	Password string    `bson:"password"` // hash
 	Email    string    `bson:"email"`    // unique key
 	Birthday time.Time `bson:"birthday,omitempty"`
+	Roles    []string  `bson:"roles,omitempty"`
 }
 
 func (r *User) GetID() vo.ID {
@@ -22,3 +24,11 @@ func (r *User) GetPassword() string {
 func (r *User) SetPassword(password string) {
 	r.Password = password
 }
+
+// GetRoles - returns the roles of user, the users which were stored before the roles were introduced are regular.
+func (r *User) GetRoles() []string {
+	if len(r.Roles) == 0 {
+		return []string{enum.UserRole}
+	}
+	return r.Roles
+}
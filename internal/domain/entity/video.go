@@ -1,14 +1,26 @@
 package entity
 
-import "github.com/Borislavv/video-streaming/internal/domain/vo"
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+)
 
 type Video struct {
 	ID          vo.ID  `json:"id" bson:",inline"`
 	UserID      vo.ID  `json:"userID" bson:"user"`
 	Name        string `json:"name" bson:"name"`
 	Description string `json:"description" bson:"description,omitempty"`
+	Visibility  string `json:"visibility" bson:"visibility,omitempty"`
 }
 
 func (r Video) GetID() vo.ID {
 	return r.ID
 }
+
+// GetVisibility - returns the visibility of video, the videos which were stored before it was introduced are private.
+func (r Video) GetVisibility() string {
+	if r.Visibility == "" {
+		return enum.PrivateVisibility
+	}
+	return r.Visibility
+}
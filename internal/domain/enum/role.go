@@ -0,0 +1,18 @@
+package enum
+
+// roles of the users, the user without stored roles has the UserRole
+const (
+	UserRole      = "user"
+	ModeratorRole = "moderator"
+	AdminRole     = "admin"
+)
+
+// permissions which are granted to the roles by the policy table of access service
+const (
+	InspectAnyPermission  = "inspect:any"  // list and get the videos and resources of any user
+	DeleteAnyPermission   = "delete:any"   // delete the videos and resources of any user
+	ManageRolesPermission = "manage:roles" // change the roles of any user
+)
+
+// UserRolesContextKey - is a key of the authorized user roles into the request context.
+const UserRolesContextKey = "UserRoles"
@@ -0,0 +1,8 @@
+package enum
+
+// kinds of the subtitle track, the same as the 'kind' attribute of the html track element
+const (
+	SubtitlesKind    = "subtitles"    // translation of the dialogues
+	CaptionsKind     = "captions"     // transcription of the dialogues and the sound effects for the deaf
+	DescriptionsKind = "descriptions" // textual description of the video for the blind
+)
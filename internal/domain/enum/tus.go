@@ -0,0 +1,18 @@
+package enum
+
+// headers and values of the tus resumable uploading protocol, see https://tus.io/protocols/resumable-upload
+const (
+	TusVersion              = "1.0.0"
+	TusExtensions           = "creation,termination,expiration"
+	TusResumableHeaderKey   = "Tus-Resumable"
+	TusVersionHeaderKey     = "Tus-Version"
+	TusExtensionHeaderKey   = "Tus-Extension"
+	TusMaxSizeHeaderKey     = "Tus-Max-Size"
+	UploadOffsetHeaderKey   = "Upload-Offset"
+	UploadLengthHeaderKey   = "Upload-Length"
+	UploadMetadataHeaderKey = "Upload-Metadata"
+	UploadExpiresHeaderKey  = "Upload-Expires"
+	UploadContentType       = "application/offset+octet-stream"
+	// ResourceIDHeaderKey - is not a part of the protocol, the created resource is exposed by it on completion
+	ResourceIDHeaderKey = "X-Resource-ID"
+)
@@ -0,0 +1,11 @@
+package enum
+
+// visibility of the video for the users who are not its owner
+const (
+	PrivateVisibility  = "private"  // owner and the holders of an active share token only
+	UnlistedVisibility = "unlisted" // anyone who knows the identifier
+	PublicVisibility   = "public"   // anyone
+)
+
+// ShareTokenQueryKey - is a query parameter which carries the share token of the video.
+const ShareTokenQueryKey = "share"
@@ -32,3 +32,8 @@ func NewAccessDeniedError(msg string) *AccessDeniedError {
 		},
 	}
 }
+
+func IsAccessDeniedError(err error) bool {
+	_, ok := err.(*AccessDeniedError)
+	return ok
+}
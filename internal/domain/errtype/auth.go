@@ -81,6 +81,45 @@ func NewAccessTokenWasBlockedError() *AccessTokenWasBlockedError {
 	}
 }
 
+// IsAccessTokenRevokedError - checks the token was rejected because of blocking (logout) or it's invalid at all.
+func IsAccessTokenRevokedError(err error) bool {
+	switch err.(type) {
+	case *AccessTokenWasBlockedError, *AccessTokenIsInvalidError:
+		return true
+	}
+	return false
+}
+
+type RefreshTokenIsInvalidError struct{ publicError }
+
+func NewRefreshTokenIsInvalidError() *RefreshTokenIsInvalidError {
+	return &RefreshTokenIsInvalidError{
+		publicError{
+			errored{
+				ErrorMessage: "authorization failed: provided refresh token is invalid, expired or revoked",
+				ErrorType:    authErrType,
+				errorStatus:  publicAuthErrStatus,
+				errorLevel:   publicAuthErrLevel,
+			},
+		},
+	}
+}
+
+type RefreshTokenWasReusedError struct{ publicError }
+
+func NewRefreshTokenWasReusedError() *RefreshTokenWasReusedError {
+	return &RefreshTokenWasReusedError{
+		publicError{
+			errored{
+				ErrorMessage: "authorization failed: refresh token was already used, the session is revoked",
+				ErrorType:    authErrType,
+				errorStatus:  publicAuthErrStatus,
+				errorLevel:   logger.WarningLevel,
+			},
+		},
+	}
+}
+
 type TokenAlgoWasNotMatchedError struct{ internalError }
 
 func NewTokenAlgoWasNotMatchedInternalError(token string) *TokenAlgoWasNotMatchedError {
@@ -111,6 +150,21 @@ func NewTokenUnexpectedSigningMethodInternalError(token string, algo interface{}
 	}
 }
 
+type TokenUnexpectedSigningKeyError struct{ internalError }
+
+func NewTokenUnexpectedSigningKeyInternalError(token string, kid string) *TokenUnexpectedSigningKeyError {
+	return &TokenUnexpectedSigningKeyError{
+		internalError{
+			errored{
+				ErrorMessage: fmt.Sprintf("unknown signing key '%v' for token '%v'", kid, token),
+				ErrorType:    authErrType,
+				errorStatus:  internalAuthErrStatus,
+				errorLevel:   internalAuthErrLevel,
+			},
+		},
+	}
+}
+
 type TokenInvalidError struct{ internalError }
 
 func NewTokenInvalidInternalError(token string) *TokenInvalidError {
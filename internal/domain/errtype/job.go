@@ -0,0 +1,44 @@
+package errtype
+
+import (
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/logger"
+	"net/http"
+)
+
+const jobErrType = "job"
+
+type JobHandlerNotFoundError struct{ internalError }
+
+func NewJobHandlerNotFoundError(jobType string) *JobHandlerNotFoundError {
+	return &JobHandlerNotFoundError{
+		internalError{
+			errored{
+				ErrorMessage: fmt.Sprintf("handler of the job type '%v' not found", jobType),
+				ErrorType:    jobErrType,
+				errorStatus:  http.StatusInternalServerError,
+				errorLevel:   logger.ErrorLevel,
+			},
+		},
+	}
+}
+
+type JobLeaseWasLostError struct{ internalError }
+
+func NewJobLeaseWasLostError(id string) *JobLeaseWasLostError {
+	return &JobLeaseWasLostError{
+		internalError{
+			errored{
+				ErrorMessage: fmt.Sprintf("the lease of job '%v' was expired and taken by another worker", id),
+				ErrorType:    jobErrType,
+				errorStatus:  http.StatusConflict,
+				errorLevel:   logger.WarningLevel,
+			},
+		},
+	}
+}
+
+func IsJobLeaseWasLostError(err error) bool {
+	_, ok := err.(*JobLeaseWasLostError)
+	return ok
+}
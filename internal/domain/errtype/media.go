@@ -0,0 +1,194 @@
+package errtype
+
+import (
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/logger"
+	"net/http"
+)
+
+const (
+	mediaErrType         = "media"
+	publicMediaErrLevel  = logger.WarningLevel
+	publicMediaErrStatus = http.StatusUnprocessableEntity
+)
+
+type ResourceIsNotSegmentableError struct{ publicError }
+
+func NewResourceIsNotSegmentableError(name string, reason string) *ResourceIsNotSegmentableError {
+	return &ResourceIsNotSegmentableError{
+		publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf("the resource '%v' cannot be segmented: %v", name, reason),
+				ErrorType:    mediaErrType,
+				errorStatus:  publicMediaErrStatus,
+				errorLevel:   publicMediaErrLevel,
+			},
+		},
+	}
+}
+
+func IsResourceIsNotSegmentableError(err error) bool {
+	_, ok := err.(*ResourceIsNotSegmentableError)
+	return ok
+}
+
+type SegmentNotFoundError struct{ publicError }
+
+func NewSegmentNotFoundError(index int) *SegmentNotFoundError {
+	return &SegmentNotFoundError{
+		publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf("segment '%d' not found", index),
+				ErrorType:    mediaErrType,
+				errorStatus:  http.StatusNotFound,
+				errorLevel:   publicMediaErrLevel,
+			},
+		},
+	}
+}
+
+type SeekIsOutOfRangeError struct {
+	publicError
+	From     float64 `json:"from"`
+	Duration float64 `json:"duration"`
+}
+
+func NewSeekIsOutOfRangeError(from float64, duration float64) *SeekIsOutOfRangeError {
+	return &SeekIsOutOfRangeError{
+		publicError: publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf("seek position %.3fs is out of range [0, %.3fs)", from, duration),
+				ErrorType:    mediaErrType,
+				errorStatus:  http.StatusRequestedRangeNotSatisfiable,
+				errorLevel:   publicMediaErrLevel,
+			},
+		},
+		From:     from,
+		Duration: duration,
+	}
+}
+
+type UnsupportedActionError struct{ publicError }
+
+func NewUnsupportedActionError(action string) *UnsupportedActionError {
+	return &UnsupportedActionError{
+		publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf("action '%v' is not supported", action),
+				ErrorType:    mediaErrType,
+				errorStatus:  http.StatusBadRequest,
+				errorLevel:   publicMediaErrLevel,
+			},
+		},
+	}
+}
+
+type NoActiveStreamError struct{ publicError }
+
+func NewNoActiveStreamError(action string) *NoActiveStreamError {
+	return &NoActiveStreamError{
+		publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf("unable to handle '%v' action, there is no active stream", action),
+				ErrorType:    mediaErrType,
+				errorStatus:  http.StatusConflict,
+				errorLevel:   publicMediaErrLevel,
+			},
+		},
+	}
+}
+
+type RenditionNotFoundError struct{ publicError }
+
+func NewRenditionNotFoundError(number int) *RenditionNotFoundError {
+	return &RenditionNotFoundError{
+		publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf("rendition '%d' not found", number),
+				ErrorType:    mediaErrType,
+				errorStatus:  http.StatusNotFound,
+				errorLevel:   publicMediaErrLevel,
+			},
+		},
+	}
+}
+
+type AudioTrackNotFoundError struct{ publicError }
+
+func NewAudioTrackNotFoundError(index int) *AudioTrackNotFoundError {
+	return &AudioTrackNotFoundError{
+		publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf("audio track '%d' not found", index),
+				ErrorType:    mediaErrType,
+				errorStatus:  http.StatusNotFound,
+				errorLevel:   publicMediaErrLevel,
+			},
+		},
+	}
+}
+
+func IsAudioTrackNotFoundError(err error) bool {
+	_, ok := err.(*AudioTrackNotFoundError)
+	return ok
+}
+
+type TranscodingFailedError struct{ internalError }
+
+func NewTranscodingFailedError(name string, reason string) *TranscodingFailedError {
+	return &TranscodingFailedError{
+		internalError{
+			errored{
+				ErrorMessage: fmt.Sprintf("transcoding of the resource '%v' failed: %v", name, reason),
+				ErrorType:    mediaErrType,
+				errorStatus:  http.StatusInternalServerError,
+				errorLevel:   logger.ErrorLevel,
+			},
+		},
+	}
+}
+
+type ResourceIsNotReadyError struct{ publicError }
+
+func NewResourceIsNotReadyError(name string, status string) *ResourceIsNotReadyError {
+	return &ResourceIsNotReadyError{
+		publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf("resource '%v' is not ready for streaming yet, current status: '%v'", name, status),
+				ErrorType:    mediaErrType,
+				errorStatus:  http.StatusConflict,
+				errorLevel:   publicMediaErrLevel,
+			},
+		},
+	}
+}
+
+type PreviewNotFoundError struct{ publicError }
+
+func NewPreviewNotFoundError(name string) *PreviewNotFoundError {
+	return &PreviewNotFoundError{
+		publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf("preview of the resource '%v' not found", name),
+				ErrorType:    mediaErrType,
+				errorStatus:  http.StatusNotFound,
+				errorLevel:   publicMediaErrLevel,
+			},
+		},
+	}
+}
+
+type SubtitleNotFoundError struct{ publicError }
+
+func NewSubtitleNotFoundError(id string) *SubtitleNotFoundError {
+	return &SubtitleNotFoundError{
+		publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf("subtitle '%v' not found", id),
+				ErrorType:    mediaErrType,
+				errorStatus:  http.StatusNotFound,
+				errorLevel:   publicMediaErrLevel,
+			},
+		},
+	}
+}
@@ -0,0 +1,46 @@
+package errtype
+
+import (
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/logger"
+	"net/http"
+)
+
+const storageErrType = "storage"
+
+type StorageObjectNotFoundError struct{ internalError }
+
+func NewStorageObjectNotFoundError(key string) *StorageObjectNotFoundError {
+	return &StorageObjectNotFoundError{
+		internalError{
+			errored{
+				ErrorMessage: fmt.Sprintf("object '%v' not found into the storage", key),
+				ErrorType:    storageErrType,
+				errorStatus:  http.StatusInternalServerError,
+				errorLevel:   logger.ErrorLevel,
+			},
+		},
+	}
+}
+
+func IsStorageObjectNotFoundError(err error) bool {
+	_, ok := err.(*StorageObjectNotFoundError)
+	return ok
+}
+
+type StorageRequestFailedError struct{ internalError }
+
+func NewStorageRequestFailedError(operation string, key string, status int, reason string) *StorageRequestFailedError {
+	return &StorageRequestFailedError{
+		internalError{
+			errored{
+				ErrorMessage: fmt.Sprintf(
+					"storage request '%v' of the object '%v' failed with status %d: %v", operation, key, status, reason,
+				),
+				ErrorType:   storageErrType,
+				errorStatus: http.StatusInternalServerError,
+				errorLevel:  logger.ErrorLevel,
+			},
+		},
+	}
+}
@@ -26,3 +26,138 @@ func NewResourceAlreadyExistsError(name string) *ResourceAlreadyExistsError {
 		},
 	}
 }
+
+type UploadOffsetMismatchError struct{ publicError }
+
+func NewUploadOffsetMismatchError(expected int64, given int64) *UploadOffsetMismatchError {
+	return &UploadOffsetMismatchError{
+		publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf("the upload offset '%d' does not match the received length '%d'", given, expected),
+				ErrorType:    uploadErrType,
+				errorStatus:  http.StatusConflict,
+				errorLevel:   publicUploadErrLevel,
+			},
+		},
+	}
+}
+
+type UploadIsLockedError struct{ publicError }
+
+func NewUploadIsLockedError() *UploadIsLockedError {
+	return &UploadIsLockedError{
+		publicError{
+			errored{
+				ErrorMessage: "the upload is receiving another chunk at now, retry later",
+				ErrorType:    uploadErrType,
+				errorStatus:  http.StatusLocked,
+				errorLevel:   publicUploadErrLevel,
+			},
+		},
+	}
+}
+
+type UploadLengthExceededError struct{ publicError }
+
+func NewUploadLengthExceededError(length int64, threshold int64) *UploadLengthExceededError {
+	return &UploadLengthExceededError{
+		publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf("the upload length '%d' exceeds the max. allowed '%d'", length, threshold),
+				ErrorType:    uploadErrType,
+				errorStatus:  http.StatusRequestEntityTooLarge,
+				errorLevel:   publicUploadErrLevel,
+			},
+		},
+	}
+}
+
+type UnsupportedTusVersionError struct{ publicError }
+
+func NewUnsupportedTusVersionError(version string, supported string) *UnsupportedTusVersionError {
+	return &UnsupportedTusVersionError{
+		publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf("the tus protocol version '%v' is not supported, use '%v'", version, supported),
+				ErrorType:    uploadErrType,
+				errorStatus:  http.StatusPreconditionFailed,
+				errorLevel:   publicUploadErrLevel,
+			},
+		},
+	}
+}
+
+type UnsupportedUploadContentTypeError struct{ publicError }
+
+func NewUnsupportedUploadContentTypeError(given string, expected string) *UnsupportedUploadContentTypeError {
+	return &UnsupportedUploadContentTypeError{
+		publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf("the content type '%v' is not supported, use '%v'", given, expected),
+				ErrorType:    uploadErrType,
+				errorStatus:  http.StatusUnsupportedMediaType,
+				errorLevel:   publicUploadErrLevel,
+			},
+		},
+	}
+}
+
+type UploadIsNotCompletedError struct{ publicError }
+
+func NewUploadIsNotCompletedError(offset int64, length int64) *UploadIsNotCompletedError {
+	return &UploadIsNotCompletedError{
+		publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf("the upload is not completed, received '%d' of '%d' bytes", offset, length),
+				ErrorType:    uploadErrType,
+				errorStatus:  http.StatusConflict,
+				errorLevel:   publicUploadErrLevel,
+			},
+		},
+	}
+}
+
+type FilesizeThresholdExceededError struct{ publicError }
+
+func NewFilesizeThresholdExceededError(threshold int64) *FilesizeThresholdExceededError {
+	return &FilesizeThresholdExceededError{
+		publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf("the uploading file exceeds the max. allowed filesize of '%d' bytes", threshold),
+				ErrorType:    uploadErrType,
+				errorStatus:  http.StatusRequestEntityTooLarge,
+				errorLevel:   publicUploadErrLevel,
+			},
+		},
+	}
+}
+
+type UnsupportedMediaContentError struct{ publicError }
+
+func NewUnsupportedMediaContentError(name string, filetype string) *UnsupportedMediaContentError {
+	return &UnsupportedMediaContentError{
+		publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf("the uploading file '%v' is not a media content, detected type: '%v'", name, filetype),
+				ErrorType:    uploadErrType,
+				errorStatus:  http.StatusUnsupportedMediaType,
+				errorLevel:   publicUploadErrLevel,
+			},
+		},
+	}
+}
+
+type UnsupportedSubtitleContentError struct{ publicError }
+
+func NewUnsupportedSubtitleContentError(name string) *UnsupportedSubtitleContentError {
+	return &UnsupportedSubtitleContentError{
+		publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf("the uploading file '%v' is neither a WebVTT nor a SubRip subtitle", name),
+				ErrorType:    uploadErrType,
+				errorStatus:  http.StatusUnsupportedMediaType,
+				errorLevel:   publicUploadErrLevel,
+			},
+		},
+	}
+}
@@ -62,6 +62,21 @@ func NewFieldCannotBeEmptyError(field string) *FieldCannotBeEmptyError {
 	}
 }
 
+type FieldValueIsInvalidError struct{ publicError }
+
+func NewFieldValueIsInvalidError(field string, reason string) *FieldValueIsInvalidError {
+	return &FieldValueIsInvalidError{
+		publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf("field '%v' is invalid: %v", field, reason),
+				ErrorType:    validationType,
+				errorStatus:  publicValidationStatus,
+				errorLevel:   publicValidationLevel,
+			},
+		},
+	}
+}
+
 type UserWithSuchEmailAlreadyExistsError struct{ publicError }
 
 func NewUserWithSuchEmailAlreadyExistsError(email string) *UserWithSuchEmailAlreadyExistsError {
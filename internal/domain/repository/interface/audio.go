@@ -3,13 +3,15 @@ package repositoryinterface
 import (
 	"context"
 	"github.com/Borislavv/video-streaming/internal/domain/agg"
-	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
-	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	queryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
 )
 
 type Audio interface {
-	Insert(ctx context.Context, audio *agg.Audio) (string, error)
-	Update(ctx context.Context, audio *agg.Audio) error
-	Find(ctx context.Context, id vo.ID) (*agg.Audio, error)
-	FindList(ctx context.Context, dto dtointerface.ListAudioRequest) ([]*agg.Audio, error)
+	FindOneByID(ctx context.Context, q queryinterface.FindOneAudioByID) (*agg.Audio, error)
+	FindOneByName(ctx context.Context, q queryinterface.FindOneAudioByName) (*agg.Audio, error)
+	FindOneByResourceID(ctx context.Context, q queryinterface.FindOneAudioByResourceID) (*agg.Audio, error)
+	FindList(ctx context.Context, q queryinterface.FindAudioList) (list []*agg.Audio, total int64, err error)
+	Insert(ctx context.Context, audio *agg.Audio) (*agg.Audio, error)
+	Update(ctx context.Context, audio *agg.Audio) (*agg.Audio, error)
+	Remove(ctx context.Context, audio *agg.Audio) error
 }
@@ -0,0 +1,16 @@
+package repositoryinterface
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+)
+
+type Blob interface {
+	// Retain - adds a reference to the blob, the blob is created by the first one.
+	Retain(ctx context.Context, userID vo.ID, filename string) (*agg.Blob, error)
+	// Release - drops a reference of the blob.
+	Release(ctx context.Context, userID vo.ID, filename string) (*agg.Blob, error)
+	// Remove - removes the blob only if it's not referenced anymore.
+	Remove(ctx context.Context, blob *agg.Blob) (removed bool, err error)
+}
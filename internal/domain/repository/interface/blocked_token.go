@@ -8,4 +8,6 @@ import (
 type BlockedToken interface {
 	Insert(ctx context.Context, token *agg.BlockedToken) error
 	Has(ctx context.Context, token string) (found bool, err error)
+	// HasSession - checks the session (family of refresh tokens) was blocked with all its access tokens.
+	HasSession(ctx context.Context, session string) (found bool, err error)
 }
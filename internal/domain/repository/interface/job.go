@@ -0,0 +1,18 @@
+package repositoryinterface
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"time"
+)
+
+type Job interface {
+	Insert(ctx context.Context, job *agg.Job) (*agg.Job, error)
+	// Lease - takes the next job which is ready to run and locks it for the worker for the given duration.
+	// The job which lease was expired is taken again while it has attempts.
+	Lease(ctx context.Context, duration time.Duration, maxAttempts int) (*agg.Job, error)
+	// FailExpired - marks as failed the job which lease was expired on the last attempt and returns it.
+	FailExpired(ctx context.Context, maxAttempts int) (*agg.Job, error)
+	// Update - saves the job which is still leased by the worker.
+	Update(ctx context.Context, job *agg.Job) (*agg.Job, error)
+}
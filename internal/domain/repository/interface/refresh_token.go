@@ -0,0 +1,20 @@
+package repositoryinterface
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+)
+
+type RefreshToken interface {
+	FindOneByHash(ctx context.Context, hash string) (*agg.RefreshToken, error)
+	Insert(ctx context.Context, token *agg.RefreshToken) (*agg.RefreshToken, error)
+	// MarkUsed - marks the token as used, returns false if it was already used by someone else.
+	MarkUsed(ctx context.Context, token *agg.RefreshToken) (marked bool, err error)
+	// RevokeFamily - revokes all tokens which are rotated from the same log in.
+	RevokeFamily(ctx context.Context, family string) error
+	// RevokeByUserID - revokes all tokens of the user (all sessions).
+	RevokeByUserID(ctx context.Context, userID vo.ID) error
+	// FindActiveFamilies - finds the families of the user which are not revoked and not expired yet.
+	FindActiveFamilies(ctx context.Context, userID vo.ID) (families []string, err error)
+}
@@ -0,0 +1,18 @@
+package repositoryinterface
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	queryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+)
+
+type Share interface {
+	FindOneByID(ctx context.Context, q queryinterface.FindOneShareByID) (*agg.Share, error)
+	FindOneByToken(ctx context.Context, token string) (*agg.Share, error)
+	FindList(ctx context.Context, q queryinterface.FindShareList) ([]*agg.Share, error)
+	Insert(ctx context.Context, share *agg.Share) (*agg.Share, error)
+	Remove(ctx context.Context, share *agg.Share) error
+	// RemoveByVideoID - revokes all shares of the video.
+	RemoveByVideoID(ctx context.Context, videoID vo.ID) error
+}
@@ -3,11 +3,14 @@ package repositoryinterface
 import (
 	"context"
 	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
 	queryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
 )
 
 type Video interface {
 	FindOneByID(ctx context.Context, q queryinterface.FindOneVideoByID) (*agg.Video, error)
+	// FindOneByIDOfAnyOwner - finds the video regardless of its owner, the access must be checked by the caller.
+	FindOneByIDOfAnyOwner(ctx context.Context, id vo.ID) (*agg.Video, error)
 	FindOneByName(ctx context.Context, q queryinterface.FindOneVideoByName) (*agg.Video, error)
 	FindOneByResourceID(ctx context.Context, q queryinterface.FindOneVideoByResourceID) (*agg.Video, error)
 	FindList(ctx context.Context, q queryinterface.FindVideoList) (list []*agg.Video, total int64, err error)
@@ -4,11 +4,13 @@ import (
 	"fmt"
 	"github.com/Borislavv/video-streaming/internal/domain/agg"
 	dtointerface "github.com/Borislavv/video-streaming/internal/domain/agg/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
 	"github.com/Borislavv/video-streaming/internal/domain/errtype"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/vo"
 	"reflect"
+	"time"
 )
 
 type AggregateAccessType int
@@ -26,6 +28,8 @@ type AccessService struct {
 	logger                    loggerinterface.Logger
 	handlers                  map[AggregateAccessType]AggregateAccessHandler
 	isAppropriateHandlerFuncs map[AggregateAccessType]AggregateAccessIsAppropriateHandler
+	// policies is a table of the permissions which are granted to the roles
+	policies map[string]map[string]bool
 }
 
 func NewAccessService(serviceContainer diinterface.ServiceContainer) (*AccessService, error) {
@@ -38,7 +42,8 @@ func NewAccessService(serviceContainer diinterface.ServiceContainer) (*AccessSer
 		logger:                    loggerService,
 		handlers:                  map[AggregateAccessType]AggregateAccessHandler{},
 		isAppropriateHandlerFuncs: map[AggregateAccessType]AggregateAccessIsAppropriateHandler{},
-	}).setHandlers(), nil
+		policies:                  map[string]map[string]bool{},
+	}).setHandlers().setPolicies(), nil
 }
 
 // IsGranted is a method which will check the access to target scope of aggregates.
@@ -67,6 +72,47 @@ func (s *AccessService) IsGranted(userID vo.ID, aggregates ...dtointerface.Aggre
 	return nil
 }
 
+// IsPermitted is a method which will check the permission is granted to one of roles by the policy table.
+func (s *AccessService) IsPermitted(roles []string, permission string) error {
+	for _, role := range roles {
+		if s.policies[role][permission] {
+			return nil
+		}
+	}
+
+	// permission was not granted, access is denied
+	return s.logger.LogPropagate(
+		errtype.NewAccessDeniedError(fmt.Sprintf("you have not enough rights, '%v' permission is required", permission)),
+	)
+}
+
+// IsViewable is a method which will check the video may be watched by the user. The owner is able to watch
+// any own video, the others are able to watch the unlisted and public ones or the private one by an active share.
+func (s *AccessService) IsViewable(userID vo.ID, video *agg.Video, share *agg.Share) error {
+	if !userID.Value.IsZero() && userID.Value == video.UserID.Value {
+		// user is owner of video
+		return nil
+	}
+
+	switch video.GetVisibility() {
+	case enum.PublicVisibility, enum.UnlistedVisibility:
+		return nil
+	}
+
+	if share != nil && share.VideoID.Value == video.ID.Value {
+		if share.IsExpired(time.Now()) {
+			return s.logger.LogPropagate(errtype.NewAccessDeniedError("the share link of video has expired"))
+		}
+		// the share link is active
+		return nil
+	}
+
+	// video is private, access is denied
+	return s.logger.LogPropagate(
+		errtype.NewAccessDeniedError("the video is private and it's not belong to you"),
+	)
+}
+
 // video
 func (s *AccessService) videoHandler(userID vo.ID, aggregate dtointerface.Aggregate) error {
 	videoAgg, ok := aggregate.(*agg.Video)
@@ -207,3 +253,20 @@ func (s *AccessService) setHandlers() *AccessService {
 	// fluent setter
 	return s
 }
+
+func (s *AccessService) setPolicies() *AccessService {
+	// user (owns the aggregates only, they are checked by the handlers)
+	s.policies[enum.UserRole] = map[string]bool{}
+	// moderator
+	s.policies[enum.ModeratorRole] = map[string]bool{
+		enum.InspectAnyPermission: true,
+	}
+	// admin
+	s.policies[enum.AdminRole] = map[string]bool{
+		enum.InspectAnyPermission:  true,
+		enum.DeleteAnyPermission:   true,
+		enum.ManageRolesPermission: true,
+	}
+	// fluent setter
+	return s
+}
@@ -1,6 +1,7 @@
 package accessorinterface
 
 import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
 	dtointerface "github.com/Borislavv/video-streaming/internal/domain/agg/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/vo"
 )
@@ -8,4 +9,8 @@ import (
 type Accessor interface {
 	// IsGranted is a method which will check the access to target aggregates scope.
 	IsGranted(userID vo.ID, aggregates ...dtointerface.Aggregate) error
+	// IsPermitted is a method which will check the permission is granted to one of roles.
+	IsPermitted(roles []string, permission string) error
+	// IsViewable is a method which will check the video may be watched by the user (empty for anonymous one).
+	IsViewable(userID vo.ID, video *agg.Video, share *agg.Share) error
 }
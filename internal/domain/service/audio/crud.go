@@ -1,8 +1,180 @@
 package audio
 
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	resourceinterface "github.com/Borislavv/video-streaming/internal/domain/service/resource/interface"
+	validatorinterface "github.com/Borislavv/video-streaming/internal/domain/validator/interface"
+)
+
 type CRUDService struct {
+	ctx             context.Context
+	logger          loggerinterface.Logger
+	builder         builderinterface.Audio
+	validator       validatorinterface.Audio
+	repository      repositoryinterface.Audio
+	resourceService resourceinterface.CRUD
+}
+
+func NewCRUDService(serviceContainer diinterface.ServiceContainer) (*CRUDService, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	audioBuilder, err := serviceContainer.GetAudioBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	audioValidator, err := serviceContainer.GetAudioValidator()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	audioRepository, err := serviceContainer.GetAudioRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	resourceCRUDService, err := serviceContainer.GetResourceCRUDService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &CRUDService{
+		ctx:             ctx,
+		logger:          loggerService,
+		builder:         audioBuilder,
+		validator:       audioValidator,
+		repository:      audioRepository,
+		resourceService: resourceCRUDService,
+	}, nil
+}
+
+// Get - will fetch a single audio aggregate by ID and specified user.
+// Access check to audio is unnecessary because the query will fetch audio only for specified user.
+func (s *CRUDService) Get(req dtointerface.GetAudioRequest) (*agg.Audio, error) {
+	// validation of input request
+	if err := s.validator.ValidateGetRequestDTO(req); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// fetching an audio by id and user
+	audio, err := s.repository.FindOneByID(s.ctx, req)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	return audio, nil
+}
+
+// List - will fetch an audio list of aggregates by given request and specified user.
+// Access check to audio is unnecessary because the query will fetch an audio list only for specified user.
+func (s *CRUDService) List(req dtointerface.ListAudioRequest) (list []*agg.Audio, total int64, err error) {
+	// validation of input request
+	if err = s.validator.ValidateListRequestDTO(req); err != nil {
+		return nil, 0, s.logger.LogPropagate(err)
+	}
+
+	// fetching an audio list by request params. and user
+	list, total, err = s.repository.FindList(s.ctx, req)
+	if err != nil {
+		return nil, 0, s.logger.LogPropagate(err)
+	}
+
+	return list, total, err
 }
 
-func NewCRUDService() *CRUDService {
-	return &CRUDService{}
+// Create - will make a new audio by given request for specified user. Have an access check for resource
+// which exists into the request.
+func (s *CRUDService) Create(req dtointerface.CreateAudioRequest) (*agg.Audio, error) {
+	// validation of input request
+	if err := s.validator.ValidateCreateRequestDTO(req); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// building an aggregate
+	audioAgg, err := s.builder.BuildAggFromCreateRequestDTO(req)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// validation of an aggregate
+	if err = s.validator.ValidateAggregate(audioAgg); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// saving an aggregate into storage
+	audioAgg, err = s.repository.Insert(s.ctx, audioAgg)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	return audioAgg, nil
+}
+
+// Update - will change the audio by given request. Have an access check for audio.resource.
+func (s *CRUDService) Update(req dtointerface.UpdateAudioRequest) (*agg.Audio, error) {
+	// validation of input request
+	if err := s.validator.ValidateUpdateRequestDTO(req); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// building an aggregate
+	audioAgg, err := s.builder.BuildAggFromUpdateRequestDTO(req)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// validation of an aggregate
+	if err = s.validator.ValidateAggregate(audioAgg); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// saving updated aggregate into storage
+	audioAgg, err = s.repository.Update(s.ctx, audioAgg)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	return audioAgg, nil
+}
+
+// Delete - will remove the audio from the storage.
+func (s *CRUDService) Delete(req dtointerface.DeleteAudioRequest) (err error) {
+	// validation of input request
+	if err = s.validator.ValidateDeleteRequestDTO(req); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	// fetching an audio which will be deleted
+	audioAgg, err := s.repository.FindOneByID(s.ctx, req)
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	// the resource must be removing first
+	q := dto.NewResourceDeleteRequestDTO(audioAgg.Resource.ID, req.GetUserID())
+	if err = s.resourceService.Delete(q); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	// audio removing
+	if err = s.repository.Remove(s.ctx, audioAgg); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	return nil
 }
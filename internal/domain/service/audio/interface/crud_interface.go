@@ -1,4 +1,14 @@
 package audiointerface
 
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+)
+
 type CRUD interface {
+	Get(reqDTO dtointerface.GetAudioRequest) (*agg.Audio, error)
+	List(reqDTO dtointerface.ListAudioRequest) (list []*agg.Audio, total int64, err error)
+	Create(reqDTO dtointerface.CreateAudioRequest) (*agg.Audio, error)
+	Update(reqDTO dtointerface.UpdateAudioRequest) (*agg.Audio, error)
+	Delete(reqDTO dtointerface.DeleteAudioRequest) error
 }
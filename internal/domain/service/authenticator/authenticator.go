@@ -1,30 +1,50 @@
 package authenticator
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
 	"github.com/Borislavv/video-streaming/internal/domain/dto"
 	"github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
 	"github.com/Borislavv/video-streaming/internal/domain/enum"
 	"github.com/Borislavv/video-streaming/internal/domain/errtype"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
 	securityinterface "github.com/Borislavv/video-streaming/internal/domain/service/security/interface"
 	tokenizerinterface "github.com/Borislavv/video-streaming/internal/domain/service/tokenizer/interface"
 	userinterface "github.com/Borislavv/video-streaming/internal/domain/service/user/interface"
 	validatorinterface "github.com/Borislavv/video-streaming/internal/domain/validator/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"net/http"
+	"time"
 )
 
 var (
 	tokenVerificationFailed = "token verification failed"
+	tokenLoggedOut          = "logged out"
+	tokenLoggedOutOfAll     = "logged out of all sessions"
+	tokenRefreshReused      = "refresh token was reused"
 )
 
+// refreshTokenLength - number of random bytes of the refresh token
+const refreshTokenLength = 32
+
 type AuthService struct {
-	logger         loggerinterface.Logger
-	userService    userinterface.CRUD
-	validator      validatorinterface.Auth
-	tokenizer      tokenizerinterface.Tokenizer
-	passwordHasher securityinterface.PasswordHasher
+	ctx                    context.Context
+	logger                 loggerinterface.Logger
+	userService            userinterface.CRUD
+	validator              validatorinterface.Auth
+	tokenizer              tokenizerinterface.Tokenizer
+	passwordHasher         securityinterface.PasswordHasher
+	refreshTokenRepository repositoryinterface.RefreshToken
+	accessTokenTTL         int64
+	refreshTokenTTL        time.Duration
 }
 
 func NewAuthService(serviceContainer diinterface.ServiceContainer) (*AuthService, error) {
@@ -33,6 +53,11 @@ func NewAuthService(serviceContainer diinterface.ServiceContainer) (*AuthService
 		return nil, err
 	}
 
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	userCRUDService, err := serviceContainer.GetUserCRUDService()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
@@ -53,65 +78,195 @@ func NewAuthService(serviceContainer diinterface.ServiceContainer) (*AuthService
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	refreshTokenRepository, err := serviceContainer.GetRefreshTokenRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	return &AuthService{
-		logger:         loggerService,
-		userService:    userCRUDService,
-		validator:      authValidator,
-		tokenizer:      tokenizerService,
-		passwordHasher: passwordHasherService,
+		ctx:                    ctx,
+		logger:                 loggerService,
+		userService:            userCRUDService,
+		validator:              authValidator,
+		tokenizer:              tokenizerService,
+		passwordHasher:         passwordHasherService,
+		refreshTokenRepository: refreshTokenRepository,
+		accessTokenTTL:         cfg.JwtTokenExpiresAfter,
+		refreshTokenTTL:        time.Second * time.Duration(cfg.JwtRefreshTokenExpiresAfter),
 	}, nil
 }
 
-// Auth will check raw credentials and generate a new access token for given user.
-func (s *AuthService) Auth(req dtointerface.AuthRequest) (token string, err error) {
+// Auth will check raw credentials and generate a new pair of tokens for given user, each log in starts a new session.
+func (s *AuthService) Auth(req dtointerface.AuthRequest) (*dto.TokensResponseDTO, error) {
 	// raw request validation (checking that email and pass is not empty)
-	if err = s.validator.ValidateAuthRequest(req); err != nil {
-		return "", s.logger.LogPropagate(err)
+	if err := s.validator.ValidateAuthRequest(req); err != nil {
+		return nil, s.logger.LogPropagate(err)
 	}
 
 	// getting the target user agg. by email
 	userAgg, err := s.userService.Get(dto.NewUserGetRequestDTO(vo.ID{}, req.GetEmail()))
 	if err != nil {
-		return "", s.logger.LogPropagate(err)
+		return nil, s.logger.LogPropagate(err)
 	}
 
 	// checking that credentials are valid
 	if err = s.passwordHasher.Verify(userAgg, req.GetPassword()); err != nil {
-		return "", s.logger.LogPropagate(err)
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// generating a new pair of tokens within a new session
+	tokens, err := s.issue(userAgg, primitive.NewObjectID().Hex())
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	return tokens, nil
+}
+
+// Refresh will exchange the refresh token to the new pair of tokens of the same session. Each refresh token
+// may be used once, the repeated usage means the token was stolen, so the whole session is revoked.
+func (s *AuthService) Refresh(req dtointerface.RefreshRequest) (*dto.TokensResponseDTO, error) {
+	// raw request validation (checking that refresh token is not empty)
+	if err := s.validator.ValidateRefreshRequest(req); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// getting the stored refresh token
+	refreshToken, err := s.refreshTokenRepository.FindOneByHash(s.ctx, s.hash(req.GetRefreshToken()))
+	if err != nil {
+		if errtype.IsEntityNotFoundError(err) {
+			return nil, s.logger.LogPropagate(errtype.NewRefreshTokenIsInvalidError())
+		}
+		return nil, s.logger.LogPropagate(err)
+	}
+	if refreshToken.RevokedAt != nil || refreshToken.IsExpired(time.Now()) {
+		return nil, s.logger.LogPropagate(errtype.NewRefreshTokenIsInvalidError())
+	}
+
+	// rotation, the token is marked as used only once
+	marked, err := s.refreshTokenRepository.MarkUsed(s.ctx, refreshToken)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+	if !marked {
+		// reuse was detected, the session is revoked with its access tokens
+		if err = s.refreshTokenRepository.RevokeFamily(s.ctx, refreshToken.Family); err != nil {
+			return nil, s.logger.LogPropagate(err)
+		}
+		if err = s.tokenizer.BlockSession(refreshToken.Family, refreshToken.UserID, tokenRefreshReused); err != nil {
+			return nil, s.logger.LogPropagate(err)
+		}
+		return nil, s.logger.LogPropagate(errtype.NewRefreshTokenWasReusedError())
+	}
+
+	// the user is fetched again, the roles may be changed since the last issue
+	userAgg, err := s.userService.Get(dto.NewUserGetRequestDTO(refreshToken.UserID, ""))
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	tokens, err := s.issue(userAgg, refreshToken.Family)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	return tokens, nil
+}
+
+// Logout will block the access token of request and revoke its session.
+func (s *AuthService) Logout(r *http.Request) error {
+	token, err := s.extractToken(r)
+	if err != nil {
+		return s.logger.LogPropagate(err)
 	}
 
-	// generating a new access token string
-	token, err = s.tokenizer.New(userAgg)
+	session, err := s.tokenizer.GetSession(token)
 	if err != nil {
-		return "", s.logger.LogPropagate(err)
+		return s.logger.LogPropagate(err)
+	}
+
+	if err = s.tokenizer.Block(token, tokenLoggedOut); err != nil {
+		return s.logger.LogPropagate(err)
 	}
 
-	return token, nil
+	if session != "" {
+		if err = s.refreshTokenRepository.RevokeFamily(s.ctx, session); err != nil {
+			return s.logger.LogPropagate(err)
+		}
+
+		// the user is set by the auth middleware, it's stored for the information only
+		userID, _ := r.Context().Value(enum.UserIDContextKey).(vo.ID)
+		if err = s.tokenizer.BlockSession(session, userID, tokenLoggedOut); err != nil {
+			return s.logger.LogPropagate(err)
+		}
+	}
+
+	return nil
 }
 
-// IsAuthed with check that token is valid and extract userID from it.
-func (s *AuthService) IsAuthed(r *http.Request) (userID vo.ID, err error) {
+// LogoutAll will block the access token of request and revoke all sessions of the user.
+func (s *AuthService) LogoutAll(r *http.Request) error {
+	token, err := s.extractToken(r)
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	userID, err := s.tokenizer.Verify(token)
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	if err = s.tokenizer.Block(token, tokenLoggedOutOfAll); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	// the sessions are found before the revocation, so their access tokens are blocked as well
+	sessions, err := s.refreshTokenRepository.FindActiveFamilies(s.ctx, userID)
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	if err = s.refreshTokenRepository.RevokeByUserID(s.ctx, userID); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	for _, session := range sessions {
+		if err = s.tokenizer.BlockSession(session, userID, tokenLoggedOutOfAll); err != nil {
+			return s.logger.LogPropagate(err)
+		}
+	}
+
+	return nil
+}
+
+// IsAuthed with check that token is valid and extract userID with the user roles from it.
+func (s *AuthService) IsAuthed(r *http.Request) (userID vo.ID, roles []string, err error) {
 	// validate that token is present into request headers
 	if err = s.validator.ValidateTokennessRequest(r); err != nil {
-		return vo.ID{}, s.logger.LogPropagate(err)
+		return vo.ID{}, nil, s.logger.LogPropagate(err)
 	}
 
 	// extract token from request
 	token, err := s.extractToken(r)
 	if err != nil {
-		return vo.ID{}, s.logger.LogPropagate(err)
+		return vo.ID{}, nil, s.logger.LogPropagate(err)
 	}
 
-	// validate token and extract userID from it
-	userID, err = s.tokenizer.Verify(token)
+	// validate token and extract userID with roles from it
+	userID, roles, err = s.tokenizer.VerifyWithRoles(token)
 	if err != nil {
 		if berr := s.tokenizer.Block(token, tokenVerificationFailed); berr != nil {
-			return vo.ID{}, s.logger.LogPropagate(berr)
+			return vo.ID{}, nil, s.logger.LogPropagate(berr)
 		}
-		return vo.ID{}, s.logger.LogPropagate(err)
+		return vo.ID{}, nil, s.logger.LogPropagate(err)
 	}
 
-	return userID, nil
+	return userID, roles, nil
 }
 
 func (s *AuthService) extractToken(r *http.Request) (token string, err error) {
@@ -127,3 +282,44 @@ func (s *AuthService) extractToken(r *http.Request) (token string, err error) {
 
 	return "", errtype.NewAccessTokenIsEmptyOrOmittedError()
 }
+
+// issue - stores a new refresh token of the session and signs the access token which belongs to it.
+func (s *AuthService) issue(user *agg.User, session string) (*dto.TokensResponseDTO, error) {
+	p := make([]byte, refreshTokenLength)
+	if _, err := rand.Read(p); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+	refreshToken := base64.RawURLEncoding.EncodeToString(p)
+
+	_, err := s.refreshTokenRepository.Insert(s.ctx, &agg.RefreshToken{
+		RefreshToken: entity.RefreshToken{
+			UserID:    user.ID,
+			Family:    session,
+			Hash:      s.hash(refreshToken),
+			ExpiresAt: time.Now().Add(s.refreshTokenTTL),
+		},
+		Timestamp: vo.Timestamp{
+			CreatedAt: time.Now(),
+		},
+	})
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	accessToken, err := s.tokenizer.New(user, session)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	return &dto.TokensResponseDTO{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    s.accessTokenTTL,
+	}, nil
+}
+
+// hash - the refresh tokens are stored by hash, so the leaked storage doesn't give the valid tokens.
+func (s *AuthService) hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
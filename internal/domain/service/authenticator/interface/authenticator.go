@@ -1,14 +1,21 @@
 package authenticatorinterface
 
 import (
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
 	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/vo"
 	"net/http"
 )
 
 type Authenticator interface {
-	// Auth will check raw credentials and generate a new access token for given user.
-	Auth(reqDTO dtointerface.AuthRequest) (token string, err error)
-	// IsAuthed with check that token is valid and extract userID from it.
-	IsAuthed(r *http.Request) (userID vo.ID, err error)
+	// Auth will check raw credentials and generate a new pair of tokens for given user.
+	Auth(reqDTO dtointerface.AuthRequest) (*dto.TokensResponseDTO, error)
+	// Refresh will exchange the refresh token to the new pair of tokens, the refresh token is rotated.
+	Refresh(reqDTO dtointerface.RefreshRequest) (*dto.TokensResponseDTO, error)
+	// Logout will revoke the session of request token.
+	Logout(r *http.Request) error
+	// LogoutAll will revoke all sessions of the request token user.
+	LogoutAll(r *http.Request) error
+	// IsAuthed with check that token is valid and extract userID with the user roles from it.
+	IsAuthed(r *http.Request) (userID vo.ID, roles []string, err error)
 }
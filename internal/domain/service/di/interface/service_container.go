@@ -7,12 +7,20 @@ import (
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
 	accessorinterface "github.com/Borislavv/video-streaming/internal/domain/service/accessor/interface"
+	audioservice "github.com/Borislavv/video-streaming/internal/domain/service/audio/interface"
 	authenticatorinterface "github.com/Borislavv/video-streaming/internal/domain/service/authenticator/interface"
 	cacherinterface "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
 	extractorinterface "github.com/Borislavv/video-streaming/internal/domain/service/extractor/interface"
+	jobinterface "github.com/Borislavv/video-streaming/internal/domain/service/job/interface"
+	moderatorservice "github.com/Borislavv/video-streaming/internal/domain/service/moderator/interface"
+	previewerinterface "github.com/Borislavv/video-streaming/internal/domain/service/previewer/interface"
+	renditioninterface "github.com/Borislavv/video-streaming/internal/domain/service/rendition/interface"
 	resourceservice "github.com/Borislavv/video-streaming/internal/domain/service/resource/interface"
 	securityinterface "github.com/Borislavv/video-streaming/internal/domain/service/security/interface"
+	shareservice "github.com/Borislavv/video-streaming/internal/domain/service/share/interface"
+	subtitleservice "github.com/Borislavv/video-streaming/internal/domain/service/subtitle/interface"
 	tokenizerinterface "github.com/Borislavv/video-streaming/internal/domain/service/tokenizer/interface"
+	transcoderinterface "github.com/Borislavv/video-streaming/internal/domain/service/transcoder/interface"
 	uploaderinterface "github.com/Borislavv/video-streaming/internal/domain/service/uploader/interface"
 	userservice "github.com/Borislavv/video-streaming/internal/domain/service/user/interface"
 	videoservice "github.com/Borislavv/video-streaming/internal/domain/service/video/interface"
@@ -22,10 +30,14 @@ import (
 	cacheinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/cache/interface"
 	mongodbinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/mongodb/interface"
 	detectorinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/detector/interface"
+	manifestinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/manifest/interface"
 	readerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/reader/interface"
+	segmenterinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/segmenter/interface"
+	abrinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/abr/interface"
 	handlerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/handler/interface"
 	strategyinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/handler/strategy/interface"
 	listenerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/listener/interface"
+	guardinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/guard/interface"
 	streamerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/interface"
 	protointerface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
 	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
@@ -43,11 +55,14 @@ type ServiceContainer interface {
 
 	GetResourceMongoRepository() (mongodbinterface.Resource, error)
 	GetVideoMongoRepository() (mongodbinterface.Video, error)
+	GetAudioMongoRepository() (mongodbinterface.Audio, error)
 	GetUserMongoRepository() (mongodbinterface.User, error)
 	GetBlockedTokenMongoRepository() (mongodbinterface.BlockedToken, error)
+	GetJobMongoRepository() (mongodbinterface.Job, error)
 
 	GetResourceCacheRepository() (cacheinterface.Resource, error)
 	GetVideoCacheRepository() (cacheinterface.Video, error)
+	GetAudioCacheRepository() (cacheinterface.Audio, error)
 	GetUserCacheRepository() (cacheinterface.User, error)
 
 	GetAccessService() (accessorinterface.Accessor, error)
@@ -56,13 +71,38 @@ type ServiceContainer interface {
 	GetResourceValidator() (validatorinterface.Resource, error)
 	GetResourceRepository() (repositoryinterface.Resource, error)
 	GetResourceCRUDService() (resourceservice.CRUD, error)
+	GetResourceResumableService() (resourceservice.Resumable, error)
 
 	GetBlockedTokenRepository() (repositoryinterface.BlockedToken, error)
+	GetRefreshTokenRepository() (repositoryinterface.RefreshToken, error)
+	GetJobRepository() (repositoryinterface.Job, error)
+	GetUploadRepository() (repositoryinterface.Upload, error)
+	GetBlobRepository() (repositoryinterface.Blob, error)
 
 	GetVideoBuilder() (builderinterface.Video, error)
 	GetVideoValidator() (validatorinterface.Video, error)
 	GetVideoRepository() (repositoryinterface.Video, error)
 	GetVideoCRUDService() (videoservice.CRUD, error)
+	GetVideoViewService() (videoservice.Viewer, error)
+
+	GetAudioBuilder() (builderinterface.Audio, error)
+	GetAudioValidator() (validatorinterface.Audio, error)
+	GetAudioRepository() (repositoryinterface.Audio, error)
+	GetAudioCRUDService() (audioservice.CRUD, error)
+
+	GetShareBuilder() (builderinterface.Share, error)
+	GetShareValidator() (validatorinterface.Share, error)
+	GetShareRepository() (repositoryinterface.Share, error)
+	GetShareCRUDService() (shareservice.CRUD, error)
+
+	GetSubtitleBuilder() (builderinterface.Subtitle, error)
+	GetSubtitleValidator() (validatorinterface.Subtitle, error)
+	GetSubtitleRepository() (repositoryinterface.Subtitle, error)
+	GetSubtitleCRUDService() (subtitleservice.CRUD, error)
+
+	GetModerationBuilder() (builderinterface.Moderation, error)
+	GetModerationValidator() (validatorinterface.Moderation, error)
+	GetModerationService() (moderatorservice.Moderator, error)
 
 	GetUserBuilder() (builderinterface.User, error)
 	GetUserValidator() (validatorinterface.User, error)
@@ -81,8 +121,12 @@ type ServiceContainer interface {
 	GetTokenizerService() (tokenizerinterface.Tokenizer, error)
 
 	GetFileStorageService() (fileinterface.Storage, error)
+	GetBlobStorageService() (fileinterface.BlobStorage, error)
 	GetFileNameComputerService() (fileinterface.NameComputer, error)
+	GetFileSnifferService() (fileinterface.Sniffer, error)
 	GetFileUploaderService() (uploaderinterface.Uploader, error)
+	GetResumableUploaderService() (uploaderinterface.Resumable, error)
+	GetSubtitleUploaderService() (uploaderinterface.Subtitle, error)
 	GetFileReaderService() (readerinterface.FileReader, error)
 
 	GetWebSocketCommunicatorService() (protointerface.Communicator, error)
@@ -91,6 +135,22 @@ type ServiceContainer interface {
 	GetWebSocketHandlerStrategies() ([]strategyinterface.ActionStrategy, error)
 
 	GetCodecsDetectorService() (detectorinterface.Codecs, error)
+	GetDurationDetectorService() (detectorinterface.Duration, error)
+	GetMediaInfoDetectorService() (detectorinterface.MediaInfo, error)
+	GetAudioTracksDetectorService() (detectorinterface.AudioTracks, error)
+	GetSegmenterService() (segmenterinterface.Segmenter, error)
+	GetDemuxerService() (segmenterinterface.Demuxer, error)
+	GetHLSManifestService() (manifestinterface.HLS, error)
+	GetTextTracksService() (manifestinterface.TextTracks, error)
+	GetDASHManifestService() (manifestinterface.DASH, error)
+	GetTranscoderService() (transcoderinterface.Transcoder, error)
+	GetRenditionProducerService() (renditioninterface.Producer, error)
+	GetPreviewerService() (previewerinterface.Previewer, error)
+	GetJobQueueService() (jobinterface.Queue, error)
+	GetJobWorkerPoolService() (jobinterface.Pool, error)
+	GetJobHandlers() ([]jobinterface.Handler, error)
 
 	GetStreamingService() (streamerinterface.Streamer, error)
+	GetAdaptiveStreamerService() (abrinterface.AdaptiveStreamer, error)
+	GetTokenGuardService() (guardinterface.TokenGuard, error)
 }
@@ -0,0 +1,250 @@
+package job
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/previewer/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/rendition/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/transcoder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	mongodbinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/mongodb/interface"
+	detectorinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/detector/interface"
+	segmenterinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/segmenter/interface"
+	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
+)
+
+const FragmentJobType = "fragment"
+
+// FragmentHandler makes the uploaded resource playable through the MSE: the file which cannot be segmented
+// is repacked into the fragmented mp4. The resource status is kept in sync with the job, and the video which
+// or audio which refers to the resource receives the ready resource (and the video renditions) on completion.
+type FragmentHandler struct {
+	ctx                context.Context
+	logger             loggerinterface.Logger
+	resourceRepository mongodbinterface.Resource
+	videoRepository    mongodbinterface.Video
+	audioRepository    mongodbinterface.Audio
+	segmenter          segmenterinterface.Segmenter
+	transcoder         transcoderinterface.Transcoder
+	renditions         renditioninterface.Producer
+	blobs              fileinterface.BlobStorage
+	mediaInfo          detectorinterface.MediaInfo
+	previewer          previewerinterface.Previewer
+}
+
+func NewFragmentHandler(serviceContainer diinterface.ServiceContainer) (*FragmentHandler, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	// the mongo repositories are used directly, the cached resource may be outdated while it's processing
+	resourceRepository, err := serviceContainer.GetResourceMongoRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	videoRepository, err := serviceContainer.GetVideoMongoRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	audioRepository, err := serviceContainer.GetAudioMongoRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	segmenterService, err := serviceContainer.GetSegmenterService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	transcoderService, err := serviceContainer.GetTranscoderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	renditionProducer, err := serviceContainer.GetRenditionProducerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	blobStorageService, err := serviceContainer.GetBlobStorageService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	mediaInfoDetector, err := serviceContainer.GetMediaInfoDetectorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	previewerService, err := serviceContainer.GetPreviewerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &FragmentHandler{
+		ctx:                ctx,
+		logger:             loggerService,
+		resourceRepository: resourceRepository,
+		videoRepository:    videoRepository,
+		audioRepository:    audioRepository,
+		segmenter:          segmenterService,
+		transcoder:         transcoderService,
+		renditions:         renditionProducer,
+		blobs:              blobStorageService,
+		mediaInfo:          mediaInfoDetector,
+		previewer:          previewerService,
+	}, nil
+}
+
+func (h *FragmentHandler) IsAppropriate(job *agg.Job) bool {
+	return job.GetType() == FragmentJobType
+}
+
+// Handle - fragments the resource of the job, the file is left as is when it's already segmentable.
+func (h *FragmentHandler) Handle(job *agg.Job, progress func(float64) bool) error {
+	resource, err := h.resourceRepository.FindOneByID(h.ctx, dto.NewResourceGetRequestDTO(job.ResourceID, job.UserID))
+	if err != nil {
+		return h.logger.LogPropagate(err)
+	}
+
+	resource.Status = entity.ResourceProcessing
+	resource.Progress = 0
+	resource.Error = ""
+	if resource, err = h.resourceRepository.Update(h.ctx, resource); err != nil {
+		return h.logger.LogPropagate(err)
+	}
+
+	original := resource.Resource
+	isFragmented := false
+	if _, ierr := h.segmenter.Index(original); ierr != nil {
+		fragmented, ferr := h.transcoder.Fragment(original, func(p float64) {
+			if progress(p) {
+				resource.Progress = p
+				if _, uerr := h.resourceRepository.Update(h.ctx, resource); uerr != nil {
+					h.logger.Log(uerr)
+				}
+			}
+		})
+		if ferr != nil {
+			return h.logger.LogPropagate(ferr)
+		}
+
+		// the fragmented file is shared as the uploaded one if the same content is fragmented for another resource
+		if fragmented.Filename, fragmented.Filepath, err = h.blobs.Adopt(fragmented.UserID, fragmented.Filename); err != nil {
+			return h.logger.LogPropagate(err)
+		}
+		resource.Resource = fragmented
+		isFragmented = true
+	}
+
+	// the media info is probed once, so the streaming reads it from the storage instead of probing each time
+	if resource.MediaInfo, err = h.mediaInfo.Detect(resource.Resource); err != nil {
+		// the resource is playable anyway, the media info will be probed on demand
+		h.logger.Log(err)
+	}
+
+	// the poster and the storyboard are made for the video content only (the audio has no frames)
+	if resource.MediaInfo != nil && resource.MediaInfo.FirstVideo() != nil {
+		if resource.Preview, err = h.previewer.Generate(resource.Resource); err != nil {
+			// the previews are optional, the video is playable without them
+			h.logger.Log(err)
+		}
+	}
+
+	resource.Status = entity.ResourceReady
+	resource.Progress = 1
+	if resource, err = h.resourceRepository.Update(h.ctx, resource); err != nil {
+		return h.logger.LogPropagate(err)
+	}
+
+	// the source file is not referenced by the resource anymore (even if the fragmented content is the same blob,
+	// it was referenced once more by adopting)
+	if isFragmented {
+		if err = h.blobs.Release(resource.GetUserID(), original.GetFilename()); err != nil {
+			h.logger.Log(err)
+		}
+	}
+
+	if err = h.syncVideo(resource); err != nil {
+		return h.logger.LogPropagate(err)
+	}
+
+	return h.syncAudio(resource)
+}
+
+// Fail - marks the resource as failed, so the reason will be visible for the owner.
+func (h *FragmentHandler) Fail(job *agg.Job, reason error) error {
+	resource, err := h.resourceRepository.FindOneByID(h.ctx, dto.NewResourceGetRequestDTO(job.ResourceID, job.UserID))
+	if err != nil {
+		return h.logger.LogPropagate(err)
+	}
+
+	resource.Status = entity.ResourceFailed
+	resource.Error = reason.Error()
+	if _, err = h.resourceRepository.Update(h.ctx, resource); err != nil {
+		return h.logger.LogPropagate(err)
+	}
+
+	return nil
+}
+
+// syncVideo - replaces the copy of the resource in the video which was created while the resource was processing
+// and produces the renditions which were skipped at that moment.
+func (h *FragmentHandler) syncVideo(resource *agg.Resource) error {
+	q := dto.NewVideoGetRequestDTO(vo.ID{}, "", resource.ID, resource.UserID)
+	video, err := h.videoRepository.FindOneByResourceID(h.ctx, q)
+	if err != nil {
+		if errtype.IsEntityNotFoundError(err) {
+			// the video was not created yet, it will take the ready resource itself
+			return nil
+		}
+		return h.logger.LogPropagate(err)
+	}
+
+	video.Resource = resource.Resource
+	if len(video.Renditions) == 0 {
+		if video.Renditions, err = h.renditions.Produce(video.Resource); err != nil {
+			return h.logger.LogPropagate(err)
+		}
+	}
+
+	renditions := video.Renditions
+	if _, err = h.videoRepository.Update(h.ctx, video); err != nil {
+		_ = h.renditions.Remove(renditions)
+		return h.logger.LogPropagate(err)
+	}
+
+	return nil
+}
+
+// syncAudio - replaces the copy of the resource in the audio which was created while the resource was processing.
+func (h *FragmentHandler) syncAudio(resource *agg.Resource) error {
+	q := dto.NewAudioGetRequestDTO(vo.ID{}, "", resource.ID, resource.UserID)
+	audio, err := h.audioRepository.FindOneByResourceID(h.ctx, q)
+	if err != nil {
+		if errtype.IsEntityNotFoundError(err) {
+			return nil
+		}
+		return h.logger.LogPropagate(err)
+	}
+
+	audio.Resource = resource.Resource
+	if _, err = h.audioRepository.Update(h.ctx, audio); err != nil {
+		return h.logger.LogPropagate(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package jobinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"sync"
+)
+
+type Queue interface {
+	// Enqueue - schedules a new job of the given type for the resource.
+	Enqueue(jobType string, resource *agg.Resource) (*agg.Job, error)
+}
+
+type Handler interface {
+	// IsAppropriate - checks whether the handler is able to process the given job.
+	IsAppropriate(job *agg.Job) bool
+	// Handle - processes the job and reports its progress from 0 to 1. The progress func returns true
+	// when the value was persisted, so the handler may mirror it on the related entities.
+	Handle(job *agg.Job, progress func(float64) bool) error
+	// Fail - is called when the job is failed and will not be retried anymore.
+	Fail(job *agg.Job, err error) error
+}
+
+type Pool interface {
+	// Run - starts the workers which will process the jobs until the app. context is done.
+	Run(wg *sync.WaitGroup)
+}
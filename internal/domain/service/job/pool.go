@@ -0,0 +1,217 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/job/interface"
+	"sync"
+	"time"
+)
+
+// progressSavingInterval - the progress is reported by the handlers very often, but it's persisted not
+// more frequently than once per interval.
+const progressSavingInterval = time.Second
+
+// WorkerPool is a set of workers which take the jobs from the repository and pass them to the appropriate
+// handlers. The failed job is retried with the exponential backoff until the attempts are exhausted.
+type WorkerPool struct {
+	ctx          context.Context
+	logger       loggerinterface.Logger
+	repository   repositoryinterface.Job
+	handlers     []jobinterface.Handler
+	workers      int
+	maxAttempts  int
+	pollInterval time.Duration
+	retryBackoff time.Duration
+	leaseTimeout time.Duration
+}
+
+func NewWorkerPool(serviceContainer diinterface.ServiceContainer) (*WorkerPool, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	jobRepository, err := serviceContainer.GetJobRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	handlers, err := serviceContainer.GetJobHandlers()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	pollInterval, err := time.ParseDuration(cfg.JobPollInterval)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	retryBackoff, err := time.ParseDuration(cfg.JobRetryBackoff)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	leaseTimeout, err := time.ParseDuration(cfg.JobLeaseTimeout)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &WorkerPool{
+		ctx:          ctx,
+		logger:       loggerService,
+		repository:   jobRepository,
+		handlers:     handlers,
+		workers:      cfg.JobWorkers,
+		maxAttempts:  cfg.JobMaxAttempts,
+		pollInterval: pollInterval,
+		retryBackoff: retryBackoff,
+		leaseTimeout: leaseTimeout,
+	}, nil
+}
+
+// Run - starts the configured number of workers.
+func (p *WorkerPool) Run(wg *sync.WaitGroup) {
+	for i := 0; i < p.workers; i++ {
+		wg.Add(1)
+		go p.work(wg)
+	}
+}
+
+// work - takes the jobs one by one, the repository is polled by interval when there is nothing to do.
+func (p *WorkerPool) work(wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	ticker := time.NewTicker(p.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		// the job which lease was expired on the last attempt crashes the worker, it's not taken anymore
+		expired, err := p.repository.FailExpired(p.ctx, p.maxAttempts)
+		if err == nil {
+			p.fail(expired, errors.New(expired.Error))
+			continue
+		}
+		if !errtype.IsEntityNotFoundError(err) && p.ctx.Err() == nil {
+			p.logger.Log(err)
+		}
+
+		job, err := p.repository.Lease(p.ctx, p.leaseTimeout, p.maxAttempts)
+		if err == nil {
+			p.process(job)
+			continue
+		}
+		if !errtype.IsEntityNotFoundError(err) && p.ctx.Err() == nil {
+			p.logger.Log(err)
+		}
+
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// process - passes the job to the handler and saves the result of the attempt.
+func (p *WorkerPool) process(job *agg.Job) {
+	handler := p.handler(job)
+	if handler == nil {
+		job.Status = entity.JobFailed
+		job.Error = p.logger.LogPropagate(errtype.NewJobHandlerNotFoundError(job.Type)).Error()
+		p.save(job)
+		return
+	}
+
+	saved := time.Now()
+	lost := false
+	err := handler.Handle(job, func(progress float64) bool {
+		if lost || time.Since(saved) < progressSavingInterval {
+			return false
+		}
+		saved = time.Now()
+
+		// each saving prolongs the lease, so the long job will not be taken by another worker
+		job.Progress = progress
+		job.LeasedTill = time.Now().Add(p.leaseTimeout)
+		if _, uerr := p.repository.Update(p.ctx, job); uerr != nil {
+			lost = errtype.IsJobLeaseWasLostError(uerr)
+			p.logger.Log(uerr)
+			return false
+		}
+		return true
+	})
+
+	if p.ctx.Err() != nil || lost {
+		// the application is stopping (the job will be taken again when its lease is expired)
+		// or the job is already processed by another worker
+		return
+	}
+
+	if err == nil {
+		job.Status = entity.JobDone
+		job.Progress = 1
+		job.Error = ""
+	} else if job.Attempts < p.maxAttempts {
+		job.Status = entity.JobPending
+		job.Error = err.Error()
+		job.RunAt = time.Now().Add(p.retryBackoff << (job.Attempts - 1))
+	} else {
+		job.Status = entity.JobFailed
+		job.Error = err.Error()
+	}
+
+	// the result of the worker which lost its lease is dropped, the job is owned by another one
+	if !p.save(job) || job.Status != entity.JobFailed {
+		return
+	}
+	if ferr := handler.Fail(job, err); ferr != nil {
+		p.logger.Log(ferr)
+	}
+}
+
+// fail - passes the failed job to its handler, so the related entities are marked as failed too.
+func (p *WorkerPool) fail(job *agg.Job, reason error) {
+	handler := p.handler(job)
+	if handler == nil {
+		p.logger.Log(errtype.NewJobHandlerNotFoundError(job.Type))
+		return
+	}
+	if err := handler.Fail(job, reason); err != nil {
+		p.logger.Log(err)
+	}
+}
+
+func (p *WorkerPool) handler(job *agg.Job) jobinterface.Handler {
+	for _, handler := range p.handlers {
+		if handler.IsAppropriate(job) {
+			return handler
+		}
+	}
+	return nil
+}
+
+// save - updates the job, returns false if it was not saved.
+func (p *WorkerPool) save(job *agg.Job) bool {
+	if _, err := p.repository.Update(p.ctx, job); err != nil {
+		p.logger.Log(err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,49 @@
+package job
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+)
+
+// Queue is a service which schedules the background jobs, they are stored into the repository
+// and will be taken by the first free worker.
+type Queue struct {
+	ctx        context.Context
+	logger     loggerinterface.Logger
+	repository repositoryinterface.Job
+}
+
+func NewQueue(serviceContainer diinterface.ServiceContainer) (*Queue, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	jobRepository, err := serviceContainer.GetJobRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &Queue{
+		ctx:        ctx,
+		logger:     loggerService,
+		repository: jobRepository,
+	}, nil
+}
+
+// Enqueue - makes a pending job of the given type for the resource.
+func (q *Queue) Enqueue(jobType string, resource *agg.Resource) (*agg.Job, error) {
+	job, err := q.repository.Insert(q.ctx, agg.NewJob(jobType, resource))
+	if err != nil {
+		return nil, q.logger.LogPropagate(err)
+	}
+	return job, nil
+}
@@ -0,0 +1,18 @@
+package moderatorinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+)
+
+// Moderator - works with the videos and resources of any user, the permissions are checked by the roles of request.
+type Moderator interface {
+	ListVideos(reqDTO dtointerface.ModerateVideoListRequest) (list []*agg.Video, total int64, err error)
+	GetVideo(reqDTO dtointerface.ModerateByIdRequest) (*agg.Video, error)
+	DeleteVideo(reqDTO dtointerface.ModerateByIdRequest) error
+	ListResources(reqDTO dtointerface.ModerateResourceListRequest) (list []*agg.Resource, total int64, err error)
+	GetResource(reqDTO dtointerface.ModerateByIdRequest) (*agg.Resource, error)
+	DeleteResource(reqDTO dtointerface.ModerateByIdRequest) error
+	UpdateUserRoles(reqDTO dtointerface.ModerateUserRolesRequest) (*agg.User, error)
+	PromoteAdmins(emails []string) error
+}
@@ -0,0 +1,286 @@
+package moderator
+
+import (
+	"context"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	accessorinterface "github.com/Borislavv/video-streaming/internal/domain/service/accessor/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	resourceinterface "github.com/Borislavv/video-streaming/internal/domain/service/resource/interface"
+	videointerface "github.com/Borislavv/video-streaming/internal/domain/service/video/interface"
+	validatorinterface "github.com/Borislavv/video-streaming/internal/domain/validator/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"slices"
+)
+
+type ModerationService struct {
+	ctx                context.Context
+	logger             loggerinterface.Logger
+	validator          validatorinterface.Moderation
+	accessService      accessorinterface.Accessor
+	videoService       videointerface.CRUD
+	resourceService    resourceinterface.CRUD
+	videoRepository    repositoryinterface.Video
+	resourceRepository repositoryinterface.Resource
+	userRepository     repositoryinterface.User
+}
+
+func NewModerationService(serviceContainer diinterface.ServiceContainer) (*ModerationService, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	moderationValidator, err := serviceContainer.GetModerationValidator()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	accessService, err := serviceContainer.GetAccessService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	videoCRUDService, err := serviceContainer.GetVideoCRUDService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	resourceCRUDService, err := serviceContainer.GetResourceCRUDService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	videoRepository, err := serviceContainer.GetVideoRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	resourceRepository, err := serviceContainer.GetResourceRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	userRepository, err := serviceContainer.GetUserRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &ModerationService{
+		ctx:                ctx,
+		logger:             loggerService,
+		validator:          moderationValidator,
+		accessService:      accessService,
+		videoService:       videoCRUDService,
+		resourceService:    resourceCRUDService,
+		videoRepository:    videoRepository,
+		resourceRepository: resourceRepository,
+		userRepository:     userRepository,
+	}, nil
+}
+
+// ListVideos - will fetch the videos of specified user or of all users if the user is omitted.
+func (s *ModerationService) ListVideos(req dtointerface.ModerateVideoListRequest) (list []*agg.Video, total int64, err error) {
+	if err = s.accessService.IsPermitted(req.GetModeratorRoles(), enum.InspectAnyPermission); err != nil {
+		return nil, 0, s.logger.LogPropagate(err)
+	}
+
+	// validation of input request
+	if err = s.validator.ValidateVideoListRequestDTO(req); err != nil {
+		return nil, 0, s.logger.LogPropagate(err)
+	}
+
+	list, total, err = s.videoRepository.FindList(s.ctx, req)
+	if err != nil {
+		return nil, 0, s.logger.LogPropagate(err)
+	}
+
+	return list, total, nil
+}
+
+// GetVideo - will fetch the video regardless of its owner.
+func (s *ModerationService) GetVideo(req dtointerface.ModerateByIdRequest) (*agg.Video, error) {
+	if err := s.accessService.IsPermitted(req.GetModeratorRoles(), enum.InspectAnyPermission); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// validation of input request
+	if err := s.validator.ValidateByIdRequestDTO(req); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	videoAgg, err := s.videoRepository.FindOneByIDOfAnyOwner(s.ctx, req.GetID())
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	return videoAgg, nil
+}
+
+// DeleteVideo - will remove the video of any user in the same way as the owner does it.
+func (s *ModerationService) DeleteVideo(req dtointerface.ModerateByIdRequest) error {
+	if err := s.accessService.IsPermitted(req.GetModeratorRoles(), enum.DeleteAnyPermission); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	// validation of input request
+	if err := s.validator.ValidateByIdRequestDTO(req); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	videoAgg, err := s.videoRepository.FindOneByIDOfAnyOwner(s.ctx, req.GetID())
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	// the video is removed on behalf of the owner
+	if err = s.videoService.Delete(dto.NewVideoDeleteRequestDto(videoAgg.ID, videoAgg.UserID)); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	return nil
+}
+
+// ListResources - will fetch the resources of specified user or of all users if the user is omitted.
+func (s *ModerationService) ListResources(
+	req dtointerface.ModerateResourceListRequest,
+) (list []*agg.Resource, total int64, err error) {
+	if err = s.accessService.IsPermitted(req.GetModeratorRoles(), enum.InspectAnyPermission); err != nil {
+		return nil, 0, s.logger.LogPropagate(err)
+	}
+
+	// validation of input request
+	if err = s.validator.ValidateResourceListRequestDTO(req); err != nil {
+		return nil, 0, s.logger.LogPropagate(err)
+	}
+
+	list, total, err = s.resourceRepository.FindList(s.ctx, req)
+	if err != nil {
+		return nil, 0, s.logger.LogPropagate(err)
+	}
+
+	return list, total, nil
+}
+
+// GetResource - will fetch the resource regardless of its owner.
+func (s *ModerationService) GetResource(req dtointerface.ModerateByIdRequest) (*agg.Resource, error) {
+	if err := s.accessService.IsPermitted(req.GetModeratorRoles(), enum.InspectAnyPermission); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// validation of input request
+	if err := s.validator.ValidateByIdRequestDTO(req); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	resourceAgg, err := s.resourceRepository.FindOneByIDOfAnyOwner(s.ctx, req.GetID())
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	return resourceAgg, nil
+}
+
+// DeleteResource - will remove the resource of any user. The video which is made of the resource
+// is removed too, otherwise it would refer to the missing file.
+func (s *ModerationService) DeleteResource(req dtointerface.ModerateByIdRequest) error {
+	if err := s.accessService.IsPermitted(req.GetModeratorRoles(), enum.DeleteAnyPermission); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	// validation of input request
+	if err := s.validator.ValidateByIdRequestDTO(req); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	resourceAgg, err := s.resourceRepository.FindOneByIDOfAnyOwner(s.ctx, req.GetID())
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	// fetching a video of the resource, the video removing takes the resource with itself
+	q := dto.NewVideoGetRequestDTO(vo.ID{}, "", resourceAgg.ID, resourceAgg.UserID)
+	videoAgg, err := s.videoRepository.FindOneByResourceID(s.ctx, q)
+	if err != nil && !errtype.IsEntityNotFoundError(err) {
+		return s.logger.LogPropagate(err)
+	}
+	if videoAgg != nil {
+		if err = s.videoService.Delete(dto.NewVideoDeleteRequestDto(videoAgg.ID, videoAgg.UserID)); err != nil {
+			return s.logger.LogPropagate(err)
+		}
+		return nil
+	}
+
+	// the resource is not used by any video, removing it on behalf of the owner
+	if err = s.resourceService.Delete(dto.NewResourceDeleteRequestDTO(resourceAgg.ID, resourceAgg.UserID)); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	return nil
+}
+
+// UpdateUserRoles - will replace the roles of the user. The issued tokens keep the previous roles
+// until they are expired, the new ones are carried by the tokens of next log in.
+func (s *ModerationService) UpdateUserRoles(req dtointerface.ModerateUserRolesRequest) (*agg.User, error) {
+	if err := s.accessService.IsPermitted(req.GetModeratorRoles(), enum.ManageRolesPermission); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// validation of input request
+	if err := s.validator.ValidateUserRolesRequestDTO(req); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	userAgg, err := s.userRepository.FindOneByID(s.ctx, dto.NewUserGetRequestDTO(req.GetID(), ""))
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	userAgg.Roles = req.GetRoles()
+
+	userAgg, err = s.userRepository.Update(s.ctx, userAgg)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	return userAgg, nil
+}
+
+// PromoteAdmins - grants the admin role to the registered users with the given emails. It's called once at startup
+// by the operator configuration, so the role is never granted by the self registration. The not registered emails
+// are skipped and promoted by the next start after the registration.
+func (s *ModerationService) PromoteAdmins(emails []string) error {
+	for _, email := range emails {
+		userAgg, err := s.userRepository.FindOneByEmail(s.ctx, dto.NewUserGetRequestDTO(vo.ID{}, email))
+		if err != nil {
+			if errtype.IsEntityNotFoundError(err) {
+				s.logger.Warning(fmt.Sprintf("admin '%v' is not registered yet, the role will be granted by the next start", email))
+				continue
+			}
+			return s.logger.LogPropagate(err)
+		}
+
+		if slices.Contains(userAgg.Roles, enum.AdminRole) {
+			continue
+		}
+		userAgg.Roles = append(userAgg.GetRoles(), enum.AdminRole)
+
+		if _, err = s.userRepository.Update(s.ctx, userAgg); err != nil {
+			return s.logger.LogPropagate(err)
+		}
+		s.logger.Info(fmt.Sprintf("user '%v' was promoted to admin", email))
+	}
+
+	return nil
+}
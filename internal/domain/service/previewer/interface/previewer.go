@@ -0,0 +1,11 @@
+package previewerinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+)
+
+type Previewer interface {
+	// Generate - makes the poster and the storyboard of the video resource, the resource must have the media info.
+	Generate(resource entity.Resource) (*vo.Preview, error)
+}
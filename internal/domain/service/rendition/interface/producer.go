@@ -0,0 +1,12 @@
+package renditioninterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+)
+
+type Producer interface {
+	// Produce - makes the rendition ladder of the given resource, the original resource is included.
+	Produce(resource entity.Resource) ([]entity.Rendition, error)
+	// Remove - deletes the files of produced renditions (the original resource is skipped).
+	Remove(renditions []entity.Rendition) error
+}
@@ -0,0 +1,137 @@
+package rendition
+
+import (
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/transcoder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// LadderProducer is a service which makes the renditions of the resource by the configured ladder.
+type LadderProducer struct {
+	logger     loggerinterface.Logger
+	transcoder transcoderinterface.Transcoder
+	storage    fileinterface.Storage
+	ladder     []vo.RenditionProfile
+}
+
+func NewLadderProducer(serviceContainer diinterface.ServiceContainer) (*LadderProducer, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	transcoderService, err := serviceContainer.GetTranscoderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	storageService, err := serviceContainer.GetFileStorageService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	ladder, err := parseLadder(cfg.RenditionLadder)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &LadderProducer{
+		logger:     loggerService,
+		transcoder: transcoderService,
+		storage:    storageService,
+		ladder:     ladder,
+	}, nil
+}
+
+// Produce - makes the rendition ladder of the given resource. If the ladder is not configured,
+// nothing will be produced and the video will be served by the original resource only.
+func (p *LadderProducer) Produce(resource entity.Resource) ([]entity.Rendition, error) {
+	if len(p.ladder) == 0 {
+		return nil, nil
+	}
+
+	original, err := p.transcoder.Probe(resource)
+	if err != nil {
+		return nil, p.logger.LogPropagate(err)
+	}
+	original.Original = true
+
+	renditions := []entity.Rendition{original}
+	for _, profile := range p.ladder {
+		// upscaling does not make sense
+		if original.Height > 0 && profile.Height >= original.Height {
+			continue
+		}
+
+		rendition, terr := p.transcoder.Transcode(resource, profile)
+		if terr != nil {
+			// the already produced files will not be referenced anywhere
+			_ = p.Remove(renditions)
+			return nil, p.logger.LogPropagate(terr)
+		}
+		renditions = append(renditions, rendition)
+	}
+
+	sort.SliceStable(renditions, func(i, j int) bool {
+		return renditions[i].Bitrate < renditions[j].Bitrate
+	})
+
+	return renditions, nil
+}
+
+// Remove - deletes the files of produced renditions, the original resource is owned by the resource service.
+func (p *LadderProducer) Remove(renditions []entity.Rendition) error {
+	for _, rendition := range renditions {
+		if rendition.Original {
+			continue
+		}
+		if err := p.storage.Remove(rendition.Resource.GetUserID(), rendition.Resource.GetFilename()); err != nil {
+			return p.logger.LogPropagate(err)
+		}
+	}
+	return nil
+}
+
+// parseLadder - parses the profiles in format 'height:kbps' and orders them by height descending.
+func parseLadder(steps []string) ([]vo.RenditionProfile, error) {
+	ladder := make([]vo.RenditionProfile, 0, len(steps))
+	for _, step := range steps {
+		step = strings.TrimSpace(step)
+		if step == "" {
+			continue
+		}
+
+		height, kbps, found := strings.Cut(step, ":")
+		if !found {
+			return nil, fmt.Errorf("invalid rendition ladder step '%v', expected format is 'height:kbps'", step)
+		}
+		h, err := strconv.Atoi(height)
+		if err != nil || h <= 0 {
+			return nil, fmt.Errorf("invalid rendition ladder step '%v', the height must be a positive integer", step)
+		}
+		k, err := strconv.ParseInt(kbps, 10, 64)
+		if err != nil || k <= 0 {
+			return nil, fmt.Errorf("invalid rendition ladder step '%v', the bitrate must be a positive integer", step)
+		}
+
+		ladder = append(ladder, vo.RenditionProfile{Height: h, Bitrate: k * 1000})
+	}
+
+	sort.Slice(ladder, func(i, j int) bool {
+		return ladder[i].Height > ladder[j].Height
+	})
+
+	return ladder, nil
+}
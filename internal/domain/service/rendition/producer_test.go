@@ -0,0 +1,158 @@
+package rendition
+
+import (
+	"github.com/Borislavv/video-streaming/internal/app"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	transcoderinterface "github.com/Borislavv/video-streaming/internal/domain/service/transcoder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/di/ditest"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/transcoder"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/filetest"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"reflect"
+	"testing"
+)
+
+func newTestProducer(t *testing.T, ladder []string) (*LadderProducer, *filetest.MemoryStorage, entity.Resource) {
+	t.Helper()
+
+	container, storage := ditest.NewStorageContainer(t, &app.Config{RenditionLadder: ladder})
+
+	fake, err := transcoder.NewFakeTranscoder(container)
+	if err != nil {
+		t.Fatal(err)
+	}
+	container.
+		Set(fake, reflect.TypeOf((*transcoderinterface.Transcoder)(nil)))
+
+	producer, err := NewLadderProducer(container)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource := entity.Resource{
+		ID:       vo.NewID(primitive.NewObjectID()),
+		UserID:   vo.NewID(primitive.NewObjectID()),
+		Name:     "video",
+		Filename: "video.mp4",
+		Filetype: "video/mp4",
+	}
+	storage.Put(resource.GetUserID(), resource.GetFilename(), []byte("content"))
+
+	return producer, storage, resource
+}
+
+func TestLadderProducer_Produce(t *testing.T) {
+	// the fake source is the 1080p one, so the 1080p and the higher profiles are skipped
+	producer, storage, resource := newTestProducer(t, []string{"480:1400", "1440:9000", "720:2800", "1080:4500", "360:800"})
+
+	renditions, err := producer.Produce(resource)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct {
+		height   int
+		bitrate  int64
+		original bool
+	}{
+		{height: 360, bitrate: 800_000},
+		{height: 480, bitrate: 1_400_000},
+		{height: 720, bitrate: 2_800_000},
+		{height: 1080, bitrate: 5_000_000, original: true},
+	}
+	if len(renditions) != len(expected) {
+		t.Fatalf("expected %d renditions, got %d: %+v", len(expected), len(renditions), renditions)
+	}
+	for i, e := range expected {
+		r := renditions[i]
+		if r.Height != e.height || r.Bitrate != e.bitrate || r.Original != e.original {
+			t.Errorf("rendition %d: expected %dp %d bps (original: %v), got %dp %d bps (original: %v)",
+				i, e.height, e.bitrate, e.original, r.Height, r.Bitrate, r.Original)
+		}
+		if r.Width%2 != 0 {
+			t.Errorf("rendition %d: width %d is not even", i, r.Width)
+		}
+		if has, _ := storage.Has(r.Resource.GetUserID(), r.Resource.GetFilename()); !has {
+			t.Errorf("rendition %d: file '%v' is not stored", i, r.Resource.GetFilename())
+		}
+	}
+
+	// the produced files are removed, the original one is kept
+	if err = producer.Remove(renditions); err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range renditions {
+		has, _ := storage.Has(r.Resource.GetUserID(), r.Resource.GetFilename())
+		if has != r.Original {
+			t.Errorf("rendition %d: expected the file is kept %v, got %v", i, r.Original, has)
+		}
+	}
+}
+
+func TestLadderProducer_ProduceWithoutLadder(t *testing.T) {
+	producer, _, resource := newTestProducer(t, nil)
+
+	renditions, err := producer.Produce(resource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(renditions) != 0 {
+		t.Fatalf("expected no renditions, got %+v", renditions)
+	}
+
+	// the video without renditions is served by the original resource
+	video := &agg.Video{Resource: resource}
+	if got := video.GetRenditions(); len(got) != 1 || got[0].Resource.GetFilename() != resource.GetFilename() {
+		t.Fatalf("expected the single original rendition, got %+v", got)
+	}
+}
+
+func TestVideo_GetRendition(t *testing.T) {
+	producer, _, resource := newTestProducer(t, []string{"720:2800", "360:800"})
+
+	renditions, err := producer.Produce(resource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	video := &agg.Video{Resource: resource, Renditions: renditions}
+
+	for _, number := range []int{-1, len(renditions), len(renditions) + 1} {
+		if _, ok := video.GetRendition(number); ok {
+			t.Errorf("expected rendition %d is out of bounds", number)
+		}
+	}
+	for number := range renditions {
+		rendition, ok := video.GetRendition(number)
+		if !ok {
+			t.Fatalf("expected rendition %d exists", number)
+		}
+		if rendition.Height != renditions[number].Height {
+			t.Errorf("rendition %d: expected %dp, got %dp", number, renditions[number].Height, rendition.Height)
+		}
+	}
+	if original := video.GetOriginalRendition(); !renditions[original].Original {
+		t.Errorf("expected rendition %d is the original one", original)
+	}
+}
+
+func TestParseLadder(t *testing.T) {
+	ladder, err := parseLadder([]string{" 360:800", "", "1080:5000 ", "720:2800"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	heights := make([]int, 0, len(ladder))
+	for _, profile := range ladder {
+		heights = append(heights, profile.Height)
+	}
+	if !reflect.DeepEqual(heights, []int{1080, 720, 360}) {
+		t.Fatalf("expected the ladder is ordered by height descending, got %v", heights)
+	}
+
+	for _, invalid := range []string{"720", "0:800", "720:-1", "abc:800"} {
+		if _, err = parseLadder([]string{invalid}); err == nil {
+			t.Errorf("expected '%v' is rejected", invalid)
+		}
+	}
+}
@@ -8,9 +8,11 @@ import (
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
 	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
-	storagerinterface "github.com/Borislavv/video-streaming/internal/domain/service/storager/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/job"
+	jobinterface "github.com/Borislavv/video-streaming/internal/domain/service/job/interface"
 	uploaderinterface "github.com/Borislavv/video-streaming/internal/domain/service/uploader/interface"
 	validatorinterface "github.com/Borislavv/video-streaming/internal/domain/validator/interface"
+	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
 )
 
 type CRUDService struct {
@@ -20,7 +22,9 @@ type CRUDService struct {
 	validator  validatorinterface.Resource
 	builder    builderinterface.Resource
 	repository repositoryinterface.Resource
-	storage    storagerinterface.Storage
+	blobs      fileinterface.BlobStorage
+	storage    fileinterface.Storage
+	jobs       jobinterface.Queue
 }
 
 func NewResourceService(serviceContainer diinterface.ServiceContainer) (*CRUDService, error) {
@@ -54,7 +58,17 @@ func NewResourceService(serviceContainer diinterface.ServiceContainer) (*CRUDSer
 		return nil, loggerService.LogPropagate(err)
 	}
 
-	fileStorageService, err := serviceContainer.GetFileStorageService()
+	blobStorageService, err := serviceContainer.GetBlobStorageService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	storageService, err := serviceContainer.GetFileStorageService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	jobQueue, err := serviceContainer.GetJobQueueService()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
 	}
@@ -66,7 +80,9 @@ func NewResourceService(serviceContainer diinterface.ServiceContainer) (*CRUDSer
 		validator:  validatorService,
 		builder:    builderService,
 		repository: resourceRepository,
-		storage:    fileStorageService,
+		blobs:      blobStorageService,
+		storage:    storageService,
+		jobs:       jobQueue,
 	}, nil
 }
 
@@ -106,23 +122,25 @@ func (s *CRUDService) Upload(req dtointerface.UploadResourceRequest) (resource *
 		return nil, s.logger.LogPropagate(err)
 	}
 
+	// the resource will be ready for streaming when the file is fragmented in background
+	if _, err = s.jobs.Enqueue(job.FragmentJobType, resource); err != nil {
+		// the file will be removed on failure, so the resource must not be left
+		if rerr := s.repository.Remove(s.ctx, resource); rerr != nil {
+			s.logger.Log(rerr)
+		}
+		return nil, s.logger.LogPropagate(err)
+	}
+
 	return resource, nil
 }
 
 // onUploadingFailed - will check that created file is removed.
 func (s *CRUDService) onUploadingFailed(req dtointerface.UploadResourceRequest) error {
 	// handle the case when the file was uploaded, but error occurred while saving an aggregate
-	if req.GetUploadedFilename() != "" { // in this case, we need remove the uploaded file
-		has, err := s.storage.Has(req.GetUserID(), req.GetUploadedFilename())
-		if err != nil {
-			s.logger.Log(err)
+	if req.GetUploadedFilename() != "" { // in this case, we need drop the reference of the uploaded file
+		if err := s.blobs.Release(req.GetUserID(), req.GetUploadedFilename()); err != nil {
 			return s.logger.LogPropagate(err)
 		}
-		if has { // check that file exists, if so, then remove it
-			if err = s.storage.Remove(req.GetUserID(), req.GetUploadedFilename()); err != nil {
-				return s.logger.LogPropagate(err)
-			}
-		}
 	}
 
 	return nil
@@ -141,11 +159,20 @@ func (s *CRUDService) Delete(req dtointerface.DeleteResourceRequest) (err error)
 		return s.logger.LogPropagate(err)
 	}
 
-	// removing the file first
-	if err = s.storage.Remove(req.GetUserID(), resourceAgg.Filename); err != nil {
+	// removing the file first (it's kept while other resources refer to the same content)
+	if err = s.blobs.Release(req.GetUserID(), resourceAgg.Filename); err != nil {
 		return s.logger.LogPropagate(err)
 	}
 
+	// the previews are owned by the resource, so they are not shared with others
+	if preview := resourceAgg.GetPreview(); preview != nil {
+		for _, filename := range preview.Filenames() {
+			if err = s.storage.Remove(req.GetUserID(), filename); err != nil {
+				return s.logger.LogPropagate(err)
+			}
+		}
+	}
+
 	// removing the resource
 	if err = s.repository.Remove(s.ctx, resourceAgg); err != nil {
 		return s.logger.LogPropagate(err)
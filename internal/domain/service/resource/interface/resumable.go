@@ -0,0 +1,14 @@
+package resourceinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+)
+
+type Resumable interface {
+	Create(reqDTO dtointerface.CreateUploadRequest) (*agg.Upload, error)
+	Find(reqDTO dtointerface.GetUploadRequest) (*agg.Upload, error)
+	// Append - writes the chunk, the resource is created when the chunk completes the upload.
+	Append(reqDTO dtointerface.PatchUploadRequest) (*agg.Upload, error)
+	Terminate(reqDTO dtointerface.DeleteUploadRequest) error
+}
@@ -0,0 +1,130 @@
+package resource
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	resourceinterface "github.com/Borislavv/video-streaming/internal/domain/service/resource/interface"
+	uploaderinterface "github.com/Borislavv/video-streaming/internal/domain/service/uploader/interface"
+	validatorinterface "github.com/Borislavv/video-streaming/internal/domain/validator/interface"
+)
+
+// ResumableService receives the file by chunks through the resumable uploader. The resource is uploaded
+// by the CRUDService when the last chunk is received, so it's processed as the resource of any other uploader.
+type ResumableService struct {
+	logger          loggerinterface.Logger
+	uploader        uploaderinterface.Resumable
+	validator       validatorinterface.Resource
+	resourceService resourceinterface.CRUD
+}
+
+func NewResumableService(serviceContainer diinterface.ServiceContainer) (*ResumableService, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	uploaderService, err := serviceContainer.GetResumableUploaderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	validatorService, err := serviceContainer.GetResourceValidator()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	resourceCRUDService, err := serviceContainer.GetResourceCRUDService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &ResumableService{
+		logger:          loggerService,
+		uploader:        uploaderService,
+		validator:       validatorService,
+		resourceService: resourceCRUDService,
+	}, nil
+}
+
+// Create - will register a new upload which file will be received by chunks.
+func (s *ResumableService) Create(req dtointerface.CreateUploadRequest) (*agg.Upload, error) {
+	// validation of input request
+	if err := s.validator.ValidateCreateUploadRequestDTO(req); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	upload, err := s.uploader.Create(req)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	return upload, nil
+}
+
+// Find - will fetch the upload with the received offset for specified user.
+func (s *ResumableService) Find(req dtointerface.GetUploadRequest) (*agg.Upload, error) {
+	// validation of input request
+	if err := s.validator.ValidateGetUploadRequestDTO(req); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	upload, err := s.uploader.Find(req)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	return upload, nil
+}
+
+// Append - will write the chunk at the upload offset. When the upload is completed, the resource will be uploaded
+// from it and the fragmentation job will be enqueued as for any other resource.
+func (s *ResumableService) Append(req dtointerface.PatchUploadRequest) (*agg.Upload, error) {
+	// validation of input request
+	if err := s.validator.ValidatePatchUploadRequestDTO(req); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	upload, err := s.uploader.Append(req)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// the resource is uploaded only once, the retried last chunk will receive the same upload
+	if !upload.IsCompleted() || !upload.ResourceID.Value.IsZero() {
+		return upload, nil
+	}
+
+	resourceReq := dto.NewResourceUploadRequest(req.GetRequest())
+	resourceReq.SetUserID(req.GetUserID())
+
+	// the upload keeps the received file if the resource was not created, so the creation is retried
+	// by the empty chunk at the last offset (or the file is removed with the upload on expiration)
+	resource, err := s.resourceService.Upload(resourceReq)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	upload, err = s.uploader.Complete(upload, resource)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	return upload, nil
+}
+
+// Terminate - will remove the upload with the received part of the file.
+func (s *ResumableService) Terminate(req dtointerface.DeleteUploadRequest) error {
+	// validation of input request
+	if err := s.validator.ValidateDeleteUploadRequestDTO(req); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	if err := s.uploader.Terminate(req); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package share
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	validatorinterface "github.com/Borislavv/video-streaming/internal/domain/validator/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+)
+
+type CRUDService struct {
+	ctx             context.Context
+	logger          loggerinterface.Logger
+	builder         builderinterface.Share
+	validator       validatorinterface.Share
+	repository      repositoryinterface.Share
+	videoRepository repositoryinterface.Video
+}
+
+func NewCRUDService(serviceContainer diinterface.ServiceContainer) (*CRUDService, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	shareBuilder, err := serviceContainer.GetShareBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	shareValidator, err := serviceContainer.GetShareValidator()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	shareRepository, err := serviceContainer.GetShareRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	videoRepository, err := serviceContainer.GetVideoRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &CRUDService{
+		ctx:             ctx,
+		logger:          loggerService,
+		builder:         shareBuilder,
+		validator:       shareValidator,
+		repository:      shareRepository,
+		videoRepository: videoRepository,
+	}, nil
+}
+
+// List - will fetch the shares of the video. The video is fetched first, so the shares
+// of video which is not belong to specified user are not found.
+func (s *CRUDService) List(req dtointerface.ListShareRequest) ([]*agg.Share, error) {
+	// validation of input request
+	if err := s.validator.ValidateListRequestDTO(req); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// fetching a video by id and user
+	q := dto.NewVideoGetRequestDTO(req.GetVideoID(), "", vo.ID{}, req.GetUserID())
+	if _, err := s.videoRepository.FindOneByID(s.ctx, q); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// fetching a share list of the video
+	list, err := s.repository.FindList(s.ctx, req)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	return list, nil
+}
+
+// Create - will make a new share link of the video for specified user, who must be an owner of the video.
+func (s *CRUDService) Create(req dtointerface.CreateShareRequest) (*agg.Share, error) {
+	// validation of input request
+	if err := s.validator.ValidateCreateRequestDTO(req); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// building an aggregate
+	shareAgg, err := s.builder.BuildAggFromCreateRequestDTO(req)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// validation of an aggregate
+	if err = s.validator.ValidateAggregate(shareAgg); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// saving an aggregate into storage
+	shareAgg, err = s.repository.Insert(s.ctx, shareAgg)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	return shareAgg, nil
+}
+
+// Delete - will revoke the share link, the token stops granting access immediately.
+func (s *CRUDService) Delete(req dtointerface.DeleteShareRequest) error {
+	// validation of input request
+	if err := s.validator.ValidateDeleteRequestDTO(req); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	// fetching a share which will be deleted
+	shareAgg, err := s.repository.FindOneByID(s.ctx, req)
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	// share removing
+	if err = s.repository.Remove(s.ctx, shareAgg); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	return nil
+}
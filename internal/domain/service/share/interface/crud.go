@@ -0,0 +1,12 @@
+package shareinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+)
+
+type CRUD interface {
+	List(reqDTO dtointerface.ListShareRequest) ([]*agg.Share, error)
+	Create(reqDTO dtointerface.CreateShareRequest) (*agg.Share, error)
+	Delete(reqDTO dtointerface.DeleteShareRequest) error
+}
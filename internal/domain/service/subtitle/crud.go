@@ -0,0 +1,270 @@
+package subtitle
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	uploaderinterface "github.com/Borislavv/video-streaming/internal/domain/service/uploader/interface"
+	videointerface "github.com/Borislavv/video-streaming/internal/domain/service/video/interface"
+	validatorinterface "github.com/Borislavv/video-streaming/internal/domain/validator/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
+)
+
+type CRUDService struct {
+	ctx             context.Context
+	logger          loggerinterface.Logger
+	builder         builderinterface.Subtitle
+	validator       validatorinterface.Subtitle
+	repository      repositoryinterface.Subtitle
+	videoRepository repositoryinterface.Video
+	viewer          videointerface.Viewer
+	uploader        uploaderinterface.Subtitle
+	blobs           fileinterface.BlobStorage
+}
+
+func NewCRUDService(serviceContainer diinterface.ServiceContainer) (*CRUDService, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	subtitleBuilder, err := serviceContainer.GetSubtitleBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	subtitleValidator, err := serviceContainer.GetSubtitleValidator()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	subtitleRepository, err := serviceContainer.GetSubtitleRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	videoRepository, err := serviceContainer.GetVideoRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	videoViewService, err := serviceContainer.GetVideoViewService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	subtitleUploader, err := serviceContainer.GetSubtitleUploaderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	blobStorageService, err := serviceContainer.GetBlobStorageService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &CRUDService{
+		ctx:             ctx,
+		logger:          loggerService,
+		builder:         subtitleBuilder,
+		validator:       subtitleValidator,
+		repository:      subtitleRepository,
+		videoRepository: videoRepository,
+		viewer:          videoViewService,
+		uploader:        subtitleUploader,
+		blobs:           blobStorageService,
+	}, nil
+}
+
+// List - will fetch the subtitle tracks of the video, they are available for anyone who may view the video.
+func (s *CRUDService) List(req dtointerface.ListSubtitleRequest) ([]*agg.Subtitle, error) {
+	// validation of input request
+	if err := s.validator.ValidateListRequestDTO(req); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// access check by owner, visibility and share
+	q := dto.NewVideoViewRequestDTO(req.GetVideoID(), req.GetUserID(), req.GetShareToken())
+	if _, err := s.viewer.View(q); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// fetching a subtitle list of the video
+	list, err := s.repository.FindList(s.ctx, req)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	return list, nil
+}
+
+// Get - will fetch a single subtitle track of the video, the access is the same as of the List.
+func (s *CRUDService) Get(req dtointerface.GetSubtitleRequest) (*agg.Subtitle, error) {
+	// validation of input request
+	if err := s.validator.ValidateGetRequestDTO(req); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// access check by owner, visibility and share
+	q := dto.NewVideoViewRequestDTO(req.GetVideoID(), req.GetUserID(), req.GetShareToken())
+	if _, err := s.viewer.View(q); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// fetching a subtitle by id and video
+	subtitle, err := s.repository.FindOneByID(s.ctx, req)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	return subtitle, nil
+}
+
+// Create - will upload a new subtitle track of the video for specified user, who must be an owner of the video.
+// Important: the input request's DTO will mutate per uploading.
+func (s *CRUDService) Create(req dtointerface.CreateSubtitleRequest) (subtitle *agg.Subtitle, err error) {
+	defer func() {
+		if err != nil {
+			if e := s.onUploadingFailed(req); e != nil {
+				s.logger.Log(e)
+			}
+		}
+	}()
+
+	// validation of raw uploading request
+	if err = s.validator.ValidateUploadRequestDTO(req); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// fetching a video by id and user, so the file of not owner is not stored
+	q := dto.NewVideoGetRequestDTO(req.GetVideoID(), "", vo.ID{}, req.GetUserID())
+	if _, err = s.videoRepository.FindOneByID(s.ctx, q); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// uploading the target file (the subrip one is converted to the webvtt)
+	if err = s.uploader.Upload(req); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// validation of the track fields which were parsed from the form by the uploader
+	if err = s.validator.ValidateCreateRequestDTO(req); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// building an aggregate
+	subtitle = s.builder.BuildAggFromCreateRequestDTO(req)
+
+	// validation of an aggregate
+	if err = s.validator.ValidateAggregate(subtitle); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// saving an aggregate into storage
+	subtitle, err = s.repository.Insert(s.ctx, subtitle)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// the video has a single default track
+	if subtitle.Default {
+		if err = s.repository.ResetDefault(s.ctx, subtitle); err != nil {
+			return nil, s.logger.LogPropagate(err)
+		}
+	}
+
+	return subtitle, nil
+}
+
+// onUploadingFailed - will drop the reference of the uploaded file.
+func (s *CRUDService) onUploadingFailed(req dtointerface.CreateSubtitleRequest) error {
+	if req.GetUploadedFilename() != "" {
+		if err := s.blobs.Release(req.GetUserID(), req.GetUploadedFilename()); err != nil {
+			return s.logger.LogPropagate(err)
+		}
+	}
+	return nil
+}
+
+// Update - will change the fields of subtitle track, only the owner of the video is able to do it.
+func (s *CRUDService) Update(req dtointerface.UpdateSubtitleRequest) (*agg.Subtitle, error) {
+	// validation of input request
+	if err := s.validator.ValidateUpdateRequestDTO(req); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// fetching a video by id and user
+	q := dto.NewVideoGetRequestDTO(req.GetVideoID(), "", vo.ID{}, req.GetUserID())
+	if _, err := s.videoRepository.FindOneByID(s.ctx, q); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// building an aggregate
+	subtitle, err := s.builder.BuildAggFromUpdateRequestDTO(req)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// validation of an aggregate
+	if err = s.validator.ValidateAggregate(subtitle); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// saving updated aggregate into storage
+	subtitle, err = s.repository.Update(s.ctx, subtitle)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// the video has a single default track
+	if subtitle.Default {
+		if err = s.repository.ResetDefault(s.ctx, subtitle); err != nil {
+			return nil, s.logger.LogPropagate(err)
+		}
+	}
+
+	return subtitle, nil
+}
+
+// Delete - will remove the subtitle track with its file, only the owner of the video is able to do it.
+func (s *CRUDService) Delete(req dtointerface.DeleteSubtitleRequest) error {
+	// validation of input request
+	if err := s.validator.ValidateDeleteRequestDTO(req); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	// fetching a video by id and user
+	q := dto.NewVideoGetRequestDTO(req.GetVideoID(), "", vo.ID{}, req.GetUserID())
+	if _, err := s.videoRepository.FindOneByID(s.ctx, q); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	// fetching a subtitle which will be deleted
+	subtitle, err := s.repository.FindOneByID(s.ctx, req)
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	// removing the file first (it's kept while other tracks refer to the same content)
+	if err = s.blobs.Release(subtitle.UserID, subtitle.Filename); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	// subtitle removing
+	if err = s.repository.Remove(s.ctx, subtitle); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,14 @@
+package subtitleinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+)
+
+type CRUD interface {
+	List(reqDTO dtointerface.ListSubtitleRequest) ([]*agg.Subtitle, error)
+	Get(reqDTO dtointerface.GetSubtitleRequest) (*agg.Subtitle, error)
+	Create(reqDTO dtointerface.CreateSubtitleRequest) (*agg.Subtitle, error)
+	Update(reqDTO dtointerface.UpdateSubtitleRequest) (*agg.Subtitle, error)
+	Delete(reqDTO dtointerface.DeleteSubtitleRequest) error
+}
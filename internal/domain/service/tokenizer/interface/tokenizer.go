@@ -2,11 +2,21 @@ package tokenizerinterface
 
 import (
 	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
 	"github.com/Borislavv/video-streaming/internal/domain/vo"
 )
 
 type Tokenizer interface {
-	New(user *agg.User) (token string, err error)
+	New(user *agg.User, session string) (token string, err error)
 	Verify(token string) (userID vo.ID, err error)
+	VerifyWithRoles(token string) (userID vo.ID, roles []string, err error)
+	GetSession(token string) (session string, err error)
+	// IsRevoked - checks the token was not blocked or logged out, the expired token is not revoked.
+	IsRevoked(token string) error
+	// Block - blocks the verified token until it's expired, the not verified or expired one is skipped.
 	Block(token string, reason string) error
+	// BlockSession - rejects all access tokens which were issued with the session.
+	BlockSession(session string, userID vo.ID, reason string) error
+	// Jwks - returns the public keys which verify the issued tokens.
+	Jwks() *dto.JwksResponseDTO
 }
@@ -0,0 +1,16 @@
+package transcoderinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+)
+
+type Transcoder interface {
+	// Probe - describes the given resource as a rendition (resolution, bitrate and codecs).
+	Probe(resource entity.Resource) (entity.Rendition, error)
+	// Transcode - makes a new rendition of the given resource by the profile.
+	Transcode(resource entity.Resource, profile vo.RenditionProfile) (entity.Rendition, error)
+	// Fragment - repacks the resource into the fragmented mp4 (which is playable through the MSE) and reports
+	// the progress from 0 to 1. Returns the resource which describes the produced file.
+	Fragment(resource entity.Resource, progress func(float64)) (entity.Resource, error)
+}
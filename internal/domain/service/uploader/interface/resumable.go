@@ -0,0 +1,25 @@
+package uploaderinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"sync"
+)
+
+// Resumable is an uploader which receives the file by chunks. The Upload method mutates the request DTO
+// by the completed upload, so the resource may be created as for any other uploader.
+type Resumable interface {
+	Uploader
+	// Create - registers a new upload of the declared length.
+	Create(req dtointerface.CreateUploadRequest) (*agg.Upload, error)
+	// Find - fetches the upload with the current offset.
+	Find(req dtointerface.GetUploadRequest) (*agg.Upload, error)
+	// Append - writes the chunk at the upload offset. The received part of the interrupted chunk is kept.
+	Append(req dtointerface.PatchUploadRequest) (*agg.Upload, error)
+	// Complete - links the completed upload with the created resource.
+	Complete(upload *agg.Upload, resource *agg.Resource) (*agg.Upload, error)
+	// Terminate - removes the upload and the received part of the file.
+	Terminate(req dtointerface.DeleteUploadRequest) error
+	// Run - starts removing of the expired uploads until the app. context is done.
+	Run(wg *sync.WaitGroup)
+}
@@ -8,3 +8,8 @@ type Uploader interface {
 	// Upload method will be store a file on a disk and calculate a new hashed name. Request DTO mutation!
 	Upload(dtointerface.UploadResourceRequest) (err error)
 }
+
+// Subtitle - is the uploader of subtitle files, it's registered apart from the resources one.
+type Subtitle interface {
+	Uploader
+}
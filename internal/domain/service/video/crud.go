@@ -6,11 +6,14 @@ import (
 	"github.com/Borislavv/video-streaming/internal/domain/builder/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/dto"
 	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	renditioninterface "github.com/Borislavv/video-streaming/internal/domain/service/rendition/interface"
 	resourceinterface "github.com/Borislavv/video-streaming/internal/domain/service/resource/interface"
 	validatorinterface "github.com/Borislavv/video-streaming/internal/domain/validator/interface"
+	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
 )
 
 type CRUDService struct {
@@ -19,7 +22,11 @@ type CRUDService struct {
 	builder         builderinterface.Video
 	validator       validatorinterface.Video
 	repository      repositoryinterface.Video
+	shareRepository repositoryinterface.Share
+	subtitles       repositoryinterface.Subtitle
 	resourceService resourceinterface.CRUD
+	renditions      renditioninterface.Producer
+	blobs           fileinterface.BlobStorage
 }
 
 func NewCRUDService(serviceContainer diinterface.ServiceContainer) (*CRUDService, error) {
@@ -48,18 +55,42 @@ func NewCRUDService(serviceContainer diinterface.ServiceContainer) (*CRUDService
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	shareRepository, err := serviceContainer.GetShareRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	subtitleRepository, err := serviceContainer.GetSubtitleRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	resourceCRUDService, err := serviceContainer.GetResourceCRUDService()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	renditionProducer, err := serviceContainer.GetRenditionProducerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	blobStorageService, err := serviceContainer.GetBlobStorageService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	return &CRUDService{
 		ctx:             ctx,
 		logger:          loggerService,
 		builder:         videoBuilder,
 		validator:       videoValidator,
 		repository:      videoRepository,
+		shareRepository: shareRepository,
+		subtitles:       subtitleRepository,
 		resourceService: resourceCRUDService,
+		renditions:      renditionProducer,
+		blobs:           blobStorageService,
 	}, nil
 }
 
@@ -116,9 +147,18 @@ func (s *CRUDService) Create(req dtointerface.CreateVideoRequest) (*agg.Video, e
 		return nil, s.logger.LogPropagate(err)
 	}
 
+	// producing the rendition ladder of the resource (the not ready one will be produced by the job on completion)
+	if videoAgg.Resource.IsReady() {
+		if videoAgg.Renditions, err = s.renditions.Produce(videoAgg.Resource); err != nil {
+			return nil, s.logger.LogPropagate(err)
+		}
+	}
+
 	// saving an aggregate into storage
+	renditions := videoAgg.Renditions
 	videoAgg, err = s.repository.Insert(s.ctx, videoAgg)
 	if err != nil {
+		_ = s.renditions.Remove(renditions)
 		return nil, s.logger.LogPropagate(err)
 	}
 
@@ -143,12 +183,28 @@ func (s *CRUDService) Update(req dtointerface.UpdateVideoRequest) (*agg.Video, e
 		return nil, s.logger.LogPropagate(err)
 	}
 
+	// the resource was changed, so the ladder must be produced again
+	var outdated []entity.Rendition
+	if !isProducedFrom(videoAgg.Renditions, videoAgg.Resource) {
+		outdated, videoAgg.Renditions = videoAgg.Renditions, nil
+		if videoAgg.Resource.IsReady() {
+			if videoAgg.Renditions, err = s.renditions.Produce(videoAgg.Resource); err != nil {
+				return nil, s.logger.LogPropagate(err)
+			}
+		}
+	}
+
 	// saving updated aggregate into storage
 	videoAgg, err = s.repository.Update(s.ctx, videoAgg)
 	if err != nil {
 		return nil, s.logger.LogPropagate(err)
 	}
 
+	// the outdated renditions are not referenced anymore
+	if err = s.renditions.Remove(outdated); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
 	return videoAgg, nil
 }
 
@@ -165,12 +221,27 @@ func (s *CRUDService) Delete(req dtointerface.DeleteVideoRequest) (err error) {
 		return s.logger.LogPropagate(err)
 	}
 
+	// the produced renditions files are not needed without the resource
+	if err = s.renditions.Remove(videoAgg.Renditions); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
 	// the resource must be removing first
 	q := dto.NewResourceDeleteRequestDTO(videoAgg.Resource.ID, req.GetUserID())
 	if err = s.resourceService.Delete(q); err != nil {
 		return s.logger.LogPropagate(err)
 	}
 
+	// the share links of removed video must not remain
+	if err = s.shareRepository.RemoveByVideoID(s.ctx, videoAgg.ID); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	// the subtitle tracks of removed video must not remain with their files
+	if err = s.removeSubtitles(videoAgg); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
 	// video removing
 	if err = s.repository.Remove(s.ctx, videoAgg); err != nil {
 		return s.logger.LogPropagate(err)
@@ -178,3 +249,28 @@ func (s *CRUDService) Delete(req dtointerface.DeleteVideoRequest) (err error) {
 
 	return nil
 }
+
+// removeSubtitles - drops the references of the subtitle files and removes the tracks of the video.
+func (s *CRUDService) removeSubtitles(video *agg.Video) error {
+	subtitles, err := s.subtitles.FindList(s.ctx, dto.NewSubtitleListRequestDTO(video.ID, video.UserID, ""))
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+	for _, subtitle := range subtitles {
+		if err = s.blobs.Release(subtitle.UserID, subtitle.Filename); err != nil {
+			return s.logger.LogPropagate(err)
+		}
+	}
+	return s.subtitles.RemoveByVideoID(s.ctx, video.ID)
+}
+
+// isProducedFrom - checks whether the renditions were produced from the given resource. The video without
+// renditions is considered as produced, it was uploaded while the ladder was not configured.
+func isProducedFrom(renditions []entity.Rendition, resource entity.Resource) bool {
+	for _, rendition := range renditions {
+		if rendition.Original {
+			return rendition.Resource.ID.Value == resource.ID.Value
+		}
+	}
+	return true
+}
@@ -0,0 +1,11 @@
+package videointerface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+)
+
+type Viewer interface {
+	// View - fetches the video for anyone who is allowed to watch it, not only for the owner.
+	View(reqDTO dtointerface.ViewVideoRequest) (*agg.Video, error)
+}
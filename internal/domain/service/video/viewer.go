@@ -0,0 +1,88 @@
+package video
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	accessorinterface "github.com/Borislavv/video-streaming/internal/domain/service/accessor/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+)
+
+const idField = "id"
+
+type ViewService struct {
+	ctx             context.Context
+	logger          loggerinterface.Logger
+	accessService   accessorinterface.Accessor
+	videoRepository repositoryinterface.Video
+	shareRepository repositoryinterface.Share
+}
+
+func NewViewService(serviceContainer diinterface.ServiceContainer) (*ViewService, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	accessService, err := serviceContainer.GetAccessService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	videoRepository, err := serviceContainer.GetVideoRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	shareRepository, err := serviceContainer.GetShareRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &ViewService{
+		ctx:             ctx,
+		logger:          loggerService,
+		accessService:   accessService,
+		videoRepository: videoRepository,
+		shareRepository: shareRepository,
+	}, nil
+}
+
+// View - will fetch a single video aggregate by ID regardless of its owner. The user may be omitted, the access
+// is granted by the video visibility or by the share token.
+func (s *ViewService) View(req dtointerface.ViewVideoRequest) (*agg.Video, error) {
+	// validation of input request
+	if req.GetID().Value.IsZero() {
+		return nil, s.logger.LogPropagate(errtype.NewFieldCannotBeEmptyError(idField))
+	}
+
+	// fetching a video by id only
+	video, err := s.videoRepository.FindOneByIDOfAnyOwner(s.ctx, req.GetID())
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// fetching a share by token, the unknown one is revoked and grants nothing
+	var share *agg.Share
+	if req.GetShareToken() != "" {
+		share, err = s.shareRepository.FindOneByToken(s.ctx, req.GetShareToken())
+		if err != nil && !errtype.IsEntityNotFoundError(err) {
+			return nil, s.logger.LogPropagate(err)
+		}
+	}
+
+	// access check by owner, visibility and share
+	if err = s.accessService.IsViewable(req.GetUserID(), video, share); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	return video, nil
+}
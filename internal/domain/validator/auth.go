@@ -9,6 +9,8 @@ import (
 	"net/http"
 )
 
+const refreshTokenField = "refreshToken"
+
 type AuthValidator struct {
 	logger                   loggerinterface.Logger
 	adminContactEmailAddress string
@@ -44,6 +46,15 @@ func (v *AuthValidator) ValidateAuthRequest(req dtointerface.AuthRequest) error
 	return nil
 }
 
+// ValidateRefreshRequest is method which will check the refresh request DTO on valid.
+func (v *AuthValidator) ValidateRefreshRequest(req dtointerface.RefreshRequest) error {
+	if req.GetRefreshToken() == "" {
+		return errtype.NewFieldCannotBeEmptyError(refreshTokenField)
+	}
+
+	return nil
+}
+
 // ValidateTokennessRequest is method which will check that access token header exists.
 func (v *AuthValidator) ValidateTokennessRequest(r *http.Request) error {
 	if token := r.Header.Get(enum.AccessTokenHeaderKey); token != "" {
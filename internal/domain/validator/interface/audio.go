@@ -0,0 +1,15 @@
+package validatorinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+)
+
+type Audio interface {
+	ValidateGetRequestDTO(req dtointerface.GetAudioRequest) error
+	ValidateListRequestDTO(req dtointerface.ListAudioRequest) error
+	ValidateCreateRequestDTO(req dtointerface.CreateAudioRequest) error
+	ValidateUpdateRequestDTO(req dtointerface.UpdateAudioRequest) error
+	ValidateDeleteRequestDTO(req dtointerface.DeleteAudioRequest) error
+	ValidateAggregate(agg *agg.Audio) error
+}
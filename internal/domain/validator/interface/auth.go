@@ -7,5 +7,6 @@ import (
 
 type Auth interface {
 	ValidateAuthRequest(reqDTO dtointerface.AuthRequest) error
+	ValidateRefreshRequest(reqDTO dtointerface.RefreshRequest) error
 	ValidateTokennessRequest(r *http.Request) error
 }
@@ -0,0 +1,12 @@
+package validatorinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+)
+
+type Moderation interface {
+	ValidateVideoListRequestDTO(req dtointerface.ModerateVideoListRequest) error
+	ValidateResourceListRequestDTO(req dtointerface.ModerateResourceListRequest) error
+	ValidateByIdRequestDTO(req dtointerface.ModerateByIdRequest) error
+	ValidateUserRolesRequestDTO(req dtointerface.ModerateUserRolesRequest) error
+}
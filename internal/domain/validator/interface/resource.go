@@ -11,4 +11,8 @@ type Resource interface {
 	ValidateEntity(entity entity.Resource) error
 	ValidateAggregate(agg *agg.Resource) error
 	ValidateDeleteRequestDTO(req dtointerface.DeleteResourceRequest) error
+	ValidateCreateUploadRequestDTO(req dtointerface.CreateUploadRequest) error
+	ValidateGetUploadRequestDTO(req dtointerface.GetUploadRequest) error
+	ValidatePatchUploadRequestDTO(req dtointerface.PatchUploadRequest) error
+	ValidateDeleteUploadRequestDTO(req dtointerface.DeleteUploadRequest) error
 }
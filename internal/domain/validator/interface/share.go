@@ -0,0 +1,13 @@
+package validatorinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+)
+
+type Share interface {
+	ValidateCreateRequestDTO(req dtointerface.CreateShareRequest) error
+	ValidateListRequestDTO(req dtointerface.ListShareRequest) error
+	ValidateDeleteRequestDTO(req dtointerface.DeleteShareRequest) error
+	ValidateAggregate(agg *agg.Share) error
+}
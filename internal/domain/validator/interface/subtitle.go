@@ -0,0 +1,16 @@
+package validatorinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+)
+
+type Subtitle interface {
+	ValidateUploadRequestDTO(req dtointerface.CreateSubtitleRequest) error
+	ValidateCreateRequestDTO(req dtointerface.CreateSubtitleRequest) error
+	ValidateUpdateRequestDTO(req dtointerface.UpdateSubtitleRequest) error
+	ValidateListRequestDTO(req dtointerface.ListSubtitleRequest) error
+	ValidateGetRequestDTO(req dtointerface.GetSubtitleRequest) error
+	ValidateDeleteRequestDTO(req dtointerface.DeleteSubtitleRequest) error
+	ValidateAggregate(agg *agg.Subtitle) error
+}
@@ -0,0 +1,86 @@
+package validator
+
+import (
+	"fmt"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"strings"
+)
+
+const (
+	rolesField = "roles"
+	pageField  = "page"
+	limitField = "limit"
+)
+
+type ModerationValidator struct {
+	logger loggerinterface.Logger
+}
+
+func NewModerationValidator(serviceContainer diinterface.ServiceContainer) (*ModerationValidator, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ModerationValidator{logger: loggerService}, nil
+}
+
+// ValidateVideoListRequestDTO - the user is optional here, the videos of all users are listed without it.
+func (v *ModerationValidator) ValidateVideoListRequestDTO(req dtointerface.ModerateVideoListRequest) error {
+	return validateVideoListFilters(req)
+}
+
+// ValidateResourceListRequestDTO - the user is optional here, the resources of all users are listed without it.
+func (v *ModerationValidator) ValidateResourceListRequestDTO(req dtointerface.ModerateResourceListRequest) error {
+	return validatePagination(req)
+}
+
+func (v *ModerationValidator) ValidateByIdRequestDTO(req dtointerface.ModerateByIdRequest) error {
+	if req.GetID().Value.IsZero() {
+		return errtype.NewFieldCannotBeEmptyError(idField)
+	}
+	return nil
+}
+
+func (v *ModerationValidator) ValidateUserRolesRequestDTO(req dtointerface.ModerateUserRolesRequest) error {
+	if req.GetID().Value.IsZero() {
+		return errtype.NewFieldCannotBeEmptyError(idField)
+	}
+	if len(req.GetRoles()) == 0 {
+		return errtype.NewFieldCannotBeEmptyError(rolesField)
+	}
+	for _, role := range req.GetRoles() {
+		if !isRole(role) {
+			return errtype.NewFieldValueIsInvalidError(
+				rolesField, fmt.Sprintf("unknown role '%v', known roles are: %v", role, strings.Join(roles(), ", ")),
+			)
+		}
+	}
+	return nil
+}
+
+func validatePagination(req dtointerface.PaginatedRequest) error {
+	if req.GetPage() < 1 {
+		return errtype.NewFieldValueIsInvalidError(pageField, "must be positive")
+	}
+	if req.GetLimit() < 1 {
+		return errtype.NewFieldValueIsInvalidError(limitField, "must be positive")
+	}
+	return nil
+}
+
+func isRole(role string) bool {
+	switch role {
+	case enum.UserRole, enum.ModeratorRole, enum.AdminRole:
+		return true
+	}
+	return false
+}
+
+func roles() []string {
+	return []string{enum.UserRole, enum.ModeratorRole, enum.AdminRole}
+}
@@ -2,15 +2,18 @@ package validator
 
 import (
 	"context"
-	"fmt"
 	"github.com/Borislavv/video-streaming/internal/domain/agg"
 	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
 	"github.com/Borislavv/video-streaming/internal/domain/errtype"
 	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
 	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
 )
 
+// filenameMetadataField - is the key of the original filename in the upload metadata
+const filenameMetadataField = "filename"
+
 type ResourceValidator struct {
 	ctx         context.Context
 	repository  repositoryinterface.Resource
@@ -53,9 +56,7 @@ func (v *ResourceValidator) ValidateUploadRequestDTO(req dtointerface.UploadReso
 		return errtype.NewInvalidUploadedFileError("request form file is empty")
 	}
 	if req.GetRequest().ContentLength > v.maxFilesize {
-		return errtype.NewInvalidUploadedFileError(
-			fmt.Sprintf("request form file is largest than threshold value %d", v.maxFilesize),
-		)
+		return errtype.NewFilesizeThresholdExceededError(v.maxFilesize)
 	}
 	return nil
 }
@@ -99,3 +100,46 @@ func (v *ResourceValidator) ValidateGetRequestDTO(req dtointerface.GetResourceRe
 func (v *ResourceValidator) ValidateDeleteRequestDTO(req dtointerface.DeleteResourceRequest) error {
 	return v.ValidateGetRequestDTO(req)
 }
+
+func (v *ResourceValidator) ValidateCreateUploadRequestDTO(req dtointerface.CreateUploadRequest) error {
+	if req.GetUserID().Value.IsZero() {
+		return errtype.NewFieldCannotBeEmptyError(userIDField)
+	}
+	if req.GetName() == "" {
+		return errtype.NewFieldCannotBeEmptyError(filenameMetadataField)
+	}
+	if req.GetLength() <= 0 {
+		return errtype.NewInvalidUploadedFileError("upload length must be greater than zero")
+	}
+	if req.GetLength() > v.maxFilesize {
+		return errtype.NewUploadLengthExceededError(req.GetLength(), v.maxFilesize)
+	}
+	return nil
+}
+
+func (v *ResourceValidator) ValidateGetUploadRequestDTO(req dtointerface.GetUploadRequest) error {
+	if req.GetID().Value.IsZero() {
+		return errtype.NewFieldCannotBeEmptyError(idField)
+	}
+	if req.GetUserID().Value.IsZero() {
+		return errtype.NewFieldCannotBeEmptyError(userIDField)
+	}
+	return nil
+}
+
+func (v *ResourceValidator) ValidatePatchUploadRequestDTO(req dtointerface.PatchUploadRequest) error {
+	if err := v.ValidateGetUploadRequestDTO(req); err != nil {
+		return err
+	}
+	if req.GetContentType() != enum.UploadContentType {
+		return errtype.NewUnsupportedUploadContentTypeError(req.GetContentType(), enum.UploadContentType)
+	}
+	if req.GetOffset() < 0 {
+		return errtype.NewUploadOffsetMismatchError(0, req.GetOffset())
+	}
+	return nil
+}
+
+func (v *ResourceValidator) ValidateDeleteUploadRequestDTO(req dtointerface.DeleteUploadRequest) error {
+	return v.ValidateGetUploadRequestDTO(req)
+}
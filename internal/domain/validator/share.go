@@ -0,0 +1,68 @@
+package validator
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"time"
+)
+
+const (
+	videoIDField   = "videoID"
+	expiresAtField = "expiresAt"
+)
+
+type ShareValidator struct {
+	logger loggerinterface.Logger
+}
+
+func NewShareValidator(serviceContainer diinterface.ServiceContainer) (*ShareValidator, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ShareValidator{logger: loggerService}, nil
+}
+
+func (v *ShareValidator) ValidateCreateRequestDTO(req dtointerface.CreateShareRequest) error {
+	if err := v.ValidateListRequestDTO(req); err != nil {
+		return err
+	}
+	if req.GetExpiresAt() != nil && !req.GetExpiresAt().After(time.Now()) {
+		return errtype.NewFieldValueIsInvalidError(expiresAtField, "the share cannot expire in the past")
+	}
+	return nil
+}
+
+func (v *ShareValidator) ValidateListRequestDTO(req dtointerface.ListShareRequest) error {
+	if req.GetVideoID().Value.IsZero() {
+		return errtype.NewFieldCannotBeEmptyError(videoIDField)
+	}
+	if req.GetUserID().Value.IsZero() {
+		return errtype.NewFieldCannotBeEmptyError(userIDField)
+	}
+	return nil
+}
+
+func (v *ShareValidator) ValidateDeleteRequestDTO(req dtointerface.DeleteShareRequest) error {
+	if req.GetID().Value.IsZero() {
+		return errtype.NewFieldCannotBeEmptyError(idField)
+	}
+	return v.ValidateListRequestDTO(req)
+}
+
+func (v *ShareValidator) ValidateAggregate(agg *agg.Share) error {
+	if agg.VideoID.Value.IsZero() {
+		return errtype.NewInternalValidationError("'videoID' cannot be empty")
+	}
+	if agg.UserID.Value.IsZero() {
+		return errtype.NewInternalValidationError("'userID' cannot be empty")
+	}
+	if agg.Token == "" {
+		return errtype.NewInternalValidationError("'token' cannot be empty")
+	}
+	return nil
+}
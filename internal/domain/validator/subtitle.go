@@ -0,0 +1,138 @@
+package validator
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"regexp"
+)
+
+const (
+	languageField = "language"
+	kindField     = "kind"
+)
+
+// languageTag - is a simplified BCP 47 language tag: the primary language with optional subtags, e.g. 'en', 'pt-BR'
+var languageTag = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{1,8})*$`)
+
+type SubtitleValidator struct {
+	logger loggerinterface.Logger
+}
+
+func NewSubtitleValidator(serviceContainer diinterface.ServiceContainer) (*SubtitleValidator, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	return &SubtitleValidator{logger: loggerService}, nil
+}
+
+// ValidateUploadRequestDTO - validates the request before the file is uploaded, the fields of track are not parsed yet.
+func (v *SubtitleValidator) ValidateUploadRequestDTO(req dtointerface.CreateSubtitleRequest) error {
+	if req.GetVideoID().Value.IsZero() {
+		return errtype.NewFieldCannotBeEmptyError(videoIDField)
+	}
+	if req.GetUserID().Value.IsZero() {
+		return errtype.NewFieldCannotBeEmptyError(userIDField)
+	}
+	return nil
+}
+
+func (v *SubtitleValidator) ValidateCreateRequestDTO(req dtointerface.CreateSubtitleRequest) error {
+	if err := v.ValidateUploadRequestDTO(req); err != nil {
+		return err
+	}
+	if req.GetLanguage() == "" {
+		return errtype.NewFieldCannotBeEmptyError(languageField)
+	}
+	if !languageTag.MatchString(req.GetLanguage()) {
+		return errtype.NewFieldValueIsInvalidError(languageField, languageReason)
+	}
+	if req.GetKind() != "" && !isSubtitleKind(req.GetKind()) {
+		return errtype.NewFieldValueIsInvalidError(kindField, subtitleKindReason)
+	}
+	return nil
+}
+
+func (v *SubtitleValidator) ValidateUpdateRequestDTO(req dtointerface.UpdateSubtitleRequest) error {
+	if req.GetID().Value.IsZero() {
+		return errtype.NewFieldCannotBeEmptyError(idField)
+	}
+	if req.GetVideoID().Value.IsZero() {
+		return errtype.NewFieldCannotBeEmptyError(videoIDField)
+	}
+	if req.GetUserID().Value.IsZero() {
+		return errtype.NewFieldCannotBeEmptyError(userIDField)
+	}
+	if req.GetLanguage() != "" && !languageTag.MatchString(req.GetLanguage()) {
+		return errtype.NewFieldValueIsInvalidError(languageField, languageReason)
+	}
+	if req.GetKind() != "" && !isSubtitleKind(req.GetKind()) {
+		return errtype.NewFieldValueIsInvalidError(kindField, subtitleKindReason)
+	}
+	return nil
+}
+
+func (v *SubtitleValidator) ValidateListRequestDTO(req dtointerface.ListSubtitleRequest) error {
+	if req.GetVideoID().Value.IsZero() {
+		return errtype.NewFieldCannotBeEmptyError(videoIDField)
+	}
+	return nil
+}
+
+func (v *SubtitleValidator) ValidateGetRequestDTO(req dtointerface.GetSubtitleRequest) error {
+	if req.GetID().Value.IsZero() {
+		return errtype.NewFieldCannotBeEmptyError(idField)
+	}
+	return v.ValidateListRequestDTO(req)
+}
+
+func (v *SubtitleValidator) ValidateDeleteRequestDTO(req dtointerface.DeleteSubtitleRequest) error {
+	if req.GetID().Value.IsZero() {
+		return errtype.NewFieldCannotBeEmptyError(idField)
+	}
+	if req.GetVideoID().Value.IsZero() {
+		return errtype.NewFieldCannotBeEmptyError(videoIDField)
+	}
+	if req.GetUserID().Value.IsZero() {
+		return errtype.NewFieldCannotBeEmptyError(userIDField)
+	}
+	return nil
+}
+
+func (v *SubtitleValidator) ValidateAggregate(agg *agg.Subtitle) error {
+	if agg.VideoID.Value.IsZero() {
+		return errtype.NewInternalValidationError("'videoID' cannot be empty")
+	}
+	if agg.UserID.Value.IsZero() {
+		return errtype.NewInternalValidationError("'userID' cannot be empty")
+	}
+	if !languageTag.MatchString(agg.Language) {
+		return errtype.NewInternalValidationError("'language' is not a language tag")
+	}
+	if !isSubtitleKind(agg.GetKind()) {
+		return errtype.NewInternalValidationError("'kind' has unknown value")
+	}
+	if agg.Filename == "" {
+		return errtype.NewInternalValidationError("'filename' cannot be empty")
+	}
+	return nil
+}
+
+const (
+	languageReason     = "must be a language tag, e.g. 'en' or 'pt-BR'"
+	subtitleKindReason = "must be one of '" + enum.SubtitlesKind + "', '" +
+		enum.CaptionsKind + "', '" + enum.DescriptionsKind + "'"
+)
+
+func isSubtitleKind(kind string) bool {
+	switch kind {
+	case enum.SubtitlesKind, enum.CaptionsKind, enum.DescriptionsKind:
+		return true
+	}
+	return false
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/Borislavv/video-streaming/internal/domain/agg"
 	"github.com/Borislavv/video-streaming/internal/domain/dto"
 	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
 	"github.com/Borislavv/video-streaming/internal/domain/errtype"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
@@ -19,6 +20,7 @@ const (
 	userIDField     = "userID"
 	nameField       = "name"
 	resourceIDField = "resourceID"
+	visibilityField = "visibility"
 )
 
 type VideoValidator struct {
@@ -82,6 +84,14 @@ func (v *VideoValidator) ValidateGetRequestDTO(req dtointerface.GetVideoRequest)
 }
 
 func (v *VideoValidator) ValidateListRequestDTO(req dtointerface.ListVideoRequest) error {
+	if req.GetUserID().Value.IsZero() {
+		return errtype.NewFieldCannotBeEmptyError(userIDField)
+	}
+	return validateVideoListFilters(req)
+}
+
+// validateVideoListFilters - checks the search filters of the video list, the user is not checked here.
+func validateVideoListFilters(req dtointerface.ListVideoRequest) error {
 	if req.GetName() != "" && len(req.GetName()) <= 3 {
 		return errtype.NewFieldLengthMustBeMoreOrLessError(nameField, true, 3)
 	}
@@ -101,6 +111,9 @@ func (v *VideoValidator) ValidateCreateRequestDTO(req dtointerface.CreateVideoRe
 	if req.GetResourceID().Value.IsZero() {
 		return errtype.NewFieldCannotBeEmptyError(resourceIDField)
 	}
+	if req.GetVisibility() != "" && !isVisibility(req.GetVisibility()) {
+		return errtype.NewFieldValueIsInvalidError(visibilityField, visibilityReason)
+	}
 	return nil
 }
 
@@ -108,6 +121,9 @@ func (v *VideoValidator) ValidateUpdateRequestDTO(req dtointerface.UpdateVideoRe
 	if err := v.ValidateGetRequestDTO(req); err != nil {
 		return err
 	}
+	if req.GetVisibility() != "" && !isVisibility(req.GetVisibility()) {
+		return errtype.NewFieldValueIsInvalidError(visibilityField, visibilityReason)
+	}
 	return nil
 }
 
@@ -126,6 +142,9 @@ func (v *VideoValidator) ValidateAggregate(agg *agg.Video) error {
 	if agg.UserID.Value.IsZero() {
 		return errtype.NewInternalValidationError("'userID' cannot be empty")
 	}
+	if !isVisibility(agg.GetVisibility()) {
+		return errtype.NewInternalValidationError("'visibility' has unknown value")
+	}
 
 	// resource fields validation
 	if err := v.resourceValidator.ValidateEntity(agg.Resource); err != nil {
@@ -168,3 +187,14 @@ func (v *VideoValidator) ValidateAggregate(agg *agg.Video) error {
 
 	return nil
 }
+
+const visibilityReason = "must be one of '" + enum.PrivateVisibility + "', '" +
+	enum.UnlistedVisibility + "', '" + enum.PublicVisibility + "'"
+
+func isVisibility(visibility string) bool {
+	switch visibility {
+	case enum.PrivateVisibility, enum.UnlistedVisibility, enum.PublicVisibility:
+		return true
+	}
+	return false
+}
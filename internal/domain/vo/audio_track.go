@@ -0,0 +1,32 @@
+package vo
+
+// AudioTrack - is an audio stream of the video which may be selected for streaming.
+type AudioTrack struct {
+	Index    int    `json:"index"`              // number among the audio streams of the resource, starting from zero
+	Language string `json:"language,omitempty"` // ISO 639-2 code, e.g. "eng"
+	Codec    string `json:"codec"`              // RFC 6381 string, e.g. "mp4a.40.2"
+	Channels int    `json:"channels,omitempty"`
+	Selected bool   `json:"selected,omitempty"` // whether the track is streamed
+}
+
+// AudioHint - is an audio track which was preferred by the client. The index takes precedence over the language,
+// a nil index and an empty language mean the default (first) track.
+type AudioHint struct {
+	Index    *int
+	Language string // BCP 47 tag or ISO 639 code, e.g. "de", "deu" or "ger"
+}
+
+// IsEmpty - returns true if the client has no preference.
+func (h AudioHint) IsEmpty() bool {
+	return h.Index == nil && h.Language == ""
+}
+
+// SelectedAudioTrack - returns the number of the selected track, the first one is streamed if no track is selected.
+func SelectedAudioTrack(tracks []AudioTrack) int {
+	for i, track := range tracks {
+		if track.Selected {
+			return i
+		}
+	}
+	return 0
+}
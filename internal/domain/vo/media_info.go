@@ -0,0 +1,66 @@
+package vo
+
+// types of the media streams
+const (
+	VideoStreamType    = "video"
+	AudioStreamType    = "audio"
+	SubtitleStreamType = "subtitle"
+)
+
+// MediaInfo - is a description of the media file which is probed once the file was processed.
+type MediaInfo struct {
+	Duration         float64       `json:"duration" bson:"duration"`   // seconds
+	Container        string        `json:"container" bson:"container"` // format names, e.g. "mov,mp4,m4a,3gp,3g2,mj2"
+	Bitrate          int64         `json:"bitrate" bson:"bitrate"`     // overall bits per second
+	Streams          []MediaStream `json:"streams" bson:"streams"`
+	KeyframeInterval float64       `json:"keyframeInterval" bson:"keyframeInterval"` // average seconds between keyframes of the first video stream
+	Rotation         int           `json:"rotation" bson:"rotation"`                 // display rotation of the first video stream in degrees
+}
+
+// MediaStream - is a single stream of the media file, the video and audio fields are filled by the stream type.
+type MediaStream struct {
+	Index       int     `json:"index" bson:"index"`
+	Type        string  `json:"type" bson:"type"`
+	Codec       string  `json:"codec" bson:"codec"`                                 // codec name, e.g. "h264"
+	CodecTag    string  `json:"codecTag" bson:"codecTag"`                           // codec tag, e.g. "avc1"
+	Profile     string  `json:"profile,omitempty" bson:"profile,omitempty"`         // e.g. "High"
+	Level       int     `json:"level,omitempty" bson:"level,omitempty"`             // e.g. 40 (4.0)
+	Bitrate     int64   `json:"bitrate,omitempty" bson:"bitrate,omitempty"`         // bits per second
+	Width       int     `json:"width,omitempty" bson:"width,omitempty"`             // video only
+	Height      int     `json:"height,omitempty" bson:"height,omitempty"`           // video only
+	FrameRate   float64 `json:"frameRate,omitempty" bson:"frameRate,omitempty"`     // video only
+	PixelFormat string  `json:"pixelFormat,omitempty" bson:"pixelFormat,omitempty"` // video only, e.g. "yuv420p10le"
+	Channels    int     `json:"channels,omitempty" bson:"channels,omitempty"`       // audio only
+	SampleRate  int     `json:"sampleRate,omitempty" bson:"sampleRate,omitempty"`   // audio only
+	Language    string  `json:"language,omitempty" bson:"language,omitempty"`       // ISO 639-2 code, e.g. "eng"
+}
+
+// FirstVideo - returns the first video stream or nil if the media has no video.
+func (m *MediaInfo) FirstVideo() *MediaStream {
+	return m.first(VideoStreamType)
+}
+
+// FirstAudio - returns the first audio stream or nil if the media has no audio.
+func (m *MediaInfo) FirstAudio() *MediaStream {
+	return m.first(AudioStreamType)
+}
+
+// StreamsOf - returns the streams of the given type in the file order.
+func (m *MediaInfo) StreamsOf(streamType string) []MediaStream {
+	var streams []MediaStream
+	for _, stream := range m.Streams {
+		if stream.Type == streamType {
+			streams = append(streams, stream)
+		}
+	}
+	return streams
+}
+
+func (m *MediaInfo) first(streamType string) *MediaStream {
+	for i := range m.Streams {
+		if m.Streams[i].Type == streamType {
+			return &m.Streams[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package vo
+
+import (
+	"strings"
+)
+
+// MediaType - is a MIME type of the media container with the RFC 6381 codecs of its streams,
+// which is passed to the MediaSource as is.
+type MediaType struct {
+	MimeType   string // container MIME type, e.g. "video/mp4"
+	AudioCodec string // e.g. "mp4a.40.2"
+	VideoCodec string // e.g. "avc1.64001F"
+}
+
+// Codecs - returns the codecs separated by comma, the video one goes first.
+func (t MediaType) Codecs() string {
+	codecs := make([]string, 0, 2)
+	if t.VideoCodec != "" {
+		codecs = append(codecs, t.VideoCodec)
+	}
+	if t.AudioCodec != "" {
+		codecs = append(codecs, t.AudioCodec)
+	}
+	return strings.Join(codecs, ", ")
+}
+
+// String - returns the full type, e.g. `video/mp4; codecs="avc1.64001F, mp4a.40.2"`.
+func (t MediaType) String() string {
+	if codecs := t.Codecs(); codecs != "" {
+		return t.MimeType + `; codecs="` + codecs + `"`
+	}
+	return t.MimeType
+}
+
+// AudioOnly - returns the type of the audio track only, e.g. "audio/mp4" with the audio codec.
+func (t MediaType) AudioOnly() MediaType {
+	mimeType := t.MimeType
+	if container, found := strings.CutPrefix(mimeType, "video/"); found {
+		mimeType = "audio/" + container
+	}
+	return MediaType{MimeType: mimeType, AudioCodec: t.AudioCodec}
+}
@@ -0,0 +1,71 @@
+package vo
+
+import (
+	"math"
+)
+
+// Preview - is a poster image and a storyboard sprite sheet of the video resource, the files are stored
+// near the resource file and owned by the resource.
+type Preview struct {
+	Poster     string     `json:"poster" bson:"poster"` // filename of the poster image
+	Storyboard Storyboard `json:"storyboard" bson:"storyboard"`
+}
+
+// Storyboard - is a sprite sheet of the frames which are taken by the interval, the tiles are placed
+// in rows from left to right.
+type Storyboard struct {
+	Filename   string  `json:"filename" bson:"filename"`     // filename of the sprite sheet image
+	Interval   float64 `json:"interval" bson:"interval"`     // seconds between tiles
+	Tiles      int     `json:"tiles" bson:"tiles"`           // number of tiles
+	Columns    int     `json:"columns" bson:"columns"`       // number of tiles per row
+	TileWidth  int     `json:"tileWidth" bson:"tileWidth"`   // pixels
+	TileHeight int     `json:"tileHeight" bson:"tileHeight"` // pixels
+}
+
+// Filenames - returns the names of all files of the preview.
+func (p *Preview) Filenames() []string {
+	filenames := make([]string, 0, 2)
+	if p.Poster != "" {
+		filenames = append(filenames, p.Poster)
+	}
+	if p.Storyboard.Filename != "" {
+		filenames = append(filenames, p.Storyboard.Filename)
+	}
+	return filenames
+}
+
+// NewStoryboard - makes the grid of the storyboard for the media of given duration. The interval is increased
+// if the media is too long to fit the tiles number into the max.
+func NewStoryboard(duration, interval float64, maxTiles, columns, tileWidth, tileHeight int) Storyboard {
+	tiles := int(math.Ceil(duration / interval))
+	if tiles > maxTiles {
+		tiles = maxTiles
+		interval = duration / float64(maxTiles)
+	}
+	if tiles < 1 {
+		tiles = 1
+	}
+	if columns > tiles {
+		columns = tiles
+	}
+	return Storyboard{
+		Interval:   interval,
+		Tiles:      tiles,
+		Columns:    columns,
+		TileWidth:  tileWidth,
+		TileHeight: tileHeight,
+	}
+}
+
+// Rows - returns the number of rows of the sprite sheet.
+func (s Storyboard) Rows() int {
+	if s.Columns == 0 {
+		return 0
+	}
+	return (s.Tiles + s.Columns - 1) / s.Columns
+}
+
+// Tile - returns the position of the tile by its number on the sprite sheet.
+func (s Storyboard) Tile(number int) (x, y int) {
+	return number % s.Columns * s.TileWidth, number / s.Columns * s.TileHeight
+}
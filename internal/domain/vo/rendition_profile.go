@@ -0,0 +1,7 @@
+package vo
+
+// RenditionProfile - is a step of the rendition ladder.
+type RenditionProfile struct {
+	Height  int   // target frame height, the width is scaled proportionally
+	Bitrate int64 // target video bitrate in bits per second
+}
@@ -0,0 +1,11 @@
+package vo
+
+// TextTrack - is an announcement of the text track of the video, it's everything the player needs for add the track.
+type TextTrack struct {
+	ID       string `json:"id"`
+	Language string `json:"language"`
+	Label    string `json:"label"`
+	Kind     string `json:"kind"`
+	Default  bool   `json:"default,omitempty"`
+	URL      string `json:"url"` // WebVTT content of the track
+}
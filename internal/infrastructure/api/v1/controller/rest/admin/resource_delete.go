@@ -0,0 +1,71 @@
+package admin
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	moderatorinterface "github.com/Borislavv/video-streaming/internal/domain/service/moderator/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const ResourceDeletePath = "/admin/resource/{id}"
+
+type ResourceDeleteController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Moderation
+	service   moderatorinterface.Moderator
+	responder responseinterface.Responder
+}
+
+func NewResourceDeleteController(serviceContainer diinterface.ServiceContainer) (*ResourceDeleteController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	moderationBuilder, err := serviceContainer.GetModerationBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	moderationService, err := serviceContainer.GetModerationService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &ResourceDeleteController{
+		logger:    loggerService,
+		builder:   moderationBuilder,
+		service:   moderationService,
+		responder: responseService,
+	}, nil
+}
+
+func (c *ResourceDeleteController) Delete(w http.ResponseWriter, r *http.Request) {
+	reqDTO, err := c.builder.BuildByIdRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	if err = c.service.DeleteResource(reqDTO); err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *ResourceDeleteController) AddRoute(router *mux.Router) {
+	router.
+		Path(ResourceDeletePath).
+		HandlerFunc(c.Delete).
+		Methods(http.MethodDelete)
+}
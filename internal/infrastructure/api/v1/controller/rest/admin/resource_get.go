@@ -0,0 +1,72 @@
+package admin
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	moderatorinterface "github.com/Borislavv/video-streaming/internal/domain/service/moderator/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const ResourceGetPath = "/admin/resource/{id}"
+
+type ResourceGetController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Moderation
+	service   moderatorinterface.Moderator
+	responder responseinterface.Responder
+}
+
+func NewResourceGetController(serviceContainer diinterface.ServiceContainer) (*ResourceGetController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	moderationBuilder, err := serviceContainer.GetModerationBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	moderationService, err := serviceContainer.GetModerationService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &ResourceGetController{
+		logger:    loggerService,
+		builder:   moderationBuilder,
+		service:   moderationService,
+		responder: responseService,
+	}, nil
+}
+
+func (c *ResourceGetController) Get(w http.ResponseWriter, r *http.Request) {
+	reqDTO, err := c.builder.BuildByIdRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	aggregate, err := c.service.GetResource(reqDTO)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	c.responder.Respond(w, aggregate)
+}
+
+func (c *ResourceGetController) AddRoute(router *mux.Router) {
+	router.
+		Path(ResourceGetPath).
+		HandlerFunc(c.Get).
+		Methods(http.MethodGet)
+}
@@ -0,0 +1,81 @@
+package admin
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	moderatorinterface "github.com/Borislavv/video-streaming/internal/domain/service/moderator/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const ResourceListPath = "/admin/resource"
+
+type ResourceListController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Moderation
+	service   moderatorinterface.Moderator
+	responder responseinterface.Responder
+}
+
+func NewResourceListController(serviceContainer diinterface.ServiceContainer) (*ResourceListController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	moderationBuilder, err := serviceContainer.GetModerationBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	moderationService, err := serviceContainer.GetModerationService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &ResourceListController{
+		logger:    loggerService,
+		builder:   moderationBuilder,
+		service:   moderationService,
+		responder: responseService,
+	}, nil
+}
+
+func (c *ResourceListController) List(w http.ResponseWriter, r *http.Request) {
+	reqDTO, e := c.builder.BuildResourceListRequestDTOFromRequest(r)
+	if e != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(e))
+		return
+	}
+
+	aggList, total, err := c.service.ListResources(reqDTO)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	c.responder.Respond(w,
+		map[string]interface{}{
+			"list": aggList,
+			"pagination": map[string]interface{}{
+				"page":  reqDTO.Page,
+				"limit": reqDTO.Limit,
+				"total": total,
+			},
+		},
+	)
+}
+
+func (c *ResourceListController) AddRoute(router *mux.Router) {
+	router.
+		Path(ResourceListPath).
+		HandlerFunc(c.List).
+		Methods(http.MethodGet)
+}
@@ -0,0 +1,85 @@
+package admin
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	moderatorinterface "github.com/Borislavv/video-streaming/internal/domain/service/moderator/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const UserRolesPath = "/admin/user/{id}/roles"
+
+type UserRolesController struct {
+	logger      loggerinterface.Logger
+	builder     builderinterface.Moderation
+	userBuilder builderinterface.User
+	service     moderatorinterface.Moderator
+	responder   responseinterface.Responder
+}
+
+func NewUserRolesController(serviceContainer diinterface.ServiceContainer) (*UserRolesController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	moderationBuilder, err := serviceContainer.GetModerationBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	userBuilder, err := serviceContainer.GetUserBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	moderationService, err := serviceContainer.GetModerationService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &UserRolesController{
+		logger:      loggerService,
+		builder:     moderationBuilder,
+		userBuilder: userBuilder,
+		service:     moderationService,
+		responder:   responseService,
+	}, nil
+}
+
+func (c *UserRolesController) Update(w http.ResponseWriter, r *http.Request) {
+	reqDTO, err := c.builder.BuildUserRolesRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	userAgg, err := c.service.UpdateUserRoles(reqDTO)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	userRespDTO, err := c.userBuilder.BuildResponseDTO(userAgg)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	c.responder.Respond(w, userRespDTO)
+}
+
+func (c *UserRolesController) AddRoute(router *mux.Router) {
+	router.
+		Path(UserRolesPath).
+		HandlerFunc(c.Update).
+		Methods(http.MethodPut)
+}
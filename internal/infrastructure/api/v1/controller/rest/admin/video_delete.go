@@ -0,0 +1,71 @@
+package admin
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	moderatorinterface "github.com/Borislavv/video-streaming/internal/domain/service/moderator/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const VideoDeletePath = "/admin/video/{id}"
+
+type VideoDeleteController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Moderation
+	service   moderatorinterface.Moderator
+	responder responseinterface.Responder
+}
+
+func NewVideoDeleteController(serviceContainer diinterface.ServiceContainer) (*VideoDeleteController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	moderationBuilder, err := serviceContainer.GetModerationBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	moderationService, err := serviceContainer.GetModerationService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &VideoDeleteController{
+		logger:    loggerService,
+		builder:   moderationBuilder,
+		service:   moderationService,
+		responder: responseService,
+	}, nil
+}
+
+func (c *VideoDeleteController) Delete(w http.ResponseWriter, r *http.Request) {
+	reqDTO, err := c.builder.BuildByIdRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	if err = c.service.DeleteVideo(reqDTO); err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *VideoDeleteController) AddRoute(router *mux.Router) {
+	router.
+		Path(VideoDeletePath).
+		HandlerFunc(c.Delete).
+		Methods(http.MethodDelete)
+}
@@ -0,0 +1,72 @@
+package admin
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	moderatorinterface "github.com/Borislavv/video-streaming/internal/domain/service/moderator/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const VideoGetPath = "/admin/video/{id}"
+
+type VideoGetController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Moderation
+	service   moderatorinterface.Moderator
+	responder responseinterface.Responder
+}
+
+func NewVideoGetController(serviceContainer diinterface.ServiceContainer) (*VideoGetController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	moderationBuilder, err := serviceContainer.GetModerationBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	moderationService, err := serviceContainer.GetModerationService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &VideoGetController{
+		logger:    loggerService,
+		builder:   moderationBuilder,
+		service:   moderationService,
+		responder: responseService,
+	}, nil
+}
+
+func (c *VideoGetController) Get(w http.ResponseWriter, r *http.Request) {
+	reqDTO, err := c.builder.BuildByIdRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	aggregate, err := c.service.GetVideo(reqDTO)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	c.responder.Respond(w, aggregate)
+}
+
+func (c *VideoGetController) AddRoute(router *mux.Router) {
+	router.
+		Path(VideoGetPath).
+		HandlerFunc(c.Get).
+		Methods(http.MethodGet)
+}
@@ -1,27 +1,79 @@
 package audio
 
 import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	audiointerface "github.com/Borislavv/video-streaming/internal/domain/service/audio/interface"
+	authenticatorinterface "github.com/Borislavv/video-streaming/internal/domain/service/authenticator/interface"
+	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
 	"github.com/gorilla/mux"
 	"net/http"
 )
 
 const CreatePath = "/audio"
 
-// CreateAudioController - not implemented yet.
-type CreateAudioController struct {
+type CreateController struct {
+	logger      loggerinterface.Logger
+	builder     builderinterface.Audio
+	service     audiointerface.CRUD
+	authService authenticatorinterface.Authenticator
+	responder   responseinterface.Responder
 }
 
-func NewCreateController() *CreateAudioController {
-	return &CreateAudioController{}
+func NewCreateController(serviceContainer diinterface.ServiceContainer) (*CreateController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	audioBuilder, err := serviceContainer.GetAudioBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	audioCRUDService, err := serviceContainer.GetAudioCRUDService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	authService, err := serviceContainer.GetAuthService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &CreateController{
+		logger:      loggerService,
+		builder:     audioBuilder,
+		service:     audioCRUDService,
+		authService: authService,
+		responder:   responseService,
+	}, nil
 }
 
-func (c *CreateAudioController) Create(w http.ResponseWriter, r *http.Request) {
-	if _, err := w.Write([]byte("Sorry, the route is not implemented yet :(")); err != nil {
-		panic(err)
+func (c *CreateController) Create(w http.ResponseWriter, r *http.Request) {
+	audioDTO, err := c.builder.BuildCreateRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
 	}
+
+	audioAgg, err := c.service.Create(audioDTO)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	c.responder.Respond(w, audioAgg)
 }
 
-func (c *CreateAudioController) AddRoute(router *mux.Router) {
+func (c *CreateController) AddRoute(router *mux.Router) {
 	router.
 		Path(CreatePath).
 		HandlerFunc(c.Create).
@@ -1,29 +1,71 @@
 package audio
 
 import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	audiointerface "github.com/Borislavv/video-streaming/internal/domain/service/audio/interface"
+	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
 	"github.com/gorilla/mux"
 	"net/http"
 )
 
 const DeletePath = "/audio/{id}"
 
-// DeleteAudioController - not implemented yet.
-type DeleteAudioController struct {
+type DeleteController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Audio
+	service   audiointerface.CRUD
+	responder responseinterface.Responder
 }
 
-func NewDeleteController() *DeleteAudioController {
-	return &DeleteAudioController{}
+func NewDeleteController(serviceContainer diinterface.ServiceContainer) (*DeleteController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	audioBuilder, err := serviceContainer.GetAudioBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	audioCRUDService, err := serviceContainer.GetAudioCRUDService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &DeleteController{
+		logger:    loggerService,
+		builder:   audioBuilder,
+		service:   audioCRUDService,
+		responder: responseService,
+	}, nil
 }
 
-func (d *DeleteAudioController) Delete(w http.ResponseWriter, r *http.Request) {
-	if _, err := w.Write([]byte("Sorry, the route is not implemented yet :(")); err != nil {
-		panic(err)
+func (c *DeleteController) Delete(w http.ResponseWriter, r *http.Request) {
+	reqDTO, err := c.builder.BuildDeleteRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
 	}
+
+	if err = c.service.Delete(reqDTO); err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
 }
 
-func (d *DeleteAudioController) AddRoute(router *mux.Router) {
+func (c *DeleteController) AddRoute(router *mux.Router) {
 	router.
 		Path(DeletePath).
-		HandlerFunc(d.Delete).
+		HandlerFunc(c.Delete).
 		Methods(http.MethodDelete)
 }
@@ -1,29 +1,72 @@
 package audio
 
 import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	audiointerface "github.com/Borislavv/video-streaming/internal/domain/service/audio/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
 	"github.com/gorilla/mux"
 	"net/http"
 )
 
 const GetPath = "/audio/{id}"
 
-// GetAudioController - not implemented yet.
-type GetAudioController struct {
+type GetController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Audio
+	service   audiointerface.CRUD
+	responder responseinterface.Responder
 }
 
-func NewGetController() *GetAudioController {
-	return &GetAudioController{}
+func NewGetController(serviceContainer diinterface.ServiceContainer) (*GetController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	audioBuilder, err := serviceContainer.GetAudioBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	audioCRUDService, err := serviceContainer.GetAudioCRUDService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &GetController{
+		logger:    loggerService,
+		builder:   audioBuilder,
+		service:   audioCRUDService,
+		responder: responseService,
+	}, nil
 }
 
-func (g *GetAudioController) Get(w http.ResponseWriter, r *http.Request) {
-	if _, err := w.Write([]byte("Sorry, the route is not implemented yet :(")); err != nil {
-		panic(err)
+func (c *GetController) Get(w http.ResponseWriter, r *http.Request) {
+	reqDTO, err := c.builder.BuildGetRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
 	}
+
+	audioAgg, err := c.service.Get(reqDTO)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	c.responder.Respond(w, audioAgg)
 }
 
-func (g *GetAudioController) AddRoute(router *mux.Router) {
+func (c *GetController) AddRoute(router *mux.Router) {
 	router.
 		Path(GetPath).
-		HandlerFunc(g.Get).
+		HandlerFunc(c.Get).
 		Methods(http.MethodGet)
 }
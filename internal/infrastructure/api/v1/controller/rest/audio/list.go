@@ -1,29 +1,82 @@
 package audio
 
 import (
+	"github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	audiointerface "github.com/Borislavv/video-streaming/internal/domain/service/audio/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
 	"github.com/gorilla/mux"
 	"net/http"
 )
 
 const ListPath = "/audio"
 
-// ListAudioController - not implemented yet.
-type ListAudioController struct {
+type ListController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Audio
+	service   audiointerface.CRUD
+	responder responseinterface.Responder
 }
 
-func NewListController() *ListAudioController {
-	return &ListAudioController{}
+func NewListController(serviceContainer diinterface.ServiceContainer) (*ListController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	audioBuilder, err := serviceContainer.GetAudioBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	audioCRUDService, err := serviceContainer.GetAudioCRUDService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &ListController{
+		logger:    loggerService,
+		builder:   audioBuilder,
+		service:   audioCRUDService,
+		responder: responseService,
+	}, nil
 }
 
-func (l *ListAudioController) List(w http.ResponseWriter, r *http.Request) {
-	if _, err := w.Write([]byte("Sorry, the route is not implemented yet :(")); err != nil {
-		panic(err)
+func (c *ListController) List(w http.ResponseWriter, r *http.Request) {
+	reqDTO, e := c.builder.BuildListRequestDTOFromRequest(r)
+	if e != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(e))
+		return
 	}
+
+	aggList, total, err := c.service.List(reqDTO)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	// TODO must be refactored to paginated list DTO.
+	c.responder.Respond(w,
+		map[string]interface{}{
+			"list": aggList,
+			"pagination": map[string]interface{}{
+				"page":  reqDTO.Page,
+				"limit": reqDTO.Limit,
+				"total": total,
+			},
+		},
+	)
 }
 
-func (l *ListAudioController) AddRoute(router *mux.Router) {
+func (c *ListController) AddRoute(router *mux.Router) {
 	router.
 		Path(ListPath).
-		HandlerFunc(l.List).
+		HandlerFunc(c.List).
 		Methods(http.MethodGet)
 }
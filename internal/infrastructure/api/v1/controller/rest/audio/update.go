@@ -1,29 +1,72 @@
 package audio
 
 import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	audiointerface "github.com/Borislavv/video-streaming/internal/domain/service/audio/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
 	"github.com/gorilla/mux"
 	"net/http"
 )
 
 const UpdatePath = "/audio/{id}"
 
-// UpdateAudioController - not implemented yet.
-type UpdateAudioController struct {
+type UpdateController struct {
+	logger   loggerinterface.Logger
+	builder  builderinterface.Audio
+	service  audiointerface.CRUD
+	response responseinterface.Responder
 }
 
-func NewUpdateController() *UpdateAudioController {
-	return &UpdateAudioController{}
+func NewUpdateController(serviceContainer diinterface.ServiceContainer) (*UpdateController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	audioBuilder, err := serviceContainer.GetAudioBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	audioCRUDService, err := serviceContainer.GetAudioCRUDService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &UpdateController{
+		logger:   loggerService,
+		builder:  audioBuilder,
+		service:  audioCRUDService,
+		response: responseService,
+	}, nil
 }
 
-func (u *UpdateAudioController) Update(w http.ResponseWriter, r *http.Request) {
-	if _, err := w.Write([]byte("Sorry, the route is not implemented yet :(")); err != nil {
-		panic(err)
+func (c *UpdateController) Update(w http.ResponseWriter, r *http.Request) {
+	audioDTO, err := c.builder.BuildUpdateRequestDTOFromRequest(r)
+	if err != nil {
+		c.response.Respond(w, c.logger.LogPropagate(err))
+		return
 	}
+
+	audioAgg, err := c.service.Update(audioDTO)
+	if err != nil {
+		c.response.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	c.response.Respond(w, audioAgg)
 }
 
-func (u *UpdateAudioController) AddRoute(router *mux.Router) {
+func (c *UpdateController) AddRoute(router *mux.Router) {
 	router.
 		Path(UpdatePath).
-		HandlerFunc(u.Update).
+		HandlerFunc(c.Update).
 		Methods(http.MethodPatch)
 }
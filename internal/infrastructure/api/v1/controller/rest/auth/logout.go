@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	authenticatorinterface "github.com/Borislavv/video-streaming/internal/domain/service/authenticator/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const LogoutPath = "/auth/logout"
+
+type LogoutController struct {
+	logger        loggerinterface.Logger
+	authenticator authenticatorinterface.Authenticator
+	responder     responseinterface.Responder
+}
+
+func NewLogoutController(serviceContainer diinterface.ServiceContainer) (*LogoutController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	authService, err := serviceContainer.GetAuthService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &LogoutController{
+		logger:        loggerService,
+		authenticator: authService,
+		responder:     responseService,
+	}, nil
+}
+
+// Logout - blocks the access token of request and revokes its session.
+func (c *LogoutController) Logout(w http.ResponseWriter, r *http.Request) {
+	if err := c.authenticator.Logout(r); err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *LogoutController) AddRoute(router *mux.Router) {
+	router.
+		Path(LogoutPath).
+		HandlerFunc(c.Logout).
+		Methods(http.MethodPost)
+}
@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	authenticatorinterface "github.com/Borislavv/video-streaming/internal/domain/service/authenticator/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const LogoutAllPath = "/auth/logout/all"
+
+type LogoutAllController struct {
+	logger        loggerinterface.Logger
+	authenticator authenticatorinterface.Authenticator
+	responder     responseinterface.Responder
+}
+
+func NewLogoutAllController(serviceContainer diinterface.ServiceContainer) (*LogoutAllController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	authService, err := serviceContainer.GetAuthService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &LogoutAllController{
+		logger:        loggerService,
+		authenticator: authService,
+		responder:     responseService,
+	}, nil
+}
+
+// LogoutAll - revokes all sessions of the user, the streams of them are interrupted too.
+func (c *LogoutAllController) LogoutAll(w http.ResponseWriter, r *http.Request) {
+	if err := c.authenticator.LogoutAll(r); err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *LogoutAllController) AddRoute(router *mux.Router) {
+	router.
+		Path(LogoutAllPath).
+		HandlerFunc(c.LogoutAll).
+		Methods(http.MethodPost)
+}
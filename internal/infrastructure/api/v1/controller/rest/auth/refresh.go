@@ -0,0 +1,74 @@
+package auth
+
+import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	authenticatorinterface "github.com/Borislavv/video-streaming/internal/domain/service/authenticator/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const RefreshPath = "/auth/refresh"
+
+type RefreshController struct {
+	logger        loggerinterface.Logger
+	builder       builderinterface.Auth
+	authenticator authenticatorinterface.Authenticator
+	responder     responseinterface.Responder
+}
+
+func NewRefreshController(serviceContainer diinterface.ServiceContainer) (*RefreshController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	authBuilder, err := serviceContainer.GetAuthBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	authService, err := serviceContainer.GetAuthService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &RefreshController{
+		logger:        loggerService,
+		builder:       authBuilder,
+		authenticator: authService,
+		responder:     responseService,
+	}, nil
+}
+
+func (c *RefreshController) Refresh(w http.ResponseWriter, r *http.Request) {
+	// building a refresh request DTO
+	req, err := c.builder.BuildRefreshRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	// exchanging the refresh token to the new pair
+	tokens, err := c.authenticator.Refresh(req)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	c.responder.Respond(w, tokens)
+}
+
+func (c *RefreshController) AddRoute(router *mux.Router) {
+	router.
+		Path(RefreshPath).
+		HandlerFunc(c.Refresh).
+		Methods(http.MethodPost)
+}
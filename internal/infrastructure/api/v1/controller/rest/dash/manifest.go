@@ -0,0 +1,91 @@
+package dash
+
+import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	videointerface "github.com/Borislavv/video-streaming/internal/domain/service/video/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/manifest"
+	manifestinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/manifest/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const ManifestPath = "/video/{id}/dash/manifest.mpd"
+
+type ManifestController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Video
+	service   videointerface.CRUD
+	manifest  manifestinterface.DASH
+	responder responseinterface.Responder
+}
+
+func NewManifestController(serviceContainer diinterface.ServiceContainer) (*ManifestController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	videoBuilder, err := serviceContainer.GetVideoBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	videoCRUDService, err := serviceContainer.GetVideoCRUDService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	dashManifestService, err := serviceContainer.GetDASHManifestService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &ManifestController{
+		logger:    loggerService,
+		builder:   videoBuilder,
+		service:   videoCRUDService,
+		manifest:  dashManifestService,
+		responder: responseService,
+	}, nil
+}
+
+func (c *ManifestController) Get(w http.ResponseWriter, r *http.Request) {
+	reqDTO, err := c.builder.BuildGetRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	videoAgg, err := c.service.Get(reqDTO)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	mpd, err := c.manifest.MPD(videoAgg)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	w.Header().Set(entity.MIMEContentTypeKey, manifest.DASHContentType)
+	if _, err = w.Write(mpd); err != nil {
+		c.logger.Error(err)
+	}
+}
+
+func (c *ManifestController) AddRoute(router *mux.Router) {
+	router.
+		Path(ManifestPath).
+		HandlerFunc(c.Get).
+		Methods(http.MethodGet)
+}
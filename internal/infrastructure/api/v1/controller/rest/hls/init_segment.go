@@ -0,0 +1,114 @@
+package hls
+
+import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	videointerface "github.com/Borislavv/video-streaming/internal/domain/service/video/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/helper"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/manifest"
+	segmenterinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/segmenter/interface"
+	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const InitSegmentPath = "/video/{id}/hls/init.mp4"
+
+type InitSegmentController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Video
+	service   videointerface.CRUD
+	segmenter segmenterinterface.Segmenter
+	storage   fileinterface.Storage
+	responder responseinterface.Responder
+}
+
+func NewInitSegmentController(serviceContainer diinterface.ServiceContainer) (*InitSegmentController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	videoBuilder, err := serviceContainer.GetVideoBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	videoCRUDService, err := serviceContainer.GetVideoCRUDService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	segmenterService, err := serviceContainer.GetSegmenterService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	storageService, err := serviceContainer.GetFileStorageService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &InitSegmentController{
+		logger:    loggerService,
+		builder:   videoBuilder,
+		service:   videoCRUDService,
+		segmenter: segmenterService,
+		storage:   storageService,
+		responder: responseService,
+	}, nil
+}
+
+func (c *InitSegmentController) Get(w http.ResponseWriter, r *http.Request) {
+	reqDTO, err := c.builder.BuildGetRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	videoAgg, err := c.service.Get(reqDTO)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	_, rendition, err := manifest.RequestedRendition(videoAgg, r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	index, err := c.segmenter.Index(rendition.Resource)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	file, err := c.storage.Open(rendition.Resource.GetUserID(), rendition.Resource.GetFilename())
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	if err = helper.WriteFileRange(
+		w, file, index.MediaType(), index.Init.Offset, index.Init.Length,
+	); err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+}
+
+func (c *InitSegmentController) AddRoute(router *mux.Router) {
+	router.
+		Path(InitSegmentPath).
+		HandlerFunc(c.Get).
+		Methods(http.MethodGet)
+}
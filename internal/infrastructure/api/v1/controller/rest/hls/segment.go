@@ -0,0 +1,148 @@
+package hls
+
+import (
+	"fmt"
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	extractorinterface "github.com/Borislavv/video-streaming/internal/domain/service/extractor/interface"
+	videointerface "github.com/Borislavv/video-streaming/internal/domain/service/video/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/helper"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/manifest"
+	segmenterinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/segmenter/interface"
+	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+)
+
+const (
+	SegmentPath = "/video/{id}/hls/segment/{index:[0-9]+}.m4s"
+
+	indexField = "index"
+)
+
+type SegmentController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Video
+	service   videointerface.CRUD
+	segmenter segmenterinterface.Segmenter
+	storage   fileinterface.Storage
+	extractor extractorinterface.RequestParams
+	responder responseinterface.Responder
+}
+
+func NewSegmentController(serviceContainer diinterface.ServiceContainer) (*SegmentController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	videoBuilder, err := serviceContainer.GetVideoBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	videoCRUDService, err := serviceContainer.GetVideoCRUDService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	segmenterService, err := serviceContainer.GetSegmenterService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	storageService, err := serviceContainer.GetFileStorageService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	requestParametersExtractor, err := serviceContainer.GetRequestParametersExtractorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &SegmentController{
+		logger:    loggerService,
+		builder:   videoBuilder,
+		service:   videoCRUDService,
+		segmenter: segmenterService,
+		storage:   storageService,
+		extractor: requestParametersExtractor,
+		responder: responseService,
+	}, nil
+}
+
+func (c *SegmentController) Get(w http.ResponseWriter, r *http.Request) {
+	reqDTO, err := c.builder.BuildGetRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	// extracting the segment serial number
+	param, err := c.extractor.GetParameter(indexField, r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+	segmentIndex, err := strconv.Atoi(param)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(
+			errtype.NewFieldValueIsInvalidError(indexField, fmt.Sprintf("'%v' is not a segment number", param)),
+		))
+		return
+	}
+
+	videoAgg, err := c.service.Get(reqDTO)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	_, rendition, err := manifest.RequestedRendition(videoAgg, r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	index, err := c.segmenter.Index(rendition.Resource)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+	if segmentIndex < 0 || segmentIndex >= len(index.Segments) {
+		c.responder.Respond(w, c.logger.LogPropagate(errtype.NewSegmentNotFoundError(segmentIndex)))
+		return
+	}
+
+	file, err := c.storage.Open(rendition.Resource.GetUserID(), rendition.Resource.GetFilename())
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	if err = helper.WriteFileRange(
+		w, file, index.MediaType(),
+		index.Segments[segmentIndex].Offset, index.Segments[segmentIndex].Length,
+	); err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+}
+
+func (c *SegmentController) AddRoute(router *mux.Router) {
+	router.
+		Path(SegmentPath).
+		HandlerFunc(c.Get).
+		Methods(http.MethodGet)
+}
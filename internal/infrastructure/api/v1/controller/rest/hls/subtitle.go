@@ -0,0 +1,92 @@
+package hls
+
+import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	videointerface "github.com/Borislavv/video-streaming/internal/domain/service/video/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/manifest"
+	manifestinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/manifest/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const SubtitlePlaylistPath = "/video/{id}/hls/subtitle.m3u8"
+
+type SubtitlePlaylistController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Video
+	service   videointerface.CRUD
+	manifest  manifestinterface.HLS
+	responder responseinterface.Responder
+}
+
+func NewSubtitlePlaylistController(serviceContainer diinterface.ServiceContainer) (*SubtitlePlaylistController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	videoBuilder, err := serviceContainer.GetVideoBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	videoCRUDService, err := serviceContainer.GetVideoCRUDService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	hlsManifestService, err := serviceContainer.GetHLSManifestService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &SubtitlePlaylistController{
+		logger:    loggerService,
+		builder:   videoBuilder,
+		service:   videoCRUDService,
+		manifest:  hlsManifestService,
+		responder: responseService,
+	}, nil
+}
+
+// Get - serves the playlist of the subtitle track which is selected by the query parameter.
+func (c *SubtitlePlaylistController) Get(w http.ResponseWriter, r *http.Request) {
+	reqDTO, err := c.builder.BuildGetRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	videoAgg, err := c.service.Get(reqDTO)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	playlist, err := c.manifest.Subtitle(videoAgg, r.URL.Query().Get(manifest.SubtitleParam))
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	w.Header().Set(entity.MIMEContentTypeKey, manifest.HLSContentType)
+	if _, err = w.Write(playlist); err != nil {
+		c.logger.Error(err)
+	}
+}
+
+func (c *SubtitlePlaylistController) AddRoute(router *mux.Router) {
+	router.
+		Path(SubtitlePlaylistPath).
+		HandlerFunc(c.Get).
+		Methods(http.MethodGet)
+}
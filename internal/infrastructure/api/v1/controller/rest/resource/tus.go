@@ -0,0 +1,30 @@
+package resource
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"net/http"
+	"strconv"
+)
+
+const (
+	TusPath       = "/resource/upload"
+	TusUploadPath = "/resource/upload/{id}"
+)
+
+// writeTusHeaders - writes the protocol version which is required for each response and the upload state.
+func writeTusHeaders(w http.ResponseWriter, upload *agg.Upload) {
+	w.Header().Set(enum.TusResumableHeaderKey, enum.TusVersion)
+	if upload == nil {
+		return
+	}
+
+	w.Header().Set(enum.UploadOffsetHeaderKey, strconv.FormatInt(upload.Offset, 10))
+	if upload.IsCompleted() {
+		if !upload.ResourceID.Value.IsZero() {
+			w.Header().Set(enum.ResourceIDHeaderKey, upload.ResourceID.Value.Hex())
+		}
+	} else {
+		w.Header().Set(enum.UploadExpiresHeaderKey, upload.ExpiresAt.UTC().Format(http.TimeFormat))
+	}
+}
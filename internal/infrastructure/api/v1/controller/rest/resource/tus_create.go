@@ -0,0 +1,76 @@
+package resource
+
+import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	resourceinterface "github.com/Borislavv/video-streaming/internal/domain/service/resource/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+type TusCreateController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Resource
+	service   resourceinterface.Resumable
+	responder responseinterface.Responder
+}
+
+func NewTusCreateController(serviceContainer diinterface.ServiceContainer) (*TusCreateController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	resourceBuilder, err := serviceContainer.GetResourceBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	resumableService, err := serviceContainer.GetResourceResumableService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &TusCreateController{
+		logger:    loggerService,
+		builder:   resourceBuilder,
+		service:   resumableService,
+		responder: responseService,
+	}, nil
+}
+
+func (c *TusCreateController) Create(w http.ResponseWriter, r *http.Request) {
+	writeTusHeaders(w, nil)
+
+	reqDTO, err := c.builder.BuildCreateUploadRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	uploadAgg, err := c.service.Create(reqDTO)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	writeTusHeaders(w, uploadAgg)
+	w.Header().Set("Location", r.URL.Path+"/"+uploadAgg.ID.Value.Hex())
+	w.WriteHeader(http.StatusCreated)
+
+	c.responder.Respond(w, uploadAgg)
+}
+
+func (c *TusCreateController) AddRoute(router *mux.Router) {
+	router.
+		Path(TusPath).
+		HandlerFunc(c.Create).
+		Methods(http.MethodPost)
+}
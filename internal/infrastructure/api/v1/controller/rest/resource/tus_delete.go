@@ -0,0 +1,71 @@
+package resource
+
+import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	resourceinterface "github.com/Borislavv/video-streaming/internal/domain/service/resource/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+type TusDeleteController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Resource
+	service   resourceinterface.Resumable
+	responder responseinterface.Responder
+}
+
+func NewTusDeleteController(serviceContainer diinterface.ServiceContainer) (*TusDeleteController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	resourceBuilder, err := serviceContainer.GetResourceBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	resumableService, err := serviceContainer.GetResourceResumableService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &TusDeleteController{
+		logger:    loggerService,
+		builder:   resourceBuilder,
+		service:   resumableService,
+		responder: responseService,
+	}, nil
+}
+
+func (c *TusDeleteController) Delete(w http.ResponseWriter, r *http.Request) {
+	writeTusHeaders(w, nil)
+
+	reqDTO, err := c.builder.BuildDeleteUploadRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	if err = c.service.Terminate(reqDTO); err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *TusDeleteController) AddRoute(router *mux.Router) {
+	router.
+		Path(TusUploadPath).
+		HandlerFunc(c.Delete).
+		Methods(http.MethodDelete)
+}
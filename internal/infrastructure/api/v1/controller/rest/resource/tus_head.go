@@ -0,0 +1,78 @@
+package resource
+
+import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	resourceinterface "github.com/Borislavv/video-streaming/internal/domain/service/resource/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+)
+
+type TusHeadController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Resource
+	service   resourceinterface.Resumable
+	responder responseinterface.Responder
+}
+
+func NewTusHeadController(serviceContainer diinterface.ServiceContainer) (*TusHeadController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	resourceBuilder, err := serviceContainer.GetResourceBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	resumableService, err := serviceContainer.GetResourceResumableService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &TusHeadController{
+		logger:    loggerService,
+		builder:   resourceBuilder,
+		service:   resumableService,
+		responder: responseService,
+	}, nil
+}
+
+// Head - responds the received offset, so the client knows where the upload must be continued from.
+func (c *TusHeadController) Head(w http.ResponseWriter, r *http.Request) {
+	writeTusHeaders(w, nil)
+
+	reqDTO, err := c.builder.BuildGetUploadRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	uploadAgg, err := c.service.Find(reqDTO)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	writeTusHeaders(w, uploadAgg)
+	w.Header().Set(enum.UploadLengthHeaderKey, strconv.FormatInt(uploadAgg.Length, 10))
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+}
+
+func (c *TusHeadController) AddRoute(router *mux.Router) {
+	router.
+		Path(TusUploadPath).
+		HandlerFunc(c.Head).
+		Methods(http.MethodHead)
+}
@@ -0,0 +1,48 @@
+package resource
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+)
+
+type TusOptionsController struct {
+	logger      loggerinterface.Logger
+	maxFilesize int64
+}
+
+func NewTusOptionsController(serviceContainer diinterface.ServiceContainer) (*TusOptionsController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &TusOptionsController{
+		logger:      loggerService,
+		maxFilesize: cfg.ResourceMaxFilesizeThreshold,
+	}, nil
+}
+
+// Options - describes the supported protocol version, extensions and the max. upload length.
+func (c *TusOptionsController) Options(w http.ResponseWriter, r *http.Request) {
+	writeTusHeaders(w, nil)
+	w.Header().Set(enum.TusVersionHeaderKey, enum.TusVersion)
+	w.Header().Set(enum.TusExtensionHeaderKey, enum.TusExtensions)
+	w.Header().Set(enum.TusMaxSizeHeaderKey, strconv.FormatInt(c.maxFilesize, 10))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *TusOptionsController) AddRoute(router *mux.Router) {
+	router.
+		Path(TusPath).
+		HandlerFunc(c.Options).
+		Methods(http.MethodOptions)
+}
@@ -0,0 +1,73 @@
+package resource
+
+import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	resourceinterface "github.com/Borislavv/video-streaming/internal/domain/service/resource/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+type TusPatchController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Resource
+	service   resourceinterface.Resumable
+	responder responseinterface.Responder
+}
+
+func NewTusPatchController(serviceContainer diinterface.ServiceContainer) (*TusPatchController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	resourceBuilder, err := serviceContainer.GetResourceBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	resumableService, err := serviceContainer.GetResourceResumableService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &TusPatchController{
+		logger:    loggerService,
+		builder:   resourceBuilder,
+		service:   resumableService,
+		responder: responseService,
+	}, nil
+}
+
+func (c *TusPatchController) Patch(w http.ResponseWriter, r *http.Request) {
+	writeTusHeaders(w, nil)
+
+	reqDTO, err := c.builder.BuildPatchUploadRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	uploadAgg, err := c.service.Append(reqDTO)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	writeTusHeaders(w, uploadAgg)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *TusPatchController) AddRoute(router *mux.Router) {
+	router.
+		Path(TusUploadPath).
+		HandlerFunc(c.Patch).
+		Methods(http.MethodPatch)
+}
@@ -0,0 +1,73 @@
+package share
+
+import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	shareinterface "github.com/Borislavv/video-streaming/internal/domain/service/share/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const CreatePath = "/video/{id}/share"
+
+type CreateController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Share
+	service   shareinterface.CRUD
+	responder responseinterface.Responder
+}
+
+func NewCreateController(serviceContainer diinterface.ServiceContainer) (*CreateController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	shareBuilder, err := serviceContainer.GetShareBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	shareCRUDService, err := serviceContainer.GetShareCRUDService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &CreateController{
+		logger:    loggerService,
+		builder:   shareBuilder,
+		service:   shareCRUDService,
+		responder: responseService,
+	}, nil
+}
+
+func (c *CreateController) Create(w http.ResponseWriter, r *http.Request) {
+	reqDTO, err := c.builder.BuildCreateRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	shareAgg, err := c.service.Create(reqDTO)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	c.responder.Respond(w, shareAgg)
+}
+
+func (c *CreateController) AddRoute(router *mux.Router) {
+	router.
+		Path(CreatePath).
+		HandlerFunc(c.Create).
+		Methods(http.MethodPost)
+}
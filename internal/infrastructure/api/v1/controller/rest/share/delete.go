@@ -0,0 +1,71 @@
+package share
+
+import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	shareinterface "github.com/Borislavv/video-streaming/internal/domain/service/share/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const DeletePath = "/video/{id}/share/{shareID}"
+
+type DeleteController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Share
+	service   shareinterface.CRUD
+	responder responseinterface.Responder
+}
+
+func NewDeleteController(serviceContainer diinterface.ServiceContainer) (*DeleteController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	shareBuilder, err := serviceContainer.GetShareBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	shareCRUDService, err := serviceContainer.GetShareCRUDService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &DeleteController{
+		logger:    loggerService,
+		builder:   shareBuilder,
+		service:   shareCRUDService,
+		responder: responseService,
+	}, nil
+}
+
+func (c *DeleteController) Delete(w http.ResponseWriter, r *http.Request) {
+	reqDTO, err := c.builder.BuildDeleteRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	if err = c.service.Delete(reqDTO); err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *DeleteController) AddRoute(router *mux.Router) {
+	router.
+		Path(DeletePath).
+		HandlerFunc(c.Delete).
+		Methods(http.MethodDelete)
+}
@@ -0,0 +1,72 @@
+package share
+
+import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	shareinterface "github.com/Borislavv/video-streaming/internal/domain/service/share/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const ListPath = "/video/{id}/share"
+
+type ListController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Share
+	service   shareinterface.CRUD
+	responder responseinterface.Responder
+}
+
+func NewListController(serviceContainer diinterface.ServiceContainer) (*ListController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	shareBuilder, err := serviceContainer.GetShareBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	shareCRUDService, err := serviceContainer.GetShareCRUDService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &ListController{
+		logger:    loggerService,
+		builder:   shareBuilder,
+		service:   shareCRUDService,
+		responder: responseService,
+	}, nil
+}
+
+func (c *ListController) List(w http.ResponseWriter, r *http.Request) {
+	reqDTO, err := c.builder.BuildListRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	aggList, err := c.service.List(reqDTO)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	c.responder.Respond(w, map[string]interface{}{"list": aggList})
+}
+
+func (c *ListController) AddRoute(router *mux.Router) {
+	router.
+		Path(ListPath).
+		HandlerFunc(c.List).
+		Methods(http.MethodGet)
+}
@@ -0,0 +1,96 @@
+package subtitle
+
+import (
+	"fmt"
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	subtitleinterface "github.com/Borislavv/video-streaming/internal/domain/service/subtitle/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/manifest"
+	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const ContentPath = "/video/{id}/subtitles/{subtitleID}"
+
+type ContentController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Subtitle
+	service   subtitleinterface.CRUD
+	storage   fileinterface.Storage
+	responder responseinterface.Responder
+}
+
+func NewContentController(serviceContainer diinterface.ServiceContainer) (*ContentController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	subtitleBuilder, err := serviceContainer.GetSubtitleBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	subtitleCRUDService, err := serviceContainer.GetSubtitleCRUDService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	storageService, err := serviceContainer.GetFileStorageService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &ContentController{
+		logger:    loggerService,
+		builder:   subtitleBuilder,
+		service:   subtitleCRUDService,
+		storage:   storageService,
+		responder: responseService,
+	}, nil
+}
+
+// Get - serves the WebVTT file of the subtitle track, it's available for anyone who may view the video.
+func (c *ContentController) Get(w http.ResponseWriter, r *http.Request) {
+	reqDTO, err := c.builder.BuildGetRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	subtitleAgg, err := c.service.Get(reqDTO)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	file, err := c.storage.Open(subtitleAgg.UserID, subtitleAgg.Filename)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	// the rest api content type must be replaced by the track type
+	w.Header().Set(entity.MIMEContentTypeKey, manifest.WebVTTContentType+"; charset=utf-8")
+	// the file is named by its content, so it's changed only with the name
+	w.Header().Set("ETag", fmt.Sprintf(`"%s"`, subtitleAgg.Filename))
+
+	http.ServeContent(w, r, file.Name(), subtitleAgg.Timestamp.CreatedAt, file)
+}
+
+func (c *ContentController) AddRoute(router *mux.Router) {
+	router.
+		Path(ContentPath).
+		HandlerFunc(c.Get).
+		Methods(http.MethodGet, http.MethodHead)
+}
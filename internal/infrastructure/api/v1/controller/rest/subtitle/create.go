@@ -0,0 +1,75 @@
+package subtitle
+
+import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	subtitleinterface "github.com/Borislavv/video-streaming/internal/domain/service/subtitle/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const CreatePath = "/video/{id}/subtitles"
+
+type CreateController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Subtitle
+	service   subtitleinterface.CRUD
+	responder responseinterface.Responder
+}
+
+func NewCreateController(serviceContainer diinterface.ServiceContainer) (*CreateController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	subtitleBuilder, err := serviceContainer.GetSubtitleBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	subtitleCRUDService, err := serviceContainer.GetSubtitleCRUDService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &CreateController{
+		logger:    loggerService,
+		builder:   subtitleBuilder,
+		service:   subtitleCRUDService,
+		responder: responseService,
+	}, nil
+}
+
+// Create - uploads the subtitle track by the multipart form with the file and the fields of track:
+// 'language' (required), 'label', 'kind' and 'default'.
+func (c *CreateController) Create(w http.ResponseWriter, r *http.Request) {
+	reqDTO, err := c.builder.BuildCreateRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	subtitleAgg, err := c.service.Create(reqDTO)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	c.responder.Respond(w, subtitleAgg)
+}
+
+func (c *CreateController) AddRoute(router *mux.Router) {
+	router.
+		Path(CreatePath).
+		HandlerFunc(c.Create).
+		Methods(http.MethodPost)
+}
@@ -0,0 +1,73 @@
+package subtitle
+
+import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	subtitleinterface "github.com/Borislavv/video-streaming/internal/domain/service/subtitle/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const UpdatePath = "/video/{id}/subtitles/{subtitleID}"
+
+type UpdateController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Subtitle
+	service   subtitleinterface.CRUD
+	responder responseinterface.Responder
+}
+
+func NewUpdateController(serviceContainer diinterface.ServiceContainer) (*UpdateController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	subtitleBuilder, err := serviceContainer.GetSubtitleBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	subtitleCRUDService, err := serviceContainer.GetSubtitleCRUDService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &UpdateController{
+		logger:    loggerService,
+		builder:   subtitleBuilder,
+		service:   subtitleCRUDService,
+		responder: responseService,
+	}, nil
+}
+
+// Update - changes the fields of subtitle track, the file is not replaceable (the track must be uploaded again).
+func (c *UpdateController) Update(w http.ResponseWriter, r *http.Request) {
+	reqDTO, err := c.builder.BuildUpdateRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	subtitleAgg, err := c.service.Update(reqDTO)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	c.responder.Respond(w, subtitleAgg)
+}
+
+func (c *UpdateController) AddRoute(router *mux.Router) {
+	router.
+		Path(UpdatePath).
+		HandlerFunc(c.Update).
+		Methods(http.MethodPatch)
+}
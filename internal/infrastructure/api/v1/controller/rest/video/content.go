@@ -0,0 +1,131 @@
+package video
+
+import (
+	"fmt"
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	accessorinterface "github.com/Borislavv/video-streaming/internal/domain/service/accessor/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	videointerface "github.com/Borislavv/video-streaming/internal/domain/service/video/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/manifest"
+	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
+	"github.com/gorilla/mux"
+	"mime"
+	"net/http"
+)
+
+const ContentPath = "/video/{id}/content"
+
+type ContentController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Video
+	service   videointerface.CRUD
+	accessor  accessorinterface.Accessor
+	storage   fileinterface.Storage
+	responder responseinterface.Responder
+}
+
+func NewContentController(serviceContainer diinterface.ServiceContainer) (*ContentController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	videoBuilder, err := serviceContainer.GetVideoBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	videoCRUDService, err := serviceContainer.GetVideoCRUDService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	accessService, err := serviceContainer.GetAccessService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	storageService, err := serviceContainer.GetFileStorageService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &ContentController{
+		logger:    loggerService,
+		builder:   videoBuilder,
+		service:   videoCRUDService,
+		accessor:  accessService,
+		storage:   storageService,
+		responder: responseService,
+	}, nil
+}
+
+// Get - serves the video resource (or its rendition) file as is. Range, If-Range, conditional requests and
+// multipart/byteranges responses are handled by http.ServeContent.
+func (c *ContentController) Get(w http.ResponseWriter, r *http.Request) {
+	reqDTO, err := c.builder.BuildGetRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	videoAgg, err := c.service.Get(reqDTO)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	// checking the user is owner of the video and its resource
+	if err = c.accessor.IsGranted(reqDTO.GetUserID(), videoAgg); err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	// the original resource is served unless another rendition is requested
+	_, rendition, err := manifest.RequestedRendition(videoAgg, r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	file, err := c.storage.Open(rendition.Resource.GetUserID(), rendition.Resource.GetFilename())
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	// the rest api content type must be replaced by the resource type
+	w.Header().Del(entity.MIMEContentTypeKey)
+	if filetype := rendition.Resource.GetFiletype(); filetype != "" {
+		w.Header().Set(entity.MIMEContentTypeKey, filetype)
+	}
+	w.Header().Set(
+		entity.MIMEContentDispositionKey,
+		mime.FormatMediaType("inline", map[string]string{"filename": rendition.Resource.GetName()}),
+	)
+	w.Header().Set("ETag", c.etag(rendition.Resource, file))
+
+	http.ServeContent(w, r, rendition.Resource.GetName(), file.ModTime(), file)
+}
+
+// etag - the resource file is immutable after uploading, so its identifier, size and
+// modification time are enough for a strong validator.
+func (c *ContentController) etag(resource entity.Resource, file fileinterface.File) string {
+	return fmt.Sprintf(`"%s-%x-%x"`, resource.GetID().Value.Hex(), file.Size(), file.ModTime().UnixNano())
+}
+
+func (c *ContentController) AddRoute(router *mux.Router) {
+	router.
+		Path(ContentPath).
+		HandlerFunc(c.Get).
+		Methods(http.MethodGet, http.MethodHead)
+}
@@ -19,6 +19,7 @@ type CreateController struct {
 	service     videointerface.CRUD
 	authService authenticatorinterface.Authenticator
 	responder   responseinterface.Responder
+	apiPrefix   string
 }
 
 func NewCreateController(serviceContainer diinterface.ServiceContainer) (*CreateController, error) {
@@ -42,6 +43,11 @@ func NewCreateController(serviceContainer diinterface.ServiceContainer) (*Create
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	responseService, err := serviceContainer.GetResponderService()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
@@ -53,6 +59,7 @@ func NewCreateController(serviceContainer diinterface.ServiceContainer) (*Create
 		service:     videoCRUDService,
 		authService: authService,
 		responder:   responseService,
+		apiPrefix:   cfg.ResourcesApiVersionPrefix,
 	}, nil
 }
 
@@ -70,7 +77,7 @@ func (c *CreateController) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.WriteHeader(http.StatusCreated)
-	c.responder.Respond(w, videoAgg)
+	c.responder.Respond(w, withPreviewURLs(c.apiPrefix, videoAgg, ""))
 }
 
 func (c *CreateController) AddRoute(router *mux.Router) {
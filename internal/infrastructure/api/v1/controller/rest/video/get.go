@@ -15,7 +15,8 @@ const GetPath = "/video/{id}"
 type GetController struct {
 	logger    loggerinterface.Logger
 	builder   builderinterface.Video
-	service   videointerface.CRUD
+	service   videointerface.Viewer
+	view      *publicView
 	responder responseinterface.Responder
 }
 
@@ -30,7 +31,12 @@ func NewGetController(serviceContainer diinterface.ServiceContainer) (*GetContro
 		return nil, loggerService.LogPropagate(err)
 	}
 
-	videoCRUDService, err := serviceContainer.GetVideoCRUDService()
+	videoViewService, err := serviceContainer.GetVideoViewService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	view, err := newPublicView(serviceContainer)
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
 	}
@@ -43,7 +49,8 @@ func NewGetController(serviceContainer diinterface.ServiceContainer) (*GetContro
 	return &GetController{
 		logger:    loggerService,
 		builder:   videoBuilder,
-		service:   videoCRUDService,
+		service:   videoViewService,
+		view:      view,
 		responder: responseService,
 	}, nil
 }
@@ -55,13 +62,20 @@ func (c *GetController) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	videoAgg, err := c.service.Get(reqDTO)
+	videoAgg, err := c.service.View(reqDTO)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	// the owner receives the whole aggregate, the others receive the public representation only
+	video, err := c.view.Represent(reqDTO.GetUserID(), videoAgg, reqDTO.GetShareToken())
 	if err != nil {
 		c.responder.Respond(w, c.logger.LogPropagate(err))
 		return
 	}
 
-	c.responder.Respond(w, videoAgg)
+	c.responder.Respond(w, video)
 }
 
 func (c *GetController) AddRoute(router *mux.Router) {
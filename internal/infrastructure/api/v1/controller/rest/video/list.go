@@ -1,6 +1,7 @@
 package video
 
 import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
 	"github.com/Borislavv/video-streaming/internal/domain/builder/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
@@ -17,6 +18,7 @@ type ListController struct {
 	builder   builderinterface.Video
 	service   videointerface.CRUD
 	responder responseinterface.Responder
+	apiPrefix string
 }
 
 func NewListController(serviceContainer diinterface.ServiceContainer) (*ListController, error) {
@@ -35,6 +37,11 @@ func NewListController(serviceContainer diinterface.ServiceContainer) (*ListCont
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	responseService, err := serviceContainer.GetResponderService()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
@@ -45,6 +52,7 @@ func NewListController(serviceContainer diinterface.ServiceContainer) (*ListCont
 		builder:   videoBuilder,
 		service:   videoCRUDService,
 		responder: responseService,
+		apiPrefix: cfg.ResourcesApiVersionPrefix,
 	}, nil
 }
 
@@ -61,10 +69,15 @@ func (c *ListController) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	list := make([]*agg.Video, 0, len(aggList))
+	for _, videoAgg := range aggList {
+		list = append(list, withPreviewURLs(c.apiPrefix, videoAgg, ""))
+	}
+
 	// TODO must be refactored to paginated list DTO.
 	c.responder.Respond(w,
 		map[string]interface{}{
-			"list": aggList,
+			"list": list,
 			"pagination": map[string]interface{}{
 				"page":  reqDTO.Page,
 				"limit": reqDTO.Limit,
@@ -0,0 +1,44 @@
+package video
+
+import (
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const previewContentType = "image/jpeg"
+
+// withPreviewURLs - returns a copy of the video with the URLs of its preview, the aggregate itself is not changed
+// because it may be shared by the cache. The share token is passed into the URLs if the video was viewed by it.
+func withPreviewURLs(apiPrefix string, video *agg.Video, shareToken string) *agg.Video {
+	if video.Resource.GetPreview() == nil {
+		return video
+	}
+
+	id := video.ID.Value.Hex()
+	withURLs := *video
+	withURLs.PosterURL = apiPrefix + strings.Replace(PosterPath, "{id}", id, 1)
+	withURLs.StoryboardURL = apiPrefix + strings.Replace(StoryboardPath, "{id}", id, 1)
+	if shareToken != "" {
+		query := "?" + url.Values{enum.ShareTokenQueryKey: {shareToken}}.Encode()
+		withURLs.PosterURL += query
+		withURLs.StoryboardURL += query
+	}
+
+	return &withURLs
+}
+
+// servePreviewImage - serves the stored preview image, the conditional requests are handled by http.ServeContent.
+func servePreviewImage(w http.ResponseWriter, r *http.Request, resource entity.Resource, file fileinterface.File) {
+	// the rest api content type must be replaced by the image type
+	w.Header().Set(entity.MIMEContentTypeKey, previewContentType)
+	// the preview is regenerated under the same name only when the resource is processed again
+	w.Header().Set("ETag", fmt.Sprintf(`"%s-%x-%x"`, resource.GetID().Value.Hex(), file.Size(), file.ModTime().UnixNano()))
+
+	http.ServeContent(w, r, file.Name(), file.ModTime(), file)
+}
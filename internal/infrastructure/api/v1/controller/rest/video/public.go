@@ -0,0 +1,129 @@
+package video
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	detectorinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/detector/interface"
+	manifestinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/manifest/interface"
+)
+
+// publicView - represents the video for the viewers. The owner receives the aggregate as is, the others receive
+// the public DTO without the owner, the storage paths of the files and the probed media details.
+type publicView struct {
+	logger      loggerinterface.Logger
+	mediaInfo   detectorinterface.MediaInfo
+	codecs      detectorinterface.Codecs
+	audioTracks detectorinterface.AudioTracks
+	textTracks  manifestinterface.TextTracks
+	apiPrefix   string
+}
+
+func newPublicView(serviceContainer diinterface.ServiceContainer) (*publicView, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	mediaInfoDetector, err := serviceContainer.GetMediaInfoDetectorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	codecsDetector, err := serviceContainer.GetCodecsDetectorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	audioTracksDetector, err := serviceContainer.GetAudioTracksDetectorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	textTracksService, err := serviceContainer.GetTextTracksService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &publicView{
+		logger:      loggerService,
+		mediaInfo:   mediaInfoDetector,
+		codecs:      codecsDetector,
+		audioTracks: audioTracksDetector,
+		textTracks:  textTracksService,
+		apiPrefix:   cfg.ResourcesApiVersionPrefix,
+	}, nil
+}
+
+// Represent - returns the aggregate with the preview URLs for the owner and the public DTO for the others.
+func (v *publicView) Represent(userID vo.ID, video *agg.Video, shareToken string) (any, error) {
+	if !userID.Value.IsZero() && userID.Value == video.UserID.Value {
+		return withPreviewURLs(v.apiPrefix, video, shareToken), nil
+	}
+	return v.Build(video, shareToken)
+}
+
+// Build - makes the public DTO of the video. The media details are taken once the resource was processed,
+// the resources which were uploaded before the media info was stored are probed.
+func (v *publicView) Build(video *agg.Video, shareToken string) (*dto.VideoResponseDTO, error) {
+	withURLs := withPreviewURLs(v.apiPrefix, video, shareToken)
+
+	respDTO := &dto.VideoResponseDTO{
+		ID:            video.ID,
+		Name:          video.Name,
+		Description:   video.Description,
+		PosterURL:     withURLs.PosterURL,
+		StoryboardURL: withURLs.StoryboardURL,
+		Codecs:        []string{},
+		AudioTracks:   []vo.AudioTrack{},
+	}
+
+	subtitles, err := v.textTracks.Tracks(video, shareToken)
+	if err != nil {
+		return nil, v.logger.LogPropagate(err)
+	}
+	respDTO.Subtitles = subtitles
+
+	if !video.Resource.IsReady() {
+		return respDTO, nil
+	}
+
+	// the resource is probed once, the detectors below take the media info from it
+	resource := video.Resource
+	if resource.GetMediaInfo() == nil {
+		if resource.MediaInfo, err = v.mediaInfo.Detect(resource); err != nil {
+			return nil, v.logger.LogPropagate(err)
+		}
+	}
+	respDTO.Duration = resource.GetMediaInfo().Duration
+
+	if respDTO.AudioTracks, err = v.audioTracks.Detect(resource); err != nil {
+		return nil, v.logger.LogPropagate(err)
+	}
+
+	seen := map[string]bool{}
+	for _, rendition := range video.GetRenditions() {
+		if rendition.Original || len(video.Renditions) == 0 {
+			rendition.Resource = resource
+		}
+		mediaType, derr := v.codecs.DetectRendition(rendition)
+		if derr != nil {
+			return nil, v.logger.LogPropagate(derr)
+		}
+		for _, codec := range []string{mediaType.VideoCodec, mediaType.AudioCodec} {
+			if codec != "" && !seen[codec] {
+				seen[codec] = true
+				respDTO.Codecs = append(respDTO.Codecs, codec)
+			}
+		}
+	}
+
+	return respDTO, nil
+}
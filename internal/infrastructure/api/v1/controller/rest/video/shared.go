@@ -0,0 +1,87 @@
+package video
+
+import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	videointerface "github.com/Borislavv/video-streaming/internal/domain/service/video/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+// SharedPath - the video is available without an account if it's not private or the share token is passed
+const SharedPath = "/shared/video/{id}"
+
+type SharedController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Video
+	service   videointerface.Viewer
+	view      *publicView
+	responder responseinterface.Responder
+}
+
+func NewSharedController(serviceContainer diinterface.ServiceContainer) (*SharedController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	videoBuilder, err := serviceContainer.GetVideoBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	videoViewService, err := serviceContainer.GetVideoViewService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	view, err := newPublicView(serviceContainer)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &SharedController{
+		logger:    loggerService,
+		builder:   videoBuilder,
+		service:   videoViewService,
+		view:      view,
+		responder: responseService,
+	}, nil
+}
+
+func (c *SharedController) Get(w http.ResponseWriter, r *http.Request) {
+	reqDTO, err := c.builder.BuildGetRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	videoAgg, err := c.service.View(reqDTO)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	// the route is not authorized, so the viewer is anonymous and receives the public representation only
+	videoDTO, err := c.view.Build(videoAgg, reqDTO.GetShareToken())
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	c.responder.Respond(w, videoDTO)
+}
+
+func (c *SharedController) AddRoute(router *mux.Router) {
+	router.
+		Path(SharedPath).
+		HandlerFunc(c.Get).
+		Methods(http.MethodGet)
+}
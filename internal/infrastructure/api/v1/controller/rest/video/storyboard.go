@@ -0,0 +1,97 @@
+package video
+
+import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	videointerface "github.com/Borislavv/video-streaming/internal/domain/service/video/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/manifest"
+	"github.com/gorilla/mux"
+	"net/http"
+	"path"
+)
+
+const StoryboardPath = "/video/{id}/storyboard.vtt"
+
+type StoryboardController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Video
+	service   videointerface.Viewer
+	responder responseinterface.Responder
+}
+
+func NewStoryboardController(serviceContainer diinterface.ServiceContainer) (*StoryboardController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	videoBuilder, err := serviceContainer.GetVideoBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	videoViewService, err := serviceContainer.GetVideoViewService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &StoryboardController{
+		logger:    loggerService,
+		builder:   videoBuilder,
+		service:   videoViewService,
+		responder: responseService,
+	}, nil
+}
+
+// Get - serves the WebVTT track of the seek previews, the track is built by the stored storyboard grid.
+func (c *StoryboardController) Get(w http.ResponseWriter, r *http.Request) {
+	reqDTO, err := c.builder.BuildGetRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	videoAgg, err := c.service.View(reqDTO)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	preview := videoAgg.Resource.GetPreview()
+	if preview == nil || preview.Storyboard.Filename == "" {
+		c.responder.Respond(w, c.logger.LogPropagate(errtype.NewPreviewNotFoundError(videoAgg.Resource.GetName())))
+		return
+	}
+
+	// the sprite sheet is addressed relative to the track, the query keeps the share token
+	imageURI := path.Base(StoryboardImagePath)
+	if r.URL.RawQuery != "" {
+		imageURI += "?" + r.URL.RawQuery
+	}
+
+	duration := 0.
+	if info := videoAgg.Resource.GetMediaInfo(); info != nil {
+		duration = info.Duration
+	}
+
+	w.Header().Set(entity.MIMEContentTypeKey, manifest.WebVTTContentType)
+	if _, err = w.Write(manifest.StoryboardVTT(preview.Storyboard, duration, imageURI)); err != nil {
+		c.logger.Error(err)
+	}
+}
+
+func (c *StoryboardController) AddRoute(router *mux.Router) {
+	router.
+		Path(StoryboardPath).
+		HandlerFunc(c.Get).
+		Methods(http.MethodGet)
+}
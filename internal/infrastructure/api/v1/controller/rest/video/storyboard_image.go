@@ -0,0 +1,95 @@
+package video
+
+import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	videointerface "github.com/Borislavv/video-streaming/internal/domain/service/video/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const StoryboardImagePath = "/video/{id}/storyboard.jpg"
+
+type StoryboardImageController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Video
+	service   videointerface.Viewer
+	storage   fileinterface.Storage
+	responder responseinterface.Responder
+}
+
+func NewStoryboardImageController(serviceContainer diinterface.ServiceContainer) (*StoryboardImageController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	videoBuilder, err := serviceContainer.GetVideoBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	videoViewService, err := serviceContainer.GetVideoViewService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	storageService, err := serviceContainer.GetFileStorageService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &StoryboardImageController{
+		logger:    loggerService,
+		builder:   videoBuilder,
+		service:   videoViewService,
+		storage:   storageService,
+		responder: responseService,
+	}, nil
+}
+
+// Get - serves the storyboard sprite sheet of the video, the tiles are addressed by the storyboard track.
+func (c *StoryboardImageController) Get(w http.ResponseWriter, r *http.Request) {
+	reqDTO, err := c.builder.BuildGetRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	videoAgg, err := c.service.View(reqDTO)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	preview := videoAgg.Resource.GetPreview()
+	if preview == nil || preview.Storyboard.Filename == "" {
+		c.responder.Respond(w, c.logger.LogPropagate(errtype.NewPreviewNotFoundError(videoAgg.Resource.GetName())))
+		return
+	}
+
+	file, err := c.storage.Open(videoAgg.Resource.GetUserID(), preview.Storyboard.Filename)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	servePreviewImage(w, r, videoAgg.Resource, file)
+}
+
+func (c *StoryboardImageController) AddRoute(router *mux.Router) {
+	router.
+		Path(StoryboardImagePath).
+		HandlerFunc(c.Get).
+		Methods(http.MethodGet, http.MethodHead)
+}
@@ -13,10 +13,11 @@ import (
 const UpdatePath = "/video/{id}"
 
 type UpdateController struct {
-	logger   loggerinterface.Logger
-	builder  builderinterface.Video
-	service  videointerface.CRUD
-	response responseinterface.Responder
+	logger    loggerinterface.Logger
+	builder   builderinterface.Video
+	service   videointerface.CRUD
+	response  responseinterface.Responder
+	apiPrefix string
 }
 
 func NewUpdateController(serviceContainer diinterface.ServiceContainer) (*UpdateController, error) {
@@ -35,16 +36,22 @@ func NewUpdateController(serviceContainer diinterface.ServiceContainer) (*Update
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	responseService, err := serviceContainer.GetResponderService()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
 	}
 
 	return &UpdateController{
-		logger:   loggerService,
-		builder:  videoBuilder,
-		service:  videoCRUDService,
-		response: responseService,
+		logger:    loggerService,
+		builder:   videoBuilder,
+		service:   videoCRUDService,
+		response:  responseService,
+		apiPrefix: cfg.ResourcesApiVersionPrefix,
 	}, nil
 }
 
@@ -61,7 +68,7 @@ func (c *UpdateController) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	c.response.Respond(w, videoAgg)
+	c.response.Respond(w, withPreviewURLs(c.apiPrefix, videoAgg, ""))
 }
 
 func (c *UpdateController) AddRoute(router *mux.Router) {
@@ -0,0 +1,56 @@
+package wellknown
+
+import (
+	"encoding/json"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	tokenizerinterface "github.com/Borislavv/video-streaming/internal/domain/service/tokenizer/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const (
+	JwksPath = "/.well-known/jwks.json"
+	// jwksMaxAge is short enough for the verifiers to take a new key in time while the keys rotation.
+	jwksMaxAge = "public, max-age=300"
+)
+
+// JwksController - serves the public keys which verify the issued tokens (RFC 7517). The key set is served
+// as is (not wrapped into the `data` field), because it's read by the standard JWT libraries.
+type JwksController struct {
+	logger    loggerinterface.Logger
+	tokenizer tokenizerinterface.Tokenizer
+}
+
+func NewJwksController(serviceContainer diinterface.ServiceContainer) (*JwksController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	tokenizerService, err := serviceContainer.GetTokenizerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &JwksController{
+		logger:    loggerService,
+		tokenizer: tokenizerService,
+	}, nil
+}
+
+func (c *JwksController) Get(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", jwksMaxAge)
+
+	if err := json.NewEncoder(w).Encode(c.tokenizer.Jwks()); err != nil {
+		c.logger.Log(err)
+	}
+}
+
+func (c *JwksController) AddRoute(router *mux.Router) {
+	router.
+		Path(JwksPath).
+		HandlerFunc(c.Get).
+		Methods(http.MethodGet)
+}
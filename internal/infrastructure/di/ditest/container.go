@@ -0,0 +1,55 @@
+package ditest
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/app"
+	loggerservice "github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/di"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/logger"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/filetest"
+	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
+	"reflect"
+	"testing"
+)
+
+// NewContainer - returns the service container of tests with the context, the stderr logger and the given config.
+// The context is canceled by the test cleanup.
+func NewContainer(t testing.TB, cfg *app.Config) *di.ServiceContainer {
+	t.Helper()
+
+	container := di.NewServiceContainerManager()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	container.
+		Set(ctx, reflect.TypeOf((*context.Context)(nil))).
+		Set(cancel, reflect.TypeOf((*context.CancelFunc)(nil)))
+
+	if cfg == nil {
+		cfg = &app.Config{}
+	}
+	container.
+		Set(cfg, nil)
+
+	// the logger channels are not closed, the late logging of the stopped services must not panic
+	loggerService, _ := logger.NewStdErr(ctx, 10, 10)
+	container.
+		Set(loggerService, reflect.TypeOf((*loggerservice.Logger)(nil))).
+		Set(loggerService, nil)
+
+	t.Cleanup(cancel)
+
+	return container
+}
+
+// NewStorageContainer - returns the container of NewContainer with the in-memory file storage.
+func NewStorageContainer(t testing.TB, cfg *app.Config) (*di.ServiceContainer, *filetest.MemoryStorage) {
+	t.Helper()
+
+	container := NewContainer(t, cfg)
+
+	storage := filetest.NewMemoryStorage()
+	container.
+		Set(storage, reflect.TypeOf((*fileinterface.Storage)(nil)))
+
+	return container, storage
+}
@@ -8,12 +8,20 @@ import (
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
 	accessorinterface "github.com/Borislavv/video-streaming/internal/domain/service/accessor/interface"
+	audioservice "github.com/Borislavv/video-streaming/internal/domain/service/audio/interface"
 	authenticatorinterface "github.com/Borislavv/video-streaming/internal/domain/service/authenticator/interface"
 	cacherinterface "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
 	extractorinterface "github.com/Borislavv/video-streaming/internal/domain/service/extractor/interface"
+	jobinterface "github.com/Borislavv/video-streaming/internal/domain/service/job/interface"
+	moderatorservice "github.com/Borislavv/video-streaming/internal/domain/service/moderator/interface"
+	previewerinterface "github.com/Borislavv/video-streaming/internal/domain/service/previewer/interface"
+	renditioninterface "github.com/Borislavv/video-streaming/internal/domain/service/rendition/interface"
 	resourceservice "github.com/Borislavv/video-streaming/internal/domain/service/resource/interface"
 	securityinterface "github.com/Borislavv/video-streaming/internal/domain/service/security/interface"
+	shareservice "github.com/Borislavv/video-streaming/internal/domain/service/share/interface"
+	subtitleservice "github.com/Borislavv/video-streaming/internal/domain/service/subtitle/interface"
 	tokenizerinterface "github.com/Borislavv/video-streaming/internal/domain/service/tokenizer/interface"
+	transcoderinterface "github.com/Borislavv/video-streaming/internal/domain/service/transcoder/interface"
 	uploaderinterface "github.com/Borislavv/video-streaming/internal/domain/service/uploader/interface"
 	userservice "github.com/Borislavv/video-streaming/internal/domain/service/user/interface"
 	videoservice "github.com/Borislavv/video-streaming/internal/domain/service/video/interface"
@@ -23,10 +31,14 @@ import (
 	cacheinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/cache/interface"
 	mongodbinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/mongodb/interface"
 	detectorinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/detector/interface"
+	manifestinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/manifest/interface"
 	readerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/reader/interface"
+	segmenterinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/segmenter/interface"
+	abrinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/abr/interface"
 	handlerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/handler/interface"
 	strategyinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/handler/strategy/interface"
 	listenerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/listener/interface"
+	guardinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/guard/interface"
 	streamerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/interface"
 	protointerface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
 	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
@@ -615,3 +627,575 @@ func (s *ServiceContainer) GetStreamingService() (streamerinterface.Streamer, er
 	}
 	return service, nil
 }
+
+func (s *ServiceContainer) GetSegmenterService() (segmenterinterface.Segmenter, error) {
+	key := (*segmenterinterface.Segmenter)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(segmenterinterface.Segmenter)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetHLSManifestService() (manifestinterface.HLS, error) {
+	key := (*manifestinterface.HLS)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(manifestinterface.HLS)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetDASHManifestService() (manifestinterface.DASH, error) {
+	key := (*manifestinterface.DASH)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(manifestinterface.DASH)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetDurationDetectorService() (detectorinterface.Duration, error) {
+	key := (*detectorinterface.Duration)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(detectorinterface.Duration)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetTranscoderService() (transcoderinterface.Transcoder, error) {
+	key := (*transcoderinterface.Transcoder)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(transcoderinterface.Transcoder)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetRenditionProducerService() (renditioninterface.Producer, error) {
+	key := (*renditioninterface.Producer)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(renditioninterface.Producer)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetPreviewerService() (previewerinterface.Previewer, error) {
+	key := (*previewerinterface.Previewer)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(previewerinterface.Previewer)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetAdaptiveStreamerService() (abrinterface.AdaptiveStreamer, error) {
+	key := (*abrinterface.AdaptiveStreamer)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(abrinterface.AdaptiveStreamer)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetJobMongoRepository() (mongodbinterface.Job, error) {
+	key := (*mongodbinterface.Job)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(mongodbinterface.Job)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetJobRepository() (repositoryinterface.Job, error) {
+	key := (*repositoryinterface.Job)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(repositoryinterface.Job)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetJobQueueService() (jobinterface.Queue, error) {
+	key := (*jobinterface.Queue)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(jobinterface.Queue)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetJobWorkerPoolService() (jobinterface.Pool, error) {
+	key := (*jobinterface.Pool)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(jobinterface.Pool)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetJobHandlers() ([]jobinterface.Handler, error) {
+	key := (*[]jobinterface.Handler)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().([]jobinterface.Handler)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetAudioMongoRepository() (mongodbinterface.Audio, error) {
+	key := (*mongodbinterface.Audio)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(mongodbinterface.Audio)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetAudioCacheRepository() (cacheinterface.Audio, error) {
+	key := (*cacheinterface.Audio)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(cacheinterface.Audio)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetAudioBuilder() (builderinterface.Audio, error) {
+	key := (*builderinterface.Audio)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(builderinterface.Audio)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetAudioValidator() (validatorinterface.Audio, error) {
+	key := (*validatorinterface.Audio)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(validatorinterface.Audio)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetAudioRepository() (repositoryinterface.Audio, error) {
+	key := (*repositoryinterface.Audio)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(repositoryinterface.Audio)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetAudioCRUDService() (audioservice.CRUD, error) {
+	key := (*audioservice.CRUD)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(audioservice.CRUD)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetUploadRepository() (repositoryinterface.Upload, error) {
+	key := (*repositoryinterface.Upload)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(repositoryinterface.Upload)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetResumableUploaderService() (uploaderinterface.Resumable, error) {
+	key := (*uploaderinterface.Resumable)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(uploaderinterface.Resumable)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetResourceResumableService() (resourceservice.Resumable, error) {
+	key := (*resourceservice.Resumable)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(resourceservice.Resumable)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetFileSnifferService() (fileinterface.Sniffer, error) {
+	key := (*fileinterface.Sniffer)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(fileinterface.Sniffer)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetBlobRepository() (repositoryinterface.Blob, error) {
+	key := (*repositoryinterface.Blob)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(repositoryinterface.Blob)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetBlobStorageService() (fileinterface.BlobStorage, error) {
+	key := (*fileinterface.BlobStorage)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(fileinterface.BlobStorage)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetShareBuilder() (builderinterface.Share, error) {
+	key := (*builderinterface.Share)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(builderinterface.Share)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetShareValidator() (validatorinterface.Share, error) {
+	key := (*validatorinterface.Share)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(validatorinterface.Share)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetShareRepository() (repositoryinterface.Share, error) {
+	key := (*repositoryinterface.Share)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(repositoryinterface.Share)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetShareCRUDService() (shareservice.CRUD, error) {
+	key := (*shareservice.CRUD)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(shareservice.CRUD)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetVideoViewService() (videoservice.Viewer, error) {
+	key := (*videoservice.Viewer)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(videoservice.Viewer)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetModerationBuilder() (builderinterface.Moderation, error) {
+	key := (*builderinterface.Moderation)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(builderinterface.Moderation)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetModerationValidator() (validatorinterface.Moderation, error) {
+	key := (*validatorinterface.Moderation)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(validatorinterface.Moderation)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetModerationService() (moderatorservice.Moderator, error) {
+	key := (*moderatorservice.Moderator)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(moderatorservice.Moderator)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetRefreshTokenRepository() (repositoryinterface.RefreshToken, error) {
+	key := (*repositoryinterface.RefreshToken)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(repositoryinterface.RefreshToken)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetTokenGuardService() (guardinterface.TokenGuard, error) {
+	key := (*guardinterface.TokenGuard)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(guardinterface.TokenGuard)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetMediaInfoDetectorService() (detectorinterface.MediaInfo, error) {
+	key := (*detectorinterface.MediaInfo)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(detectorinterface.MediaInfo)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetSubtitleRepository() (repositoryinterface.Subtitle, error) {
+	key := (*repositoryinterface.Subtitle)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(repositoryinterface.Subtitle)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetSubtitleBuilder() (builderinterface.Subtitle, error) {
+	key := (*builderinterface.Subtitle)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(builderinterface.Subtitle)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetSubtitleValidator() (validatorinterface.Subtitle, error) {
+	key := (*validatorinterface.Subtitle)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(validatorinterface.Subtitle)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetSubtitleCRUDService() (subtitleservice.CRUD, error) {
+	key := (*subtitleservice.CRUD)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(subtitleservice.CRUD)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetSubtitleUploaderService() (uploaderinterface.Subtitle, error) {
+	key := (*uploaderinterface.Subtitle)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(uploaderinterface.Subtitle)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetTextTracksService() (manifestinterface.TextTracks, error) {
+	key := (*manifestinterface.TextTracks)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(manifestinterface.TextTracks)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetAudioTracksDetectorService() (detectorinterface.AudioTracks, error) {
+	key := (*detectorinterface.AudioTracks)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(detectorinterface.AudioTracks)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetDemuxerService() (segmenterinterface.Demuxer, error) {
+	key := (*segmenterinterface.Demuxer)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(segmenterinterface.Demuxer)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
@@ -0,0 +1,10 @@
+package helper
+
+// SecondsPerByte is a helper function which calculates the average playback duration of one byte of the media file.
+// Returns zero if the duration or the size is unknown.
+func SecondsPerByte(duration float64, size int64) float64 {
+	if duration <= 0 || size <= 0 {
+		return 0
+	}
+	return duration / float64(size)
+}
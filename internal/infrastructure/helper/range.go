@@ -0,0 +1,34 @@
+package helper
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// rangeHeadSize - is a size of the first block which is read before the response headers
+const rangeHeadSize = 32 << 10
+
+// WriteFileRange - writes the given part of file into the response. An error may be returned only
+// before the response headers were written, so the caller still able to respond with an error.
+func WriteFileRange(w http.ResponseWriter, file io.ReaderAt, contentType string, offset int64, length int64) error {
+	section := io.NewSectionReader(file, offset, length)
+
+	// the file may be remote, so its unavailability is detected by the first block
+	head := make([]byte, min(length, rangeHeadSize))
+	if _, err := io.ReadFull(section, head); err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(length, 10))
+	w.WriteHeader(http.StatusOK)
+
+	// the client may close connection while copying, so the error is not interesting here
+	if _, err := w.Write(head); err != nil {
+		return nil
+	}
+	_, _ = io.Copy(w, section)
+
+	return nil
+}
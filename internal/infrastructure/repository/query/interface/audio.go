@@ -0,0 +1,30 @@
+package queryinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"time"
+)
+
+type FindOneAudioByID interface {
+	GetID() vo.ID
+	GetUserID() vo.ID
+}
+
+type FindOneAudioByName interface {
+	GetName() string
+	GetUserID() vo.ID
+}
+
+type FindOneAudioByResourceID interface {
+	GetResourceID() vo.ID
+	GetUserID() vo.ID
+}
+
+type FindAudioList interface {
+	GetName() string         // part of name
+	GetUserID() vo.ID        // user identifier
+	GetCreatedAt() time.Time // concrete search date point
+	GetFrom() time.Time      // search date limit from
+	GetTo() time.Time        // search date limit to
+	Pagination
+}
@@ -6,3 +6,8 @@ type FindOneResourceByID interface {
 	GetID() vo.ID
 	GetUserID() vo.ID
 }
+
+type FindResourceList interface {
+	GetUserID() vo.ID // user identifier (empty means all users)
+	Pagination
+}
@@ -0,0 +1,14 @@
+package queryinterface
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+type FindOneShareByID interface {
+	GetID() vo.ID
+	GetVideoID() vo.ID
+	GetUserID() vo.ID
+}
+
+type FindShareList interface {
+	GetVideoID() vo.ID
+	GetUserID() vo.ID
+}
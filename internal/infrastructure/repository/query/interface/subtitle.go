@@ -0,0 +1,12 @@
+package queryinterface
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+type FindOneSubtitleByID interface {
+	GetID() vo.ID
+	GetVideoID() vo.ID
+}
+
+type FindSubtitleList interface {
+	GetVideoID() vo.ID
+}
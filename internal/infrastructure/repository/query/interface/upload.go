@@ -0,0 +1,8 @@
+package queryinterface
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+type FindOneUploadByID interface {
+	GetID() vo.ID
+	GetUserID() vo.ID
+}
@@ -22,7 +22,7 @@ type FindOneVideoByResourceID interface {
 
 type FindVideoList interface {
 	GetName() string         // part of name
-	GetUserID() vo.ID        // user identifier
+	GetUserID() vo.ID        // user identifier (empty means all users)
 	GetCreatedAt() time.Time // concrete search date point
 	GetFrom() time.Time      // search date limit from
 	GetTo() time.Time        // search date limit to
@@ -0,0 +1,254 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	cacherinterface "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
+	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/helper"
+	queryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/mongodb"
+	mongodbinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/mongodb/interface"
+	"reflect"
+	"time"
+)
+
+var (
+	AudioNotFoundByIdError         = errtype.NewEntityNotFoundError("cache", "audio", "id")
+	AudioNotFoundByNameError       = errtype.NewEntityNotFoundError("cache", "audio", "name")
+	AudioNotFoundByResourceIdError = errtype.NewEntityNotFoundError("cache", "audio", "resource.id")
+)
+
+type AudioRepository struct {
+	mongodbinterface.Audio
+	logger loggerinterface.Logger
+	cache  cacherinterface.Cacher
+}
+
+func NewAudioRepository(serviceContainer diinterface.ServiceContainer) (*AudioRepository, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	audioMongoDbRepository, err := serviceContainer.GetAudioMongoRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cacheService, err := serviceContainer.GetCacheService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &AudioRepository{
+		cache:  cacheService,
+		logger: loggerService,
+		Audio:  audioMongoDbRepository,
+	}, nil
+}
+
+func (r *AudioRepository) FindOneByID(ctx context.Context, q queryinterface.FindOneAudioByID) (*agg.Audio, error) {
+	// attempt to fetch data from cache
+	if audio, err := r.findOneByID(ctx, q); err == nil {
+		return audio, nil
+	}
+	// fetch data from storage if an error occurred
+	return r.Audio.FindOneByID(ctx, q)
+}
+
+func (r *AudioRepository) findOneByID(ctx context.Context, q queryinterface.FindOneAudioByID) (*agg.Audio, error) {
+	p, err := json.Marshal(q)
+	if err != nil {
+		return nil, r.logger.LogPropagate(err)
+	}
+	cacheKey := helper.MD5(p)
+
+	// fetching data from cache/storage
+	audioInterface, err := r.cache.Get(
+		cacheKey,
+		func(item cacherinterface.CacheItem) (data interface{}, err error) {
+			item.SetTTL(time.Hour)
+
+			audioAgg, err := r.Audio.FindOneByID(ctx, q)
+			if err != nil {
+				if errors.Is(err, mongodb.AudioNotFoundByIdError) {
+					return nil, AudioNotFoundByIdError
+				}
+				return nil, r.logger.LogPropagate(err)
+			}
+			if !audioAgg.Resource.IsReady() {
+				item.SetTTL(processingResourceTTL)
+			}
+			return audioAgg, nil
+		})
+	if err != nil {
+		if errors.Is(err, mongodb.AudioNotFoundByIdError) {
+			return nil, AudioNotFoundByIdError
+		} else {
+			return nil, r.logger.LogPropagate(err)
+		}
+	}
+
+	// casting found data to struct
+	audioAgg, ok := audioInterface.(*agg.Audio)
+	if !ok {
+		return nil, errtype.NewCachedDataTypeWasNotMatchedError(
+			cacheKey, reflect.TypeOf(&agg.Audio{}), reflect.TypeOf(audioInterface),
+		)
+	}
+
+	return audioAgg, nil
+}
+
+func (r *AudioRepository) FindList(ctx context.Context, q queryinterface.FindAudioList) (list []*agg.Audio, total int64, err error) {
+	// attempt to fetch data from cache
+	if list, total, err = r.findList(ctx, q); err == nil {
+		return list, total, nil
+	}
+	// fetch data from storage if an error occurred
+	return r.Audio.FindList(ctx, q)
+}
+
+func (r *AudioRepository) findList(ctx context.Context, q queryinterface.FindAudioList) (list []*agg.Audio, total int64, err error) {
+	p, err := json.Marshal(q)
+	if err != nil {
+		return nil, 0, r.logger.LogPropagate(err)
+	}
+	cacheKey := helper.MD5(p)
+
+	type response struct {
+		List  []*agg.Audio
+		Total int64
+	}
+
+	responseInterface, err := r.cache.Get(
+		cacheKey,
+		func(item cacherinterface.CacheItem) (data interface{}, err error) {
+			item.SetTTL(time.Hour)
+
+			l, t, e := r.Audio.FindList(ctx, q)
+			if e != nil {
+				return nil, r.logger.LogPropagate(e)
+			}
+
+			return response{List: l, Total: t}, nil
+		},
+	)
+	if err != nil {
+		return nil, 0, r.logger.LogPropagate(err)
+	}
+
+	listResponse, ok := responseInterface.(response)
+	if !ok {
+		return nil, 0, errtype.NewCachedDataTypeWasNotMatchedError(
+			cacheKey, reflect.TypeOf(response{}), reflect.TypeOf(responseInterface),
+		)
+	}
+
+	return listResponse.List, listResponse.Total, nil
+}
+
+func (r *AudioRepository) FindOneByName(ctx context.Context, q queryinterface.FindOneAudioByName) (*agg.Audio, error) {
+	// attempt to fetch data from cache
+	if audio, err := r.findOneByName(ctx, q); err == nil {
+		return audio, nil
+	}
+	// fetch data from storage if an error occurred
+	return r.Audio.FindOneByName(ctx, q)
+}
+
+func (r *AudioRepository) findOneByName(ctx context.Context, q queryinterface.FindOneAudioByName) (*agg.Audio, error) {
+	p, err := json.Marshal(q)
+	if err != nil {
+		return nil, r.logger.LogPropagate(err)
+	}
+	cacheKey := helper.MD5(p)
+
+	audioInterface, err := r.cache.Get(cacheKey, func(item cacherinterface.CacheItem) (data interface{}, err error) {
+		item.SetTTL(time.Hour)
+
+		audioAgg, err := r.Audio.FindOneByName(ctx, q)
+		if err != nil {
+			if errors.Is(err, mongodb.AudioNotFoundByNameError) {
+				return nil, AudioNotFoundByNameError
+			}
+			return nil, r.logger.LogPropagate(err)
+		}
+
+		if !audioAgg.Resource.IsReady() {
+			item.SetTTL(processingResourceTTL)
+		}
+		return audioAgg, nil
+	})
+	if err != nil {
+		if errors.Is(err, AudioNotFoundByNameError) {
+			return nil, AudioNotFoundByNameError
+		} else {
+			return nil, r.logger.LogPropagate(err)
+		}
+	}
+
+	audioAgg, ok := audioInterface.(*agg.Audio)
+	if !ok {
+		return nil, errtype.NewCachedDataTypeWasNotMatchedError(
+			cacheKey, reflect.TypeOf(&agg.Audio{}), reflect.TypeOf(audioInterface),
+		)
+	}
+
+	return audioAgg, nil
+}
+
+func (r *AudioRepository) FindOneByResourceID(ctx context.Context, q queryinterface.FindOneAudioByResourceID) (*agg.Audio, error) {
+	// attempt to fetch data from cache
+	if audio, err := r.findOneByResourceID(ctx, q); err == nil {
+		return audio, nil
+	}
+	// fetch data from storage if an error occurred
+	return r.Audio.FindOneByResourceID(ctx, q)
+}
+
+func (r *AudioRepository) findOneByResourceID(ctx context.Context, q queryinterface.FindOneAudioByResourceID) (*agg.Audio, error) {
+	p, err := json.Marshal(q)
+	if err != nil {
+		return nil, r.logger.LogPropagate(err)
+	}
+	cacheKey := helper.MD5(p)
+
+	audioInterface, err := r.cache.Get(cacheKey, func(item cacherinterface.CacheItem) (data interface{}, err error) {
+		item.SetTTL(time.Hour)
+
+		audioAgg, err := r.Audio.FindOneByResourceID(ctx, q)
+		if err != nil {
+			if errors.Is(err, mongodb.AudioNotFoundByResourceIdError) {
+				return nil, AudioNotFoundByResourceIdError
+			}
+			return nil, r.logger.LogPropagate(err)
+		}
+
+		if !audioAgg.Resource.IsReady() {
+			item.SetTTL(processingResourceTTL)
+		}
+		return audioAgg, nil
+	})
+	if err != nil {
+		if errors.Is(err, AudioNotFoundByResourceIdError) {
+			return nil, AudioNotFoundByResourceIdError
+		} else {
+			return nil, r.logger.LogPropagate(err)
+		}
+	}
+
+	audioAgg, ok := audioInterface.(*agg.Audio)
+	if !ok {
+		return nil, errtype.NewCachedDataTypeWasNotMatchedError(
+			cacheKey, reflect.TypeOf(&agg.Audio{}), reflect.TypeOf(audioInterface),
+		)
+	}
+
+	return audioAgg, nil
+}
@@ -0,0 +1,316 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	mongodbinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/mongodb/interface"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// BlockedTokenRepository - keeps the not expired blocked tokens and sessions in memory, so the token verification
+// doesn't query the database. The set is updated by the change stream of the blocked tokens or by polling when
+// the change streams are not available. The set is limited by the capacity, the least recently checked entries
+// are evicted when it's full. Until the whole set is loaded or while some of the blocked entries are evicted,
+// the misses are confirmed by the database.
+type BlockedTokenRepository struct {
+	mongodbinterface.BlockedToken
+	ctx      context.Context
+	logger   loggerinterface.Logger
+	interval time.Duration
+	ttl      time.Duration // access token lifetime, the expiration of the legacy blocked tokens
+	mu       *sync.Mutex
+	blocked  *blockedSet
+	complete *atomic.Bool
+}
+
+func NewBlockedTokenRepository(serviceContainer diinterface.ServiceContainer) (*BlockedTokenRepository, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	blockedTokenMongoDbRepository, err := serviceContainer.GetBlockedTokenMongoRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	interval, err := time.ParseDuration(cfg.BlockedTokensPollInterval)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &BlockedTokenRepository{
+		BlockedToken: blockedTokenMongoDbRepository,
+		ctx:          ctx,
+		logger:       loggerService,
+		interval:     interval,
+		ttl:          time.Second * time.Duration(cfg.JwtTokenExpiresAfter),
+		mu:           &sync.Mutex{},
+		blocked:      newBlockedSet(cfg.BlockedTokensCacheSize),
+		complete:     &atomic.Bool{},
+	}, nil
+}
+
+func (r *BlockedTokenRepository) Insert(ctx context.Context, token *agg.BlockedToken) error {
+	if err := r.BlockedToken.Insert(ctx, token); err != nil {
+		return r.logger.LogPropagate(err)
+	}
+	// the token is blocked for the current instance immediately, the others will receive it by the stream or polling
+	r.add(token)
+	return nil
+}
+
+func (r *BlockedTokenRepository) Has(ctx context.Context, token string) (found bool, err error) {
+	if r.has(tokenKey(token)) {
+		return true, nil
+	}
+	if !r.complete.Load() {
+		return r.BlockedToken.Has(ctx, token)
+	}
+	return false, nil
+}
+
+func (r *BlockedTokenRepository) HasSession(ctx context.Context, session string) (found bool, err error) {
+	if r.has(sessionKey(session)) {
+		return true, nil
+	}
+	if !r.complete.Load() {
+		return r.BlockedToken.HasSession(ctx, session)
+	}
+	return false, nil
+}
+
+// Run - loads the blocked tokens and keeps them up to date until the app. context is done.
+func (r *BlockedTokenRepository) Run(wg *sync.WaitGroup) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		// the stream is opened before loading, so the tokens blocked while loading are not missed
+		events, err := r.BlockedToken.Watch(r.ctx)
+		if err != nil {
+			r.logger.Info("blocked tokens change stream is not available, polling is used")
+		}
+
+		// the first loading takes the whole set
+		lastSync := r.reload(time.Time{})
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.ctx.Done():
+				return
+			case token, ok := <-events:
+				if !ok {
+					// the stream was broken, the missed tokens will be loaded by polling
+					r.logger.Info("blocked tokens change stream was closed, polling is used")
+					events = nil
+					continue
+				}
+				r.add(token)
+			case <-ticker.C:
+				r.removeExpired()
+				if !r.complete.Load() && r.hasRoom() {
+					// the whole set is loaded again, since the evicted entries may fit after the expired were removed
+					lastSync = r.reload(lastSync)
+					continue
+				}
+				if events != nil {
+					// the stream is alive, so nothing is missed up to now
+					lastSync = time.Now()
+					continue
+				}
+				lastSync = r.poll(lastSync)
+			}
+		}
+	}()
+}
+
+// reload - loads the whole set, it's complete if nothing was evicted while loading.
+func (r *BlockedTokenRepository) reload(lastSync time.Time) time.Time {
+	r.mu.Lock()
+	evictions := r.blocked.evictions
+	r.mu.Unlock()
+
+	synced := r.poll(time.Time{})
+	if synced.IsZero() {
+		// the loading was failed
+		return lastSync
+	}
+
+	r.mu.Lock()
+	r.complete.Store(r.blocked.evictions == evictions)
+	r.mu.Unlock()
+
+	return synced
+}
+
+// poll - loads the tokens which were blocked since the last sync and returns the new sync time. The interval is
+// subtracted from the since time to cover a clock skew between instances.
+func (r *BlockedTokenRepository) poll(lastSync time.Time) time.Time {
+	since := lastSync
+	if !since.IsZero() {
+		since = since.Add(-r.interval)
+	}
+
+	now := time.Now()
+	tokens, err := r.BlockedToken.FindActive(r.ctx, since)
+	if err != nil {
+		r.logger.Log(err)
+		return lastSync
+	}
+
+	for _, token := range tokens {
+		r.add(token)
+	}
+
+	return now
+}
+
+func (r *BlockedTokenRepository) has(key string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	expiresAt, found := r.blocked.get(key)
+	return found && time.Now().Before(expiresAt)
+}
+
+func (r *BlockedTokenRepository) hasRoom() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.blocked.order.Len() < r.blocked.capacity
+}
+
+func (r *BlockedTokenRepository) add(token *agg.BlockedToken) {
+	if token == nil {
+		return
+	}
+
+	expiresAt := token.ExpiresAt
+	if expiresAt.IsZero() {
+		// the token was blocked by the instance which doesn't set the expiration, the token was issued before
+		// blocking, so it's expired after the access token lifetime since then
+		expiresAt = token.BlockedAt.Add(r.ttl)
+	}
+	if !time.Now().Before(expiresAt) {
+		return
+	}
+
+	key := tokenKey(token.Value)
+	if token.Session != "" {
+		key = sessionKey(token.Session)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.blocked.add(key, expiresAt) {
+		// the evicted entry is not known anymore, so the misses are confirmed by the database
+		r.complete.Store(false)
+	}
+}
+
+func (r *BlockedTokenRepository) removeExpired() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.blocked.removeExpired(time.Now())
+}
+
+// tokenKey - the tokens are kept by the hash, so the long tokens don't take the memory.
+func tokenKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return "t" + string(sum[:])
+}
+
+func sessionKey(session string) string {
+	return "s" + session
+}
+
+// blockedSet - is the LRU set of the blocked keys with their expirations, it's not safe for concurrent use.
+type blockedSet struct {
+	capacity  int
+	items     map[string]*list.Element
+	order     *list.List // the recently used entries are at the front
+	evictions uint64     // number of not expired entries which were evicted
+}
+
+type blockedEntry struct {
+	key       string
+	expiresAt time.Time
+}
+
+func newBlockedSet(capacity int) *blockedSet {
+	if capacity < 0 {
+		capacity = 0
+	}
+	return &blockedSet{
+		capacity: capacity,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (s *blockedSet) get(key string) (expiresAt time.Time, found bool) {
+	element, found := s.items[key]
+	if !found {
+		return time.Time{}, false
+	}
+	s.order.MoveToFront(element)
+	return element.Value.(*blockedEntry).expiresAt, true
+}
+
+// add - puts the entry and returns true if the not expired one was evicted to free the place.
+func (s *blockedSet) add(key string, expiresAt time.Time) (evicted bool) {
+	if element, found := s.items[key]; found {
+		entry := element.Value.(*blockedEntry)
+		if expiresAt.After(entry.expiresAt) {
+			entry.expiresAt = expiresAt
+		}
+		s.order.MoveToFront(element)
+		return false
+	}
+
+	s.items[key] = s.order.PushFront(&blockedEntry{key: key, expiresAt: expiresAt})
+
+	now := time.Now()
+	for s.order.Len() > s.capacity {
+		entry := s.order.Remove(s.order.Back()).(*blockedEntry)
+		delete(s.items, entry.key)
+		if now.Before(entry.expiresAt) {
+			s.evictions++
+			evicted = true
+		}
+	}
+	return evicted
+}
+
+func (s *blockedSet) removeExpired(now time.Time) {
+	for element := s.order.Front(); element != nil; {
+		next := element.Next()
+		if entry := element.Value.(*blockedEntry); !now.Before(entry.expiresAt) {
+			s.order.Remove(element)
+			delete(s.items, entry.key)
+		}
+		element = next
+	}
+}
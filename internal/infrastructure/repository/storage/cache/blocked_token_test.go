@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBlockedSet(t *testing.T) {
+	now := time.Now()
+	active, expired := now.Add(time.Hour), now.Add(-time.Second)
+
+	set := newBlockedSet(2)
+	if set.add("a", active) || set.add("b", active) {
+		t.Fatal("expected nothing is evicted until the set is full")
+	}
+
+	// the checked entry becomes the recently used one, so the other is evicted
+	if _, found := set.get("a"); !found {
+		t.Fatal("expected 'a' is found")
+	}
+	if !set.add("c", active) {
+		t.Fatal("expected the active entry is evicted")
+	}
+	if _, found := set.get("b"); found {
+		t.Error("expected the least recently used 'b' is evicted")
+	}
+	if _, found := set.get("a"); !found {
+		t.Error("expected the recently used 'a' is kept")
+	}
+
+	if set.evictions != 1 {
+		t.Errorf("expected 1 eviction of the active entry, got %d", set.evictions)
+	}
+
+	// the expired entry is evicted silently
+	set = newBlockedSet(2)
+	set.add("a", expired)
+	set.add("b", active)
+	if set.add("c", active) {
+		t.Error("expected the eviction of the expired entry is not reported")
+	}
+
+	set.removeExpired(now.Add(2 * time.Hour))
+	if set.order.Len() != 0 || len(set.items) != 0 {
+		t.Errorf("expected the expired entries are removed, got %d", set.order.Len())
+	}
+}
+
+func TestBlockedSet_ZeroCapacity(t *testing.T) {
+	set := newBlockedSet(0)
+	if !set.add("a", time.Now().Add(time.Hour)) {
+		t.Error("expected the entry is evicted at once")
+	}
+	if _, found := set.get("a"); found {
+		t.Error("expected nothing is kept")
+	}
+}
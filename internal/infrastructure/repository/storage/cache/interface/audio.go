@@ -0,0 +1,17 @@
+package cacheinterface
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	queryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+)
+
+type Audio interface {
+	FindOneByID(ctx context.Context, q queryinterface.FindOneAudioByID) (*agg.Audio, error)
+	FindOneByName(ctx context.Context, q queryinterface.FindOneAudioByName) (*agg.Audio, error)
+	FindOneByResourceID(ctx context.Context, q queryinterface.FindOneAudioByResourceID) (*agg.Audio, error)
+	FindList(ctx context.Context, q queryinterface.FindAudioList) (list []*agg.Audio, total int64, err error)
+	Insert(ctx context.Context, audio *agg.Audio) (*agg.Audio, error)
+	Update(ctx context.Context, audio *agg.Audio) (*agg.Audio, error)
+	Remove(ctx context.Context, audio *agg.Audio) error
+}
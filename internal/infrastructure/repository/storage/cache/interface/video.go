@@ -3,11 +3,13 @@ package cacheinterface
 import (
 	"context"
 	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
 	queryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
 )
 
 type Video interface {
 	FindOneByID(ctx context.Context, q queryinterface.FindOneVideoByID) (*agg.Video, error)
+	FindOneByIDOfAnyOwner(ctx context.Context, id vo.ID) (*agg.Video, error)
 	FindOneByName(ctx context.Context, q queryinterface.FindOneVideoByName) (*agg.Video, error)
 	FindOneByResourceID(ctx context.Context, q queryinterface.FindOneVideoByResourceID) (*agg.Video, error)
 	FindList(ctx context.Context, q queryinterface.FindVideoList) (list []*agg.Video, total int64, err error)
@@ -15,6 +15,10 @@ import (
 	"time"
 )
 
+// processingResourceTTL is a cache TTL of the resource which is not ready yet, because its status
+// is changed by the background job (and possibly by the other application instance)
+const processingResourceTTL = 5 * time.Second
+
 type ResourceRepository struct {
 	mongodbinterface.Resource
 	logger loggerinterface.Logger
@@ -67,6 +71,9 @@ func (r *ResourceRepository) findOneByID(ctx context.Context, q queryinterface.F
 		if err != nil {
 			return nil, r.logger.LogPropagate(err)
 		}
+		if !resourceAgg.IsReady() {
+			item.SetTTL(processingResourceTTL)
+		}
 
 		return resourceAgg, nil
 	})
@@ -81,6 +81,9 @@ func (r *VideoRepository) findOneByID(ctx context.Context, q queryinterface.Find
 				}
 				return nil, r.logger.LogPropagate(err)
 			}
+			if !videoAgg.Resource.IsReady() {
+				item.SetTTL(processingResourceTTL)
+			}
 			return videoAgg, nil
 		})
 	if err != nil {
@@ -177,6 +180,9 @@ func (r *VideoRepository) findOneByName(ctx context.Context, q queryinterface.Fi
 			return nil, r.logger.LogPropagate(err)
 		}
 
+		if !videoAgg.Resource.IsReady() {
+			item.SetTTL(processingResourceTTL)
+		}
 		return videoAgg, nil
 	})
 	if err != nil {
@@ -224,6 +230,9 @@ func (r *VideoRepository) findOneByResourceID(ctx context.Context, q queryinterf
 			return nil, r.logger.LogPropagate(err)
 		}
 
+		if !videoAgg.Resource.IsReady() {
+			item.SetTTL(processingResourceTTL)
+		}
 		return videoAgg, nil
 	})
 	if err != nil {
@@ -0,0 +1,247 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	queryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"sync"
+	"time"
+)
+
+const AudiosCollection = "audios"
+
+var (
+	AudioNotFoundByIdError         = errtype.NewEntityNotFoundError("mongo", "audio", "id")
+	AudioNotFoundByNameError       = errtype.NewEntityNotFoundError("mongo", "audio", "name")
+	AudioNotFoundByResourceIdError = errtype.NewEntityNotFoundError("mongo", "audio", "resource.id")
+	AudioInsertingFailedError      = errtype.NewInternalValidationError("unable to store 'audio' or get inserted 'id'")
+	AudioWasNotDeletedError        = errtype.NewInternalValidationError("audio was not deleted")
+)
+
+type AudioRepository struct {
+	db      *mongo.Collection
+	mu      *sync.Mutex
+	logger  loggerinterface.Logger
+	timeout time.Duration
+}
+
+func NewAudioRepository(serviceContainer diinterface.ServiceContainer) (*AudioRepository, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	mongodb, err := serviceContainer.GetMongoDatabase()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	timeout, err := time.ParseDuration(cfg.MongoTimeout)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &AudioRepository{
+		db:      mongodb.Collection(AudiosCollection),
+		logger:  loggerService,
+		mu:      &sync.Mutex{},
+		timeout: timeout,
+	}, nil
+}
+
+func (r *AudioRepository) FindOneByID(ctx context.Context, q queryinterface.FindOneAudioByID) (*agg.Audio, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"_id":      q.GetID().Value,
+		"user._id": q.GetUserID().Value,
+	}
+
+	audio := &agg.Audio{}
+	if err := r.db.FindOne(qCtx, filter).Decode(audio); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, r.logger.InfoPropagate(AudioNotFoundByIdError)
+		}
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	return audio, nil
+}
+
+func (r *AudioRepository) FindList(ctx context.Context, q queryinterface.FindAudioList) (list []*agg.Audio, total int64, err error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{"user._id": q.GetUserID().Value}
+
+	if q.GetName() != "" {
+		filter["name"] = primitive.Regex{Pattern: q.GetName(), Options: "i"}
+	}
+	if !q.GetCreatedAt().IsZero() {
+		y := q.GetCreatedAt().Year()
+		m := q.GetCreatedAt().Month()
+		d := q.GetCreatedAt().Day()
+
+		filter["createdAt"] = bson.M{
+			"$gt":  time.Date(y, m, d, 0, 0, 0, 0, time.UTC),
+			"$lte": time.Date(y, m, d, 23, 59, 59, 0, time.UTC),
+		}
+	} else if !q.GetFrom().IsZero() || !q.GetTo().IsZero() {
+		createdAtFilter := bson.M{}
+		if !q.GetFrom().IsZero() {
+			createdAtFilter["$gt"] = q.GetFrom()
+		}
+		if !q.GetTo().IsZero() {
+			createdAtFilter["$lte"] = q.GetTo()
+		}
+		filter["createdAt"] = createdAtFilter
+	}
+
+	opts := options.Find().
+		SetSkip((int64(q.GetPage()) - 1) * int64(q.GetLimit())).
+		SetLimit(int64(q.GetLimit()))
+
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+
+	list = []*agg.Audio{}
+	go func() {
+		defer wg.Done()
+
+		c, e := r.db.Find(qCtx, filter, opts)
+		if e != nil && e != mongo.ErrNoDocuments {
+			r.logger.Error(e)
+			return
+		}
+		defer func() { _ = c.Close(qCtx) }()
+
+		if e = c.All(qCtx, &list); e != nil {
+			r.logger.Error(e)
+		}
+	}()
+
+	total = 0
+	go func() {
+		defer wg.Done()
+
+		c, e := r.db.CountDocuments(qCtx, filter)
+		if e != nil {
+			r.logger.Error(e)
+			return
+		}
+
+		total = c
+	}()
+
+	wg.Wait()
+
+	return list, total, nil
+}
+
+func (r *AudioRepository) FindOneByName(ctx context.Context, q queryinterface.FindOneAudioByName) (*agg.Audio, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"name":     q.GetName(),
+		"user._id": q.GetUserID().Value,
+	}
+
+	audio := &agg.Audio{}
+	if err := r.db.FindOne(qCtx, filter).Decode(audio); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, AudioNotFoundByNameError
+		}
+		return nil, r.logger.LogPropagate(err)
+	}
+
+	return audio, nil
+}
+
+func (r *AudioRepository) FindOneByResourceID(ctx context.Context, q queryinterface.FindOneAudioByResourceID) (*agg.Audio, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"resource._id": q.GetResourceID().Value,
+		"user._id":     q.GetUserID().Value,
+	}
+
+	audio := &agg.Audio{}
+	if err := r.db.FindOne(qCtx, filter).Decode(audio); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, AudioNotFoundByResourceIdError
+		}
+		return nil, r.logger.LogPropagate(err)
+	}
+
+	return audio, nil
+}
+
+func (r *AudioRepository) Insert(ctx context.Context, audio *agg.Audio) (*agg.Audio, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.InsertOne(qCtx, audio, options.InsertOne())
+	if err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	if oid, ok := res.InsertedID.(primitive.ObjectID); ok {
+		q := dto.NewAudioGetRequestDTO(vo.ID{Value: oid}, "", vo.ID{}, audio.UserID)
+		return r.FindOneByID(qCtx, q)
+	}
+
+	return nil, r.logger.CriticalPropagate(AudioInsertingFailedError)
+}
+
+func (r *AudioRepository) Update(ctx context.Context, audio *agg.Audio) (*agg.Audio, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.UpdateByID(qCtx, audio.ID.Value, bson.M{"$set": audio})
+	if err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	// check the record is really updated
+	if res.ModifiedCount > 0 {
+		q := dto.NewAudioGetRequestDTO(audio.ID, "", vo.ID{}, audio.UserID)
+		return r.FindOneByID(qCtx, q)
+	}
+
+	// if changes is not exists, then return the original data
+	return audio, nil
+}
+
+func (r *AudioRepository) Remove(ctx context.Context, audio *agg.Audio) error {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.DeleteOne(qCtx, bson.M{"_id": audio.ID.Value})
+	if err != nil {
+		return r.logger.ErrorPropagate(err)
+	}
+
+	if res.DeletedCount == 0 { // checking the audio is really deleted
+		return r.logger.CriticalPropagate(AudioWasNotDeletedError)
+	}
+
+	return nil
+}
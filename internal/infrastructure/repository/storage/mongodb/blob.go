@@ -0,0 +1,123 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"sync"
+	"time"
+)
+
+const BlobsCollection = "blobs"
+
+var BlobNotFoundByFilenameError = errtype.NewEntityNotFoundError("mongo", "blob", "filename")
+
+type BlobRepository struct {
+	db      *mongo.Collection
+	mu      *sync.Mutex
+	logger  loggerinterface.Logger
+	timeout time.Duration
+}
+
+func NewBlobRepository(serviceContainer diinterface.ServiceContainer) (*BlobRepository, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	mongodb, err := serviceContainer.GetMongoDatabase()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	timeout, err := time.ParseDuration(cfg.MongoTimeout)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &BlobRepository{
+		db:      mongodb.Collection(BlobsCollection),
+		logger:  loggerService,
+		mu:      &sync.Mutex{},
+		timeout: timeout,
+	}, nil
+}
+
+// Retain - atomically increments the references of the blob, the missing blob is inserted with the single one.
+func (r *BlobRepository) Retain(ctx context.Context, userID vo.ID, filename string) (*agg.Blob, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{
+		"user._id": userID.Value,
+		"filename": filename,
+	}
+	update := bson.M{
+		"$inc":         bson.M{"references": 1},
+		"$set":         bson.M{"updatedAt": now},
+		"$setOnInsert": bson.M{"createdAt": now},
+	}
+	opts := options.FindOneAndUpdate().
+		SetUpsert(true).
+		SetReturnDocument(options.After)
+
+	blob := &agg.Blob{}
+	if err := r.db.FindOneAndUpdate(qCtx, filter, update, opts).Decode(blob); err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	return blob, nil
+}
+
+// Release - atomically decrements the references of the existing blob.
+func (r *BlobRepository) Release(ctx context.Context, userID vo.ID, filename string) (*agg.Blob, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"user._id": userID.Value,
+		"filename": filename,
+	}
+	update := bson.M{
+		"$inc": bson.M{"references": -1},
+		"$set": bson.M{"updatedAt": time.Now()},
+	}
+	opts := options.FindOneAndUpdate().
+		SetReturnDocument(options.After)
+
+	blob := &agg.Blob{}
+	if err := r.db.FindOneAndUpdate(qCtx, filter, update, opts).Decode(blob); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, r.logger.InfoPropagate(BlobNotFoundByFilenameError)
+		}
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	return blob, nil
+}
+
+// Remove - removes the blob if it was not retained again since it was released.
+func (r *BlobRepository) Remove(ctx context.Context, blob *agg.Blob) (removed bool, err error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.DeleteOne(qCtx, bson.M{"_id": blob.ID.Value, "references": bson.M{"$lte": 0}})
+	if err != nil {
+		return false, r.logger.ErrorPropagate(err)
+	}
+
+	return res.DeletedCount > 0, nil
+}
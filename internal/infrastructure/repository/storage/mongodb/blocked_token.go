@@ -22,6 +22,7 @@ type BlockedTokenRepository struct {
 	mu      *sync.Mutex
 	logger  loggerinterface.Logger
 	timeout time.Duration
+	ttl     time.Duration // access token lifetime
 }
 
 func NewBlockedTokenRepository(serviceContainer diinterface.ServiceContainer) (*BlockedTokenRepository, error) {
@@ -30,6 +31,11 @@ func NewBlockedTokenRepository(serviceContainer diinterface.ServiceContainer) (*
 		return nil, err
 	}
 
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	mongodb, err := serviceContainer.GetMongoDatabase()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
@@ -45,12 +51,64 @@ func NewBlockedTokenRepository(serviceContainer diinterface.ServiceContainer) (*
 		return nil, loggerService.LogPropagate(err)
 	}
 
-	return &BlockedTokenRepository{
+	r := &BlockedTokenRepository{
 		db:      mongodb.Collection(BlockedTokensCollection),
 		logger:  loggerService,
 		mu:      &sync.Mutex{},
 		timeout: timeout,
-	}, nil
+		ttl:     time.Second * time.Duration(cfg.JwtTokenExpiresAfter),
+	}
+
+	if err = r.createIndexes(ctx); err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	if err = r.backfillExpiration(ctx); err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return r, nil
+}
+
+// createIndexes - the TTL index removes the blocked token once the token itself is expired (the blocked session
+// once the last access token of it is expired).
+func (r *BlockedTokenRepository) createIndexes(ctx context.Context) error {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_, err := r.db.Indexes().CreateMany(qCtx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "value", Value: 1}}},
+		{Keys: bson.D{{Key: "session", Value: 1}}, Options: options.Index().SetSparse(true)},
+		{Keys: bson.D{{Key: "expiresAt", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	})
+	if err != nil {
+		return r.logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
+
+// backfillExpiration - sets the expiration of the tokens which were blocked before it was introduced, so they are
+// removed by the TTL index too. The token was issued before blocking, so it's expired after the access token
+// lifetime since then.
+func (r *BlockedTokenRepository) backfillExpiration(ctx context.Context) error {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{"expiresAt": bson.M{"$exists": false}}
+	update := mongo.Pipeline{
+		bson.D{{Key: "$set", Value: bson.M{"expiresAt": bson.M{"$add": bson.A{"$blockedAt", r.ttl.Milliseconds()}}}}},
+	}
+
+	res, err := r.db.UpdateMany(qCtx, filter, update)
+	if err != nil {
+		return r.logger.ErrorPropagate(err)
+	}
+	if res.ModifiedCount > 0 {
+		r.logger.Info(fmt.Sprintf("expiration of %d legacy blocked tokens was set", res.ModifiedCount))
+	}
+
+	return nil
 }
 
 func (r *BlockedTokenRepository) Insert(ctx context.Context, token *agg.BlockedToken) error {
@@ -88,3 +146,90 @@ func (r *BlockedTokenRepository) Has(ctx context.Context, token string) (found b
 
 	return true, nil
 }
+
+func (r *BlockedTokenRepository) HasSession(ctx context.Context, session string) (found bool, err error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{"session": session}
+
+	if err = r.db.FindOne(qCtx, filter).Decode(&agg.BlockedToken{}); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return false, nil
+		}
+		return false, r.logger.ErrorPropagate(err)
+	}
+
+	return true, nil
+}
+
+// FindActive - finds the not expired tokens and sessions which were blocked since the given time.
+func (r *BlockedTokenRepository) FindActive(ctx context.Context, since time.Time) ([]*agg.BlockedToken, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"blockedAt": bson.M{"$gte": since},
+		"$or": bson.A{
+			bson.M{"expiresAt": bson.M{"$gt": time.Now()}},
+			// blocked by the instance which doesn't set the expiration yet
+			bson.M{"expiresAt": bson.M{"$exists": false}},
+		},
+	}
+	opts := options.Find().SetProjection(bson.M{"value": 1, "session": 1, "blockedAt": 1, "expiresAt": 1})
+
+	c, err := r.db.Find(qCtx, filter, opts)
+	if err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+	defer func() { _ = c.Close(qCtx) }()
+
+	list := []*agg.BlockedToken{}
+	if err = c.All(qCtx, &list); err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	return list, nil
+}
+
+// Watch - opens the change stream of the blocked tokens, the inserted tokens are sent to the returned channel
+// which is closed when the context is done or the stream was broken. The change stream is available on the replica
+// set only, so the error is returned by the standalone server.
+func (r *BlockedTokenRepository) Watch(ctx context.Context) (<-chan *agg.BlockedToken, error) {
+	pipeline := mongo.Pipeline{bson.D{{Key: "$match", Value: bson.M{"operationType": "insert"}}}}
+
+	stream, err := r.db.Watch(ctx, pipeline)
+	if err != nil {
+		return nil, r.logger.LogPropagate(err)
+	}
+
+	tokens := make(chan *agg.BlockedToken)
+	go func() {
+		defer func() {
+			_ = stream.Close(context.Background())
+			close(tokens)
+		}()
+
+		for stream.Next(ctx) {
+			event := struct {
+				Token *agg.BlockedToken `bson:"fullDocument"`
+			}{}
+			if err := stream.Decode(&event); err != nil {
+				r.logger.Log(err)
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case tokens <- event.Token:
+			}
+		}
+
+		if err := stream.Err(); err != nil && ctx.Err() == nil {
+			r.logger.Log(err)
+		}
+	}()
+
+	return tokens, nil
+}
@@ -3,9 +3,13 @@ package mongodbinterface
 import (
 	"context"
 	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"time"
 )
 
 type BlockedToken interface {
 	Insert(ctx context.Context, token *agg.BlockedToken) error
 	Has(ctx context.Context, token string) (found bool, err error)
+	HasSession(ctx context.Context, session string) (found bool, err error)
+	FindActive(ctx context.Context, since time.Time) ([]*agg.BlockedToken, error)
+	Watch(ctx context.Context) (<-chan *agg.BlockedToken, error)
 }
@@ -3,11 +3,16 @@ package mongodbinterface
 import (
 	"context"
 	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
 	queryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
 )
 
 type Resource interface {
 	FindOneByID(context.Context, queryinterface.FindOneResourceByID) (*agg.Resource, error)
+	// FindOneByIDOfAnyOwner - finds the resource regardless of its owner, the access must be checked by the caller.
+	FindOneByIDOfAnyOwner(ctx context.Context, id vo.ID) (*agg.Resource, error)
+	FindList(ctx context.Context, q queryinterface.FindResourceList) (list []*agg.Resource, total int64, err error)
 	Insert(context.Context, *agg.Resource) (*agg.Resource, error)
+	Update(context.Context, *agg.Resource) (*agg.Resource, error)
 	Remove(context.Context, *agg.Resource) error
 }
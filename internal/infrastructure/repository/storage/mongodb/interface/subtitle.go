@@ -0,0 +1,20 @@
+package mongodbinterface
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	queryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+)
+
+type Subtitle interface {
+	FindOneByID(ctx context.Context, q queryinterface.FindOneSubtitleByID) (*agg.Subtitle, error)
+	FindList(ctx context.Context, q queryinterface.FindSubtitleList) ([]*agg.Subtitle, error)
+	Insert(ctx context.Context, subtitle *agg.Subtitle) (*agg.Subtitle, error)
+	Update(ctx context.Context, subtitle *agg.Subtitle) (*agg.Subtitle, error)
+	// ResetDefault - unsets the default flag of the other tracks of the video.
+	ResetDefault(ctx context.Context, subtitle *agg.Subtitle) error
+	Remove(ctx context.Context, subtitle *agg.Subtitle) error
+	// RemoveByVideoID - removes all tracks of the video, the files must be released by the caller.
+	RemoveByVideoID(ctx context.Context, videoID vo.ID) error
+}
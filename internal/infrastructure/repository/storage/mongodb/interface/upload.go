@@ -0,0 +1,17 @@
+package mongodbinterface
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	queryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+	"time"
+)
+
+type Upload interface {
+	FindOneByID(context.Context, queryinterface.FindOneUploadByID) (*agg.Upload, error)
+	// FindExpired - fetches the uploads which expiration time has come.
+	FindExpired(ctx context.Context, now time.Time) ([]*agg.Upload, error)
+	Insert(context.Context, *agg.Upload) (*agg.Upload, error)
+	Update(context.Context, *agg.Upload) (*agg.Upload, error)
+	Remove(context.Context, *agg.Upload) error
+}
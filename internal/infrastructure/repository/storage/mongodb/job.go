@@ -0,0 +1,191 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"sync"
+	"time"
+)
+
+const JobsCollection = "jobs"
+
+var (
+	JobNotFoundByIdError     = errtype.NewEntityNotFoundError("mongo", "job", "id")
+	JobNotFoundByStatusError = errtype.NewEntityNotFoundError("mongo", "job", "status")
+	JobInsertingFailedError  = errtype.NewInternalRepositoryError("unable to store 'job' or retrieve inserted 'id'")
+)
+
+// JobLeaseExpiredReason - is the error of the job which lease was expired on the last attempt.
+const JobLeaseExpiredReason = "the job lease was expired on the last attempt"
+
+type JobRepository struct {
+	db      *mongo.Collection
+	mu      *sync.Mutex
+	logger  loggerinterface.Logger
+	timeout time.Duration
+}
+
+func NewJobRepository(serviceContainer diinterface.ServiceContainer) (*JobRepository, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	mongodb, err := serviceContainer.GetMongoDatabase()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	timeout, err := time.ParseDuration(cfg.MongoTimeout)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &JobRepository{
+		db:      mongodb.Collection(JobsCollection),
+		logger:  loggerService,
+		mu:      &sync.Mutex{},
+		timeout: timeout,
+	}, nil
+}
+
+func (r *JobRepository) FindOneByID(ctx context.Context, id primitive.ObjectID) (*agg.Job, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	job := &agg.Job{}
+	if err := r.db.FindOne(qCtx, bson.M{"_id": id}).Decode(job); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, r.logger.InfoPropagate(JobNotFoundByIdError)
+		}
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	return job, nil
+}
+
+func (r *JobRepository) Insert(ctx context.Context, job *agg.Job) (*agg.Job, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.InsertOne(qCtx, job, options.InsertOne())
+	if err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	if oid, ok := res.InsertedID.(primitive.ObjectID); ok {
+		return r.FindOneByID(qCtx, oid)
+	}
+
+	return nil, r.logger.CriticalPropagate(JobInsertingFailedError)
+}
+
+// Lease - atomically takes the oldest pending job which run time has come or the processing one
+// which lease was expired (the worker was crashed or the application was stopped) and still has attempts.
+// The job is marked by a new lease token, so it's updated by the owner of the lease only.
+func (r *JobRepository) Lease(ctx context.Context, duration time.Duration, maxAttempts int) (*agg.Job, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{
+		"$or": bson.A{
+			bson.M{"status": entity.JobPending, "runAt": bson.M{"$lte": now}},
+			bson.M{
+				"status":     entity.JobProcessing,
+				"leasedTill": bson.M{"$lte": now},
+				"attempts":   bson.M{"$lt": maxAttempts},
+			},
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":     entity.JobProcessing,
+			"progress":   0,
+			"lease":      primitive.NewObjectID().Hex(),
+			"leasedTill": now.Add(duration),
+			"updatedAt":  now,
+		},
+		"$inc": bson.M{"attempts": 1},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "runAt", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	job := &agg.Job{}
+	if err := r.db.FindOneAndUpdate(qCtx, filter, update, opts).Decode(job); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, JobNotFoundByStatusError
+		}
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	return job, nil
+}
+
+// FailExpired - atomically marks as failed the processing job which lease was expired on the last attempt
+// (the job crashes the worker), so it's not retried anymore.
+func (r *JobRepository) FailExpired(ctx context.Context, maxAttempts int) (*agg.Job, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{
+		"status":     entity.JobProcessing,
+		"leasedTill": bson.M{"$lte": now},
+		"attempts":   bson.M{"$gte": maxAttempts},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":    entity.JobFailed,
+			"error":     JobLeaseExpiredReason,
+			"lease":     "",
+			"updatedAt": now,
+		},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "runAt", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	job := &agg.Job{}
+	if err := r.db.FindOneAndUpdate(qCtx, filter, update, opts).Decode(job); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, JobNotFoundByStatusError
+		}
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	return job, nil
+}
+
+// Update - saves the job if it's still leased by the same worker, the job which was taken by another worker
+// after the lease expiration is not overwritten.
+func (r *JobRepository) Update(ctx context.Context, job *agg.Job) (*agg.Job, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	job.Timestamp.UpdatedAt = time.Now()
+	res, err := r.db.UpdateOne(qCtx, bson.M{"_id": job.ID.Value, "lease": job.Lease}, bson.M{"$set": job})
+	if err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+	if res.MatchedCount == 0 {
+		return nil, r.logger.LogPropagate(errtype.NewJobLeaseWasLostError(job.ID.Value.Hex()))
+	}
+
+	return job, nil
+}
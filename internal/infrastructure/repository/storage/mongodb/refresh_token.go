@@ -0,0 +1,157 @@
+package mongodb
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"sync"
+	"time"
+)
+
+const RefreshTokensCollection = "refreshTokens"
+
+var (
+	RefreshTokenNotFoundByHashError  = errtype.NewEntityNotFoundError("mongo", "refresh token", "hash")
+	RefreshTokenInsertingFailedError = errtype.NewInternalValidationError("unable to store 'refresh token' or get inserted 'id'")
+)
+
+type RefreshTokenRepository struct {
+	db      *mongo.Collection
+	mu      *sync.Mutex
+	logger  loggerinterface.Logger
+	timeout time.Duration
+}
+
+func NewRefreshTokenRepository(serviceContainer diinterface.ServiceContainer) (*RefreshTokenRepository, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	mongodb, err := serviceContainer.GetMongoDatabase()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	timeout, err := time.ParseDuration(cfg.MongoTimeout)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &RefreshTokenRepository{
+		db:      mongodb.Collection(RefreshTokensCollection),
+		logger:  loggerService,
+		mu:      &sync.Mutex{},
+		timeout: timeout,
+	}, nil
+}
+
+func (r *RefreshTokenRepository) FindOneByHash(ctx context.Context, hash string) (*agg.RefreshToken, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	token := &agg.RefreshToken{}
+	if err := r.db.FindOne(qCtx, bson.M{"hash": hash}).Decode(token); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, r.logger.InfoPropagate(RefreshTokenNotFoundByHashError)
+		}
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	return token, nil
+}
+
+func (r *RefreshTokenRepository) Insert(ctx context.Context, token *agg.RefreshToken) (*agg.RefreshToken, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.InsertOne(qCtx, token, options.InsertOne())
+	if err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	if _, ok := res.InsertedID.(primitive.ObjectID); ok {
+		return r.FindOneByHash(qCtx, token.Hash)
+	}
+
+	return nil, r.logger.CriticalPropagate(RefreshTokenInsertingFailedError)
+}
+
+// MarkUsed - the token is marked only if it's not used yet, so the concurrent refreshes
+// by the same token are not able to pass both, the second one is treated as reuse.
+func (r *RefreshTokenRepository) MarkUsed(ctx context.Context, token *agg.RefreshToken) (marked bool, err error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"_id":    token.ID.Value,
+		"usedAt": bson.M{"$exists": false},
+	}
+	update := bson.M{"$set": bson.M{"usedAt": time.Now(), "updatedAt": time.Now()}}
+
+	res, err := r.db.UpdateOne(qCtx, filter, update)
+	if err != nil {
+		return false, r.logger.ErrorPropagate(err)
+	}
+
+	return res.ModifiedCount == 1, nil
+}
+
+func (r *RefreshTokenRepository) RevokeFamily(ctx context.Context, family string) error {
+	return r.revoke(ctx, bson.M{"family": family})
+}
+
+func (r *RefreshTokenRepository) RevokeByUserID(ctx context.Context, userID vo.ID) error {
+	return r.revoke(ctx, bson.M{"user._id": userID.Value})
+}
+
+func (r *RefreshTokenRepository) FindActiveFamilies(ctx context.Context, userID vo.ID) (families []string, err error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"user._id":  userID.Value,
+		"revokedAt": bson.M{"$exists": false},
+		"expiresAt": bson.M{"$gt": time.Now()},
+	}
+
+	values, err := r.db.Distinct(qCtx, "family", filter)
+	if err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	families = make([]string, 0, len(values))
+	for _, value := range values {
+		if family, ok := value.(string); ok {
+			families = append(families, family)
+		}
+	}
+
+	return families, nil
+}
+
+func (r *RefreshTokenRepository) revoke(ctx context.Context, filter bson.M) error {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter["revokedAt"] = bson.M{"$exists": false}
+	update := bson.M{"$set": bson.M{"revokedAt": time.Now(), "updatedAt": time.Now()}}
+
+	if _, err := r.db.UpdateMany(qCtx, filter, update); err != nil {
+		return r.logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
@@ -80,6 +80,53 @@ func (r *ResourceRepository) FindOneByID(ctx context.Context, q queryinterface.F
 	return resourceAgg, nil
 }
 
+func (r *ResourceRepository) FindOneByIDOfAnyOwner(ctx context.Context, id vo.ID) (*agg.Resource, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	resourceAgg := &agg.Resource{}
+	if err := r.db.FindOne(qCtx, bson.M{"_id": id.Value}).Decode(resourceAgg); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, r.logger.InfoPropagate(ResourceNotFoundByIdError)
+		}
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	return resourceAgg, nil
+}
+
+func (r *ResourceRepository) FindList(ctx context.Context, q queryinterface.FindResourceList) (list []*agg.Resource, total int64, err error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{}
+	if !q.GetUserID().Value.IsZero() {
+		filter["user._id"] = q.GetUserID().Value
+	}
+
+	opts := options.Find().
+		SetSort(bson.M{"createdAt": -1}).
+		SetSkip((int64(q.GetPage()) - 1) * int64(q.GetLimit())).
+		SetLimit(int64(q.GetLimit()))
+
+	c, err := r.db.Find(qCtx, filter, opts)
+	if err != nil {
+		return nil, 0, r.logger.ErrorPropagate(err)
+	}
+	defer func() { _ = c.Close(qCtx) }()
+
+	list = []*agg.Resource{}
+	if err = c.All(qCtx, &list); err != nil {
+		return nil, 0, r.logger.ErrorPropagate(err)
+	}
+
+	if total, err = r.db.CountDocuments(qCtx, filter); err != nil {
+		return nil, 0, r.logger.ErrorPropagate(err)
+	}
+
+	return list, total, nil
+}
+
 func (r *ResourceRepository) Insert(ctx context.Context, resource *agg.Resource) (*agg.Resource, error) {
 	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
@@ -97,6 +144,25 @@ func (r *ResourceRepository) Insert(ctx context.Context, resource *agg.Resource)
 	return nil, r.logger.CriticalPropagate(ResourceInsertingFailedError)
 }
 
+func (r *ResourceRepository) Update(ctx context.Context, resource *agg.Resource) (*agg.Resource, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.UpdateByID(qCtx, resource.ID.Value, bson.M{"$set": resource})
+	if err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	// check the record is really updated
+	if res.ModifiedCount > 0 {
+		q := dto.NewResourceGetRequestDTO(resource.ID, resource.UserID)
+		return r.FindOneByID(qCtx, q)
+	}
+
+	// if changes is not exists, then return the original data
+	return resource, nil
+}
+
 func (r *ResourceRepository) Remove(ctx context.Context, resource *agg.Resource) error {
 	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
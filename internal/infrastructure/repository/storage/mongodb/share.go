@@ -0,0 +1,166 @@
+package mongodb
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	queryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"sync"
+	"time"
+)
+
+const SharesCollection = "shares"
+
+var (
+	ShareNotFoundByIdError    = errtype.NewEntityNotFoundError("mongo", "share", "id")
+	ShareNotFoundByTokenError = errtype.NewEntityNotFoundError("mongo", "share", "token")
+	ShareInsertingFailedError = errtype.NewInternalValidationError("unable to store 'share' or get inserted 'id'")
+	ShareWasNotDeletedError   = errtype.NewInternalValidationError("share was not deleted")
+)
+
+type ShareRepository struct {
+	db      *mongo.Collection
+	mu      *sync.Mutex
+	logger  loggerinterface.Logger
+	timeout time.Duration
+}
+
+func NewShareRepository(serviceContainer diinterface.ServiceContainer) (*ShareRepository, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	mongodb, err := serviceContainer.GetMongoDatabase()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	timeout, err := time.ParseDuration(cfg.MongoTimeout)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &ShareRepository{
+		db:      mongodb.Collection(SharesCollection),
+		logger:  loggerService,
+		mu:      &sync.Mutex{},
+		timeout: timeout,
+	}, nil
+}
+
+func (r *ShareRepository) FindOneByID(ctx context.Context, q queryinterface.FindOneShareByID) (*agg.Share, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"_id":       q.GetID().Value,
+		"video._id": q.GetVideoID().Value,
+		"user._id":  q.GetUserID().Value,
+	}
+
+	share := &agg.Share{}
+	if err := r.db.FindOne(qCtx, filter).Decode(share); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, r.logger.InfoPropagate(ShareNotFoundByIdError)
+		}
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	return share, nil
+}
+
+func (r *ShareRepository) FindOneByToken(ctx context.Context, token string) (*agg.Share, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	share := &agg.Share{}
+	if err := r.db.FindOne(qCtx, bson.M{"token": token}).Decode(share); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, r.logger.InfoPropagate(ShareNotFoundByTokenError)
+		}
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	return share, nil
+}
+
+func (r *ShareRepository) FindList(ctx context.Context, q queryinterface.FindShareList) ([]*agg.Share, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"video._id": q.GetVideoID().Value,
+		"user._id":  q.GetUserID().Value,
+	}
+
+	c, err := r.db.Find(qCtx, filter, options.Find().SetSort(bson.M{"createdAt": -1}))
+	if err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+	defer func() { _ = c.Close(qCtx) }()
+
+	list := []*agg.Share{}
+	if err = c.All(qCtx, &list); err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	return list, nil
+}
+
+func (r *ShareRepository) Insert(ctx context.Context, share *agg.Share) (*agg.Share, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.InsertOne(qCtx, share, options.InsertOne())
+	if err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	if oid, ok := res.InsertedID.(primitive.ObjectID); ok {
+		q := dto.NewShareDeleteRequestDTO(vo.ID{Value: oid}, share.VideoID, share.UserID)
+		return r.FindOneByID(qCtx, q)
+	}
+
+	return nil, r.logger.CriticalPropagate(ShareInsertingFailedError)
+}
+
+func (r *ShareRepository) Remove(ctx context.Context, share *agg.Share) error {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.DeleteOne(qCtx, bson.M{"_id": share.ID.Value})
+	if err != nil {
+		return r.logger.ErrorPropagate(err)
+	}
+
+	if res.DeletedCount == 0 { // checking the share is really deleted
+		return r.logger.CriticalPropagate(ShareWasNotDeletedError)
+	}
+
+	return nil
+}
+
+func (r *ShareRepository) RemoveByVideoID(ctx context.Context, videoID vo.ID) error {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	if _, err := r.db.DeleteMany(qCtx, bson.M{"video._id": videoID.Value}); err != nil {
+		return r.logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package mongodb
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	queryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"sync"
+	"time"
+)
+
+const SubtitlesCollection = "subtitles"
+
+var (
+	SubtitleNotFoundByIdError    = errtype.NewEntityNotFoundError("mongo", "subtitle", "id")
+	SubtitleInsertingFailedError = errtype.NewInternalValidationError("unable to store 'subtitle' or get inserted 'id'")
+	SubtitleWasNotDeletedError   = errtype.NewInternalValidationError("subtitle was not deleted")
+)
+
+type SubtitleRepository struct {
+	db      *mongo.Collection
+	mu      *sync.Mutex
+	logger  loggerinterface.Logger
+	timeout time.Duration
+}
+
+func NewSubtitleRepository(serviceContainer diinterface.ServiceContainer) (*SubtitleRepository, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	mongodb, err := serviceContainer.GetMongoDatabase()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	timeout, err := time.ParseDuration(cfg.MongoTimeout)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &SubtitleRepository{
+		db:      mongodb.Collection(SubtitlesCollection),
+		logger:  loggerService,
+		mu:      &sync.Mutex{},
+		timeout: timeout,
+	}, nil
+}
+
+func (r *SubtitleRepository) FindOneByID(ctx context.Context, q queryinterface.FindOneSubtitleByID) (*agg.Subtitle, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"_id":       q.GetID().Value,
+		"video._id": q.GetVideoID().Value,
+	}
+
+	subtitle := &agg.Subtitle{}
+	if err := r.db.FindOne(qCtx, filter).Decode(subtitle); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, r.logger.InfoPropagate(SubtitleNotFoundByIdError)
+		}
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	return subtitle, nil
+}
+
+// FindList - finds the tracks of the video in the order of uploading.
+func (r *SubtitleRepository) FindList(ctx context.Context, q queryinterface.FindSubtitleList) ([]*agg.Subtitle, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{"video._id": q.GetVideoID().Value}
+
+	c, err := r.db.Find(qCtx, filter, options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+	defer func() { _ = c.Close(qCtx) }()
+
+	list := []*agg.Subtitle{}
+	if err = c.All(qCtx, &list); err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	return list, nil
+}
+
+func (r *SubtitleRepository) Insert(ctx context.Context, subtitle *agg.Subtitle) (*agg.Subtitle, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.InsertOne(qCtx, subtitle, options.InsertOne())
+	if err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	if oid, ok := res.InsertedID.(primitive.ObjectID); ok {
+		q := dto.NewSubtitleDeleteRequestDTO(vo.ID{Value: oid}, subtitle.VideoID, subtitle.UserID)
+		return r.FindOneByID(qCtx, q)
+	}
+
+	return nil, r.logger.CriticalPropagate(SubtitleInsertingFailedError)
+}
+
+func (r *SubtitleRepository) Update(ctx context.Context, subtitle *agg.Subtitle) (*agg.Subtitle, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.UpdateByID(qCtx, subtitle.ID.Value, bson.M{"$set": subtitle})
+	if err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	// check the record is really updated
+	if res.ModifiedCount > 0 {
+		q := dto.NewSubtitleDeleteRequestDTO(subtitle.ID, subtitle.VideoID, subtitle.UserID)
+		return r.FindOneByID(qCtx, q)
+	}
+
+	// if changes is not exists, then return the original data
+	return subtitle, nil
+}
+
+func (r *SubtitleRepository) ResetDefault(ctx context.Context, subtitle *agg.Subtitle) error {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"_id":       bson.M{"$ne": subtitle.ID.Value},
+		"video._id": subtitle.VideoID.Value,
+		"default":   true,
+	}
+
+	if _, err := r.db.UpdateMany(qCtx, filter, bson.M{"$set": bson.M{"default": false}}); err != nil {
+		return r.logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
+
+func (r *SubtitleRepository) Remove(ctx context.Context, subtitle *agg.Subtitle) error {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.DeleteOne(qCtx, bson.M{"_id": subtitle.ID.Value})
+	if err != nil {
+		return r.logger.ErrorPropagate(err)
+	}
+
+	if res.DeletedCount == 0 { // checking the subtitle is really deleted
+		return r.logger.CriticalPropagate(SubtitleWasNotDeletedError)
+	}
+
+	return nil
+}
+
+func (r *SubtitleRepository) RemoveByVideoID(ctx context.Context, videoID vo.ID) error {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	if _, err := r.db.DeleteMany(qCtx, bson.M{"video._id": videoID.Value}); err != nil {
+		return r.logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	queryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"sync"
+	"time"
+)
+
+const UploadsCollection = "uploads"
+
+var (
+	UploadNotFoundByIdError    = errtype.NewEntityNotFoundError("mongo", "upload", "id")
+	UploadInsertingFailedError = errtype.NewInternalRepositoryError("unable to store 'upload' or retrieve inserted 'id'")
+	UploadWasNotDeletedError   = errtype.NewInternalRepositoryError("upload was not deleted")
+)
+
+type UploadRepository struct {
+	db      *mongo.Collection
+	mu      *sync.Mutex
+	logger  loggerinterface.Logger
+	timeout time.Duration
+}
+
+func NewUploadRepository(serviceContainer diinterface.ServiceContainer) (*UploadRepository, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	mongodb, err := serviceContainer.GetMongoDatabase()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	timeout, err := time.ParseDuration(cfg.MongoTimeout)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &UploadRepository{
+		db:      mongodb.Collection(UploadsCollection),
+		logger:  loggerService,
+		mu:      &sync.Mutex{},
+		timeout: timeout,
+	}, nil
+}
+
+func (r *UploadRepository) FindOneByID(ctx context.Context, q queryinterface.FindOneUploadByID) (*agg.Upload, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"_id":      q.GetID().Value,
+		"user._id": q.GetUserID().Value,
+	}
+
+	upload := &agg.Upload{}
+	if err := r.db.FindOne(qCtx, filter).Decode(upload); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, r.logger.InfoPropagate(UploadNotFoundByIdError)
+		}
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	return upload, nil
+}
+
+// FindExpired - fetches the uploads of all users which expiration time has come.
+func (r *UploadRepository) FindExpired(ctx context.Context, now time.Time) ([]*agg.Upload, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	c, err := r.db.Find(qCtx, bson.M{"expiresAt": bson.M{"$lte": now}})
+	if err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+	defer func() { _ = c.Close(qCtx) }()
+
+	list := []*agg.Upload{}
+	if err = c.All(qCtx, &list); err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	return list, nil
+}
+
+func (r *UploadRepository) Insert(ctx context.Context, upload *agg.Upload) (*agg.Upload, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.InsertOne(qCtx, upload, options.InsertOne())
+	if err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	if oid, ok := res.InsertedID.(primitive.ObjectID); ok {
+		q := dto.NewUploadGetRequestDTO(vo.ID{Value: oid}, upload.UserID)
+		return r.FindOneByID(qCtx, q)
+	}
+
+	return nil, r.logger.CriticalPropagate(UploadInsertingFailedError)
+}
+
+func (r *UploadRepository) Update(ctx context.Context, upload *agg.Upload) (*agg.Upload, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	upload.Timestamp.UpdatedAt = time.Now()
+	if _, err := r.db.UpdateByID(qCtx, upload.ID.Value, bson.M{"$set": upload}); err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	return upload, nil
+}
+
+func (r *UploadRepository) Remove(ctx context.Context, upload *agg.Upload) error {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.DeleteOne(qCtx, bson.M{"_id": upload.ID.Value})
+	if err != nil {
+		return r.logger.ErrorPropagate(err)
+	}
+
+	if res.DeletedCount == 0 { // checking the upload was deleted
+		return r.logger.CriticalPropagate(UploadWasNotDeletedError)
+	}
+
+	return nil
+}
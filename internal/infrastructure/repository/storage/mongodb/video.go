@@ -84,12 +84,31 @@ func (r *VideoRepository) FindOneByID(ctx context.Context, q queryinterface.Find
 	return video, nil
 }
 
+func (r *VideoRepository) FindOneByIDOfAnyOwner(ctx context.Context, id vo.ID) (*agg.Video, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	video := &agg.Video{}
+	if err := r.db.FindOne(qCtx, bson.M{"_id": id.Value}).Decode(video); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, r.logger.InfoPropagate(VideoNotFoundByIdError)
+		}
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	return video, nil
+}
+
 func (r *VideoRepository) FindList(ctx context.Context, q queryinterface.FindVideoList) (list []*agg.Video, total int64, err error) {
 	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
-	filter := bson.M{"user._id": q.GetUserID().Value}
+	filter := bson.M{}
 
+	// the videos of all users are listed only by the moderation requests
+	if !q.GetUserID().Value.IsZero() {
+		filter["user._id"] = q.GetUserID().Value
+	}
 	if q.GetName() != "" {
 		filter["name"] = primitive.Regex{Pattern: q.GetName(), Options: "i"}
 	}
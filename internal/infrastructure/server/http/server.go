@@ -36,6 +36,7 @@ type Server struct {
 	renderAuthedControllers   []controller.Controller
 	renderUnauthedControllers []controller.Controller
 	staticControllers         []controller.Controller
+	wellKnownControllers      []controller.Controller
 
 	logger             loggerinterface.Logger
 	authService        authenticatorinterface.Authenticator
@@ -50,6 +51,7 @@ func NewHttpServer(
 	renderAuthedControllers []controller.Controller,
 	renderUnauthedControllers []controller.Controller,
 	staticControllers []controller.Controller,
+	wellKnownControllers []controller.Controller,
 ) (*Server, error) {
 	loggerService, err := serviceContainer.GetLoggerService()
 	if err != nil {
@@ -94,6 +96,7 @@ func NewHttpServer(
 		renderAuthedControllers:   renderAuthedControllers,
 		renderUnauthedControllers: renderUnauthedControllers,
 		staticControllers:         staticControllers,
+		wellKnownControllers:      wellKnownControllers,
 		logger:                    loggerService,
 		authService:               authService,
 		reqParamsExtractor:        requestParametersExtractorService,
@@ -144,6 +147,19 @@ func (s *Server) Listen(ctx context.Context, wg *sync.WaitGroup) {
 func (s *Server) addRoutes() *mux.Router {
 	router := mux.NewRouter()
 
+	// well-known controllers are served without a version prefix because their paths are defined by RFC 8615
+	wellKnownRouter := router.
+		NewRoute().
+		Subrouter()
+	wellKnownRouter.
+		Use(
+			s.requestsLoggingMiddleware,
+		)
+
+	for _, c := range s.wellKnownControllers {
+		c.AddRoute(wellKnownRouter)
+	}
+
 	// [AUTHED] rest api controllers which requires authorization token
 	restAuthedRouterV1 := router.
 		PathPrefix(s.apiVersionPrefix).
@@ -217,13 +233,14 @@ func (s *Server) addRoutes() *mux.Router {
 func (s *Server) restAuthorizationMiddleware(handler http.Handler) http.Handler {
 	return http.HandlerFunc(
 		func(w http.ResponseWriter, r *http.Request) {
-			userID, err := s.authService.IsAuthed(r)
+			userID, roles, err := s.authService.IsAuthed(r)
 			if err != nil {
 				s.responder.Respond(w, s.logger.LogPropagate(err))
 				return
 			}
-			// create a new context with userID value
+			// create a new context with userID and roles values
 			ctx := context.WithValue(r.Context(), enum.UserIDContextKey, userID)
+			ctx = context.WithValue(ctx, enum.UserRolesContextKey, roles)
 			// serve the next layer
 			handler.ServeHTTP(w, r.WithContext(ctx))
 		},
@@ -235,7 +252,7 @@ func (s *Server) restAuthorizationMiddleware(handler http.Handler) http.Handler
 func (s *Server) renderAuthorizationMiddleware(handler http.Handler) http.Handler {
 	return http.HandlerFunc(
 		func(w http.ResponseWriter, r *http.Request) {
-			userID, err := s.authService.IsAuthed(r)
+			userID, roles, err := s.authService.IsAuthed(r)
 			if err != nil {
 				// error logging
 				s.logger.Log(err)
@@ -245,8 +262,9 @@ func (s *Server) renderAuthorizationMiddleware(handler http.Handler) http.Handle
 				http.Redirect(w, r, render.LoginPath, http.StatusSeeOther)
 				return
 			}
-			// create a new context with userID value
+			// create a new context with userID and roles values
 			ctx := context.WithValue(r.Context(), enum.UserIDContextKey, userID)
+			ctx = context.WithValue(ctx, enum.UserRolesContextKey, roles)
 			// serve the next layer
 			handler.ServeHTTP(w, r.WithContext(ctx))
 		},
@@ -7,6 +7,7 @@ import (
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
 	streamerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/interface"
+	protoenum "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/enum"
 	"github.com/gorilla/websocket"
 	"net"
 	"net/http"
@@ -92,6 +93,9 @@ func (s *Server) Listen(ctx context.Context, wg *sync.WaitGroup) {
 // handleConnection is method which handle each websocket connection
 func (s *Server) handleConnection(w http.ResponseWriter, r *http.Request) {
 	upgrader := websocket.Upgrader{
+		// the protocol version is negotiated through the Sec-WebSocket-Protocol header,
+		// the connection without any requested subprotocol is served by the legacy v0
+		Subprotocols: protoenum.Subprotocols(),
 		CheckOrigin: func(r *http.Request) bool {
 			return true
 		},
@@ -109,7 +113,9 @@ func (s *Server) handleConnection(w http.ResponseWriter, r *http.Request) {
 		}
 	}()
 
-	s.logger.Info(fmt.Sprintf("[%v]: accpted a new connection", conn.RemoteAddr()))
+	s.logger.Info(
+		fmt.Sprintf("[%v]: accpted a new connection (protocol: '%v')", conn.RemoteAddr(), conn.Subprotocol()),
+	)
 
 	s.streamer.HandleConn(conn)
 }
@@ -0,0 +1,95 @@
+package detector
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	detectorinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/detector/interface"
+	"golang.org/x/text/language"
+)
+
+type ResourceAudioTracks struct {
+	logger    loggerinterface.Logger
+	mediaInfo detectorinterface.MediaInfo
+}
+
+func NewResourceAudioTracks(serviceContainer diinterface.ServiceContainer) (*ResourceAudioTracks, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	mediaInfoDetector, err := serviceContainer.GetMediaInfoDetectorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &ResourceAudioTracks{
+		logger:    loggerService,
+		mediaInfo: mediaInfoDetector,
+	}, nil
+}
+
+// Detect will describe the audio streams of target resource, the stored media info is used if the resource
+// has it, otherwise the file is probed
+func (d *ResourceAudioTracks) Detect(resource entity.Resource) ([]vo.AudioTrack, error) {
+	info := resource.GetMediaInfo()
+	if info == nil {
+		var err error
+		if info, err = d.mediaInfo.Detect(resource); err != nil {
+			return nil, d.logger.LogPropagate(err)
+		}
+	}
+
+	streams := info.StreamsOf(vo.AudioStreamType)
+	tracks := make([]vo.AudioTrack, 0, len(streams))
+	for i, stream := range streams {
+		tracks = append(tracks, vo.AudioTrack{
+			Index:    i,
+			Language: stream.Language,
+			Codec:    CodecString(stream),
+			Channels: stream.Channels,
+		})
+	}
+
+	return tracks, nil
+}
+
+// Select will pick the track by the index or by the language of the hint. The unknown index is an error,
+// while the language is a preference only, so the first track is selected if no track has it.
+func (d *ResourceAudioTracks) Select(tracks []vo.AudioTrack, hint vo.AudioHint) ([]vo.AudioTrack, error) {
+	if len(tracks) == 0 {
+		return tracks, nil
+	}
+
+	selected := 0
+	if hint.Index != nil {
+		if *hint.Index < 0 || *hint.Index >= len(tracks) {
+			return nil, d.logger.LogPropagate(errtype.NewAudioTrackNotFoundError(*hint.Index))
+		}
+		selected = *hint.Index
+	} else if hint.Language != "" {
+		for i, track := range tracks {
+			if isSameLanguage(track.Language, hint.Language) {
+				selected = i
+				break
+			}
+		}
+	}
+
+	marked := make([]vo.AudioTrack, len(tracks))
+	copy(marked, tracks)
+	marked[selected].Selected = true
+
+	return marked, nil
+}
+
+// isSameLanguage - compares the languages by their base, so the ISO 639-1 and both of ISO 639-2 codes
+// of the same language are matched (e.g. "de", "deu" and "ger").
+func isSameLanguage(a string, b string) bool {
+	baseA, confidenceA := language.Make(a).Base()
+	baseB, confidenceB := language.Make(b).Base()
+	return confidenceA == language.Exact && confidenceB == language.Exact && baseA == baseB
+}
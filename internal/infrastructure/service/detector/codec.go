@@ -1,17 +1,16 @@
 package detector
 
 import (
-	"context"
 	"github.com/Borislavv/video-streaming/internal/domain/entity"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
-	"gopkg.in/vansante/go-ffprobe.v2"
-	"os"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	detectorinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/detector/interface"
 )
 
 type ResourceCodecs struct {
-	ctx    context.Context
-	logger loggerinterface.Logger
+	logger    loggerinterface.Logger
+	mediaInfo detectorinterface.MediaInfo
 }
 
 func NewResourceCodecs(serviceContainer diinterface.ServiceContainer) (*ResourceCodecs, error) {
@@ -20,44 +19,44 @@ func NewResourceCodecs(serviceContainer diinterface.ServiceContainer) (*Resource
 		return nil, err
 	}
 
-	ctx, err := serviceContainer.GetCtx()
+	mediaInfoDetector, err := serviceContainer.GetMediaInfoDetectorService()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
 	}
 
 	return &ResourceCodecs{
-		ctx:    ctx,
-		logger: loggerService,
+		logger:    loggerService,
+		mediaInfo: mediaInfoDetector,
 	}, nil
 }
 
-// Detect will determine video and audio stream codecs of target resource
-func (d *ResourceCodecs) Detect(
-	resource entity.Resource,
-) (
-	audioCodec string,
-	videoCodec string,
-	e error,
-) {
-	file, err := os.Open(resource.GetFilepath())
-	if err != nil {
-		return "", "", d.logger.LogPropagate(err)
+// Detect will determine the media type with video and audio stream codecs of target resource, the stored media info
+// is used if the resource has it, otherwise the file is probed
+func (d *ResourceCodecs) Detect(resource entity.Resource) (vo.MediaType, error) {
+	info := resource.GetMediaInfo()
+	if info == nil {
+		var err error
+		if info, err = d.mediaInfo.Detect(resource); err != nil {
+			return vo.MediaType{}, d.logger.LogPropagate(err)
+		}
 	}
-	defer func() { _ = file.Close() }()
 
-	data, err := ffprobe.ProbeReader(d.ctx, file)
-	if err != nil {
-		return "", "", d.logger.LogPropagate(err)
-	}
+	return MediaTypeOf(info), nil
+}
 
-	audioCodec = ""
-	videoCodec = ""
-	if data.FirstAudioStream() != nil {
-		audioCodec = data.FirstAudioStream().CodecTagString
-	}
-	if data.FirstVideoStream() != nil {
-		videoCodec = data.FirstVideoStream().CodecTagString
+// DetectRendition will determine the media type of the rendition, the renditions are fragmented mp4 files which
+// store their codecs, the renditions which were produced before the RFC 6381 codecs were stored are probed
+func (d *ResourceCodecs) DetectRendition(rendition entity.Rendition) (vo.MediaType, error) {
+	if rendition.Resource.GetMediaInfo() == nil &&
+		(rendition.AudioCodec != "" || rendition.VideoCodec != "") &&
+		(rendition.AudioCodec == "" || IsCodecString(rendition.AudioCodec)) &&
+		(rendition.VideoCodec == "" || IsCodecString(rendition.VideoCodec)) {
+		mediaType := vo.MediaType{MimeType: "video/mp4", AudioCodec: rendition.AudioCodec, VideoCodec: rendition.VideoCodec}
+		if rendition.VideoCodec == "" {
+			return mediaType.AudioOnly(), nil
+		}
+		return mediaType, nil
 	}
 
-	return audioCodec, videoCodec, nil
+	return d.Detect(rendition.Resource)
 }
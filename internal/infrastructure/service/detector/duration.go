@@ -0,0 +1,63 @@
+package detector
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
+	"gopkg.in/vansante/go-ffprobe.v2"
+)
+
+type ResourceDuration struct {
+	ctx     context.Context
+	logger  loggerinterface.Logger
+	storage fileinterface.Storage
+}
+
+func NewResourceDuration(serviceContainer diinterface.ServiceContainer) (*ResourceDuration, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	storageService, err := serviceContainer.GetFileStorageService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &ResourceDuration{
+		ctx:     ctx,
+		logger:  loggerService,
+		storage: storageService,
+	}, nil
+}
+
+// Detect will determine the real media duration of target resource in seconds
+func (d *ResourceDuration) Detect(resource entity.Resource) (seconds float64, err error) {
+	if info := resource.GetMediaInfo(); info != nil && info.Duration > 0 {
+		return info.Duration, nil
+	}
+
+	file, err := d.storage.Open(resource.GetUserID(), resource.GetFilename())
+	if err != nil {
+		return 0, d.logger.LogPropagate(err)
+	}
+	defer func() { _ = file.Close() }()
+
+	data, err := ffprobe.ProbeReader(d.ctx, file)
+	if err != nil {
+		return 0, d.logger.LogPropagate(err)
+	}
+
+	if data.Format == nil {
+		return 0, nil
+	}
+
+	return data.Format.DurationSeconds, nil
+}
@@ -0,0 +1,13 @@
+package detectorinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+)
+
+type AudioTracks interface {
+	// Detect - returns the audio streams of the resource in the file order.
+	Detect(resource entity.Resource) ([]vo.AudioTrack, error)
+	// Select - returns the tracks with the one which fits the client hint marked as selected.
+	Select(tracks []vo.AudioTrack, hint vo.AudioHint) ([]vo.AudioTrack, error)
+}
@@ -1,7 +1,13 @@
 package detectorinterface
 
-import "github.com/Borislavv/video-streaming/internal/domain/entity"
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+)
 
 type Codecs interface {
-	Detect(resource entity.Resource) (audioCodec string, videoCodec string, err error)
+	// Detect - returns the container MIME type with the RFC 6381 codecs of the first audio and video streams.
+	Detect(resource entity.Resource) (vo.MediaType, error)
+	// DetectRendition - the same, but the codecs which are stored by the rendition are preferred.
+	DetectRendition(rendition entity.Rendition) (vo.MediaType, error)
 }
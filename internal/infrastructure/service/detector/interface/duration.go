@@ -0,0 +1,7 @@
+package detectorinterface
+
+import "github.com/Borislavv/video-streaming/internal/domain/entity"
+
+type Duration interface {
+	Detect(resource entity.Resource) (seconds float64, err error)
+}
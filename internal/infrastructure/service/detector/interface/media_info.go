@@ -0,0 +1,10 @@
+package detectorinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+)
+
+type MediaInfo interface {
+	Detect(resource entity.Resource) (*vo.MediaInfo, error)
+}
@@ -0,0 +1,220 @@
+package detector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// keyframesProbeInterval - the keyframe interval is measured by the beginning of the video only,
+// the packets are read without decoding, so it's cheap even for the long videos.
+const keyframesProbeInterval = "%+60"
+
+// probeData - is a part of the ffprobe json output, the go-ffprobe data has no side data (the rotation)
+// and no packets (the keyframes), so the ffprobe is called directly.
+type probeData struct {
+	Format *struct {
+		FormatName string `json:"format_name"`
+		Duration   string `json:"duration"`
+		BitRate    string `json:"bit_rate"`
+	} `json:"format"`
+	Streams []struct {
+		Index          int    `json:"index"`
+		CodecType      string `json:"codec_type"`
+		CodecName      string `json:"codec_name"`
+		CodecTagString string `json:"codec_tag_string"`
+		Profile        string `json:"profile"`
+		Level          int    `json:"level"`
+		BitRate        string `json:"bit_rate"`
+		Width          int    `json:"width"`
+		Height         int    `json:"height"`
+		AvgFrameRate   string `json:"avg_frame_rate"`
+		PixFmt         string `json:"pix_fmt"`
+		Channels       int    `json:"channels"`
+		SampleRate     string `json:"sample_rate"`
+		Tags           struct {
+			Language string `json:"language"`
+			Rotate   string `json:"rotate"`
+		} `json:"tags"`
+		SideDataList []struct {
+			Rotation int `json:"rotation"`
+		} `json:"side_data_list"`
+	} `json:"streams"`
+	Packets []struct {
+		PtsTime string `json:"pts_time"`
+		Flags   string `json:"flags"`
+	} `json:"packets"`
+}
+
+type ResourceMediaInfo struct {
+	ctx     context.Context
+	logger  loggerinterface.Logger
+	storage fileinterface.Storage
+}
+
+func NewResourceMediaInfo(serviceContainer diinterface.ServiceContainer) (*ResourceMediaInfo, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	storageService, err := serviceContainer.GetFileStorageService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &ResourceMediaInfo{
+		ctx:     ctx,
+		logger:  loggerService,
+		storage: storageService,
+	}, nil
+}
+
+// Detect will describe the container and each of streams of target resource
+func (d *ResourceMediaInfo) Detect(resource entity.Resource) (*vo.MediaInfo, error) {
+	// the local file is probed, because the non-fragmented mp4 cannot be read by the pipe (the index is at the end)
+	path, release, err := d.storage.Local(resource.GetUserID(), resource.GetFilename())
+	if err != nil {
+		return nil, d.logger.LogPropagate(err)
+	}
+	defer release()
+
+	data, err := d.probe("-show_format", "-show_streams", path)
+	if err != nil {
+		return nil, d.logger.LogPropagate(err)
+	}
+
+	info := &vo.MediaInfo{Streams: make([]vo.MediaStream, 0, len(data.Streams))}
+	if data.Format != nil {
+		info.Container = data.Format.FormatName
+		info.Duration, _ = strconv.ParseFloat(data.Format.Duration, 64)
+		info.Bitrate, _ = strconv.ParseInt(data.Format.BitRate, 10, 64)
+	}
+
+	isRotationFound := false
+	for _, s := range data.Streams {
+		stream := vo.MediaStream{
+			Index:    s.Index,
+			Type:     s.CodecType,
+			Codec:    s.CodecName,
+			CodecTag: s.CodecTagString,
+			Profile:  s.Profile,
+			Language: s.Tags.Language,
+		}
+		stream.Bitrate, _ = strconv.ParseInt(s.BitRate, 10, 64)
+
+		switch s.CodecType {
+		case vo.VideoStreamType:
+			stream.Level = s.Level
+			stream.Width = s.Width
+			stream.Height = s.Height
+			stream.FrameRate = frameRate(s.AvgFrameRate)
+			stream.PixelFormat = s.PixFmt
+
+			if !isRotationFound {
+				isRotationFound = true
+				// the display matrix is used by the modern ffprobe, the tag by the older one
+				if len(s.SideDataList) > 0 && s.SideDataList[0].Rotation != 0 {
+					info.Rotation = s.SideDataList[0].Rotation
+				} else if rotate, rerr := strconv.Atoi(s.Tags.Rotate); rerr == nil {
+					info.Rotation = rotate
+				}
+			}
+		case vo.AudioStreamType:
+			stream.Channels = s.Channels
+			stream.SampleRate, _ = strconv.Atoi(s.SampleRate)
+		}
+
+		info.Streams = append(info.Streams, stream)
+	}
+
+	if info.FirstVideo() != nil {
+		if info.KeyframeInterval, err = d.keyframeInterval(path); err != nil {
+			// the interval is informational, so the media info is stored without it
+			d.logger.Log(err)
+		}
+	}
+
+	return info, nil
+}
+
+// keyframeInterval - calculates the average distance in seconds between keyframes of the first video stream.
+func (d *ResourceMediaInfo) keyframeInterval(path string) (float64, error) {
+	data, err := d.probe(
+		"-select_streams", "v:0",
+		"-read_intervals", keyframesProbeInterval,
+		"-show_entries", "packet=pts_time,flags",
+		path,
+	)
+	if err != nil {
+		return 0, d.logger.LogPropagate(err)
+	}
+
+	var keyframes []float64
+	for _, packet := range data.Packets {
+		if !strings.HasPrefix(packet.Flags, "K") {
+			continue
+		}
+		if pts, perr := strconv.ParseFloat(packet.PtsTime, 64); perr == nil {
+			keyframes = append(keyframes, pts)
+		}
+	}
+	if len(keyframes) < 2 {
+		return 0, nil
+	}
+
+	return (keyframes[len(keyframes)-1] - keyframes[0]) / float64(len(keyframes)-1), nil
+}
+
+func (d *ResourceMediaInfo) probe(args ...string) (*probeData, error) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	cmd := exec.CommandContext(d.ctx, "ffprobe", append([]string{"-loglevel", "error", "-print_format", "json"}, args...)...)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		reason := strings.TrimSpace(stderr.String())
+		if reason == "" {
+			reason = err.Error()
+		}
+		return nil, d.logger.LogPropagate(fmt.Errorf("unable to probe '%v': %v", args[len(args)-1], reason))
+	}
+
+	data := &probeData{}
+	if err := json.Unmarshal(stdout.Bytes(), data); err != nil {
+		return nil, d.logger.LogPropagate(err)
+	}
+
+	return data, nil
+}
+
+// frameRate - parses the ffprobe rational value, e.g. "30000/1001".
+func frameRate(rational string) float64 {
+	num, den, found := strings.Cut(rational, "/")
+	n, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0
+	}
+	if !found {
+		return n
+	}
+	if dn, derr := strconv.ParseFloat(den, 64); derr == nil && dn != 0 {
+		return n / dn
+	}
+	return 0
+}
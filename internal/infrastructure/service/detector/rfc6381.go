@@ -0,0 +1,210 @@
+package detector
+
+import (
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"strconv"
+	"strings"
+)
+
+// h264Profiles - profile_idc and constraint flags of the H.264 profiles by the ffprobe names.
+var h264Profiles = map[string][2]int{
+	"Constrained Baseline":  {66, 0xE0},
+	"Baseline":              {66, 0x00},
+	"Main":                  {77, 0x00},
+	"Extended":              {88, 0x00},
+	"High":                  {100, 0x00},
+	"High 10":               {110, 0x00},
+	"High 10 Intra":         {110, 0x10},
+	"High 4:2:2":            {122, 0x00},
+	"High 4:2:2 Intra":      {122, 0x10},
+	"High 4:4:4 Predictive": {244, 0x00},
+	"High 4:4:4 Intra":      {244, 0x10},
+}
+
+// hevcProfiles - general_profile_idc and the compatibility flags (reversed, hex) of the HEVC profiles.
+var hevcProfiles = map[string][2]string{
+	"Main":               {"1", "6"},
+	"Main 10":            {"2", "4"},
+	"Main Still Picture": {"3", "8"},
+	"Rext":               {"4", "10"},
+}
+
+// aacObjectTypes - MPEG-4 audio object types of the AAC profiles by the ffprobe names.
+var aacObjectTypes = map[string]int{
+	"Main":     1,
+	"LC":       2,
+	"SSR":      3,
+	"LTP":      4,
+	"HE-AAC":   5,
+	"HE-AACv2": 29,
+	"LD":       23,
+	"ELD":      39,
+}
+
+// levelLimit - is the max. picture size (luma samples) of the codec level, it's used when the level
+// is not reported by ffprobe (VP9 and AV1 streams often have no level).
+type levelLimit struct {
+	level   int
+	samples int
+}
+
+var (
+	vp9Levels = []levelLimit{
+		{10, 36864}, {11, 73728}, {20, 122880}, {21, 245760}, {30, 552960},
+		{31, 983040}, {40, 2228224}, {50, 8912896}, {60, 35651584},
+	}
+	av1Levels = []levelLimit{
+		{0, 147456}, {1, 278784}, {4, 665856}, {5, 1065024}, {8, 2359296},
+		{12, 8912896}, {16, 35651584},
+	}
+)
+
+// CodecString will build the RFC 6381 codec of the stream, e.g. 'avc1.64001F' or 'mp4a.40.2'.
+// The codec which is unknown or which profile or level is unknown is represented by its bare tag, so a wrong
+// codec is never declared (the bare tag is not an RFC 6381 string, see IsCodecString).
+func CodecString(stream vo.MediaStream) string {
+	switch stream.Codec {
+	case "h264":
+		return h264CodecString(stream)
+	case "hevc":
+		return hevcCodecString(stream)
+	case "vp9":
+		return vp9CodecString(stream)
+	case "av1":
+		return av1CodecString(stream)
+	case "aac":
+		objectType, found := aacObjectTypes[stream.Profile]
+		if !found {
+			return "mp4a"
+		}
+		return fmt.Sprintf("mp4a.40.%d", objectType)
+	case "opus":
+		return "opus"
+	default:
+		return stream.CodecTag
+	}
+}
+
+// MimeType will determine the MIME type of the media container, the media without video is 'audio/*'.
+func MimeType(info *vo.MediaInfo) string {
+	container := "mp4"
+	if strings.Contains(info.Container, "webm") || strings.Contains(info.Container, "matroska") {
+		container = "webm"
+	}
+	if info.FirstVideo() == nil {
+		return "audio/" + container
+	}
+	return "video/" + container
+}
+
+// MediaTypeOf will build the media type of the first audio and video streams.
+func MediaTypeOf(info *vo.MediaInfo) vo.MediaType {
+	mediaType := vo.MediaType{MimeType: MimeType(info)}
+	if stream := info.FirstAudio(); stream != nil {
+		mediaType.AudioCodec = CodecString(*stream)
+	}
+	if stream := info.FirstVideo(); stream != nil {
+		mediaType.VideoCodec = CodecString(*stream)
+	}
+	return mediaType
+}
+
+// IsCodecString - checks the codec is an RFC 6381 string, not a bare tag (e.g. 'avc1' which was stored before).
+func IsCodecString(codec string) bool {
+	return codec == "opus" || strings.Contains(codec, ".")
+}
+
+// h264CodecString - 'avc1.PPCCLL': profile_idc, constraint flags and level_idc in hex.
+func h264CodecString(stream vo.MediaStream) string {
+	tag := stream.CodecTag
+	if tag != "avc1" && tag != "avc3" {
+		tag = "avc1"
+	}
+	profile, found := h264Profiles[stream.Profile]
+	if !found || stream.Level <= 0 {
+		return tag
+	}
+	return fmt.Sprintf("%v.%02X%02X%02X", tag, profile[0], profile[1], stream.Level)
+}
+
+// hevcCodecString - 'hvc1.P.C.TL.B0': profile_idc, compatibility flags, main tier with level_idc and constraints.
+func hevcCodecString(stream vo.MediaStream) string {
+	tag := stream.CodecTag
+	if tag != "hvc1" && tag != "hev1" {
+		tag = "hvc1"
+	}
+	profile, found := hevcProfiles[stream.Profile]
+	if !found || stream.Level <= 0 {
+		return tag
+	}
+	return fmt.Sprintf("%v.%v.%v.L%d.B0", tag, profile[0], profile[1], stream.Level)
+}
+
+// vp9CodecString - 'vp09.PP.LL.DD': profile, level and bit depth, the level is taken by the picture size
+// when it's not reported.
+func vp9CodecString(stream vo.MediaStream) string {
+	profile, err := strconv.Atoi(strings.TrimPrefix(stream.Profile, "Profile "))
+	if err != nil || profile < 0 || profile > 3 {
+		return "vp09"
+	}
+	level := stream.Level
+	if level <= 0 {
+		found := false
+		if level, found = levelBySize(vp9Levels, stream); !found {
+			return "vp09"
+		}
+	}
+	return fmt.Sprintf("vp09.%02d.%02d.%02d", profile, level, bitDepth(stream))
+}
+
+// av1CodecString - 'av01.P.LLT.DD': profile, seq_level_idx with main tier and bit depth, the level is taken
+// by the picture size when it's not reported.
+func av1CodecString(stream vo.MediaStream) string {
+	var profile int
+	switch stream.Profile {
+	case "Main":
+		profile = 0
+	case "High":
+		profile = 1
+	case "Professional":
+		profile = 2
+	default:
+		return "av01"
+	}
+	level := stream.Level
+	if level <= 0 || level > 31 {
+		found := false
+		if level, found = levelBySize(av1Levels, stream); !found {
+			return "av01"
+		}
+	}
+	return fmt.Sprintf("av01.%d.%02dM.%02d", profile, level, bitDepth(stream))
+}
+
+// levelBySize - returns the lowest level which supports the picture size of the stream, the level is not found
+// when the size is unknown or exceeds the highest level.
+func levelBySize(levels []levelLimit, stream vo.MediaStream) (level int, found bool) {
+	samples := stream.Width * stream.Height
+	if samples <= 0 {
+		return 0, false
+	}
+	for _, limit := range levels {
+		if samples <= limit.samples {
+			return limit.level, true
+		}
+	}
+	return 0, false
+}
+
+// bitDepth - takes the bit depth from the pixel format, e.g. 'yuv420p10le' is 10 bits.
+func bitDepth(stream vo.MediaStream) int {
+	switch {
+	case strings.Contains(stream.PixelFormat, "p10"):
+		return 10
+	case strings.Contains(stream.PixelFormat, "p12"):
+		return 12
+	default:
+		return 8
+	}
+}
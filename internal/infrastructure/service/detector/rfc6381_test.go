@@ -0,0 +1,104 @@
+package detector
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"testing"
+)
+
+func TestCodecString(t *testing.T) {
+	tests := []struct {
+		name     string
+		stream   vo.MediaStream // as reported by ffprobe
+		expected string
+	}{
+		{
+			name:     "h264 high",
+			stream:   vo.MediaStream{Codec: "h264", CodecTag: "avc1", Profile: "High", Level: 40},
+			expected: "avc1.640028",
+		},
+		{
+			name:     "h264 constrained baseline of mkv",
+			stream:   vo.MediaStream{Codec: "h264", CodecTag: "[0][0][0][0]", Profile: "Constrained Baseline", Level: 30},
+			expected: "avc1.42E01E",
+		},
+		{
+			name:     "hevc main 10",
+			stream:   vo.MediaStream{Codec: "hevc", CodecTag: "hvc1", Profile: "Main 10", Level: 120},
+			expected: "hvc1.2.4.L120.B0",
+		},
+		{
+			name: "vp9 profile 0 1080p",
+			stream: vo.MediaStream{
+				Codec: "vp9", CodecTag: "vp09", Profile: "Profile 0", Level: -99,
+				Width: 1920, Height: 1080, PixelFormat: "yuv420p",
+			},
+			expected: "vp09.00.40.08",
+		},
+		{
+			name: "av1 main 10-bit 1080p",
+			stream: vo.MediaStream{
+				Codec: "av1", CodecTag: "av01", Profile: "Main", Level: -99,
+				Width: 1920, Height: 1080, PixelFormat: "yuv420p10le",
+			},
+			expected: "av01.0.08M.10",
+		},
+		{
+			name:     "aac he",
+			stream:   vo.MediaStream{Codec: "aac", CodecTag: "mp4a", Profile: "HE-AAC"},
+			expected: "mp4a.40.5",
+		},
+		{
+			name:     "aac lc",
+			stream:   vo.MediaStream{Codec: "aac", CodecTag: "mp4a", Profile: "LC"},
+			expected: "mp4a.40.2",
+		},
+		{
+			name:     "opus",
+			stream:   vo.MediaStream{Codec: "opus", CodecTag: "Opus"},
+			expected: "opus",
+		},
+		// the unknown profiles and levels are not made up
+		{
+			name:     "h264 unknown profile",
+			stream:   vo.MediaStream{Codec: "h264", CodecTag: "avc1", Profile: "Multiview High", Level: 40},
+			expected: "avc1",
+		},
+		{
+			name:     "h264 unknown level",
+			stream:   vo.MediaStream{Codec: "h264", CodecTag: "avc3", Profile: "High", Level: -99},
+			expected: "avc3",
+		},
+		{
+			name:     "hevc unknown level",
+			stream:   vo.MediaStream{Codec: "hevc", CodecTag: "hev1", Profile: "Main", Level: -99},
+			expected: "hev1",
+		},
+		{
+			name:     "vp9 unknown size",
+			stream:   vo.MediaStream{Codec: "vp9", CodecTag: "vp09", Profile: "Profile 0", Level: -99},
+			expected: "vp09",
+		},
+		{
+			name:     "av1 unknown profile",
+			stream:   vo.MediaStream{Codec: "av1", CodecTag: "av01", Level: 8},
+			expected: "av01",
+		},
+		{
+			name:     "aac unknown profile",
+			stream:   vo.MediaStream{Codec: "aac", CodecTag: "mp4a", Profile: "unknown"},
+			expected: "mp4a",
+		},
+		{
+			name:     "unknown codec",
+			stream:   vo.MediaStream{Codec: "mpeg4", CodecTag: "mp4v"},
+			expected: "mp4v",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if codec := CodecString(test.stream); codec != test.expected {
+				t.Errorf("expected '%v', got '%v'", test.expected, codec)
+			}
+		})
+	}
+}
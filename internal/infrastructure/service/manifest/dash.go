@@ -0,0 +1,262 @@
+package manifest
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	cacherinterface "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/helper"
+	detectorinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/detector/interface"
+	manifestinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/manifest/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/manifest/model"
+	segmenterinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/segmenter/interface"
+	segmentermodel "github.com/Borislavv/video-streaming/internal/infrastructure/service/segmenter/model"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+const (
+	DASHContentType = "application/dash+xml"
+
+	DASHInitSegmentURI   = "init.mp4"
+	DASHSegmentURIFormat = "segment/$Number$.m4s"
+
+	dashXMLNS            = "urn:mpeg:dash:schema:mpd:2011"
+	dashProfile          = "urn:mpeg:dash:profile:isoff-live:2011"
+	dashTimescale        = 1000
+	dashManifestCacheKey = "dash_manifest_"
+	dashRoleScheme       = "urn:mpeg:dash:role:2011"
+	// dashTextBandwidth - the bandwidth of text track is not measured, it's negligible in comparison with the media
+	dashTextBandwidth = 256
+)
+
+type DASHGenerator struct {
+	logger    loggerinterface.Logger
+	cache     cacherinterface.Cacher
+	segmenter segmenterinterface.Segmenter
+	codecs    detectorinterface.Codecs
+	tracks    manifestinterface.TextTracks
+}
+
+func NewDASHGenerator(serviceContainer diinterface.ServiceContainer) (*DASHGenerator, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	cacheService, err := serviceContainer.GetCacheService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	segmenterService, err := serviceContainer.GetSegmenterService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	codecsDetector, err := serviceContainer.GetCodecsDetectorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	textTracksService, err := serviceContainer.GetTextTracksService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &DASHGenerator{
+		logger:    loggerService,
+		cache:     cacheService,
+		segmenter: segmenterService,
+		codecs:    codecsDetector,
+		tracks:    textTracksService,
+	}, nil
+}
+
+// MPD - will return a static media presentation description of the video resource.
+// The manifest is cached per resource, its renditions and subtitle tracks, so the files will not be probed
+// on each request.
+func (g *DASHGenerator) MPD(video *agg.Video) ([]byte, error) {
+	tracks, err := g.tracks.Tracks(video, "")
+	if err != nil {
+		return nil, g.logger.LogPropagate(err)
+	}
+
+	p, err := json.Marshal(struct {
+		Renditions []entity.Rendition
+		Tracks     []vo.TextTrack
+	}{video.GetRenditions(), tracks})
+	if err != nil {
+		return nil, g.logger.LogPropagate(err)
+	}
+	cacheKey := dashManifestCacheKey + video.Resource.ID.Value.Hex() + "_" + helper.MD5(p)
+
+	mpdInterface, err := g.cache.Get(cacheKey, func(item cacherinterface.CacheItem) (data interface{}, err error) {
+		item.SetTTL(time.Hour)
+
+		mpd, err := g.mpd(video, tracks)
+		if err != nil {
+			return nil, g.logger.LogPropagate(err)
+		}
+
+		return mpd, nil
+	})
+	if err != nil {
+		return nil, g.logger.LogPropagate(err)
+	}
+
+	mpd, ok := mpdInterface.([]byte)
+	if !ok {
+		return nil, errtype.NewCachedDataTypeWasNotMatchedError(
+			cacheKey, reflect.TypeOf([]byte{}), reflect.TypeOf(mpdInterface),
+		)
+	}
+
+	return mpd, nil
+}
+
+// mpd - builds the manifest. Each rendition is a single file with muxed audio and video tracks, so they are
+// described as one adaptation set with a content component per each of found codecs and a representation
+// per each of renditions. Each subtitle track is an adaptation set with the single WebVTT file.
+func (g *DASHGenerator) mpd(video *agg.Video, tracks []vo.TextTrack) ([]byte, error) {
+	var (
+		index         *segmentermodel.Index
+		adaptationSet model.AdaptationSet
+	)
+	for number, rendition := range video.GetRenditions() {
+		renditionIndex, err := g.segmenter.Index(rendition.Resource)
+		if err != nil {
+			return nil, g.logger.LogPropagate(err)
+		}
+
+		// the codecs differ by the renditions (e.g. the level of video codec depends on the resolution)
+		mediaType, err := g.codecs.DetectRendition(rendition)
+		if err != nil {
+			return nil, g.logger.LogPropagate(err)
+		}
+		audioCodec, videoCodec := mediaType.AudioCodec, mediaType.VideoCodec
+
+		// the adaptation set is described by the first rendition, the others have the same tracks
+		if index == nil {
+			index = renditionIndex
+			adaptationSet = g.adaptationSet(index, audioCodec, videoCodec)
+		}
+
+		timeline := model.SegmentTimeline{}
+		for _, segment := range renditionIndex.Segments {
+			t := int64(math.Round(segment.Start * dashTimescale))
+			d := int64(math.Round((segment.Start+segment.Duration)*dashTimescale)) - t
+			timeline.Segments = append(timeline.Segments, model.S{T: t, D: d})
+		}
+
+		codecs := videoCodec
+		if audioCodec != "" {
+			if codecs != "" {
+				codecs += ","
+			}
+			codecs += audioCodec
+		}
+
+		adaptationSet.Representations = append(adaptationSet.Representations, model.Representation{
+			ID:        rendition.Resource.ID.Value.Hex(),
+			Bandwidth: renditionIndex.PeakBandwidth(),
+			Width:     rendition.Width,
+			Height:    rendition.Height,
+			Codecs:    codecs,
+			SegmentTemplate: &model.SegmentTemplate{
+				Timescale:       dashTimescale,
+				Initialization:  renditionURI(video, DASHInitSegmentURI, number),
+				Media:           renditionURI(video, DASHSegmentURIFormat, number),
+				StartNumber:     0,
+				SegmentTimeline: timeline,
+			},
+		})
+	}
+
+	mpd := model.MPD{
+		XMLNS:                     dashXMLNS,
+		Profiles:                  dashProfile,
+		Type:                      "static",
+		MediaPresentationDuration: fmt.Sprintf("PT%.3fS", index.Duration),
+		MinBufferTime:             fmt.Sprintf("PT%dS", int(math.Ceil(index.MaxDuration()))),
+		Periods: []model.Period{
+			{
+				ID:             "0",
+				Start:          "PT0S",
+				AdaptationSets: append([]model.AdaptationSet{adaptationSet}, textAdaptationSets(tracks)...),
+			},
+		},
+	}
+
+	b, err := xml.MarshalIndent(mpd, "", "  ")
+	if err != nil {
+		return nil, g.logger.LogPropagate(err)
+	}
+
+	return append([]byte(xml.Header), b...), nil
+}
+
+// adaptationSet - describes the tracks which are muxed into the resource.
+func (g *DASHGenerator) adaptationSet(index *segmentermodel.Index, audioCodec, videoCodec string) model.AdaptationSet {
+	adaptationSet := model.AdaptationSet{
+		ID:               "0",
+		MimeType:         index.MediaType(),
+		SegmentAlignment: true,
+		StartWithSAP:     1,
+	}
+	if videoCodec != "" {
+		adaptationSet.ContentComponents = append(
+			adaptationSet.ContentComponents, model.ContentComponent{ID: "1", ContentType: "video"},
+		)
+	} else {
+		adaptationSet.MimeType = "audio/" + index.Container
+	}
+	if audioCodec != "" {
+		adaptationSet.ContentComponents = append(
+			adaptationSet.ContentComponents, model.ContentComponent{ID: "2", ContentType: "audio"},
+		)
+	}
+	return adaptationSet
+}
+
+// textAdaptationSets - describes the subtitle tracks, the kind of track is its role and the default one is main.
+func textAdaptationSets(tracks []vo.TextTrack) []model.AdaptationSet {
+	adaptationSets := make([]model.AdaptationSet, 0, len(tracks))
+	for number, track := range tracks {
+		adaptationSet := model.AdaptationSet{
+			ID:       strconv.Itoa(number + 1),
+			MimeType: WebVTTContentType,
+			Lang:     track.Language,
+			Roles:    []model.Role{{SchemeIDURI: dashRoleScheme, Value: dashRole(track.Kind)}},
+			Labels:   []string{track.Label},
+			Representations: []model.Representation{
+				{ID: track.ID, Bandwidth: dashTextBandwidth, BaseURL: track.URL},
+			},
+		}
+		if track.Default {
+			adaptationSet.Roles = append(adaptationSet.Roles, model.Role{SchemeIDURI: dashRoleScheme, Value: "main"})
+		}
+		adaptationSets = append(adaptationSets, adaptationSet)
+	}
+	return adaptationSets
+}
+
+// dashRole - the roles are named in singular, e.g. 'subtitle' for the 'subtitles' kind.
+func dashRole(kind string) string {
+	switch kind {
+	case enum.CaptionsKind:
+		return "caption"
+	case enum.DescriptionsKind:
+		return "description"
+	}
+	return "subtitle"
+}
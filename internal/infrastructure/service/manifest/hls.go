@@ -0,0 +1,208 @@
+package manifest
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	manifestinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/manifest/interface"
+	segmenterinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/segmenter/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/segmenter/model"
+	"math"
+	"strings"
+)
+
+const (
+	HLSContentType = "application/vnd.apple.mpegurl"
+	// hlsVersion 7 is required for fragmented mp4 segments (EXT-X-MAP into the VOD playlist)
+	hlsVersion = 7
+
+	HLSMediaPlaylistURI    = "media.m3u8"
+	HLSSubtitlePlaylistURI = "subtitle.m3u8"
+	HLSInitSegmentURI      = "init.mp4"
+	HLSSegmentURIFormat    = "segment/%d.m4s"
+
+	// SubtitleParam is a query parameter which selects the subtitle track of the subtitle playlist.
+	SubtitleParam = "subtitle"
+
+	hlsSubtitlesGroupID = "subs"
+)
+
+type HLSGenerator struct {
+	logger    loggerinterface.Logger
+	segmenter segmenterinterface.Segmenter
+	tracks    manifestinterface.TextTracks
+}
+
+func NewHLSGenerator(serviceContainer diinterface.ServiceContainer) (*HLSGenerator, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	segmenterService, err := serviceContainer.GetSegmenterService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	textTracksService, err := serviceContainer.GetTextTracksService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &HLSGenerator{
+		logger:    loggerService,
+		segmenter: segmenterService,
+		tracks:    textTracksService,
+	}, nil
+}
+
+// Master - will generate a master playlist of the video which refers to the media playlist of each rendition
+// and to the subtitle playlist of each subtitle track. URIs are relative, so the playlists must be served
+// from the same directory.
+func (g *HLSGenerator) Master(video *agg.Video) ([]byte, error) {
+	tracks, err := g.tracks.Tracks(video, "")
+	if err != nil {
+		return nil, g.logger.LogPropagate(err)
+	}
+
+	b := &bytes.Buffer{}
+	b.WriteString("#EXTM3U\n")
+	b.WriteString(fmt.Sprintf("#EXT-X-VERSION:%d\n", hlsVersion))
+	b.WriteString("#EXT-X-INDEPENDENT-SEGMENTS\n")
+	for _, track := range tracks {
+		b.WriteString(hlsSubtitleMedia(track) + "\n")
+	}
+	for number, rendition := range video.GetRenditions() {
+		index, err := g.index(rendition.Resource)
+		if err != nil {
+			return nil, g.logger.LogPropagate(err)
+		}
+
+		inf := fmt.Sprintf(
+			"#EXT-X-STREAM-INF:BANDWIDTH=%d,AVERAGE-BANDWIDTH=%d", index.PeakBandwidth(), index.Bandwidth(),
+		)
+		if rendition.Width > 0 && rendition.Height > 0 {
+			inf += fmt.Sprintf(",RESOLUTION=%dx%d", rendition.Width, rendition.Height)
+		}
+		if len(tracks) > 0 {
+			inf += fmt.Sprintf(",SUBTITLES=\"%s\"", hlsSubtitlesGroupID)
+		}
+		b.WriteString(inf + "\n")
+		b.WriteString(renditionURI(video, HLSMediaPlaylistURI, number) + "\n")
+	}
+
+	return b.Bytes(), nil
+}
+
+// Media - will generate a VOD media playlist with the segments list of the video rendition.
+func (g *HLSGenerator) Media(video *agg.Video, number int) ([]byte, error) {
+	rendition, ok := video.GetRendition(number)
+	if !ok {
+		return nil, g.logger.LogPropagate(errtype.NewRenditionNotFoundError(number))
+	}
+
+	index, err := g.index(rendition.Resource)
+	if err != nil {
+		return nil, g.logger.LogPropagate(err)
+	}
+
+	b := &bytes.Buffer{}
+	b.WriteString("#EXTM3U\n")
+	b.WriteString(fmt.Sprintf("#EXT-X-VERSION:%d\n", hlsVersion))
+	b.WriteString(fmt.Sprintf("#EXT-X-TARGETDURATION:%d\n", int(math.Ceil(index.MaxDuration()))))
+	b.WriteString("#EXT-X-MEDIA-SEQUENCE:0\n")
+	b.WriteString("#EXT-X-PLAYLIST-TYPE:VOD\n")
+	b.WriteString("#EXT-X-INDEPENDENT-SEGMENTS\n")
+	b.WriteString(fmt.Sprintf("#EXT-X-MAP:URI=\"%s\"\n", renditionURI(video, HLSInitSegmentURI, number)))
+	for i, segment := range index.Segments {
+		b.WriteString(fmt.Sprintf("#EXTINF:%.6f,\n", segment.Duration))
+		b.WriteString(renditionURI(video, fmt.Sprintf(HLSSegmentURIFormat, i), number) + "\n")
+	}
+	b.WriteString("#EXT-X-ENDLIST\n")
+
+	return b.Bytes(), nil
+}
+
+// Subtitle - will generate a VOD subtitle playlist of the track, the whole WebVTT file is its single segment.
+func (g *HLSGenerator) Subtitle(video *agg.Video, id string) ([]byte, error) {
+	tracks, err := g.tracks.Tracks(video, "")
+	if err != nil {
+		return nil, g.logger.LogPropagate(err)
+	}
+
+	var track *vo.TextTrack
+	for i := range tracks {
+		if tracks[i].ID == id {
+			track = &tracks[i]
+			break
+		}
+	}
+	if track == nil {
+		return nil, g.logger.LogPropagate(errtype.NewSubtitleNotFoundError(id))
+	}
+
+	// the segment lasts the whole video
+	rendition, _ := video.GetRendition(video.GetOriginalRendition())
+	index, err := g.index(rendition.Resource)
+	if err != nil {
+		return nil, g.logger.LogPropagate(err)
+	}
+
+	b := &bytes.Buffer{}
+	b.WriteString("#EXTM3U\n")
+	b.WriteString(fmt.Sprintf("#EXT-X-VERSION:%d\n", hlsVersion))
+	b.WriteString(fmt.Sprintf("#EXT-X-TARGETDURATION:%d\n", int(math.Ceil(index.Duration))))
+	b.WriteString("#EXT-X-MEDIA-SEQUENCE:0\n")
+	b.WriteString("#EXT-X-PLAYLIST-TYPE:VOD\n")
+	b.WriteString(fmt.Sprintf("#EXTINF:%.6f,\n", index.Duration))
+	b.WriteString(track.URL + "\n")
+	b.WriteString("#EXT-X-ENDLIST\n")
+
+	return b.Bytes(), nil
+}
+
+// index - returns the segments map of the resource, only fragmented mp4 is supported by the HLS.
+func (g *HLSGenerator) index(resource entity.Resource) (*model.Index, error) {
+	index, err := g.segmenter.Index(resource)
+	if err != nil {
+		return nil, g.logger.LogPropagate(err)
+	}
+	if index.Container != model.MP4Container {
+		return nil, g.logger.LogPropagate(
+			errtype.NewResourceIsNotSegmentableError(resource.GetName(), "hls supports only mp4 container"),
+		)
+	}
+	return index, nil
+}
+
+// hlsSubtitleMedia - describes the subtitle track as the rendition of subtitles group. The captions and descriptions
+// are marked by the accessibility characteristics, so the players are able to select them by the user's settings.
+func hlsSubtitleMedia(track vo.TextTrack) string {
+	media := fmt.Sprintf(
+		"#EXT-X-MEDIA:TYPE=SUBTITLES,GROUP-ID=\"%s\",NAME=\"%s\",LANGUAGE=\"%s\"",
+		hlsSubtitlesGroupID, hlsQuoted(track.Label), hlsQuoted(track.Language),
+	)
+	if track.Default {
+		media += ",DEFAULT=YES"
+	}
+	media += ",AUTOSELECT=YES"
+	switch track.Kind {
+	case enum.CaptionsKind:
+		media += ",CHARACTERISTICS=\"public.accessibility.transcribes-spoken-dialog," +
+			"public.accessibility.describes-music-and-sound\""
+	case enum.DescriptionsKind:
+		media += ",CHARACTERISTICS=\"public.accessibility.describes-video\""
+	}
+	return media + fmt.Sprintf(",URI=\"%s?%s=%s\"", HLSSubtitlePlaylistURI, SubtitleParam, track.ID)
+}
+
+// hlsQuoted - the quoted string attribute cannot contain the double quotes and line breaks.
+func hlsQuoted(value string) string {
+	return strings.NewReplacer("\"", "'", "\r", " ", "\n", " ").Replace(value)
+}
@@ -0,0 +1,7 @@
+package manifestinterface
+
+import "github.com/Borislavv/video-streaming/internal/domain/agg"
+
+type DASH interface {
+	MPD(video *agg.Video) ([]byte, error)
+}
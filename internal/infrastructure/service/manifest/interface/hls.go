@@ -0,0 +1,9 @@
+package manifestinterface
+
+import "github.com/Borislavv/video-streaming/internal/domain/agg"
+
+type HLS interface {
+	Master(video *agg.Video) ([]byte, error)
+	Media(video *agg.Video, rendition int) ([]byte, error)
+	Subtitle(video *agg.Video, id string) ([]byte, error)
+}
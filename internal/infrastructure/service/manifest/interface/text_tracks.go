@@ -0,0 +1,11 @@
+package manifestinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+)
+
+type TextTracks interface {
+	// Tracks - announces the subtitle tracks of the video, the share token is passed into the tracks URLs.
+	Tracks(video *agg.Video, shareToken string) ([]vo.TextTrack, error)
+}
@@ -0,0 +1,71 @@
+package model
+
+import "encoding/xml"
+
+// MPD is a root element of MPEG-DASH media presentation description (ISO/IEC 23009-1).
+type MPD struct {
+	XMLName                   xml.Name `xml:"MPD"`
+	XMLNS                     string   `xml:"xmlns,attr"`
+	Profiles                  string   `xml:"profiles,attr"`
+	Type                      string   `xml:"type,attr"`
+	MediaPresentationDuration string   `xml:"mediaPresentationDuration,attr"`
+	MinBufferTime             string   `xml:"minBufferTime,attr"`
+	Periods                   []Period `xml:"Period"`
+}
+
+type Period struct {
+	ID             string          `xml:"id,attr"`
+	Start          string          `xml:"start,attr"`
+	AdaptationSets []AdaptationSet `xml:"AdaptationSet"`
+}
+
+type AdaptationSet struct {
+	ID                string             `xml:"id,attr"`
+	MimeType          string             `xml:"mimeType,attr"`
+	Lang              string             `xml:"lang,attr,omitempty"`
+	SegmentAlignment  bool               `xml:"segmentAlignment,attr,omitempty"`
+	StartWithSAP      int                `xml:"startWithSAP,attr,omitempty"`
+	Roles             []Role             `xml:"Role"`
+	Labels            []string           `xml:"Label"`
+	ContentComponents []ContentComponent `xml:"ContentComponent"`
+	Representations   []Representation   `xml:"Representation"`
+}
+
+// Role - describes the purpose of the adaptation set, e.g. 'main', 'subtitle' or 'caption'.
+type Role struct {
+	SchemeIDURI string `xml:"schemeIdUri,attr"`
+	Value       string `xml:"value,attr"`
+}
+
+type ContentComponent struct {
+	ID          string `xml:"id,attr"`
+	ContentType string `xml:"contentType,attr"`
+}
+
+type Representation struct {
+	ID              string           `xml:"id,attr"`
+	Bandwidth       int64            `xml:"bandwidth,attr"`
+	Width           int              `xml:"width,attr,omitempty"`
+	Height          int              `xml:"height,attr,omitempty"`
+	Codecs          string           `xml:"codecs,attr,omitempty"`
+	BaseURL         string           `xml:"BaseURL,omitempty"` // the single file representation (the text tracks)
+	SegmentTemplate *SegmentTemplate `xml:"SegmentTemplate"`
+}
+
+type SegmentTemplate struct {
+	Timescale       int64           `xml:"timescale,attr"`
+	Initialization  string          `xml:"initialization,attr"`
+	Media           string          `xml:"media,attr"`
+	StartNumber     int             `xml:"startNumber,attr"`
+	SegmentTimeline SegmentTimeline `xml:"SegmentTimeline"`
+}
+
+type SegmentTimeline struct {
+	Segments []S `xml:"S"`
+}
+
+// S is a segment timeline entry: start time (t) and duration (d) in timescale units.
+type S struct {
+	T int64 `xml:"t,attr"`
+	D int64 `xml:"d,attr"`
+}
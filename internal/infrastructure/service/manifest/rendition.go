@@ -0,0 +1,40 @@
+package manifest
+
+import (
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"net/http"
+	"strconv"
+)
+
+// RenditionParam is a query parameter which selects the rendition by its number in the ladder.
+const RenditionParam = "rendition"
+
+// RequestedRendition - returns the rendition which was selected by the request query,
+// the original resource is served if the parameter is omitted.
+func RequestedRendition(video *agg.Video, r *http.Request) (number int, rendition entity.Rendition, err error) {
+	number = video.GetOriginalRendition()
+	if param := r.URL.Query().Get(RenditionParam); param != "" {
+		if number, err = strconv.Atoi(param); err != nil {
+			return 0, entity.Rendition{}, errtype.NewRenditionNotFoundError(number)
+		}
+	}
+
+	rendition, ok := video.GetRendition(number)
+	if !ok {
+		return 0, entity.Rendition{}, errtype.NewRenditionNotFoundError(number)
+	}
+
+	return number, rendition, nil
+}
+
+// renditionURI - appends the rendition number to the relative URI. The video without ladder
+// is addressed by plain URIs, as the original resource is served by default.
+func renditionURI(video *agg.Video, uri string, number int) string {
+	if len(video.GetRenditions()) <= 1 {
+		return uri
+	}
+	return fmt.Sprintf("%s?%s=%d", uri, RenditionParam, number)
+}
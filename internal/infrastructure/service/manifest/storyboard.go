@@ -0,0 +1,41 @@
+package manifest
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"math"
+)
+
+const WebVTTContentType = "text/vtt"
+
+// StoryboardVTT - builds the WebVTT track of the storyboard, each cue refers to its tile on the sprite sheet
+// by the media fragment, e.g. 'storyboard.jpg#xywh=160,0,160,90'. The duration limits the last cue.
+func StoryboardVTT(storyboard vo.Storyboard, duration float64, imageURI string) []byte {
+	if duration <= 0 {
+		duration = float64(storyboard.Tiles) * storyboard.Interval
+	}
+
+	b := &bytes.Buffer{}
+	b.WriteString("WEBVTT\n")
+	for number := 0; number < storyboard.Tiles; number++ {
+		start := float64(number) * storyboard.Interval
+		if start >= duration {
+			break
+		}
+		end := math.Min(start+storyboard.Interval, duration)
+		x, y := storyboard.Tile(number)
+
+		_, _ = fmt.Fprintf(b, "\n%s --> %s\n%s#xywh=%d,%d,%d,%d\n",
+			vttTimestamp(start), vttTimestamp(end), imageURI, x, y, storyboard.TileWidth, storyboard.TileHeight,
+		)
+	}
+
+	return b.Bytes()
+}
+
+// vttTimestamp - formats the seconds as 'hh:mm:ss.ttt'.
+func vttTimestamp(seconds float64) string {
+	ms := int64(math.Round(seconds * 1000))
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", ms/3_600_000, ms/60_000%60, ms/1000%60, ms%1000)
+}
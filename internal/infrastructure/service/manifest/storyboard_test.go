@@ -0,0 +1,227 @@
+package manifest
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/app"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/di/ditest"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/previewer"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/filetest"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"image/jpeg"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+const testSpriteURI = "storyboard.jpg"
+
+var cueRegexp = regexp.MustCompile(
+	`(\d{2}:\d{2}:\d{2}\.\d{3}) --> (\d{2}:\d{2}:\d{2}\.\d{3})\n` + regexp.QuoteMeta(testSpriteURI) +
+		`#xywh=(\d+),(\d+),(\d+),(\d+)\n`,
+)
+
+type cue struct {
+	start, end string
+	x, y, w, h int
+}
+
+// newTestPreview - generates the preview of the resource by the fake previewer with the 10 seconds interval
+// and the 160px tiles.
+func newTestPreview(t *testing.T, info *vo.MediaInfo) (*vo.Preview, *filetest.MemoryStorage, entity.Resource) {
+	t.Helper()
+
+	container, storage := ditest.NewStorageContainer(t, &app.Config{StoryboardInterval: 10, StoryboardTileWidth: 160})
+
+	fake, err := previewer.NewFakePreviewer(container)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource := entity.Resource{
+		ID:        vo.NewID(primitive.NewObjectID()),
+		UserID:    vo.NewID(primitive.NewObjectID()),
+		Filename:  "video.mp4",
+		MediaInfo: info,
+	}
+	preview, err := fake.Generate(resource)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return preview, storage, resource
+}
+
+func parseCues(t *testing.T, vtt []byte) []cue {
+	t.Helper()
+
+	if !bytes.HasPrefix(vtt, []byte("WEBVTT\n")) {
+		t.Fatalf("expected the WebVTT header, got %q", vtt)
+	}
+
+	matches := cueRegexp.FindAllSubmatch(vtt, -1)
+	cues := make([]cue, 0, len(matches))
+	for _, match := range matches {
+		numbers := make([]int, 4)
+		for i := range numbers {
+			numbers[i], _ = strconv.Atoi(string(match[3+i]))
+		}
+		cues = append(cues, cue{
+			start: string(match[1]), end: string(match[2]),
+			x: numbers[0], y: numbers[1], w: numbers[2], h: numbers[3],
+		})
+	}
+	if blocks := bytes.Count(vtt, []byte(" --> ")); blocks != len(cues) {
+		t.Fatalf("expected all %d cues are well-formed, parsed %d:\n%s", blocks, len(cues), vtt)
+	}
+	return cues
+}
+
+func videoInfo(width, height int, duration float64) *vo.MediaInfo {
+	return &vo.MediaInfo{
+		Duration: duration,
+		Streams:  []vo.MediaStream{{Type: vo.VideoStreamType, Codec: "h264", Width: width, Height: height}},
+	}
+}
+
+func TestStoryboardVTT_FakePreview(t *testing.T) {
+	tests := []struct {
+		name     string
+		info     *vo.MediaInfo
+		tiles    int
+		columns  int
+		duration float64
+		// expected - the cues by their numbers
+		expected map[int]cue
+	}{
+		{
+			name:     "the last cue is limited by the duration",
+			info:     videoInfo(1920, 1080, 25),
+			tiles:    3,
+			columns:  3,
+			duration: 25,
+			expected: map[int]cue{
+				0: {start: "00:00:00.000", end: "00:00:10.000", x: 0, y: 0, w: 160, h: 90},
+				1: {start: "00:00:10.000", end: "00:00:20.000", x: 160, y: 0, w: 160, h: 90},
+				2: {start: "00:00:20.000", end: "00:00:25.000", x: 320, y: 0, w: 160, h: 90},
+			},
+		},
+		{
+			name:     "the long video stretches the interval to fit the max. tiles into the rows",
+			info:     videoInfo(1920, 1080, 1250),
+			tiles:    100,
+			columns:  10,
+			duration: 1250,
+			expected: map[int]cue{
+				0:  {start: "00:00:00.000", end: "00:00:12.500", x: 0, y: 0, w: 160, h: 90},
+				9:  {start: "00:01:52.500", end: "00:02:05.000", x: 1440, y: 0, w: 160, h: 90},
+				10: {start: "00:02:05.000", end: "00:02:17.500", x: 0, y: 90, w: 160, h: 90},
+				47: {start: "00:09:47.500", end: "00:10:00.000", x: 1120, y: 360, w: 160, h: 90},
+				99: {start: "00:20:37.500", end: "00:20:50.000", x: 1440, y: 810, w: 160, h: 90},
+			},
+		},
+		{
+			name:     "the portrait tiles are scaled by the width",
+			info:     videoInfo(1080, 1920, 20),
+			tiles:    2,
+			columns:  2,
+			duration: 20,
+			expected: map[int]cue{
+				0: {start: "00:00:00.000", end: "00:00:10.000", x: 0, y: 0, w: 160, h: 284},
+				1: {start: "00:00:10.000", end: "00:00:20.000", x: 160, y: 0, w: 160, h: 284},
+			},
+		},
+		{
+			name:     "the resource without media info is described as the 1080p minute",
+			tiles:    6,
+			columns:  6,
+			duration: 60,
+			expected: map[int]cue{
+				0: {start: "00:00:00.000", end: "00:00:10.000", x: 0, y: 0, w: 160, h: 90},
+				5: {start: "00:00:50.000", end: "00:01:00.000", x: 800, y: 0, w: 160, h: 90},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			preview, storage, resource := newTestPreview(t, test.info)
+			storyboard := preview.Storyboard
+
+			if storyboard.Tiles != test.tiles || storyboard.Columns != test.columns {
+				t.Fatalf("expected %d tiles by %d columns, got %d by %d",
+					test.tiles, test.columns, storyboard.Tiles, storyboard.Columns)
+			}
+			if _, ok := storage.Get(resource.GetUserID(), preview.Poster); !ok {
+				t.Fatalf("expected the poster '%v' is stored", preview.Poster)
+			}
+
+			cues := parseCues(t, StoryboardVTT(storyboard, test.duration, testSpriteURI))
+			if len(cues) != test.tiles {
+				t.Fatalf("expected %d cues, got %d", test.tiles, len(cues))
+			}
+			for number, expected := range test.expected {
+				if cues[number] != expected {
+					t.Errorf("cue %d: expected %+v, got %+v", number, expected, cues[number])
+				}
+			}
+			// the cues follow each other without gaps
+			for number := 1; number < len(cues); number++ {
+				if cues[number].start != cues[number-1].end {
+					t.Errorf("cue %d: starts at %v, the previous one ends at %v",
+						number, cues[number].start, cues[number-1].end)
+				}
+			}
+
+			// the sprite sheet contains all tiles, and each cue points to its own one (the fake shades the tile
+			// by its number)
+			data, ok := storage.Get(resource.GetUserID(), storyboard.Filename)
+			if !ok {
+				t.Fatalf("expected the storyboard '%v' is stored", storyboard.Filename)
+			}
+			sprite, err := jpeg.Decode(bytes.NewReader(data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			size := sprite.Bounds().Size()
+			if size.X != storyboard.Columns*storyboard.TileWidth || size.Y != storyboard.Rows()*storyboard.TileHeight {
+				t.Fatalf("expected the %dx%d sprite, got %dx%d", storyboard.Columns*storyboard.TileWidth,
+					storyboard.Rows()*storyboard.TileHeight, size.X, size.Y)
+			}
+			for number, c := range cues {
+				if c.x+c.w > size.X || c.y+c.h > size.Y {
+					t.Fatalf("cue %d: the tile %+v is out of the sprite", number, c)
+				}
+				gray, _, _, _ := sprite.At(c.x+c.w/2, c.y+c.h/2).RGBA()
+				if shade, expected := int(gray>>8), number*255/storyboard.Tiles; abs(shade-expected) > 4 {
+					t.Errorf("cue %d: expected the tile of shade %d, got %d", number, expected, shade)
+				}
+			}
+		})
+	}
+}
+
+func TestVttTimestamp(t *testing.T) {
+	for seconds, expected := range map[float64]string{
+		0:       "00:00:00.000",
+		12.5:    "00:00:12.500",
+		59.9996: "00:01:00.000",
+		3725.25: "01:02:05.250",
+	} {
+		if got := vttTimestamp(seconds); got != expected {
+			t.Errorf("%v: expected '%v', got '%v'", seconds, expected, got)
+		}
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func (c cue) String() string {
+	return fmt.Sprintf("%v --> %v #xywh=%d,%d,%d,%d", c.start, c.end, c.x, c.y, c.w, c.h)
+}
@@ -0,0 +1,83 @@
+package manifest
+
+import (
+	"context"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"net/url"
+)
+
+// SubtitlePathFormat - is a path of the subtitle track content under the api prefix, it's served by the rest api.
+const SubtitlePathFormat = "/video/%s/subtitles/%s"
+
+// SubtitleTracks is a service which announces the subtitle tracks of the video for the players,
+// the same announcements are used by the start message of stream and by the manifests.
+type SubtitleTracks struct {
+	ctx        context.Context
+	logger     loggerinterface.Logger
+	repository repositoryinterface.Subtitle
+	apiPrefix  string
+}
+
+func NewSubtitleTracks(serviceContainer diinterface.ServiceContainer) (*SubtitleTracks, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	subtitleRepository, err := serviceContainer.GetSubtitleRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &SubtitleTracks{
+		ctx:        ctx,
+		logger:     loggerService,
+		repository: subtitleRepository,
+		apiPrefix:  cfg.ResourcesApiVersionPrefix,
+	}, nil
+}
+
+// Tracks - returns the announcements of the subtitle tracks in the order of uploading. The URLs are absolute paths
+// because the players resolve them against the manifests and the page, which are served by the different paths.
+func (t *SubtitleTracks) Tracks(video *agg.Video, shareToken string) ([]vo.TextTrack, error) {
+	subtitles, err := t.repository.FindList(t.ctx, dto.NewSubtitleListRequestDTO(video.ID, vo.ID{}, shareToken))
+	if err != nil {
+		return nil, t.logger.LogPropagate(err)
+	}
+
+	tracks := make([]vo.TextTrack, 0, len(subtitles))
+	for _, subtitle := range subtitles {
+		uri := t.apiPrefix + fmt.Sprintf(SubtitlePathFormat, video.ID.Value.Hex(), subtitle.ID.Value.Hex())
+		if shareToken != "" {
+			uri += "?" + url.Values{enum.ShareTokenQueryKey: {shareToken}}.Encode()
+		}
+
+		tracks = append(tracks, vo.TextTrack{
+			ID:       subtitle.ID.Value.Hex(),
+			Language: subtitle.Language,
+			Label:    subtitle.Label,
+			Kind:     subtitle.GetKind(),
+			Default:  subtitle.Default,
+			URL:      uri,
+		})
+	}
+
+	return tracks, nil
+}
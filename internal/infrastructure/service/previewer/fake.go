@@ -0,0 +1,106 @@
+package previewer
+
+import (
+	"bytes"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+)
+
+const FakePreviewerType = "fake"
+
+const (
+	fakeSourceWidth    = 1920
+	fakeSourceHeight   = 1080
+	fakeSourceDuration = 60
+)
+
+// FakePreviewer is a deterministic previewer which does not require the ffmpeg. The poster is a plain gray image
+// and each storyboard tile is filled by the shade of its number, so the tiles are distinguishable.
+type FakePreviewer struct {
+	logger    loggerinterface.Logger
+	storage   fileinterface.Storage
+	interval  float64
+	tileWidth int
+}
+
+func NewFakePreviewer(serviceContainer diinterface.ServiceContainer) (*FakePreviewer, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	storageService, err := serviceContainer.GetFileStorageService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &FakePreviewer{
+		logger:    loggerService,
+		storage:   storageService,
+		interval:  cfg.StoryboardInterval,
+		tileWidth: cfg.StoryboardTileWidth,
+	}, nil
+}
+
+// Generate - draws the preview of the resource, the resource without media info is described as the 1080p minute.
+func (p *FakePreviewer) Generate(resource entity.Resource) (*vo.Preview, error) {
+	width, height, duration, err := dimensions(resource)
+	if err != nil {
+		width, height, duration = fakeSourceWidth, fakeSourceHeight, fakeSourceDuration
+	}
+
+	storyboard := vo.NewStoryboard(
+		duration, p.interval, storyboardMaxTiles, storyboardColumns, p.tileWidth, tileHeight(p.tileWidth, width, height),
+	)
+
+	posterWidth := width
+	if posterWidth > posterMaxWidth {
+		posterWidth = posterMaxWidth
+	}
+	poster := image.NewGray(image.Rect(0, 0, posterWidth, tileHeight(posterWidth, width, height)))
+	draw.Draw(poster, poster.Bounds(), image.NewUniform(color.Gray{Y: 128}), image.Point{}, draw.Src)
+
+	sprite := image.NewGray(image.Rect(0, 0, storyboard.Columns*storyboard.TileWidth, storyboard.Rows()*storyboard.TileHeight))
+	for number := 0; number < storyboard.Tiles; number++ {
+		x, y := storyboard.Tile(number)
+		shade := image.NewUniform(color.Gray{Y: uint8(number * 255 / storyboard.Tiles)})
+		draw.Draw(sprite, image.Rect(x, y, x+storyboard.TileWidth, y+storyboard.TileHeight), shade, image.Point{}, draw.Src)
+	}
+
+	preview := &vo.Preview{}
+	if preview.Poster, err = p.store(resource, posterFilename(resource), poster); err != nil {
+		return nil, p.logger.LogPropagate(err)
+	}
+	if storyboard.Filename, err = p.store(resource, storyboardFilename(resource), sprite); err != nil {
+		_ = p.storage.Remove(resource.GetUserID(), preview.Poster)
+		return nil, p.logger.LogPropagate(err)
+	}
+	preview.Storyboard = storyboard
+
+	return preview, nil
+}
+
+func (p *FakePreviewer) store(resource entity.Resource, filename string, img image.Image) (string, error) {
+	b := &bytes.Buffer{}
+	if err := jpeg.Encode(b, img, nil); err != nil {
+		return "", p.logger.LogPropagate(err)
+	}
+
+	if _, _, err := p.storage.Store(resource.GetUserID(), filename, b); err != nil {
+		return "", p.logger.LogPropagate(err)
+	}
+
+	return filename, nil
+}
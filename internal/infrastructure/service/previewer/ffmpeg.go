@@ -0,0 +1,192 @@
+package previewer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const FFmpegPreviewerType = "ffmpeg"
+
+const (
+	// posterPosition - the poster frame is taken at the part of the duration, the first frames are often black.
+	posterPosition     = 0.1
+	posterMaxWidth     = 1280
+	storyboardMaxTiles = 100
+	storyboardColumns  = 10
+)
+
+// FFmpegPreviewer is a service which takes the preview frames of the video resource by the ffmpeg binary.
+type FFmpegPreviewer struct {
+	ctx       context.Context
+	logger    loggerinterface.Logger
+	storage   fileinterface.Storage
+	interval  float64
+	tileWidth int
+}
+
+func NewFFmpegPreviewer(serviceContainer diinterface.ServiceContainer) (*FFmpegPreviewer, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	storageService, err := serviceContainer.GetFileStorageService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &FFmpegPreviewer{
+		ctx:       ctx,
+		logger:    loggerService,
+		storage:   storageService,
+		interval:  cfg.StoryboardInterval,
+		tileWidth: cfg.StoryboardTileWidth,
+	}, nil
+}
+
+// Generate - takes the poster frame and tiles the storyboard frames into a single jpeg sprite sheet.
+func (p *FFmpegPreviewer) Generate(resource entity.Resource) (*vo.Preview, error) {
+	width, height, duration, err := dimensions(resource)
+	if err != nil {
+		return nil, p.logger.LogPropagate(err)
+	}
+
+	source, release, err := p.storage.Local(resource.GetUserID(), resource.GetFilename())
+	if err != nil {
+		return nil, p.logger.LogPropagate(err)
+	}
+	defer release()
+
+	storyboard := vo.NewStoryboard(
+		duration, p.interval, storyboardMaxTiles, storyboardColumns, p.tileWidth, tileHeight(p.tileWidth, width, height),
+	)
+
+	preview := &vo.Preview{}
+	if preview.Poster, err = p.render(resource, posterFilename(resource),
+		"-ss", strconv.FormatFloat(duration*posterPosition, 'f', 3, 64),
+		"-i", source,
+		"-frames:v", "1",
+		"-vf", fmt.Sprintf("scale='min(%d,iw)':-2", posterMaxWidth),
+		"-q:v", "3",
+	); err != nil {
+		return nil, p.logger.LogPropagate(err)
+	}
+
+	if storyboard.Filename, err = p.render(resource, storyboardFilename(resource),
+		"-i", source,
+		"-frames:v", "1",
+		"-vf", fmt.Sprintf(
+			"fps=%v,scale=%d:%d,tile=%dx%d",
+			strconv.FormatFloat(1/storyboard.Interval, 'f', 6, 64),
+			storyboard.TileWidth, storyboard.TileHeight,
+			storyboard.Columns, storyboard.Rows(),
+		),
+		"-q:v", "5",
+	); err != nil {
+		_ = p.storage.Remove(resource.GetUserID(), preview.Poster)
+		return nil, p.logger.LogPropagate(err)
+	}
+	preview.Storyboard = storyboard
+
+	return preview, nil
+}
+
+// render - runs the ffmpeg which writes a single jpeg image and stores it under the given name.
+func (p *FFmpegPreviewer) render(resource entity.Resource, filename string, args ...string) (string, error) {
+	tmp, err := os.CreateTemp("", "preview-*.jpg")
+	if err != nil {
+		return "", p.logger.LogPropagate(err)
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	stderr := &bytes.Buffer{}
+	cmd := exec.CommandContext(p.ctx, "ffmpeg",
+		append(append([]string{"-y", "-loglevel", "error"}, args...), "-f", "image2", tmp.Name())...,
+	)
+	cmd.Stderr = stderr
+	if err = cmd.Run(); err != nil {
+		return "", p.logger.LogPropagate(errtype.NewTranscodingFailedError(resource.GetName(), reason(stderr, err)))
+	}
+
+	if _, _, err = p.storage.Store(resource.GetUserID(), filename, tmp); err != nil {
+		return "", p.logger.LogPropagate(err)
+	}
+
+	return filename, nil
+}
+
+// dimensions - takes the displayed size of the first video stream (the ffmpeg applies the rotation) and the duration.
+func dimensions(resource entity.Resource) (width, height int, duration float64, err error) {
+	info := resource.GetMediaInfo()
+	if info == nil || info.FirstVideo() == nil {
+		return 0, 0, 0, fmt.Errorf("resource '%v' has no video stream to preview", resource.GetName())
+	}
+
+	stream := info.FirstVideo()
+	width, height = stream.Width, stream.Height
+	if info.Rotation%180 != 0 {
+		width, height = height, width
+	}
+
+	return width, height, info.Duration, nil
+}
+
+// tileHeight - scales the height proportionally to the tile width and rounds it to the even number.
+func tileHeight(tileWidth, width, height int) int {
+	if width <= 0 || height <= 0 {
+		// 16:9 is assumed when the size is unknown
+		width, height = 16, 9
+	}
+	h := int(math.Round(float64(tileWidth) * float64(height) / float64(width)))
+	return h + h%2
+}
+
+// posterFilename - makes a filename of the poster from the resource one, e.g. 'a1b2c3_6f1d_poster.jpg'.
+func posterFilename(resource entity.Resource) string {
+	return previewFilename(resource, "poster")
+}
+
+// storyboardFilename - makes a filename of the storyboard from the resource one, e.g. 'a1b2c3_6f1d_storyboard.jpg'.
+func storyboardFilename(resource entity.Resource) string {
+	return previewFilename(resource, "storyboard")
+}
+
+// previewFilename - the previews are owned by the resource, even if its file is shared with others.
+func previewFilename(resource entity.Resource, kind string) string {
+	base := strings.TrimSuffix(resource.GetFilename(), filepath.Ext(resource.GetFilename()))
+	return fmt.Sprintf("%v_%v_%v.jpg", base, resource.ID.Value.Hex(), kind)
+}
+
+// reason - takes the ffmpeg error output, the exit status is used if it's empty.
+func reason(stderr *bytes.Buffer, err error) string {
+	if r := strings.TrimSpace(stderr.String()); r != "" {
+		return r
+	}
+	return err.Error()
+}
@@ -6,8 +6,8 @@ import (
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/reader/model"
+	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
 	"math"
-	"os"
 	"sync"
 )
 
@@ -17,7 +17,6 @@ const (
 )
 
 type FileReaderService struct {
-	ctx       context.Context
 	logger    loggerinterface.Logger
 	chunkSize int
 }
@@ -28,35 +27,23 @@ func NewFileReaderService(serviceContainer diinterface.ServiceContainer) (*FileR
 		return nil, err
 	}
 
-	ctx, err := serviceContainer.GetCtx()
-	if err != nil {
-		return nil, loggerService.LogPropagate(err)
-	}
-
 	cfg, err := serviceContainer.GetConfig()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
 	}
 
 	return &FileReaderService{
-		ctx:       ctx,
 		logger:    loggerService,
 		chunkSize: cfg.StreamingChunkSize,
 	}, nil
 }
 
 // ReadAll - reads a whole file in a single chunk.
-func (r *FileReaderService) ReadAll(file *os.File) *model.Chunk {
+func (r *FileReaderService) ReadAll(file fileinterface.File) *model.Chunk {
 	r.logger.Info(fmt.Sprintf("reading all file '%v' started", file.Name()))
 
-	stat, err := file.Stat()
-	if err != nil {
-		r.logger.Critical(fmt.Sprintf("reading all file '%v' error: %v", file.Name(), err))
-		return nil
-	}
-
 	// chunks number
-	chunks := int64(math.Ceil(float64(stat.Size()) / float64(r.chunkSize)))
+	chunks := int64(math.Ceil(float64(file.Size()) / float64(r.chunkSize)))
 	// reading threads number
 	threads := int64(readingThreads)
 	// check the num of chunks more than threads
@@ -82,8 +69,8 @@ func (r *FileReaderService) ReadAll(file *os.File) *model.Chunk {
 			offset := chk * int64(r.chunkSize)
 
 			length := int64(r.chunkSize)
-			if length > (stat.Size() - offset) {
-				length = stat.Size() - offset
+			if length > (file.Size() - offset) {
+				length = file.Size() - offset
 			}
 
 			taskCh <- &struct {
@@ -134,7 +121,7 @@ func (r *FileReaderService) ReadAll(file *os.File) *model.Chunk {
 	defer r.logger.Info(fmt.Sprintf("reading all file '%v' finished properly", file.Name()))
 
 	// collect the entire file into the one chunk
-	chunk := model.NewChunk(0, stat.Size())
+	chunk := model.NewChunk(0, file.Size())
 	for i := int64(0); i < int64(len(fileMap)); i++ {
 		chunk.Data = append(chunk.Data, fileMap[i]...)
 	}
@@ -143,26 +130,20 @@ func (r *FileReaderService) ReadAll(file *os.File) *model.Chunk {
 
 // ReadByChunks - reads a file by separated chunks
 // and passed it into the channel (chunk size is setting up through env. configuration).
-func (r *FileReaderService) ReadByChunks(file *os.File, offset int64) chan *model.Chunk {
+func (r *FileReaderService) ReadByChunks(ctx context.Context, file fileinterface.File, offset int64) chan *model.Chunk {
 	r.logger.Info(fmt.Sprintf("reading file '%v' by chunks started", file.Name()))
 
-	stat, err := file.Stat()
-	if err != nil {
-		r.logger.Info(fmt.Sprintf("reading file '%v' by chunks file stat with errors: %v", file.Name(), err))
-		return nil
-	}
-
 	ch := make(chan *model.Chunk, chunksChBuffer)
 	go func() {
 		defer close(ch)
 		for {
 			select {
-			case <-r.ctx.Done():
+			case <-ctx.Done():
 				r.logger.Info(fmt.Sprintf("reading file '%v' by chunks interrupted", file.Name()))
 				return
 			default:
 				currentChunkSize := int64(r.chunkSize)
-				currentLastDataSize := stat.Size() - offset
+				currentLastDataSize := file.Size() - offset
 				if currentChunkSize > currentLastDataSize {
 					currentChunkSize = currentLastDataSize
 				}
@@ -189,7 +170,12 @@ func (r *FileReaderService) ReadByChunks(file *os.File, offset int64) chan *mode
 				}
 
 				// sent the chunk to consumer
-				ch <- chunk
+				select {
+				case <-ctx.Done():
+					r.logger.Info(fmt.Sprintf("reading file '%v' by chunks interrupted", file.Name()))
+					return
+				case ch <- chunk:
+				}
 			}
 		}
 	}()
@@ -1,13 +1,14 @@
 package readerinterface
 
 import (
+	"context"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/reader/model"
-	"os"
+	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
 )
 
 type FileReader interface {
 	// ReadAll - reads a whole file in a single chunk.
-	ReadAll(file *os.File) *model.Chunk
+	ReadAll(file fileinterface.File) *model.Chunk
 	// ReadByChunks - reads a file by separated chunks and passed it into the channel.
-	ReadByChunks(file *os.File, offset int64) chan *model.Chunk
+	ReadByChunks(ctx context.Context, file fileinterface.File, offset int64) chan *model.Chunk
 }
@@ -0,0 +1,495 @@
+package segmenter
+
+import (
+	"encoding/binary"
+	"errors"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"io"
+	"math"
+	"sort"
+)
+
+// part is a continuous piece of the demuxed file, it's taken either from the memory or from the original file.
+type part struct {
+	offset int64  // offset into the demuxed file
+	length int64  // length in bytes
+	data   []byte // rewritten bytes, nil if the part is taken from the original file
+	origin int64  // offset into the original file
+}
+
+// boundary maps the start of the top level box of the original file into the demuxed file.
+type boundary struct {
+	origin int64
+	offset int64
+	copied bool // whether the box is copied as is, so its inner offsets are shifted by the same delta
+}
+
+// layout is a map of the demuxed file, it's shared by all opened files of the same resource.
+type layout struct {
+	identity   bool // whether the file has nothing to demux, so it's read as is
+	parts      []part
+	boundaries []boundary
+	size       int64
+}
+
+// offset - translates the offset of the top level box of the original file into the demuxed one.
+func (l *layout) offset(origin int64) int64 {
+	i := sort.Search(len(l.boundaries), func(i int) bool { return l.boundaries[i].origin > origin }) - 1
+	if i < 0 {
+		return 0
+	}
+	b := l.boundaries[i]
+	if b.copied {
+		return b.offset + origin - b.origin
+	}
+	return b.offset
+}
+
+// copy - takes the box of the original file as is.
+func (l *layout) copy(b box) {
+	l.boundaries = append(l.boundaries, boundary{origin: b.offset, offset: l.size, copied: true})
+	l.parts = append(l.parts, part{offset: l.size, length: b.size, origin: b.offset})
+	l.size += b.size
+}
+
+// drop - skips the box of the original file, its offset is mapped to the next box.
+func (l *layout) drop(b box) {
+	l.boundaries = append(l.boundaries, boundary{origin: b.offset, offset: l.size})
+}
+
+// replace - puts the given bytes instead of the box of the original file.
+func (l *layout) replace(b box, data ...[]byte) {
+	l.boundaries = append(l.boundaries, boundary{origin: b.offset, offset: l.size})
+	for _, d := range data {
+		l.parts = append(l.parts, part{offset: l.size, length: int64(len(d)), data: d})
+		l.size += int64(len(d))
+	}
+}
+
+// take - appends the range of the original file to the box which is being replaced.
+func (l *layout) take(origin int64, length int64) {
+	l.parts = append(l.parts, part{offset: l.size, length: length, origin: origin})
+	l.size += length
+}
+
+// dataRun is a continuous samples data of the single trun box.
+type dataRun struct {
+	start  int64 // offset into the original file
+	length int64
+}
+
+// keptTrun is a trun box of the kept track which data offset must be rewritten.
+type keptTrun struct {
+	run      dataRun
+	position int // offset of the data_offset field into the rewritten moof, -1 if the field is absent
+}
+
+// demuxFragmentedMP4 - builds the layout of the fragmented mp4 which contains all the tracks except the audio
+// ones which were not selected. The moov and moof boxes are rewritten without the dropped tracks, the mdat
+// boxes are assembled from the samples data of the kept tracks, the other boxes are taken as is.
+func demuxFragmentedMP4(r io.ReaderAt, size int64, audio int) (*layout, error) {
+	var (
+		l       = &layout{}
+		movie   *mp4Movie
+		kept    map[uint32]bool
+		pending []dataRun // samples data of the last rewritten moof which is awaiting for its mdat
+		skip    bool      // whether the mdat of the last moof is dropped
+	)
+
+	for offset := int64(0); offset < size; {
+		b, err := readBox(r, offset, size)
+		if err != nil {
+			return nil, err
+		}
+
+		// the data offsets are rewritten relatively to the moof, so nothing may be placed before its mdat
+		if pending != nil && b.typ != "mdat" {
+			return nil, errors.New("moof box is not followed by mdat")
+		}
+
+		switch b.typ {
+		case "moov":
+			payload, perr := readPayload(r, b)
+			if perr != nil {
+				return nil, perr
+			}
+			if movie, perr = parseMoov(payload); perr != nil {
+				return nil, perr
+			}
+			if kept, perr = keptTracks(payload, audio); perr != nil {
+				return nil, perr
+			}
+			if kept == nil {
+				// there is nothing to drop, so the file is read as is
+				return &layout{identity: true}, nil
+			}
+			if !movie.fragmented {
+				// the chunk offsets of the sample tables are absolute, so the tracks are not dropped
+				if audio != 0 {
+					return nil, errors.New("the audio track cannot be selected in not fragmented mp4")
+				}
+				return &layout{identity: true}, nil
+			}
+			moov, perr := rewriteContainer(payload, kept)
+			if perr != nil {
+				return nil, perr
+			}
+			l.replace(b, makeBox("moov", moov))
+		case "moof":
+			if movie == nil {
+				return nil, errors.New("moof box found before moov")
+			}
+			payload, perr := readPayload(r, b)
+			if perr != nil {
+				return nil, perr
+			}
+			moof, runs, perr := rewriteMoof(payload, b.offset, l.size, movie, kept)
+			if perr != nil {
+				return nil, perr
+			}
+			if moof == nil {
+				// the fragment has no samples of the kept tracks
+				l.drop(b)
+				skip = true
+				break
+			}
+			l.replace(b, moof)
+			pending, skip = runs, false
+		case "mdat":
+			if skip {
+				l.drop(b)
+				skip = false
+				break
+			}
+			if pending == nil {
+				l.copy(b)
+				break
+			}
+			for _, run := range pending {
+				if run.start < b.offset+b.header || run.start+run.length > b.end() {
+					return nil, errors.New("trun data is out of the following mdat box")
+				}
+			}
+			l.replace(b, mdatHeader(pending))
+			for _, run := range pending {
+				l.take(run.start, run.length)
+			}
+			pending = nil
+		case "sidx", "mfra":
+			// the indexes refer to the dropped tracks and to the original offsets
+			l.drop(b)
+		default:
+			l.copy(b)
+		}
+
+		offset = b.end()
+	}
+
+	if movie == nil {
+		return nil, errors.New("moov box was not found")
+	}
+	l.boundaries = append(l.boundaries, boundary{origin: size, offset: l.size})
+
+	return l, nil
+}
+
+// keptTracks - returns the ids of the tracks which are kept, the audio one is selected by its number among
+// the audio tracks in the moov order. Returns nil if the file has no more than one audio track.
+func keptTracks(moov []byte, audio int) (map[uint32]bool, error) {
+	boxes, err := parseBoxes(moov)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		kept   = make(map[uint32]bool)
+		sounds []uint32
+	)
+	for _, b := range boxes {
+		if b.typ != "trak" {
+			continue
+		}
+		track, terr := parseTrak(b.payload)
+		if terr != nil {
+			return nil, terr
+		}
+		if track.handler == audioHandler {
+			sounds = append(sounds, track.id)
+			continue
+		}
+		kept[track.id] = true
+	}
+
+	if audio < 0 || (audio > 0 && audio >= len(sounds)) {
+		return nil, errtype.NewAudioTrackNotFoundError(audio)
+	}
+	if len(sounds) <= 1 {
+		return nil, nil
+	}
+	kept[sounds[audio]] = true
+
+	return kept, nil
+}
+
+// rewriteContainer - rebuilds the moov (or mvex) payload without the trak and trex boxes of the dropped tracks.
+func rewriteContainer(payload []byte, kept map[uint32]bool) ([]byte, error) {
+	boxes, err := parseBoxes(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var rewritten []byte
+	for _, b := range boxes {
+		switch b.typ {
+		case "trak":
+			track, terr := parseTrak(b.payload)
+			if terr != nil {
+				return nil, terr
+			}
+			if !kept[track.id] {
+				continue
+			}
+		case "trex":
+			if len(b.payload) < 8 {
+				return nil, errMalformedBox
+			}
+			if !kept[binary.BigEndian.Uint32(b.payload[4:8])] {
+				continue
+			}
+		case "mvex":
+			mvex, merr := rewriteContainer(b.payload, kept)
+			if merr != nil {
+				return nil, merr
+			}
+			rewritten = append(rewritten, makeBox(b.typ, mvex)...)
+			continue
+		}
+		rewritten = append(rewritten, makeBox(b.typ, b.payload)...)
+	}
+
+	return rewritten, nil
+}
+
+// rewriteMoof - rebuilds the moof box without the traf boxes of the dropped tracks, the moofOrigin is an offset
+// of the box into the original file and the moofOffset is its offset into the demuxed one. The data offsets of
+// the kept truns are rewritten relatively to the moof, so they point into the mdat which is assembled from
+// the returned runs. Returns nil if the fragment has no kept tracks.
+func rewriteMoof(
+	payload []byte,
+	moofOrigin int64,
+	moofOffset int64,
+	movie *mp4Movie,
+	kept map[uint32]bool,
+) ([]byte, []dataRun, error) {
+	boxes, err := parseBoxes(payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		moof      = make([]byte, 8, len(payload)+8)
+		truns     []keptTrun
+		tfhds     []int // offsets of the tfhd payloads of the kept trafs into the rewritten moof
+		implicit  = moofOrigin
+		trafFound bool
+	)
+	for _, b := range boxes {
+		if b.typ != "traf" {
+			moof = append(moof, makeBox(b.typ, b.payload)...)
+			continue
+		}
+
+		trafBoxes, terr := parseBoxes(b.payload)
+		if terr != nil {
+			return nil, nil, terr
+		}
+		tfhd, ok := findBox(trafBoxes, "tfhd")
+		if !ok {
+			return nil, nil, errors.New("traf box has no tfhd")
+		}
+		_, tfhdFlags, data, ferr := fullBox(tfhd.payload)
+		if ferr != nil {
+			return nil, nil, ferr
+		}
+		c := &cursor{data: data}
+		trackID := c.u32()
+		track, found := movie.tracks[trackID]
+		if !found {
+			return nil, nil, errors.New("traf box refers to unknown track")
+		}
+
+		// the data of the first traf starts at the moof, the data of the others follows the previous traf
+		base := implicit
+		if tfhdFlags&tfhdBaseDataOffsetPresent != 0 {
+			base = int64(c.u64())
+		} else if tfhdFlags&tfhdDefaultBaseIsMoof != 0 {
+			base = moofOrigin
+		}
+		if tfhdFlags&tfhdSampleDescriptionIndexPresent != 0 {
+			c.skip(4)
+		}
+		if tfhdFlags&tfhdDefaultSampleDurationPresent != 0 {
+			c.skip(4)
+		}
+		defaultSize := track.defaultSampleSize
+		if tfhdFlags&tfhdDefaultSampleSizePresent != 0 {
+			defaultSize = c.u32()
+		}
+		if c.err != nil {
+			return nil, nil, c.err
+		}
+
+		// the traf is placed after the moof header and the already kept boxes
+		trafPayload := len(moof) + 8
+		isKept := kept[trackID]
+
+		end := base
+		first := true
+		for _, tb := range trafBoxes {
+			if tb.typ != "trun" {
+				continue
+			}
+			_, trunFlags, trunData, trunErr := fullBox(tb.payload)
+			if trunErr != nil {
+				return nil, nil, trunErr
+			}
+			tc := &cursor{data: trunData}
+			count := tc.u32()
+			start, position := end, -1
+			if trunFlags&trunDataOffsetPresent != 0 {
+				start = base + int64(int32(tc.u32()))
+				position = trafPayload + tb.offset + 8
+			} else if first && isKept {
+				return nil, nil, errors.New("trun box has no data offset")
+			}
+			if trunFlags&trunFirstSampleFlagsPresent != 0 {
+				tc.skip(4)
+			}
+			length := int64(0)
+			for i := uint32(0); i < count && tc.err == nil; i++ {
+				if trunFlags&trunSampleDurationPresent != 0 {
+					tc.skip(4)
+				}
+				if trunFlags&trunSampleSizePresent != 0 {
+					length += int64(tc.u32())
+				} else {
+					length += int64(defaultSize)
+				}
+				if trunFlags&trunSampleFlagsPresent != 0 {
+					tc.skip(4)
+				}
+				if trunFlags&trunSampleCompositionTimeOffsetPresent != 0 {
+					tc.skip(4)
+				}
+			}
+			if tc.err != nil {
+				return nil, nil, tc.err
+			}
+			if isKept {
+				truns = append(truns, keptTrun{run: dataRun{start: start, length: length}, position: position})
+			}
+			end = start + length
+			first = false
+		}
+		implicit = end
+
+		if !isKept {
+			continue
+		}
+		trafFound = true
+		tfhds = append(tfhds, trafPayload+tfhd.offset)
+		moof = append(moof, makeBox(b.typ, b.payload)...)
+	}
+	if !trafFound {
+		return nil, nil, nil
+	}
+	binary.BigEndian.PutUint32(moof[:4], uint32(len(moof)))
+	copy(moof[4:8], "moof")
+
+	// the samples data is packed in the original order, so the continuous truns of the traf stay continuous
+	runs := make([]dataRun, 0, len(truns))
+	for _, t := range truns {
+		if t.run.length > 0 {
+			runs = append(runs, t.run)
+		}
+	}
+	sort.Slice(runs, func(i, j int) bool { return runs[i].start < runs[j].start })
+	runs = mergeRuns(runs)
+
+	dataOffset := int64(len(moof)) + int64(len(mdatHeader(runs)))
+	for _, t := range truns {
+		if t.position < 0 {
+			continue
+		}
+		relative := dataOffset + packedOffset(runs, t.run.start)
+		if relative > math.MaxInt32 {
+			return nil, nil, errors.New("trun data offset overflows")
+		}
+		binary.BigEndian.PutUint32(moof[t.position:t.position+4], uint32(relative))
+	}
+	for _, position := range tfhds {
+		flags := binary.BigEndian.Uint32(moof[position:position+4]) & 0x00ffffff
+		if flags&tfhdBaseDataOffsetPresent != 0 {
+			// the explicit base is an absolute offset, so it's moved to the rewritten moof
+			binary.BigEndian.PutUint64(moof[position+8:position+16], uint64(moofOffset))
+			continue
+		}
+		binary.BigEndian.PutUint32(moof[position:position+4], flags|tfhdDefaultBaseIsMoof)
+	}
+
+	return moof, runs, nil
+}
+
+// mergeRuns - joins the adjacent runs, the given ones must be sorted by the start.
+func mergeRuns(runs []dataRun) []dataRun {
+	merged := runs[:0]
+	for _, run := range runs {
+		if n := len(merged); n > 0 && merged[n-1].start+merged[n-1].length >= run.start {
+			if end := run.start + run.length; end > merged[n-1].start+merged[n-1].length {
+				merged[n-1].length = end - merged[n-1].start
+			}
+			continue
+		}
+		merged = append(merged, run)
+	}
+	return merged
+}
+
+// packedOffset - returns the offset of the original file position into the packed runs data.
+func packedOffset(runs []dataRun, origin int64) int64 {
+	packed := int64(0)
+	for _, run := range runs {
+		if origin >= run.start && origin < run.start+run.length {
+			return packed + origin - run.start
+		}
+		packed += run.length
+	}
+	return packed
+}
+
+// mdatHeader - makes the header of the mdat box which contains the given runs.
+func mdatHeader(runs []dataRun) []byte {
+	length := int64(0)
+	for _, run := range runs {
+		length += run.length
+	}
+	if length+8 > math.MaxUint32 {
+		header := make([]byte, 16)
+		binary.BigEndian.PutUint32(header[:4], 1)
+		copy(header[4:8], "mdat")
+		binary.BigEndian.PutUint64(header[8:16], uint64(length+16))
+		return header
+	}
+	header := make([]byte, 8)
+	binary.BigEndian.PutUint32(header[:4], uint32(length+8))
+	copy(header[4:8], "mdat")
+	return header
+}
+
+// makeBox - serializes the box with the given payload.
+func makeBox(typ string, payload []byte) []byte {
+	b := make([]byte, 8, len(payload)+8)
+	binary.BigEndian.PutUint32(b[:4], uint32(len(payload)+8))
+	copy(b[4:8], typ)
+	return append(b, payload...)
+}
@@ -0,0 +1,279 @@
+package segmenter
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/segmenter/model"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/filetest"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"io"
+	"testing"
+)
+
+// testDemuxSizes - the samples sizes of the video track 1 and the audio tracks 2 and 3 by the fragments
+var testDemuxSizes = []map[uint32][]uint32{
+	{1: {100, 60}, 2: {20, 20, 20}, 3: {30, 30}},
+	{1: {80}, 2: {25}, 3: {35, 15}},
+}
+
+// testDemuxMP4 - makes the fragmented mp4 of the video track 1 and the given audio ones, returns the file with
+// the offsets of its fragments and its size at the end.
+func testDemuxMP4(audio ...uint32) (file []byte, fragments []int64) {
+	traks := [][]byte{testMvhd(1000, 0), testTrak(1, videoHandler, 1000)}
+	trexes := [][]byte{testTrex(1)}
+	for _, id := range audio {
+		traks = append(traks, testTrak(id, audioHandler, 48000))
+		trexes = append(trexes, testTrex(id))
+	}
+
+	file = join(testFtyp(), testBox("moov", append(traks, testBox("mvex", trexes...))...))
+	for i, sizes := range testDemuxSizes {
+		fragments = append(fragments, int64(len(file)))
+		file = append(file, testFragment(uint32(i+1), append([]uint32{1}, audio...), sizes)...)
+	}
+
+	return file, append(fragments, int64(len(file)))
+}
+
+func TestDemuxFragmentedMP4(t *testing.T) {
+	original, originFragments := testDemuxMP4(2, 3)
+
+	tests := []struct {
+		name  string
+		audio int
+		kept  uint32
+	}{
+		{name: "first audio track", audio: 0, kept: 2},
+		{name: "second audio track", audio: 1, kept: 3},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// the demuxed file is the same as the file which was made of the kept tracks only
+			expected, fragments := testDemuxMP4(test.kept)
+
+			l, err := demuxFragmentedMP4(bytes.NewReader(original), int64(len(original)), test.audio)
+			if err != nil {
+				t.Fatal(err)
+			}
+			demuxed := testDemuxedFile(t, original, l)
+
+			if demuxed.Size() != int64(len(expected)) {
+				t.Fatalf("expected size %d, got %d", len(expected), demuxed.Size())
+			}
+			whole, err := io.ReadAll(demuxed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			testKeptTracks(t, whole, test.kept)
+			testTrunData(t, whole, test.kept)
+			if !bytes.Equal(whole, expected) {
+				t.Error("expected the demuxed file is equal to the file of the kept tracks")
+			}
+
+			// the init part is mapped to the rewritten moov and the fragments to the rewritten ones
+			for i, origin := range originFragments {
+				if offset := demuxed.Offset(origin); offset != fragments[i] {
+					t.Errorf("fragment %d: expected offset %d, got %d", i, fragments[i], offset)
+				}
+			}
+			init := demuxed.Range(model.Range{Offset: 0, Length: originFragments[0]})
+			if init != (model.Range{Offset: 0, Length: fragments[0]}) {
+				t.Errorf("expected init range %d-%d, got %+v", 0, fragments[0], init)
+			}
+			for i := 0; i+1 < len(originFragments); i++ {
+				r := demuxed.Range(model.Range{Offset: originFragments[i], Length: originFragments[i+1] - originFragments[i]})
+				if r != (model.Range{Offset: fragments[i], Length: fragments[i+1] - fragments[i]}) {
+					t.Errorf("fragment %d: expected range %d-%d, got %+v", i, fragments[i], fragments[i+1], r)
+				}
+			}
+		})
+	}
+}
+
+func TestDemuxedFile_ReadAt(t *testing.T) {
+	original, _ := testDemuxMP4(2, 3)
+	expected, _ := testDemuxMP4(3)
+
+	l, err := demuxFragmentedMP4(bytes.NewReader(original), int64(len(original)), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	demuxed := testDemuxedFile(t, original, l)
+
+	// the reads start into each part and cross the boundaries of the rewritten boxes and of the taken ranges
+	for _, length := range []int{1, 7, 64, 333} {
+		for off := 0; off < len(expected); off += 5 {
+			p := make([]byte, length)
+			n, rerr := demuxed.ReadAt(p, int64(off))
+
+			want := expected[off:min(off+length, len(expected))]
+			if n != len(want) || !bytes.Equal(p[:n], want) {
+				t.Fatalf("read of %d bytes at %d: expected %d bytes, got %d", length, off, len(want), n)
+			}
+			if len(want) < length && rerr != io.EOF {
+				t.Fatalf("read of %d bytes at %d: expected io.EOF, got %v", length, off, rerr)
+			}
+			if len(want) == length && rerr != nil {
+				t.Fatalf("read of %d bytes at %d: %v", length, off, rerr)
+			}
+		}
+	}
+}
+
+func TestDemuxFragmentedMP4_Identity(t *testing.T) {
+	single, _ := testDemuxMP4(2)
+
+	l, err := demuxFragmentedMP4(bytes.NewReader(single), int64(len(single)), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !l.identity {
+		t.Error("expected the file with the single audio track is read as is")
+	}
+}
+
+func TestDemuxFragmentedMP4_AudioTrackNotFound(t *testing.T) {
+	tests := []struct {
+		name  string
+		audio []uint32
+		index int
+	}{
+		{name: "out of two tracks", audio: []uint32{2, 3}, index: 2},
+		{name: "negative", audio: []uint32{2, 3}, index: -1},
+		{name: "out of single track", audio: []uint32{2}, index: 1},
+		{name: "no audio tracks", index: 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			file, _ := testDemuxMP4(test.audio...)
+			_, err := demuxFragmentedMP4(bytes.NewReader(file), int64(len(file)), test.index)
+			if !errtype.IsAudioTrackNotFoundError(err) {
+				t.Errorf("expected the audio track is not found, got %v", err)
+			}
+		})
+	}
+}
+
+func testDemuxedFile(t *testing.T, original []byte, l *layout) *demuxedFile {
+	t.Helper()
+
+	userID := vo.NewID(primitive.NewObjectID())
+	storage := filetest.NewMemoryStorage()
+	storage.Put(userID, "video.mp4", original)
+	file, err := storage.Open(userID, "video.mp4")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return newDemuxedFile(file, l)
+}
+
+// testKeptTracks - checks the moov has the trak and trex of the video and of the kept audio track only.
+func testKeptTracks(t *testing.T, file []byte, audio uint32) {
+	t.Helper()
+
+	boxes, err := parseBoxes(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	moov, found := findBox(boxes, "moov")
+	if !found {
+		t.Fatal("expected the moov box")
+	}
+	moovBoxes, err := parseBoxes(moov.payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var traks, trexes []uint32
+	for _, b := range moovBoxes {
+		if b.typ == "trak" {
+			track, terr := parseTrak(b.payload)
+			if terr != nil {
+				t.Fatal(terr)
+			}
+			traks = append(traks, track.id)
+		}
+	}
+	if mvex, ok := nestedBox(moov.payload, "mvex"); ok {
+		mvexBoxes, merr := parseBoxes(mvex.payload)
+		if merr != nil {
+			t.Fatal(merr)
+		}
+		for _, b := range mvexBoxes {
+			trexes = append(trexes, binary.BigEndian.Uint32(b.payload[4:8]))
+		}
+	}
+
+	expected := []uint32{1, audio}
+	if len(traks) != 2 || traks[0] != expected[0] || traks[1] != expected[1] {
+		t.Errorf("expected traks %v, got %v", expected, traks)
+	}
+	if len(trexes) != 2 || trexes[0] != expected[0] || trexes[1] != expected[1] {
+		t.Errorf("expected trexes %v, got %v", expected, trexes)
+	}
+}
+
+// testTrunData - checks each trun data offset points at the samples data of its track into the following mdat.
+func testTrunData(t *testing.T, file []byte, audio uint32) {
+	t.Helper()
+
+	boxes, err := parseBoxes(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fragments := 0
+	for i, b := range boxes {
+		if b.typ != "moof" {
+			continue
+		}
+		fragments++
+		if i+1 >= len(boxes) || boxes[i+1].typ != "mdat" {
+			t.Fatalf("fragment %d: expected the moof is followed by the mdat", fragments)
+		}
+		moofStart := b.offset - 8
+		mdat := boxes[i+1]
+
+		moofBoxes, perr := parseBoxes(b.payload)
+		if perr != nil {
+			t.Fatal(perr)
+		}
+		mfhd, _ := findBox(moofBoxes, "mfhd")
+		sequence := binary.BigEndian.Uint32(mfhd.payload[4:8])
+
+		var tracks []uint32
+		for _, traf := range moofBoxes {
+			if traf.typ != "traf" {
+				continue
+			}
+			tfhd, _ := nestedBox(traf.payload, "tfhd")
+			trun, _ := nestedBox(traf.payload, "trun")
+			track := binary.BigEndian.Uint32(tfhd.payload[4:8])
+			tracks = append(tracks, track)
+
+			count := binary.BigEndian.Uint32(trun.payload[4:8])
+			start := moofStart + int(int32(binary.BigEndian.Uint32(trun.payload[8:12])))
+			size := uint32(0)
+			for s := uint32(0); s < count; s++ {
+				size += binary.BigEndian.Uint32(trun.payload[12+4*s : 16+4*s])
+			}
+
+			if start < mdat.offset || start+int(size) > mdat.offset+len(mdat.payload) {
+				t.Fatalf("fragment %d track %d: expected the data into the mdat, got %d bytes at %d",
+					fragments, track, size, start)
+			}
+			if !bytes.Equal(file[start:start+int(size)], testSampleData(sequence, track, size)) {
+				t.Errorf("fragment %d track %d: expected the data offset points at the track samples", fragments, track)
+			}
+		}
+		if len(tracks) != 2 || tracks[0] != 1 || tracks[1] != audio {
+			t.Errorf("fragment %d: expected trafs of tracks [1 %d], got %v", fragments, audio, tracks)
+		}
+	}
+	if fragments != len(testDemuxSizes) {
+		t.Errorf("expected %d fragments, got %d", len(testDemuxSizes), fragments)
+	}
+}
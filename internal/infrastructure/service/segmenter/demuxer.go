@@ -0,0 +1,183 @@
+package segmenter
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	cacherinterface "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	segmenterinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/segmenter/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/segmenter/model"
+	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
+	"io"
+	"reflect"
+	"sort"
+	"time"
+)
+
+const layoutCacheKeyPrefix = "segmenter_layout_"
+
+// TrackDemuxer is a service which drops the not selected audio tracks of the fragmented mp4 on the fly,
+// so the MediaSource receives the single audio track which is played.
+type TrackDemuxer struct {
+	logger loggerinterface.Logger
+	cache  cacherinterface.Cacher
+}
+
+func NewTrackDemuxer(serviceContainer diinterface.ServiceContainer) (*TrackDemuxer, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	cacheService, err := serviceContainer.GetCacheService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &TrackDemuxer{
+		logger: loggerService,
+		cache:  cacheService,
+	}, nil
+}
+
+// Demux - will build (or take from cache) the layout of the file with the given audio track. The file which
+// has a single audio track is read as is. The stored files are named by their content, so the layout is cached
+// by the name.
+func (d *TrackDemuxer) Demux(file fileinterface.File, audio int) (segmenterinterface.DemuxedFile, error) {
+	cacheKey := fmt.Sprintf("%v%v_%d", layoutCacheKeyPrefix, file.Name(), audio)
+
+	layoutInterface, err := d.cache.Get(cacheKey, func(item cacherinterface.CacheItem) (data interface{}, err error) {
+		item.SetTTL(time.Hour)
+
+		l, err := d.layout(file, audio)
+		if err != nil {
+			return nil, d.logger.LogPropagate(err)
+		}
+
+		return l, nil
+	})
+	if err != nil {
+		return nil, d.logger.LogPropagate(err)
+	}
+
+	l, ok := layoutInterface.(*layout)
+	if !ok {
+		return nil, errtype.NewCachedDataTypeWasNotMatchedError(
+			cacheKey, reflect.TypeOf(&layout{}), reflect.TypeOf(layoutInterface),
+		)
+	}
+
+	return newDemuxedFile(file, l), nil
+}
+
+func (d *TrackDemuxer) layout(file fileinterface.File, audio int) (*layout, error) {
+	magic := make([]byte, 8)
+	if _, err := file.ReadAt(magic, 0); err != nil {
+		return nil, d.logger.LogPropagate(err)
+	}
+
+	// the webm clusters are not rewritten, so its first audio track is played by the browser
+	if binary.BigEndian.Uint32(magic[:4]) == ebmlHeaderID {
+		if audio != 0 {
+			return nil, d.logger.LogPropagate(
+				errtype.NewResourceIsNotSegmentableError(file.Name(), "the audio track cannot be selected in webm"),
+			)
+		}
+		return &layout{identity: true}, nil
+	}
+
+	// the other containers (mpeg-ts of the legacy uploads) are not indexed, so they are read as is too
+	if string(magic[4:8]) != "ftyp" {
+		if audio != 0 {
+			return nil, d.logger.LogPropagate(
+				errtype.NewResourceIsNotSegmentableError(file.Name(), "the audio track cannot be selected in the container"),
+			)
+		}
+		return &layout{identity: true}, nil
+	}
+
+	l, err := demuxFragmentedMP4(file, file.Size(), audio)
+	if err != nil {
+		if errtype.IsAudioTrackNotFoundError(err) {
+			return nil, d.logger.LogPropagate(err)
+		}
+		return nil, d.logger.LogPropagate(errtype.NewResourceIsNotSegmentableError(file.Name(), err.Error()))
+	}
+
+	return l, nil
+}
+
+// demuxedFile - reads the original file through the layout.
+type demuxedFile struct {
+	fileinterface.File
+	layout *layout
+	reader *io.SectionReader
+}
+
+func newDemuxedFile(file fileinterface.File, l *layout) *demuxedFile {
+	f := &demuxedFile{File: file, layout: l}
+	if l.identity {
+		f.reader = io.NewSectionReader(file, 0, file.Size())
+	} else {
+		f.reader = io.NewSectionReader(f, 0, l.size)
+	}
+	return f
+}
+
+func (f *demuxedFile) Read(p []byte) (n int, err error) {
+	return f.reader.Read(p)
+}
+
+func (f *demuxedFile) Seek(offset int64, whence int) (int64, error) {
+	return f.reader.Seek(offset, whence)
+}
+
+func (f *demuxedFile) Size() int64 {
+	return f.reader.Size()
+}
+
+func (f *demuxedFile) ReadAt(p []byte, off int64) (n int, err error) {
+	if f.layout.identity {
+		return f.File.ReadAt(p, off)
+	}
+	if off < 0 {
+		return 0, errors.New("negative offset")
+	}
+
+	parts := f.layout.parts
+	i := sort.Search(len(parts), func(i int) bool { return parts[i].offset+parts[i].length > off })
+	for ; n < len(p) && i < len(parts); i++ {
+		pt := parts[i]
+		delta := off + int64(n) - pt.offset
+		chunk := p[n:]
+		if int64(len(chunk)) > pt.length-delta {
+			chunk = chunk[:pt.length-delta]
+		}
+		if pt.data != nil {
+			copy(chunk, pt.data[delta:])
+		} else if m, rerr := f.File.ReadAt(chunk, pt.origin+delta); rerr != nil && (m < len(chunk) || rerr != io.EOF) {
+			return n + m, rerr
+		}
+		n += len(chunk)
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+
+	return n, nil
+}
+
+func (f *demuxedFile) Offset(origin int64) int64 {
+	if f.layout.identity {
+		return origin
+	}
+	return f.layout.offset(origin)
+}
+
+func (f *demuxedFile) Range(origin model.Range) model.Range {
+	offset := f.Offset(origin.Offset)
+	return model.Range{Offset: offset, Length: f.Offset(origin.Offset+origin.Length) - offset}
+}
@@ -0,0 +1,10 @@
+package segmenter
+
+// fragment is an independently addressable part of a resource file (mp4 moof+mdat or webm cluster).
+type fragment struct {
+	offset   int64   // offset of fragment start
+	end      int64   // offset of fragment end (exclusive)
+	start    float64 // start time in seconds
+	duration float64 // duration in seconds (zero means unknown)
+	sync     bool    // whether the fragment starts with a keyframe
+}
@@ -0,0 +1,21 @@
+package segmenterinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/segmenter/model"
+	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
+)
+
+type Demuxer interface {
+	// Demux - returns the view of the file which contains the video and the given audio track only.
+	Demux(file fileinterface.File, audio int) (DemuxedFile, error)
+}
+
+// DemuxedFile - is a view of the file without the dropped tracks. The index of the original file
+// is still valid, its offsets are translated into the view ones.
+type DemuxedFile interface {
+	fileinterface.File
+	// Offset - translates the offset of the original file.
+	Offset(origin int64) int64
+	// Range - translates the range of the original file.
+	Range(origin model.Range) model.Range
+}
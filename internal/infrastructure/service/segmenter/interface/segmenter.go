@@ -0,0 +1,13 @@
+package segmenterinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/segmenter/model"
+)
+
+type Segmenter interface {
+	// Index - returns the segments map of the fragmented resource.
+	Index(resource entity.Resource) (*model.Index, error)
+	// SeekIndex - returns the keyframes map of the resource, the not fragmented mp4 is indexed without segments.
+	SeekIndex(resource entity.Resource) (*model.Index, error)
+}
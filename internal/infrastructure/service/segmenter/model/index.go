@@ -0,0 +1,104 @@
+package model
+
+const (
+	MP4Container  = "mp4"
+	WebMContainer = "webm"
+
+	segmentBoundaryTolerance = 0.001
+)
+
+// Range is a continuous part of a resource file in bytes.
+type Range struct {
+	Offset int64 `json:"offset"`
+	Length int64 `json:"length"`
+}
+
+// Segment is an independently decodable part of a resource (starts with a keyframe).
+type Segment struct {
+	Range
+	Start    float64 `json:"start"`    // start time of segment in seconds
+	Duration float64 `json:"duration"` // duration of segment in seconds
+}
+
+// Keyframe is a position of fragment which starts with a keyframe.
+type Keyframe struct {
+	Offset int64   `json:"offset"` // offset of fragment in bytes
+	Time   float64 `json:"time"`   // start time of fragment in seconds
+}
+
+// Index is a segments map of a resource file.
+type Index struct {
+	Container string     `json:"container"` // mp4 or webm
+	Init      Range      `json:"init"`      // initialization part (ftyp+moov or ebml header+info+tracks)
+	Segments  []Segment  `json:"segments"`  // media parts (moof+mdat or clusters)
+	Keyframes []Keyframe `json:"keyframes"` // all fragments which may be used as a seek point
+	Duration  float64    `json:"duration"`  // total duration in seconds
+	Filesize  int64      `json:"filesize"`  // total size in bytes
+}
+
+// KeyframeAt - returns the nearest keyframe at or before the given time in seconds.
+func (i *Index) KeyframeAt(t float64) Keyframe {
+	keyframe := Keyframe{Offset: i.Init.Length}
+	if len(i.Keyframes) > 0 {
+		keyframe = i.Keyframes[0]
+	}
+	for _, k := range i.Keyframes {
+		if k.Time > t {
+			break
+		}
+		keyframe = k
+	}
+	return keyframe
+}
+
+// SegmentAt - returns the number of segment which contains the given time in seconds. The time which is equal
+// to the segment end (with a millisecond tolerance) belongs to the next one.
+func (i *Index) SegmentAt(t float64) (int, bool) {
+	for n, segment := range i.Segments {
+		if t < segment.Start+segment.Duration-segmentBoundaryTolerance {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+// MaxDuration - returns the longest segment duration in seconds.
+func (i *Index) MaxDuration() float64 {
+	max := 0.
+	for _, segment := range i.Segments {
+		if segment.Duration > max {
+			max = segment.Duration
+		}
+	}
+	return max
+}
+
+// Bandwidth - returns an average bitrate of the resource in bits per second.
+func (i *Index) Bandwidth() int64 {
+	if i.Duration <= 0 {
+		return 0
+	}
+	return int64(float64(i.Filesize*8) / i.Duration)
+}
+
+// PeakBandwidth - returns the highest bitrate among segments in bits per second.
+func (i *Index) PeakBandwidth() int64 {
+	peak := int64(0)
+	for _, segment := range i.Segments {
+		if segment.Duration <= 0 {
+			continue
+		}
+		if bandwidth := int64(float64(segment.Length*8) / segment.Duration); bandwidth > peak {
+			peak = bandwidth
+		}
+	}
+	if peak == 0 {
+		return i.Bandwidth()
+	}
+	return peak
+}
+
+// MediaType - returns a MIME type of the segments.
+func (i *Index) MediaType() string {
+	return "video/" + i.Container
+}
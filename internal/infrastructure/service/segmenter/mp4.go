@@ -0,0 +1,597 @@
+package segmenter
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+const (
+	// tfhd flags
+	tfhdBaseDataOffsetPresent         = 0x000001
+	tfhdSampleDescriptionIndexPresent = 0x000002
+	tfhdDefaultSampleDurationPresent  = 0x000008
+	tfhdDefaultSampleSizePresent      = 0x000010
+	tfhdDefaultSampleFlagsPresent     = 0x000020
+	tfhdDefaultBaseIsMoof             = 0x020000
+	// trun flags
+	trunDataOffsetPresent                  = 0x000001
+	trunFirstSampleFlagsPresent            = 0x000004
+	trunSampleDurationPresent              = 0x000100
+	trunSampleSizePresent                  = 0x000200
+	trunSampleFlagsPresent                 = 0x000400
+	trunSampleCompositionTimeOffsetPresent = 0x000800
+	// sample flags
+	sampleIsNonSyncSample = 0x00010000
+	// handler types
+	videoHandler = "vide"
+	audioHandler = "soun"
+)
+
+var (
+	errMalformedBox  = errors.New("malformed mp4 box")
+	errNotFragmented = errors.New("resource is not a fragmented mp4")
+)
+
+// box is a header of ISO BMFF box which was found into the file.
+type box struct {
+	typ    string
+	offset int64 // offset of box start (header included)
+	size   int64 // size of box (header included)
+	header int64 // size of header
+}
+
+func (b box) end() int64 {
+	return b.offset + b.size
+}
+
+// readBox - reads a box header at given offset, the limit is an end of parent box or file.
+func readBox(r io.ReaderAt, offset int64, limit int64) (box, error) {
+	buf := make([]byte, 16)
+	if _, err := r.ReadAt(buf[:8], offset); err != nil {
+		return box{}, err
+	}
+
+	b := box{typ: string(buf[4:8]), offset: offset, header: 8}
+
+	size := int64(binary.BigEndian.Uint32(buf[:4]))
+	switch size {
+	case 0: // box lasts till the end of file
+		size = limit - offset
+	case 1: // 64-bit largesize follows the type
+		if _, err := r.ReadAt(buf[8:16], offset+8); err != nil {
+			return box{}, err
+		}
+		size = int64(binary.BigEndian.Uint64(buf[8:16]))
+		b.header = 16
+	}
+	if size < b.header || offset+size > limit {
+		return box{}, errMalformedBox
+	}
+	b.size = size
+
+	return b, nil
+}
+
+// readPayload - reads a whole box payload into the memory (use it only for small boxes like moov or moof).
+func readPayload(r io.ReaderAt, b box) ([]byte, error) {
+	payload := make([]byte, b.size-b.header)
+	if _, err := r.ReadAt(payload, b.offset+b.header); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// rawBox is a box which was already loaded into the memory.
+type rawBox struct {
+	typ     string
+	payload []byte
+	offset  int // offset of the payload into the parsed data
+}
+
+// parseBoxes - splits given data into the sequence of boxes.
+func parseBoxes(data []byte) ([]rawBox, error) {
+	var (
+		boxes    []rawBox
+		consumed int
+	)
+	for len(data) > 0 {
+		if len(data) < 8 {
+			return nil, errMalformedBox
+		}
+		size := uint64(binary.BigEndian.Uint32(data[:4]))
+		typ := string(data[4:8])
+		header := uint64(8)
+		switch size {
+		case 0:
+			size = uint64(len(data))
+		case 1:
+			if len(data) < 16 {
+				return nil, errMalformedBox
+			}
+			size = binary.BigEndian.Uint64(data[8:16])
+			header = 16
+		}
+		if size < header || size > uint64(len(data)) {
+			return nil, errMalformedBox
+		}
+		boxes = append(boxes, rawBox{typ: typ, payload: data[header:size], offset: consumed + int(header)})
+		data = data[size:]
+		consumed += int(size)
+	}
+	return boxes, nil
+}
+
+// findBox - searches the first box of given type.
+func findBox(boxes []rawBox, typ string) (rawBox, bool) {
+	for _, b := range boxes {
+		if b.typ == typ {
+			return b, true
+		}
+	}
+	return rawBox{}, false
+}
+
+// fullBox - splits a full box payload into version, flags and the rest of data.
+func fullBox(payload []byte) (version uint8, flags uint32, data []byte, err error) {
+	if len(payload) < 4 {
+		return 0, 0, nil, errMalformedBox
+	}
+	return payload[0], binary.BigEndian.Uint32(payload[:4]) & 0x00ffffff, payload[4:], nil
+}
+
+// cursor is a simple big-endian reader over the box payload.
+type cursor struct {
+	data []byte
+	err  error
+}
+
+func (c *cursor) skip(n int) {
+	if c.err != nil {
+		return
+	}
+	if len(c.data) < n {
+		c.err = errMalformedBox
+		return
+	}
+	c.data = c.data[n:]
+}
+
+func (c *cursor) u32() uint32 {
+	if c.err != nil || len(c.data) < 4 {
+		c.err = errMalformedBox
+		return 0
+	}
+	v := binary.BigEndian.Uint32(c.data[:4])
+	c.data = c.data[4:]
+	return v
+}
+
+func (c *cursor) u64() uint64 {
+	if c.err != nil || len(c.data) < 8 {
+		c.err = errMalformedBox
+		return 0
+	}
+	v := binary.BigEndian.Uint64(c.data[:8])
+	c.data = c.data[8:]
+	return v
+}
+
+// mp4Track is a track description from the moov box.
+type mp4Track struct {
+	id                  uint32
+	timescale           uint32
+	handler             string
+	defaultDuration     uint32 // from trex
+	defaultSampleSize   uint32 // from trex
+	defaultSampleFlags  uint32 // from trex
+	defaultFlagsPresent bool
+}
+
+// mp4Movie is a parsed moov box.
+type mp4Movie struct {
+	timescale  uint32
+	duration   uint64 // in movie timescale (mvhd or mehd)
+	tracks     map[uint32]*mp4Track
+	reference  uint32 // id of track which will be used for timing (video if exists)
+	fragmented bool   // whether the moov has mvex, so the samples are described by the moof boxes
+}
+
+// parseMoov - parses tracks timing information from the moov box payload.
+func parseMoov(payload []byte) (*mp4Movie, error) {
+	boxes, err := parseBoxes(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	movie := &mp4Movie{tracks: make(map[uint32]*mp4Track)}
+
+	if mvhd, ok := findBox(boxes, "mvhd"); ok {
+		version, _, data, ferr := fullBox(mvhd.payload)
+		if ferr != nil {
+			return nil, ferr
+		}
+		c := &cursor{data: data}
+		if version == 1 {
+			c.skip(16)
+			movie.timescale = c.u32()
+			movie.duration = c.u64()
+		} else {
+			c.skip(8)
+			movie.timescale = c.u32()
+			movie.duration = uint64(c.u32())
+		}
+		if c.err != nil {
+			return nil, c.err
+		}
+	}
+
+	for _, trak := range boxes {
+		if trak.typ != "trak" {
+			continue
+		}
+		track, terr := parseTrak(trak.payload)
+		if terr != nil {
+			return nil, terr
+		}
+		movie.tracks[track.id] = track
+		if movie.reference == 0 || (track.handler == videoHandler && movie.tracks[movie.reference].handler != videoHandler) {
+			movie.reference = track.id
+		}
+	}
+
+	if mvex, ok := findBox(boxes, "mvex"); ok {
+		movie.fragmented = true
+		mvexBoxes, merr := parseBoxes(mvex.payload)
+		if merr != nil {
+			return nil, merr
+		}
+		for _, b := range mvexBoxes {
+			switch b.typ {
+			case "mehd":
+				version, _, data, ferr := fullBox(b.payload)
+				if ferr != nil {
+					return nil, ferr
+				}
+				c := &cursor{data: data}
+				if version == 1 {
+					movie.duration = c.u64()
+				} else {
+					movie.duration = uint64(c.u32())
+				}
+				if c.err != nil {
+					return nil, c.err
+				}
+			case "trex":
+				_, _, data, ferr := fullBox(b.payload)
+				if ferr != nil {
+					return nil, ferr
+				}
+				c := &cursor{data: data}
+				id := c.u32()
+				c.skip(4) // default_sample_description_index
+				duration := c.u32()
+				size := c.u32()
+				flags := c.u32()
+				if c.err != nil {
+					return nil, c.err
+				}
+				if track, found := movie.tracks[id]; found {
+					track.defaultDuration = duration
+					track.defaultSampleSize = size
+					track.defaultSampleFlags = flags
+					track.defaultFlagsPresent = true
+				}
+			}
+		}
+	}
+
+	if len(movie.tracks) == 0 {
+		return nil, errors.New("moov box has no tracks")
+	}
+
+	return movie, nil
+}
+
+// parseTrak - parses the track id, timescale and handler type.
+func parseTrak(payload []byte) (*mp4Track, error) {
+	boxes, err := parseBoxes(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	track := &mp4Track{}
+
+	if tkhd, ok := findBox(boxes, "tkhd"); ok {
+		version, _, data, ferr := fullBox(tkhd.payload)
+		if ferr != nil {
+			return nil, ferr
+		}
+		c := &cursor{data: data}
+		if version == 1 {
+			c.skip(16)
+		} else {
+			c.skip(8)
+		}
+		track.id = c.u32()
+		if c.err != nil {
+			return nil, c.err
+		}
+	}
+
+	mdia, ok := findBox(boxes, "mdia")
+	if !ok {
+		return nil, errors.New("trak box has no mdia")
+	}
+	mdiaBoxes, err := parseBoxes(mdia.payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if mdhd, found := findBox(mdiaBoxes, "mdhd"); found {
+		version, _, data, ferr := fullBox(mdhd.payload)
+		if ferr != nil {
+			return nil, ferr
+		}
+		c := &cursor{data: data}
+		if version == 1 {
+			c.skip(16)
+		} else {
+			c.skip(8)
+		}
+		track.timescale = c.u32()
+		if c.err != nil {
+			return nil, c.err
+		}
+	}
+	if track.timescale == 0 {
+		return nil, errors.New("track has zero timescale")
+	}
+
+	if hdlr, found := findBox(mdiaBoxes, "hdlr"); found {
+		_, _, data, ferr := fullBox(hdlr.payload)
+		if ferr != nil {
+			return nil, ferr
+		}
+		if len(data) >= 8 {
+			track.handler = string(data[4:8])
+		}
+	}
+
+	return track, nil
+}
+
+// mp4Fragment is a timing information of the reference track into the single moof box.
+type mp4Fragment struct {
+	found          bool   // whether the reference track is present into the fragment
+	decodeTime     uint64 // tfdt base media decode time
+	decodeTimeSet  bool
+	duration       uint64 // sum of samples durations
+	startsWithSync bool   // whether the first sample is a keyframe
+}
+
+// parseMoof - parses timing of the reference track from the moof box payload.
+func parseMoof(payload []byte, movie *mp4Movie) (mp4Fragment, error) {
+	boxes, err := parseBoxes(payload)
+	if err != nil {
+		return mp4Fragment{}, err
+	}
+
+	for _, traf := range boxes {
+		if traf.typ != "traf" {
+			continue
+		}
+
+		trafBoxes, terr := parseBoxes(traf.payload)
+		if terr != nil {
+			return mp4Fragment{}, terr
+		}
+
+		tfhd, ok := findBox(trafBoxes, "tfhd")
+		if !ok {
+			return mp4Fragment{}, errors.New("traf box has no tfhd")
+		}
+		_, tfhdFlags, data, ferr := fullBox(tfhd.payload)
+		if ferr != nil {
+			return mp4Fragment{}, ferr
+		}
+		c := &cursor{data: data}
+		trackID := c.u32()
+		if trackID != movie.reference {
+			continue
+		}
+
+		track := movie.tracks[trackID]
+		defaultDuration := track.defaultDuration
+		defaultFlags := track.defaultSampleFlags
+		defaultFlagsPresent := track.defaultFlagsPresent
+
+		if tfhdFlags&tfhdBaseDataOffsetPresent != 0 {
+			c.skip(8)
+		}
+		if tfhdFlags&tfhdSampleDescriptionIndexPresent != 0 {
+			c.skip(4)
+		}
+		if tfhdFlags&tfhdDefaultSampleDurationPresent != 0 {
+			defaultDuration = c.u32()
+		}
+		if tfhdFlags&tfhdDefaultSampleSizePresent != 0 {
+			c.skip(4)
+		}
+		if tfhdFlags&tfhdDefaultSampleFlagsPresent != 0 {
+			defaultFlags = c.u32()
+			defaultFlagsPresent = true
+		}
+		if c.err != nil {
+			return mp4Fragment{}, c.err
+		}
+
+		fragment := mp4Fragment{found: true, startsWithSync: true}
+
+		firstSample := true
+		for _, b := range trafBoxes {
+			switch b.typ {
+			case "tfdt":
+				version, _, tfdtData, tfdtErr := fullBox(b.payload)
+				if tfdtErr != nil {
+					return mp4Fragment{}, tfdtErr
+				}
+				tc := &cursor{data: tfdtData}
+				if version == 1 {
+					fragment.decodeTime = tc.u64()
+				} else {
+					fragment.decodeTime = uint64(tc.u32())
+				}
+				if tc.err != nil {
+					return mp4Fragment{}, tc.err
+				}
+				fragment.decodeTimeSet = true
+			case "trun":
+				_, trunFlags, trunData, trunErr := fullBox(b.payload)
+				if trunErr != nil {
+					return mp4Fragment{}, trunErr
+				}
+				tc := &cursor{data: trunData}
+				count := tc.u32()
+				if trunFlags&trunDataOffsetPresent != 0 {
+					tc.skip(4)
+				}
+				var firstFlags uint32
+				firstFlagsPresent := false
+				if trunFlags&trunFirstSampleFlagsPresent != 0 {
+					firstFlags = tc.u32()
+					firstFlagsPresent = true
+				}
+				for i := uint32(0); i < count && tc.err == nil; i++ {
+					duration := defaultDuration
+					if trunFlags&trunSampleDurationPresent != 0 {
+						duration = tc.u32()
+					}
+					if trunFlags&trunSampleSizePresent != 0 {
+						tc.skip(4)
+					}
+					flags, flagsPresent := defaultFlags, defaultFlagsPresent
+					if trunFlags&trunSampleFlagsPresent != 0 {
+						flags, flagsPresent = tc.u32(), true
+					}
+					if trunFlags&trunSampleCompositionTimeOffsetPresent != 0 {
+						tc.skip(4)
+					}
+					if i == 0 && firstFlagsPresent {
+						flags, flagsPresent = firstFlags, true
+					}
+					if firstSample {
+						if flagsPresent && flags&sampleIsNonSyncSample != 0 {
+							fragment.startsWithSync = false
+						}
+						firstSample = false
+					}
+					fragment.duration += uint64(duration)
+				}
+				if tc.err != nil {
+					return mp4Fragment{}, tc.err
+				}
+			}
+		}
+
+		return fragment, nil
+	}
+
+	return mp4Fragment{}, nil
+}
+
+// indexFragmentedMP4 - scans top level boxes of fragmented mp4 and builds the fragments list (moof+mdat pairs),
+// the timing is taken from the tfdt/trun boxes of the reference track.
+func indexFragmentedMP4(r io.ReaderAt, size int64) (initEnd int64, fragments []*fragment, duration float64, err error) {
+	var (
+		movie      *mp4Movie
+		current    *fragment
+		pending    int64 = -1 // offset of styp/prft/emsg box which precedes the next moof
+		mediaEnd         = size
+		decodeTime       = make(map[*fragment]float64)
+	)
+
+	for offset := int64(0); offset < size; {
+		b, rerr := readBox(r, offset, size)
+		if rerr != nil {
+			return 0, nil, 0, rerr
+		}
+
+		switch b.typ {
+		case "moov":
+			payload, perr := readPayload(r, b)
+			if perr != nil {
+				return 0, nil, 0, perr
+			}
+			if movie, perr = parseMoov(payload); perr != nil {
+				return 0, nil, 0, perr
+			}
+			initEnd = b.end()
+		case "styp", "prft", "emsg":
+			if pending < 0 {
+				pending = b.offset
+			}
+		case "moof":
+			if movie == nil {
+				return 0, nil, 0, errors.New("moof box found before moov")
+			}
+			payload, perr := readPayload(r, b)
+			if perr != nil {
+				return 0, nil, 0, perr
+			}
+			parsed, perr := parseMoof(payload, movie)
+			if perr != nil {
+				return 0, nil, 0, perr
+			}
+
+			start := b.offset
+			if pending >= 0 {
+				start = pending
+				pending = -1
+			}
+			if current != nil {
+				current.end = start
+			}
+
+			timescale := float64(movie.tracks[movie.reference].timescale)
+			current = &fragment{offset: start, sync: !parsed.found || parsed.startsWithSync}
+			if parsed.found {
+				current.duration = float64(parsed.duration) / timescale
+				if parsed.decodeTimeSet {
+					decodeTime[current] = float64(parsed.decodeTime) / timescale
+				}
+			}
+			fragments = append(fragments, current)
+		case "mfra":
+			mediaEnd = b.offset
+		}
+
+		offset = b.end()
+	}
+
+	if movie == nil {
+		return 0, nil, 0, errors.New("moov box was not found")
+	}
+	if len(fragments) == 0 {
+		return 0, nil, 0, errNotFragmented
+	}
+	current.end = mediaEnd
+
+	// computing the start time of each fragment relatively to the first one
+	firstDecodeTime := decodeTime[fragments[0]]
+	cumulative := 0.
+	for _, f := range fragments {
+		if t, found := decodeTime[f]; found {
+			f.start = t - firstDecodeTime
+		} else {
+			f.start = cumulative
+		}
+		cumulative = f.start + f.duration
+	}
+
+	if movie.timescale > 0 && movie.duration > 0 {
+		duration = float64(movie.duration) / float64(movie.timescale)
+	}
+
+	return initEnd, fragments, duration, nil
+}
@@ -0,0 +1,96 @@
+package segmenter
+
+import (
+	"encoding/binary"
+)
+
+// the helpers below build the synthetic mp4 boxes, only the fields which are read by the segmenter are filled
+
+func u32(values ...uint32) []byte {
+	b := make([]byte, 0, 4*len(values))
+	for _, v := range values {
+		b = binary.BigEndian.AppendUint32(b, v)
+	}
+	return b
+}
+
+func u64(v uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, v)
+}
+
+func join(parts ...[]byte) []byte {
+	var b []byte
+	for _, p := range parts {
+		b = append(b, p...)
+	}
+	return b
+}
+
+func testBox(typ string, children ...[]byte) []byte {
+	return makeBox(typ, join(children...))
+}
+
+func testFullBox(typ string, version uint8, flags uint32, payload ...[]byte) []byte {
+	return testBox(typ, u32(uint32(version)<<24|flags), join(payload...))
+}
+
+func testFtyp() []byte {
+	return testBox("ftyp", []byte("isom"), u32(0x200), []byte("isomiso6mp41"))
+}
+
+func testMvhd(timescale uint32, duration uint32) []byte {
+	return testFullBox("mvhd", 0, 0, u32(0, 0, timescale, duration), make([]byte, 80))
+}
+
+// testTrak - makes the trak with the given handler, the stbl children are optional.
+func testTrak(id uint32, handler string, timescale uint32, stbl ...[]byte) []byte {
+	return testBox("trak",
+		testFullBox("tkhd", 0, 3, u32(0, 0, id, 0, 0), make([]byte, 60)),
+		testBox("mdia",
+			testFullBox("mdhd", 0, 0, u32(0, 0, timescale, 0, 0)),
+			testFullBox("hdlr", 0, 0, u32(0), []byte(handler), make([]byte, 13)),
+			testBox("minf", testBox("stbl", stbl...)),
+		),
+	)
+}
+
+func testTrex(id uint32) []byte {
+	return testFullBox("trex", 0, 0, u32(id, 1, 0, 0, 0))
+}
+
+// testSampleData - fills the samples data of the track into the fragment by the distinct bytes, so the misplaced
+// data is detected.
+func testSampleData(sequence uint32, track uint32, size uint32) []byte {
+	data := make([]byte, size)
+	for i := range data {
+		data[i] = byte((int(sequence)*17 + int(track)*31 + i) % 251)
+	}
+	return data
+}
+
+// testFragment - makes the moof with the traf of each given track and the mdat with their samples data in the same
+// order. The trafs are based on the moof (default-base-is-moof), so the data offsets are relative to it.
+func testFragment(sequence uint32, tracks []uint32, sizes map[uint32][]uint32) []byte {
+	var data []byte
+	moof := func(dataOffset int) []byte {
+		data = nil
+		trafs := [][]byte{testFullBox("mfhd", 0, 0, u32(sequence))}
+		for _, track := range tracks {
+			total := uint32(0)
+			for _, size := range sizes[track] {
+				total += size
+			}
+			trafs = append(trafs, testBox("traf",
+				testFullBox("tfhd", 0, tfhdDefaultBaseIsMoof, u32(track)),
+				testFullBox("trun", 0, trunDataOffsetPresent|trunSampleSizePresent,
+					u32(uint32(len(sizes[track])), uint32(dataOffset+len(data))), u32(sizes[track]...)),
+			))
+			data = append(data, testSampleData(sequence, track, total)...)
+		}
+		return testBox("moof", trafs...)
+	}
+
+	// the size of moof does not depend on the offsets values
+	header := len(moof(0)) + 8
+	return join(moof(header), testBox("mdat", data))
+}
@@ -0,0 +1,285 @@
+package segmenter
+
+import (
+	"errors"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/segmenter/model"
+	"io"
+)
+
+// sttsEntry is a run of samples with the same duration (time-to-sample box).
+type sttsEntry struct {
+	count uint32
+	delta uint32
+}
+
+// stscEntry is a run of chunks with the same number of samples (sample-to-chunk box).
+type stscEntry struct {
+	firstChunk      uint32 // 1-based
+	samplesPerChunk uint32
+}
+
+// sampleTable is a part of the stbl box which locates the samples of the track into the file.
+type sampleTable struct {
+	durations  []sttsEntry
+	sync       []uint32 // 1-based numbers of the sync samples, nil means all samples are sync (no stss)
+	chunks     []stscEntry
+	offsets    []uint64 // chunk offsets (stco or co64)
+	sampleSize uint32   // the size of all samples, zero means the sizes are listed
+	sizes      []uint32
+	count      uint32
+}
+
+// indexProgressiveMP4 - scans top level boxes of not fragmented mp4 and takes the keyframes positions of the
+// reference track from the moov sample tables (stts, stss, stsc, stco/co64 and stsz). The init part is everything
+// before the media data, so it contains the moov if the file is optimized for streaming.
+func indexProgressiveMP4(r io.ReaderAt, size int64) (initEnd int64, keyframes []model.Keyframe, duration float64, err error) {
+	var (
+		movie   *mp4Movie
+		moov    []byte
+		mdatEnd int64 = -1
+	)
+	initEnd = -1
+
+	for offset := int64(0); offset < size; {
+		b, rerr := readBox(r, offset, size)
+		if rerr != nil {
+			return 0, nil, 0, rerr
+		}
+
+		switch b.typ {
+		case "moov":
+			if moov, rerr = readPayload(r, b); rerr != nil {
+				return 0, nil, 0, rerr
+			}
+			if movie, rerr = parseMoov(moov); rerr != nil {
+				return 0, nil, 0, rerr
+			}
+		case "moof":
+			return 0, nil, 0, errors.New("resource is a fragmented mp4")
+		case "mdat":
+			if initEnd < 0 {
+				initEnd = b.offset
+			}
+			mdatEnd = b.end()
+		}
+
+		offset = b.end()
+	}
+
+	if movie == nil {
+		return 0, nil, 0, errors.New("moov box was not found")
+	}
+	if initEnd < 0 {
+		return 0, nil, 0, errors.New("mdat box was not found")
+	}
+
+	table, err := referenceSampleTable(moov, movie.reference)
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	positions, err := table.syncSamples()
+	if err != nil {
+		return 0, nil, 0, err
+	}
+
+	timescale := float64(movie.tracks[movie.reference].timescale)
+	for _, p := range positions {
+		if int64(p.offset) >= mdatEnd {
+			return 0, nil, 0, errors.New("sample is out of the mdat box")
+		}
+		keyframes = append(keyframes, model.Keyframe{Offset: int64(p.offset), Time: float64(p.decodeTime) / timescale})
+	}
+	if len(keyframes) == 0 {
+		return 0, nil, 0, errors.New("track has no sync samples")
+	}
+
+	if movie.timescale > 0 && movie.duration > 0 {
+		duration = float64(movie.duration) / float64(movie.timescale)
+	} else {
+		duration = float64(table.totalDuration()) / timescale
+	}
+
+	return initEnd, keyframes, duration, nil
+}
+
+// referenceSampleTable - finds the trak of the given track and parses its stbl box.
+func referenceSampleTable(moov []byte, trackID uint32) (*sampleTable, error) {
+	boxes, err := parseBoxes(moov)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, trak := range boxes {
+		if trak.typ != "trak" {
+			continue
+		}
+		track, terr := parseTrak(trak.payload)
+		if terr != nil {
+			return nil, terr
+		}
+		if track.id != trackID {
+			continue
+		}
+
+		stbl, found := nestedBox(trak.payload, "mdia", "minf", "stbl")
+		if !found {
+			return nil, errors.New("trak box has no stbl")
+		}
+		return parseSampleTable(stbl.payload)
+	}
+
+	return nil, errors.New("reference track was not found")
+}
+
+// nestedBox - searches the box by the path of types into the given payload.
+func nestedBox(payload []byte, path ...string) (rawBox, bool) {
+	var b rawBox
+	for _, typ := range path {
+		boxes, err := parseBoxes(payload)
+		if err != nil {
+			return rawBox{}, false
+		}
+		found := false
+		if b, found = findBox(boxes, typ); !found {
+			return rawBox{}, false
+		}
+		payload = b.payload
+	}
+	return b, true
+}
+
+// parseSampleTable - parses the boxes of the stbl payload which are needed to locate the samples.
+func parseSampleTable(payload []byte) (*sampleTable, error) {
+	boxes, err := parseBoxes(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	table := &sampleTable{}
+	for _, b := range boxes {
+		_, _, data, ferr := fullBox(b.payload)
+		if ferr != nil {
+			return nil, ferr
+		}
+		c := &cursor{data: data}
+
+		switch b.typ {
+		case "stts":
+			for n := c.u32(); n > 0 && c.err == nil; n-- {
+				table.durations = append(table.durations, sttsEntry{count: c.u32(), delta: c.u32()})
+			}
+		case "stss":
+			table.sync = []uint32{}
+			for n := c.u32(); n > 0 && c.err == nil; n-- {
+				table.sync = append(table.sync, c.u32())
+			}
+		case "stsc":
+			for n := c.u32(); n > 0 && c.err == nil; n-- {
+				entry := stscEntry{firstChunk: c.u32(), samplesPerChunk: c.u32()}
+				c.skip(4) // sample_description_index
+				table.chunks = append(table.chunks, entry)
+			}
+		case "stco":
+			for n := c.u32(); n > 0 && c.err == nil; n-- {
+				table.offsets = append(table.offsets, uint64(c.u32()))
+			}
+		case "co64":
+			for n := c.u32(); n > 0 && c.err == nil; n-- {
+				table.offsets = append(table.offsets, c.u64())
+			}
+		case "stsz":
+			table.sampleSize = c.u32()
+			table.count = c.u32()
+			if table.sampleSize == 0 {
+				for n := table.count; n > 0 && c.err == nil; n-- {
+					table.sizes = append(table.sizes, c.u32())
+				}
+			}
+		case "stz2":
+			return nil, errors.New("compact sample sizes are not supported")
+		}
+		if c.err != nil {
+			return nil, c.err
+		}
+	}
+
+	if table.count == 0 || len(table.chunks) == 0 || len(table.offsets) == 0 {
+		return nil, errors.New("stbl box has no samples")
+	}
+	if table.chunks[0].firstChunk != 1 {
+		return nil, errMalformedBox
+	}
+
+	return table, nil
+}
+
+// samplePosition is a decode time (in the track timescale) and a file offset of the sample.
+type samplePosition struct {
+	decodeTime uint64
+	offset     uint64
+}
+
+// syncSamples - walks the samples chunk by chunk and returns the positions of the sync ones.
+func (t *sampleTable) syncSamples() ([]samplePosition, error) {
+	var (
+		positions []samplePosition
+		sample    uint32 // 1-based number of the current sample
+		sync      int    // index of the next sync sample into the stss
+		stts      int    // index of the current stts entry
+		sttsLeft  uint32 // samples left in the current stts entry
+		delta     uint32
+		time      uint64
+	)
+	if len(t.durations) > 0 {
+		sttsLeft, delta = t.durations[0].count, t.durations[0].delta
+	}
+
+	for chunk := range t.offsets {
+		// the last stsc entry which starts at or before the chunk defines its samples number
+		perChunk := uint32(0)
+		for _, entry := range t.chunks {
+			if entry.firstChunk > uint32(chunk+1) {
+				break
+			}
+			perChunk = entry.samplesPerChunk
+		}
+
+		offset := t.offsets[chunk]
+		for i := uint32(0); i < perChunk && sample < t.count; i++ {
+			sample++
+			if t.sync == nil || (sync < len(t.sync) && t.sync[sync] == sample) {
+				positions = append(positions, samplePosition{decodeTime: time, offset: offset})
+				sync++
+			}
+
+			size := t.sampleSize
+			if size == 0 {
+				if int(sample) > len(t.sizes) {
+					return nil, errMalformedBox
+				}
+				size = t.sizes[sample-1]
+			}
+			offset += uint64(size)
+
+			for sttsLeft == 0 && stts+1 < len(t.durations) {
+				stts++
+				sttsLeft, delta = t.durations[stts].count, t.durations[stts].delta
+			}
+			if sttsLeft > 0 {
+				sttsLeft--
+			}
+			time += uint64(delta)
+		}
+	}
+
+	return positions, nil
+}
+
+// totalDuration - returns the sum of the samples durations in the track timescale.
+func (t *sampleTable) totalDuration() uint64 {
+	total := uint64(0)
+	for _, entry := range t.durations {
+		total += uint64(entry.count) * uint64(entry.delta)
+	}
+	return total
+}
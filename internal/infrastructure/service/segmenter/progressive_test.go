@@ -0,0 +1,140 @@
+package segmenter
+
+import (
+	"bytes"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/segmenter/model"
+	"testing"
+)
+
+// testProgressiveMP4 - makes the not fragmented mp4 of the video track and the audio one. The stbl of the video is
+// made by the given function from the offset of the mdat payload, the audio trak has no samples tables since it's
+// not the reference one.
+func testProgressiveMP4(moovLast bool, payload int, stbl func(base uint64) [][]byte) (file []byte, mdatOffset int) {
+	ftyp := testFtyp()
+	moov := func(base uint64) []byte {
+		return testBox("moov",
+			testMvhd(1000, 3000),
+			testTrak(2, audioHandler, 48000),
+			testTrak(1, videoHandler, 1000, stbl(base)...),
+		)
+	}
+	mdat := testBox("mdat", make([]byte, payload))
+
+	if moovLast {
+		base := uint64(len(ftyp) + 8)
+		return join(ftyp, mdat, moov(base)), len(ftyp)
+	}
+	// the size of moov does not depend on the offsets values
+	base := uint64(len(ftyp) + len(moov(0)) + 8)
+	return join(ftyp, moov(base), mdat), len(ftyp) + len(moov(base))
+}
+
+func TestIndexProgressiveMP4(t *testing.T) {
+	// six samples of 500ms: 10, 20 | 30, 40 | 50 | 60 bytes by chunks, the 1st and the 4th are the sync ones
+	listed := func(base uint64) [][]byte {
+		return [][]byte{
+			testFullBox("stts", 0, 0, u32(1, 6, 500)),
+			testFullBox("stss", 0, 0, u32(2, 1, 4)),
+			testFullBox("stsc", 0, 0, u32(2, 1, 2, 1, 3, 1, 1)),
+			testFullBox("stsz", 0, 0, u32(0, 6, 10, 20, 30, 40, 50, 60)),
+			testFullBox("stco", 0, 0, u32(4, uint32(base), uint32(base+30), uint32(base+100), uint32(base+150))),
+		}
+	}
+
+	tests := []struct {
+		name     string
+		moovLast bool
+		payload  int
+		stbl     func(base uint64) [][]byte
+		// expected - the keyframes offsets relatively to the mdat payload and their times
+		expected []model.Keyframe
+	}{
+		{
+			name:     "moov before mdat",
+			payload:  210,
+			stbl:     listed,
+			expected: []model.Keyframe{{Offset: 0, Time: 0}, {Offset: 60, Time: 1.5}},
+		},
+		{
+			name:     "moov after mdat",
+			moovLast: true,
+			payload:  210,
+			stbl:     listed,
+			expected: []model.Keyframe{{Offset: 0, Time: 0}, {Offset: 60, Time: 1.5}},
+		},
+		{
+			name:    "64-bit chunk offsets, constant size and durations runs",
+			payload: 300,
+			stbl: func(base uint64) [][]byte {
+				return [][]byte{
+					testFullBox("stts", 0, 0, u32(2, 2, 1000, 4, 250)),
+					testFullBox("stss", 0, 0, u32(3, 1, 3, 5)),
+					testFullBox("stsc", 0, 0, u32(1, 1, 3, 1)),
+					testFullBox("stsz", 0, 0, u32(50, 6)),
+					testFullBox("co64", 0, 0, u32(2), u64(base), u64(base+150)),
+				}
+			},
+			expected: []model.Keyframe{{Offset: 0, Time: 0}, {Offset: 100, Time: 2}, {Offset: 200, Time: 2.5}},
+		},
+		{
+			name:    "all samples are sync without stss",
+			payload: 30,
+			stbl: func(base uint64) [][]byte {
+				return [][]byte{
+					testFullBox("stts", 0, 0, u32(1, 3, 1000)),
+					testFullBox("stsc", 0, 0, u32(1, 1, 3, 1)),
+					testFullBox("stsz", 0, 0, u32(0, 3, 5, 10, 15)),
+					testFullBox("stco", 0, 0, u32(1, uint32(base))),
+				}
+			},
+			expected: []model.Keyframe{{Offset: 0, Time: 0}, {Offset: 5, Time: 1}, {Offset: 15, Time: 2}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			file, mdatOffset := testProgressiveMP4(test.moovLast, test.payload, test.stbl)
+
+			initEnd, keyframes, duration, err := indexProgressiveMP4(bytes.NewReader(file), int64(len(file)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if initEnd != int64(mdatOffset) {
+				t.Errorf("expected init till the mdat at %d, got %d", mdatOffset, initEnd)
+			}
+			if duration != 3 {
+				t.Errorf("expected duration 3s of mvhd, got %v", duration)
+			}
+			if len(keyframes) != len(test.expected) {
+				t.Fatalf("expected %d keyframes, got %+v", len(test.expected), keyframes)
+			}
+			for i, expected := range test.expected {
+				expected.Offset += int64(mdatOffset + 8)
+				if keyframes[i] != expected {
+					t.Errorf("keyframe %d: expected %+v, got %+v", i, expected, keyframes[i])
+				}
+			}
+		})
+	}
+}
+
+func TestIndexProgressiveMP4_Rejects(t *testing.T) {
+	fragmented := join(testFtyp(), testBox("moov", testMvhd(1000, 0), testTrak(1, videoHandler, 1000)),
+		testBox("moof"), testBox("mdat"))
+	if _, _, _, err := indexProgressiveMP4(bytes.NewReader(fragmented), int64(len(fragmented))); err == nil {
+		t.Error("expected the fragmented mp4 is rejected")
+	}
+
+	// the chunk points out of the mdat
+	file, _ := testProgressiveMP4(false, 10, func(base uint64) [][]byte {
+		return [][]byte{
+			testFullBox("stts", 0, 0, u32(1, 1, 1000)),
+			testFullBox("stsc", 0, 0, u32(1, 1, 1, 1)),
+			testFullBox("stsz", 0, 0, u32(10, 1)),
+			testFullBox("stco", 0, 0, u32(1, uint32(base+10))),
+		}
+	})
+	if _, _, _, err := indexProgressiveMP4(bytes.NewReader(file), int64(len(file))); err == nil {
+		t.Error("expected the sample out of the mdat is rejected")
+	}
+}
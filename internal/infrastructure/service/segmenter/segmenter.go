@@ -0,0 +1,211 @@
+package segmenter
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	cacherinterface "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/helper"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/segmenter/model"
+	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
+	"reflect"
+	"time"
+)
+
+const (
+	indexCacheKeyPrefix     = "segmenter_index_"
+	seekIndexCacheKeyPrefix = "segmenter_seek_index_"
+)
+
+type ResourceSegmenter struct {
+	logger         loggerinterface.Logger
+	cache          cacherinterface.Cacher
+	storage        fileinterface.Storage
+	targetDuration float64
+}
+
+func NewResourceSegmenter(serviceContainer diinterface.ServiceContainer) (*ResourceSegmenter, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	cacheService, err := serviceContainer.GetCacheService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	storageService, err := serviceContainer.GetFileStorageService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &ResourceSegmenter{
+		logger:         loggerService,
+		cache:          cacheService,
+		storage:        storageService,
+		targetDuration: cfg.SegmentTargetDuration,
+	}, nil
+}
+
+// Index - will build (or take from cache) a segments map of the given resource.
+func (s *ResourceSegmenter) Index(resource entity.Resource) (*model.Index, error) {
+	return s.cached(indexCacheKeyPrefix, resource, false)
+}
+
+// SeekIndex - will build (or take from cache) a keyframes map of the given resource for seeking. The fragmented
+// resources are indexed as by Index, the not fragmented mp4 (uploaded before the processing pipeline) is indexed
+// by its moov sample tables, so its index has no segments.
+func (s *ResourceSegmenter) SeekIndex(resource entity.Resource) (*model.Index, error) {
+	return s.cached(seekIndexCacheKeyPrefix, resource, true)
+}
+
+func (s *ResourceSegmenter) cached(prefix string, resource entity.Resource, seekable bool) (*model.Index, error) {
+	p, err := json.Marshal(resource)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+	cacheKey := prefix + helper.MD5(p)
+
+	indexInterface, err := s.cache.Get(cacheKey, func(item cacherinterface.CacheItem) (data interface{}, err error) {
+		item.SetTTL(time.Hour)
+
+		index, err := s.index(resource, seekable)
+		if err != nil {
+			return nil, s.logger.LogPropagate(err)
+		}
+
+		return index, nil
+	})
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	index, ok := indexInterface.(*model.Index)
+	if !ok {
+		return nil, errtype.NewCachedDataTypeWasNotMatchedError(
+			cacheKey, reflect.TypeOf(&model.Index{}), reflect.TypeOf(indexInterface),
+		)
+	}
+
+	return index, nil
+}
+
+func (s *ResourceSegmenter) index(resource entity.Resource, seekable bool) (*model.Index, error) {
+	file, err := s.storage.Open(resource.GetUserID(), resource.GetFilename())
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+	defer func() { _ = file.Close() }()
+
+	// sniffing the container by the first bytes
+	magic := make([]byte, 8)
+	if _, err = file.ReadAt(magic, 0); err != nil {
+		return nil, s.logger.LogPropagate(
+			errtype.NewResourceIsNotSegmentableError(resource.GetName(), err.Error()),
+		)
+	}
+
+	var (
+		container string
+		initEnd   int64
+		fragments []*fragment
+		duration  float64
+	)
+	if binary.BigEndian.Uint32(magic[:4]) == ebmlHeaderID {
+		container = model.WebMContainer
+		initEnd, fragments, duration, err = indexWebM(file, file.Size())
+	} else {
+		container = model.MP4Container
+		initEnd, fragments, duration, err = indexFragmentedMP4(file, file.Size())
+		if errors.Is(err, errNotFragmented) && seekable {
+			return s.progressive(resource, file)
+		}
+	}
+	if err != nil {
+		return nil, s.logger.LogPropagate(
+			errtype.NewResourceIsNotSegmentableError(resource.GetName(), err.Error()),
+		)
+	}
+
+	return s.build(container, initEnd, fragments, duration, file.Size()), nil
+}
+
+// progressive - makes the index of the not fragmented mp4 which contains the keyframes only.
+func (s *ResourceSegmenter) progressive(resource entity.Resource, file fileinterface.File) (*model.Index, error) {
+	initEnd, keyframes, duration, err := indexProgressiveMP4(file, file.Size())
+	if err != nil {
+		return nil, s.logger.LogPropagate(
+			errtype.NewResourceIsNotSegmentableError(resource.GetName(), err.Error()),
+		)
+	}
+
+	return &model.Index{
+		Container: model.MP4Container,
+		Init:      model.Range{Offset: 0, Length: initEnd},
+		Keyframes: keyframes,
+		Duration:  duration,
+		Filesize:  file.Size(),
+	}, nil
+}
+
+// build - groups the fragments into segments which are close to the target duration,
+// a new segment always starts with a keyframe.
+func (s *ResourceSegmenter) build(
+	container string,
+	initEnd int64,
+	fragments []*fragment,
+	duration float64,
+	filesize int64,
+) *model.Index {
+	// fill in the missing durations by the next fragment start
+	for i := 1; i < len(fragments); i++ {
+		if fragments[i-1].duration == 0 {
+			fragments[i-1].duration = fragments[i].start - fragments[i-1].start
+		}
+	}
+	last := fragments[len(fragments)-1]
+	if end := last.start + last.duration; duration < end {
+		duration = end
+	}
+	if last.duration == 0 {
+		last.duration = duration - last.start
+	}
+
+	index := &model.Index{
+		Container: container,
+		Init:      model.Range{Offset: 0, Length: initEnd},
+		Duration:  duration,
+		Filesize:  filesize,
+	}
+
+	var segment *model.Segment
+	for _, f := range fragments {
+		if f.sync {
+			index.Keyframes = append(index.Keyframes, model.Keyframe{Offset: f.offset, Time: f.start})
+		}
+		if segment == nil || (f.sync && segment.Duration >= s.targetDuration) {
+			if segment != nil {
+				index.Segments = append(index.Segments, *segment)
+			}
+			segment = &model.Segment{
+				Range: model.Range{Offset: f.offset},
+				Start: f.start,
+			}
+		}
+		segment.Length = f.end - segment.Offset
+		segment.Duration = f.start + f.duration - segment.Start
+	}
+	index.Segments = append(index.Segments, *segment)
+
+	return index
+}
@@ -0,0 +1,338 @@
+package segmenter
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+)
+
+const (
+	// EBML element identifiers (Matroska/WebM)
+	ebmlHeaderID       = 0x1A45DFA3
+	ebmlSegmentID      = 0x18538067
+	ebmlInfoID         = 0x1549A966
+	ebmlTimecodeScale  = 0x2AD7B1
+	ebmlDurationID     = 0x4489
+	ebmlTracksID       = 0x1654AE6B
+	ebmlCuesID         = 0x1C53BB6B
+	ebmlCuePointID     = 0xBB
+	ebmlCueTrackPosID  = 0xB7
+	ebmlCueClusterPos  = 0xF1
+	ebmlClusterID      = 0x1F43B675
+	ebmlTimecodeID     = 0xE7
+	ebmlSeekHeadID     = 0x114D9B74
+	ebmlAttachmentsID  = 0x1941A469
+	ebmlChaptersID     = 0x1043A770
+	ebmlTagsID         = 0x1254C367
+	ebmlUnknownSize    = -1
+	defaultWebMScaleNs = 1000000
+)
+
+var errMalformedElement = errors.New("malformed ebml element")
+
+// webmTopLevel is a set of the segment children identifiers, used for determine the end of unknown-sized cluster.
+var webmTopLevel = map[uint32]struct{}{
+	ebmlSeekHeadID:    {},
+	ebmlInfoID:        {},
+	ebmlTracksID:      {},
+	ebmlCuesID:        {},
+	ebmlClusterID:     {},
+	ebmlAttachmentsID: {},
+	ebmlChaptersID:    {},
+	ebmlTagsID:        {},
+}
+
+// element is a header of EBML element which was found into the file.
+type element struct {
+	id     uint32
+	offset int64 // offset of element start (header included)
+	header int64 // size of header
+	size   int64 // size of data or ebmlUnknownSize
+}
+
+func (e element) data() int64 {
+	return e.offset + e.header
+}
+
+func (e element) end() int64 {
+	return e.data() + e.size
+}
+
+// readVint - reads an EBML variable length integer, the marker bit is kept for identifiers.
+func readVint(r io.ReaderAt, offset int64, keepMarker bool) (value uint64, length int, unknown bool, err error) {
+	first := make([]byte, 1)
+	if _, err = r.ReadAt(first, offset); err != nil {
+		return 0, 0, false, err
+	}
+
+	length = 1
+	for mask := byte(0x80); length <= 8 && first[0]&mask == 0; mask >>= 1 {
+		length++
+	}
+	if length > 8 {
+		return 0, 0, false, errMalformedElement
+	}
+
+	buf := make([]byte, length)
+	if _, err = r.ReadAt(buf, offset); err != nil {
+		return 0, 0, false, err
+	}
+
+	if !keepMarker {
+		buf[0] &= byte(0xff >> length)
+	}
+	unknown = !keepMarker
+	for _, b := range buf {
+		value = value<<8 | uint64(b)
+	}
+	if unknown {
+		// all data bits are set, so the size is unknown
+		unknown = value == (uint64(1)<<(7*length))-1
+	}
+
+	return value, length, unknown, nil
+}
+
+// readElement - reads an element header at given offset.
+func readElement(r io.ReaderAt, offset int64) (element, error) {
+	id, idLength, _, err := readVint(r, offset, true)
+	if err != nil {
+		return element{}, err
+	}
+	if idLength > 4 {
+		return element{}, errMalformedElement
+	}
+
+	size, sizeLength, unknown, err := readVint(r, offset+int64(idLength), false)
+	if err != nil {
+		return element{}, err
+	}
+
+	e := element{id: uint32(id), offset: offset, header: int64(idLength + sizeLength), size: int64(size)}
+	if unknown {
+		e.size = ebmlUnknownSize
+	}
+
+	return e, nil
+}
+
+// readElementData - reads a whole element data into the memory (use it only for small elements).
+func readElementData(r io.ReaderAt, e element) ([]byte, error) {
+	if e.size < 0 {
+		return nil, errMalformedElement
+	}
+	data := make([]byte, e.size)
+	if _, err := r.ReadAt(data, e.data()); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// readUint - reads a big-endian unsigned integer of any EBML length.
+func readUint(data []byte) uint64 {
+	var v uint64
+	for _, b := range data {
+		v = v<<8 | uint64(b)
+	}
+	return v
+}
+
+// readFloat - reads an EBML float (4 or 8 bytes).
+func readFloat(data []byte) float64 {
+	switch len(data) {
+	case 4:
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(data)))
+	case 8:
+		return math.Float64frombits(binary.BigEndian.Uint64(data))
+	}
+	return 0
+}
+
+// children - iterates over the elements inside the in-memory data.
+func children(data []byte, fn func(id uint32, payload []byte)) error {
+	r := &memReaderAt{data: data}
+	for offset := int64(0); offset < int64(len(data)); {
+		e, err := readElement(r, offset)
+		if err != nil {
+			return err
+		}
+		if e.size < 0 || e.end() > int64(len(data)) {
+			return errMalformedElement
+		}
+		fn(e.id, data[e.data():e.end()])
+		offset = e.end()
+	}
+	return nil
+}
+
+type memReaderAt struct{ data []byte }
+
+func (m *memReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if off >= int64(len(m.data)) {
+		return 0, io.EOF
+	}
+	n := copy(p, m.data[off:])
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// indexWebM - scans the segment of webm file and builds the fragments list by clusters,
+// the Cues are used for determine which clusters are starting with a keyframe.
+func indexWebM(r io.ReaderAt, size int64) (initEnd int64, fragments []*fragment, duration float64, err error) {
+	header, err := readElement(r, 0)
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	if header.id != ebmlHeaderID || header.size < 0 {
+		return 0, nil, 0, errors.New("ebml header was not found")
+	}
+
+	segment, err := readElement(r, header.end())
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	if segment.id != ebmlSegmentID {
+		return 0, nil, 0, errors.New("ebml segment was not found")
+	}
+	segmentEnd := size
+	if segment.size >= 0 && segment.end() < size {
+		segmentEnd = segment.end()
+	}
+
+	var (
+		scale      = float64(defaultWebMScaleNs)
+		rawLength  float64
+		cued       = make(map[int64]struct{}) // cluster positions relative to segment data
+		hasCues    bool
+		clusterEnd int64
+	)
+
+	for offset := segment.data(); offset < segmentEnd; {
+		e, rerr := readElement(r, offset)
+		if rerr != nil {
+			return 0, nil, 0, rerr
+		}
+
+		switch e.id {
+		case ebmlInfoID:
+			data, derr := readElementData(r, e)
+			if derr != nil {
+				return 0, nil, 0, derr
+			}
+			if cerr := children(data, func(id uint32, payload []byte) {
+				switch id {
+				case ebmlTimecodeScale:
+					scale = float64(readUint(payload))
+				case ebmlDurationID:
+					rawLength = readFloat(payload)
+				}
+			}); cerr != nil {
+				return 0, nil, 0, cerr
+			}
+		case ebmlCuesID:
+			data, derr := readElementData(r, e)
+			if derr != nil {
+				return 0, nil, 0, derr
+			}
+			if cerr := children(data, func(id uint32, payload []byte) {
+				if id != ebmlCuePointID {
+					return
+				}
+				_ = children(payload, func(id uint32, payload []byte) {
+					if id != ebmlCueTrackPosID {
+						return
+					}
+					_ = children(payload, func(id uint32, payload []byte) {
+						if id == ebmlCueClusterPos {
+							cued[int64(readUint(payload))] = struct{}{}
+							hasCues = true
+						}
+					})
+				})
+			}); cerr != nil {
+				return 0, nil, 0, cerr
+			}
+		case ebmlClusterID:
+			if initEnd == 0 {
+				initEnd = e.offset
+			}
+			timecode, end, cerr := readCluster(r, e, segmentEnd)
+			if cerr != nil {
+				return 0, nil, 0, cerr
+			}
+			if len(fragments) > 0 {
+				fragments[len(fragments)-1].end = e.offset
+			}
+			fragments = append(fragments, &fragment{
+				offset: e.offset,
+				start:  float64(timecode) * scale / 1e9,
+			})
+			clusterEnd = end
+			offset = end
+			continue
+		}
+
+		if e.size < 0 {
+			return 0, nil, 0, errMalformedElement
+		}
+		offset = e.end()
+	}
+
+	if len(fragments) == 0 {
+		return 0, nil, 0, errors.New("webm has no clusters")
+	}
+	fragments[len(fragments)-1].end = clusterEnd
+
+	// without cues each cluster is considered as started with a keyframe (muxers start a cluster on keyframe)
+	for _, f := range fragments {
+		_, found := cued[f.offset-segment.data()]
+		f.sync = !hasCues || found
+	}
+
+	duration = rawLength * scale / 1e9
+
+	return initEnd, fragments, duration, nil
+}
+
+// readCluster - reads the cluster timecode and determines the cluster end (also for unknown-sized clusters).
+func readCluster(r io.ReaderAt, cluster element, limit int64) (timecode uint64, end int64, err error) {
+	end = limit
+	if cluster.size >= 0 {
+		end = cluster.end()
+	}
+
+	found := false
+	for offset := cluster.data(); offset < end; {
+		e, rerr := readElement(r, offset)
+		if rerr != nil {
+			return 0, 0, rerr
+		}
+		if _, isTopLevel := webmTopLevel[e.id]; isTopLevel {
+			// the next segment child was reached, so the unknown-sized cluster ends here
+			return timecode, e.offset, nil
+		}
+		if e.id == ebmlTimecodeID {
+			data, derr := readElementData(r, e)
+			if derr != nil {
+				return 0, 0, derr
+			}
+			timecode = readUint(data)
+			found = true
+			if cluster.size >= 0 {
+				return timecode, end, nil
+			}
+		}
+		if e.size < 0 {
+			return 0, 0, errMalformedElement
+		}
+		offset = e.end()
+	}
+	if !found {
+		return 0, 0, errors.New("cluster has no timecode")
+	}
+
+	return timecode, end, nil
+}
@@ -0,0 +1,23 @@
+package abrinterface
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/session"
+	"github.com/gorilla/websocket"
+)
+
+type AdaptiveStreamer interface {
+	// Stream - streams the video from the given position switching its renditions at the segment boundaries,
+	// the text and audio tracks are announced by the start message, only the selected audio track is streamed.
+	Stream(
+		ctx context.Context,
+		sess *session.Session,
+		video *agg.Video,
+		from float64,
+		tracks []vo.TextTrack,
+		audio []vo.AudioTrack,
+		conn *websocket.Conn,
+	) error
+}
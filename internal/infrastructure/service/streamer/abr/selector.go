@@ -0,0 +1,47 @@
+package abr
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	segmentermodel "github.com/Borislavv/video-streaming/internal/infrastructure/service/segmenter/model"
+)
+
+// bandwidthSafetyFactor is a part of the measured bandwidth which may be taken by the rendition bitrate,
+// the rest is a reserve for the throughput fluctuations.
+const bandwidthSafetyFactor = 0.8
+
+// candidate - is a rendition which may be streamed within the current stream.
+type candidate struct {
+	number    int
+	rendition entity.Rendition
+	index     *segmentermodel.Index
+}
+
+// bitrate - returns the declared bitrate of the rendition or the calculated one by its index.
+func (c candidate) bitrate() int64 {
+	if c.rendition.Bitrate > 0 {
+		return c.rendition.Bitrate
+	}
+	return c.index.Bandwidth()
+}
+
+// selectCandidate - picks the rendition among the candidates which are ordered by bitrate ascending:
+//  1. the highest one which is not higher than the client hint (if it's pinned);
+//  2. the highest one which bitrate fits into the measured bandwidth;
+//  3. the lowest one, while the bandwidth is not measured yet or nothing fits.
+func selectCandidate(candidates []candidate, hint int, bandwidth float64) candidate {
+	selected := candidates[0]
+	if hint > 0 {
+		for _, c := range candidates {
+			if c.rendition.Height > 0 && c.rendition.Height <= hint && c.rendition.Height >= selected.rendition.Height {
+				selected = c
+			}
+		}
+		return selected
+	}
+	for _, c := range candidates {
+		if float64(c.bitrate()) <= bandwidth*bandwidthSafetyFactor {
+			selected = c
+		}
+	}
+	return selected
+}
@@ -0,0 +1,122 @@
+package abr
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/di/ditest"
+	segmentermodel "github.com/Borislavv/video-streaming/internal/infrastructure/service/segmenter/model"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/session"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/transcoder"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"testing"
+	"time"
+)
+
+// newTestCandidates - makes the 360p, 720p and the original 1080p renditions by the fake transcoder.
+func newTestCandidates(t *testing.T) []candidate {
+	t.Helper()
+
+	container, storage := ditest.NewStorageContainer(t, nil)
+
+	fake, err := transcoder.NewFakeTranscoder(container)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource := entity.Resource{
+		ID:       vo.NewID(primitive.NewObjectID()),
+		UserID:   vo.NewID(primitive.NewObjectID()),
+		Filename: "video.mp4",
+	}
+	storage.Put(resource.GetUserID(), resource.GetFilename(), []byte("content"))
+
+	original, err := fake.Probe(resource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	renditions := []entity.Rendition{}
+	for _, profile := range []vo.RenditionProfile{{Height: 360, Bitrate: 800_000}, {Height: 720, Bitrate: 2_800_000}} {
+		rendition, terr := fake.Transcode(resource, profile)
+		if terr != nil {
+			t.Fatal(terr)
+		}
+		renditions = append(renditions, rendition)
+	}
+	renditions = append(renditions, original)
+
+	candidates := make([]candidate, 0, len(renditions))
+	for number, rendition := range renditions {
+		candidates = append(candidates, candidate{number: number, rendition: rendition, index: &segmentermodel.Index{}})
+	}
+	return candidates
+}
+
+func TestSelectCandidate(t *testing.T) {
+	candidates := newTestCandidates(t)
+
+	tests := []struct {
+		name      string
+		hint      int
+		bandwidth float64
+		expected  int
+	}{
+		{name: "not measured bandwidth takes the lowest", expected: 360},
+		{name: "nothing fits takes the lowest", bandwidth: 500_000, expected: 360},
+		{name: "the highest which fits with the reserve", bandwidth: 4_000_000, expected: 720},
+		{name: "the bitrate which takes the whole bandwidth does not fit", bandwidth: 2_800_000, expected: 360},
+		{name: "fast connection takes the highest", bandwidth: 10_000_000, expected: 1080},
+		{name: "hint pins the rendition regardless the bandwidth", hint: 720, bandwidth: 100_000, expected: 720},
+		{name: "hint takes the highest not higher", hint: 1000, bandwidth: 100_000_000, expected: 720},
+		{name: "hint lower than the ladder takes the lowest", hint: 240, bandwidth: 100_000_000, expected: 360},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			selected := selectCandidate(candidates, test.hint, test.bandwidth)
+			if selected.rendition.Height != test.expected {
+				t.Fatalf("expected %dp, got %dp", test.expected, selected.rendition.Height)
+			}
+		})
+	}
+}
+
+func TestSelectCandidate_Switching(t *testing.T) {
+	candidates := newTestCandidates(t)
+	sess := session.NewSession(context.Background(), 0)
+
+	// one second segments of the selected rendition are sent through the connection of the given throughput
+	send := func(throughput float64) int {
+		selected := selectCandidate(candidates, sess.RenditionHint(), sess.Bandwidth())
+		bytes := int(selected.bitrate() / 8)
+		sess.Measure(bytes, time.Duration(float64(bytes*8)/throughput*float64(time.Second)))
+		return selected.rendition.Height
+	}
+	stream := func(throughput float64) (height int) {
+		for i := 0; i < 20; i++ {
+			height = send(throughput)
+		}
+		return height
+	}
+
+	if height := send(50_000_000); height != 360 {
+		t.Fatalf("expected the stream starts from 360p, got %dp", height)
+	}
+	if height := stream(50_000_000); height != 1080 {
+		t.Fatalf("expected the fast connection switches up to 1080p, got %dp", height)
+	}
+	if height := stream(4_000_000); height != 720 {
+		t.Fatalf("expected the degraded connection switches down to 720p, got %dp", height)
+	}
+	if height := stream(1_500_000); height != 360 {
+		t.Fatalf("expected the slow connection switches down to 360p, got %dp", height)
+	}
+	if height := stream(8_000_000); height != 1080 {
+		t.Fatalf("expected the recovered connection switches up to 1080p, got %dp", height)
+	}
+
+	// the pinned rendition is kept on the slow connection
+	sess.SetRenditionHint(1080)
+	if height := stream(1_500_000); height != 1080 {
+		t.Fatalf("expected the pinned 1080p, got %dp", height)
+	}
+}
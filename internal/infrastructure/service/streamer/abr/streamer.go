@@ -0,0 +1,253 @@
+package abr
+
+import (
+	"context"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	detectorinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/detector/interface"
+	readermodel "github.com/Borislavv/video-streaming/internal/infrastructure/service/reader/model"
+	segmenterinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/segmenter/interface"
+	segmentermodel "github.com/Borislavv/video-streaming/internal/infrastructure/service/segmenter/model"
+	protointerface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
+	protomodel "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/model"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/session"
+	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
+	"github.com/gorilla/websocket"
+	"time"
+)
+
+// AdaptiveStreamer is a service which streams the video by segments and selects the rendition of each segment
+// by the measured throughput of the connection or by the client hint.
+type AdaptiveStreamer struct {
+	logger       loggerinterface.Logger
+	segmenter    segmenterinterface.Segmenter
+	codecInfo    detectorinterface.Codecs
+	communicator protointerface.Communicator
+	storage      fileinterface.Storage
+	demuxer      segmenterinterface.Demuxer
+}
+
+func NewAdaptiveStreamer(serviceContainer diinterface.ServiceContainer) (*AdaptiveStreamer, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	segmenterService, err := serviceContainer.GetSegmenterService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	codecsDetector, err := serviceContainer.GetCodecsDetectorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	webSocketCommunicator, err := serviceContainer.GetWebSocketCommunicatorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	storageService, err := serviceContainer.GetFileStorageService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	demuxerService, err := serviceContainer.GetDemuxerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &AdaptiveStreamer{
+		logger:       loggerService,
+		segmenter:    segmenterService,
+		codecInfo:    codecsDetector,
+		communicator: webSocketCommunicator,
+		storage:      storageService,
+		demuxer:      demuxerService,
+	}, nil
+}
+
+// Stream - streams the video from the segment which contains the given position. The init segment of
+// the rendition is sent before its first media segment, so the client side SourceBuffer is reinitialized
+// on each switching. The renditions keep all audio tracks of the source, so the selected one is demuxed
+// from each of them. Returns nil if the stream was finished or interrupted by the session.
+func (s *AdaptiveStreamer) Stream(
+	ctx context.Context,
+	sess *session.Session,
+	video *agg.Video,
+	from float64,
+	tracks []vo.TextTrack,
+	audio []vo.AudioTrack,
+	conn *websocket.Conn,
+) error {
+	streamID := sess.StreamID()
+	control := protomodel.NewControlFrame(streamID)
+
+	candidates, err := s.candidates(video)
+	if err != nil {
+		if e := s.communicator.Error(control, err, conn); e != nil {
+			return s.logger.LogPropagate(e)
+		}
+		return s.logger.LogPropagate(err)
+	}
+
+	// the renditions are switched within a single SourceBuffer, so they are announced by the codecs of the first one
+	mediaType, err := s.codecInfo.DetectRendition(candidates[0].rendition)
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+	selectedAudio := vo.SelectedAudioTrack(audio)
+	if len(audio) > 0 && audio[selectedAudio].Codec != "" {
+		mediaType.AudioCodec = audio[selectedAudio].Codec
+	}
+	if err = s.communicator.Start(control, mediaType, tracks, audio, conn); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	files := make(map[int]segmenterinterface.DemuxedFile, len(candidates))
+	defer func() {
+		for _, file := range files {
+			_ = file.Close()
+		}
+	}()
+
+	var (
+		current  = -1
+		position = from
+	)
+	for {
+		// wait while the stream is paused
+		if err = sess.Await(ctx); err != nil {
+			return nil
+		}
+
+		selected := selectCandidate(candidates, sess.RenditionHint(), sess.Bandwidth())
+		number, ok := selected.index.SegmentAt(position)
+		if !ok {
+			break
+		}
+		segment := selected.index.Segments[number]
+
+		file, ok := files[selected.number]
+		if !ok {
+			if file, err = s.open(selected.rendition.Resource, selectedAudio); err != nil {
+				if e := s.communicator.Error(control, err, conn); e != nil {
+					return s.logger.LogPropagate(e)
+				}
+				return s.logger.LogPropagate(err)
+			}
+			files[selected.number] = file
+		}
+
+		// the switched rendition must be initialized before its media segments
+		if selected.number != current {
+			if err = s.send(ctx, sess, conn, file, file.Range(selected.index.Init), 0,
+				func(seq int) protomodel.Frame { return protomodel.NewInitFrame(streamID, seq, segment.Start) },
+			); err != nil {
+				break
+			}
+			s.logger.Info(
+				fmt.Sprintf("[%v]: switched '%v' to rendition %d (%dp, %d bps) at %.3fs, bandwidth is %.0f bps",
+					conn.RemoteAddr(), video.Name, selected.number, selected.rendition.Height,
+					selected.bitrate(), segment.Start, sess.Bandwidth(),
+				),
+			)
+			current = selected.number
+		}
+
+		if err = s.send(ctx, sess, conn, file, file.Range(segment.Range), segment.Duration,
+			func(seq int) protomodel.Frame { return protomodel.NewMediaFrame(streamID, seq, segment.Start) },
+		); err != nil {
+			break
+		}
+		position = segment.Start + segment.Duration
+	}
+
+	// the interrupted stream is replaced or stopped by the control action
+	if ctx.Err() != nil {
+		s.logger.Info(fmt.Sprintf("[%v]: streaming '%v' is interrupted", conn.RemoteAddr(), video.Name))
+		return nil
+	}
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	if err = s.communicator.Stop(control, conn); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	return nil
+}
+
+// candidates - indexes the renditions of the video. Only the renditions which are packed in the same container
+// as the lowest one may be switched in the same SourceBuffer, so the others are skipped.
+func (s *AdaptiveStreamer) candidates(video *agg.Video) ([]candidate, error) {
+	var candidates []candidate
+	for number, rendition := range video.GetRenditions() {
+		index, err := s.segmenter.Index(rendition.Resource)
+		if err != nil {
+			return nil, s.logger.LogPropagate(err)
+		}
+		if len(candidates) > 0 && candidates[0].index.Container != index.Container {
+			continue
+		}
+		candidates = append(candidates, candidate{number: number, rendition: rendition, index: index})
+	}
+	if len(candidates) == 0 {
+		return nil, errtype.NewRenditionNotFoundError(0)
+	}
+	return candidates, nil
+}
+
+// open - opens the rendition file without the audio tracks which are not selected.
+func (s *AdaptiveStreamer) open(resource entity.Resource, audio int) (segmenterinterface.DemuxedFile, error) {
+	file, err := s.storage.Open(resource.GetUserID(), resource.GetFilename())
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	demuxed, err := s.demuxer.Demux(file, audio)
+	if err != nil {
+		_ = file.Close()
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	return demuxed, nil
+}
+
+// send - reads the given range of the rendition file and sends it as a single frame. The throughput
+// of the sending is accounted by the session for the next rendition selection.
+func (s *AdaptiveStreamer) send(
+	ctx context.Context,
+	sess *session.Session,
+	conn *websocket.Conn,
+	file fileinterface.File,
+	r segmentermodel.Range,
+	seconds float64,
+	frame func(seq int) protomodel.Frame,
+) error {
+	chunk := readermodel.NewChunk(r.Length, r.Length)
+	if _, err := file.ReadAt(chunk.Data, r.Offset); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	// wait while the stream is too far ahead of the client
+	seq, err := sess.Acquire(ctx, seconds)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	if err = s.communicator.Send(frame(seq), chunk, conn); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+	sess.Measure(int(r.Length), time.Since(start))
+
+	return nil
+}
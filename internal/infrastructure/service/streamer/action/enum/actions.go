@@ -3,6 +3,20 @@ package enum
 const (
 	StreamByID           Actions = "ID"
 	StreamByIDWithOffset Actions = "ID_WITH_OFFSET"
+	StreamAudioByID      Actions = "AUDIO_ID"
+	// playback control actions, they are affecting the in-flight stream of the connection
+	Pause  Actions = "PAUSE"
+	Resume Actions = "RESUME"
+	Seek   Actions = "SEEK"
+	Stop   Actions = "STOP"
+	Switch Actions = "SWITCH"
+	// flow control actions, they are pacing the in-flight stream of the connection
+	Ack    Actions = "ACK"
+	Buffer Actions = "BUFFER"
+	// adaptive streaming actions, they are affecting the rendition selection of the connection streams
+	Rendition Actions = "RENDITION"
+	// audio track selection, it restarts the in-flight stream with the selected track
+	Audio Actions = "AUDIO"
 )
 
 type Actions string
@@ -0,0 +1,53 @@
+package strategy
+
+import (
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
+)
+
+type FlowControlActionStrategy struct {
+	logger loggerinterface.Logger
+}
+
+func NewFlowControlActionStrategy(serviceContainer diinterface.ServiceContainer) (*FlowControlActionStrategy, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	return &FlowControlActionStrategy{
+		logger: loggerService,
+	}, nil
+}
+
+// IsAppropriate - method will tell the service architect that the strategy is acceptable.
+func (s *FlowControlActionStrategy) IsAppropriate(action model.Action) bool {
+	return action.Do == enum.Ack || action.Do == enum.Buffer
+}
+
+// Do - will pass the client acknowledgement or buffered-ahead report to the in-flight stream of the connection.
+// Late reports (for example, after the stream was finished) are expected, so they are just ignored.
+func (s *FlowControlActionStrategy) Do(action model.Action) error {
+	var isAccepted bool
+	switch data := action.Data.(type) {
+	case *model.AckData:
+		isAccepted = action.Session.Ack(data.Seq)
+	case *model.BufferData:
+		isAccepted = action.Session.Report(data.Ahead)
+	default:
+		return s.logger.CriticalPropagate(
+			fmt.Errorf("'flow control' strategy cannot handle the given data '%+v'", action.Data),
+		)
+	}
+
+	if !isAccepted {
+		s.logger.Info(fmt.Sprintf("[%v]: action '%v' with data '%+v' was ignored",
+			action.Conn.RemoteAddr(), action.Do, action.Data),
+		)
+	}
+
+	return nil
+}
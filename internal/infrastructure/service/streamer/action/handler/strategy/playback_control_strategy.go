@@ -0,0 +1,71 @@
+package strategy
+
+import (
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
+	protointerface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
+	protomodel "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/model"
+)
+
+type PlaybackControlActionStrategy struct {
+	logger       loggerinterface.Logger
+	communicator protointerface.Communicator
+}
+
+func NewPlaybackControlActionStrategy(
+	serviceContainer diinterface.ServiceContainer,
+) (*PlaybackControlActionStrategy, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	webSocketCommunicator, err := serviceContainer.GetWebSocketCommunicatorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &PlaybackControlActionStrategy{
+		logger:       loggerService,
+		communicator: webSocketCommunicator,
+	}, nil
+}
+
+// IsAppropriate - method will tell the service architect that the strategy is acceptable.
+func (s *PlaybackControlActionStrategy) IsAppropriate(action model.Action) bool {
+	return action.Do == enum.Pause || action.Do == enum.Resume || action.Do == enum.Stop
+}
+
+// Do - will pause, resume or stop the in-flight stream of the connection.
+func (s *PlaybackControlActionStrategy) Do(action model.Action) error {
+	var isActive bool
+	switch action.Do {
+	case enum.Pause:
+		isActive = action.Session.Pause()
+	case enum.Resume:
+		isActive = action.Session.Resume()
+	case enum.Stop:
+		// the stream is finished here, so the connection is free for writing the stop message
+		if isActive = action.Session.Stop(); isActive {
+			if err := s.communicator.Stop(protomodel.NewControlFrame(action.Session.StreamID()), action.Conn); err != nil {
+				return s.logger.LogPropagate(err)
+			}
+		}
+	}
+
+	if !isActive {
+		err := errtype.NewNoActiveStreamError(action.Do.String())
+		if e := s.communicator.Error(protomodel.NewControlFrame(action.Session.StreamID()), err, action.Conn); e != nil {
+			return s.logger.LogPropagate(e)
+		}
+		return s.logger.LogPropagate(err)
+	}
+
+	s.logger.Info(fmt.Sprintf("[%v]: action '%v' applied to the current stream", action.Conn.RemoteAddr(), action.Do))
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package strategy
+
+import (
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
+)
+
+type RenditionActionStrategy struct {
+	logger loggerinterface.Logger
+}
+
+func NewRenditionActionStrategy(serviceContainer diinterface.ServiceContainer) (*RenditionActionStrategy, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	return &RenditionActionStrategy{
+		logger: loggerService,
+	}, nil
+}
+
+// IsAppropriate - method will tell the service architect that the strategy is acceptable.
+func (s *RenditionActionStrategy) IsAppropriate(action model.Action) bool {
+	return action.Do == enum.Rendition
+}
+
+// Do - will pin the rendition of the connection streams by the client preferred height. The hint is kept by
+// the session, so it may be sent before the stream is started and the in-flight stream switches at the next segment.
+func (s *RenditionActionStrategy) Do(action model.Action) error {
+	data, ok := action.Data.(*model.RenditionData)
+	if !ok {
+		return s.logger.CriticalPropagate(
+			fmt.Errorf("'rendition' strategy cannot handle the given data '%+v'", action.Data),
+		)
+	}
+
+	height := data.Height
+	if height < 0 {
+		height = 0
+	}
+	action.Session.SetRenditionHint(height)
+
+	if height == 0 {
+		s.logger.Info(fmt.Sprintf("[%v]: rendition is selected automatically", action.Conn.RemoteAddr()))
+	} else {
+		s.logger.Info(fmt.Sprintf("[%v]: rendition is pinned to %dp", action.Conn.RemoteAddr(), height))
+	}
+
+	return nil
+}
@@ -0,0 +1,331 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	tokenizerinterface "github.com/Borislavv/video-streaming/internal/domain/service/tokenizer/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/helper"
+	detectorinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/detector/interface"
+	readerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/reader/interface"
+	readermodel "github.com/Borislavv/video-streaming/internal/infrastructure/service/reader/model"
+	segmenterinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/segmenter/interface"
+	segmentermodel "github.com/Borislavv/video-streaming/internal/infrastructure/service/segmenter/model"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
+	guardinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/guard/interface"
+	protointerface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
+	protomodel "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/model"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/session"
+	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
+	"github.com/gorilla/websocket"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type StreamAudioByIDActionStrategy struct {
+	ctx             context.Context
+	logger          loggerinterface.Logger
+	audioRepository repositoryinterface.Audio
+	reader          readerinterface.FileReader
+	codecInfo       detectorinterface.Codecs
+	durationInfo    detectorinterface.Duration
+	communicator    protointerface.Communicator
+	tokenizer       tokenizerinterface.Tokenizer
+	guard           guardinterface.TokenGuard
+	storage         fileinterface.Storage
+	segmenter       segmenterinterface.Segmenter
+}
+
+func NewStreamAudioByIDActionStrategy(serviceContainer diinterface.ServiceContainer) (*StreamAudioByIDActionStrategy, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	audioRepository, err := serviceContainer.GetAudioRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	fileReader, err := serviceContainer.GetFileReaderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	codecsDetector, err := serviceContainer.GetCodecsDetectorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	durationDetector, err := serviceContainer.GetDurationDetectorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	webSocketCommunicator, err := serviceContainer.GetWebSocketCommunicatorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	tokenizerService, err := serviceContainer.GetTokenizerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	tokenGuard, err := serviceContainer.GetTokenGuardService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	storageService, err := serviceContainer.GetFileStorageService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	segmenterService, err := serviceContainer.GetSegmenterService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &StreamAudioByIDActionStrategy{
+		ctx:             ctx,
+		logger:          loggerService,
+		audioRepository: audioRepository,
+		reader:          fileReader,
+		codecInfo:       codecsDetector,
+		durationInfo:    durationDetector,
+		communicator:    webSocketCommunicator,
+		tokenizer:       tokenizerService,
+		guard:           tokenGuard,
+		storage:         storageService,
+		segmenter:       segmenterService,
+	}, nil
+}
+
+// IsAppropriate - method will tell the service architect that the strategy is acceptable.
+func (s *StreamAudioByIDActionStrategy) IsAppropriate(action model.Action) bool {
+	return action.Do == enum.StreamAudioByID || (action.Do == enum.Seek && action.Session.Kind() == session.AudioStream)
+}
+
+// Do - will be streaming a target audio resource by ID. The start message of audio stream has no video codec.
+// The seek action restarts the current audio stream from the requested position.
+func (s *StreamAudioByIDActionStrategy) Do(action model.Action) error {
+	// the current stream will be replaced, so it must be stopped before anything is written to the connection
+	action.Session.Stop()
+
+	// check the data is eligible
+	var (
+		data *model.StreamByIdData
+		from float64
+	)
+	switch actionData := action.Data.(type) {
+	case *model.StreamByIdData:
+		data = actionData
+		action.Session.SetFlowControl(data.FlowControl)
+	case *model.SeekData:
+		audioID, token, _, ok := action.Session.Current()
+		if !ok {
+			err := errtype.NewNoActiveStreamError(action.Do.String())
+			if e := s.communicator.Error(protomodel.NewControlFrame(action.Session.StreamID()), err, action.Conn); e != nil {
+				return s.logger.LogPropagate(e)
+			}
+			return s.logger.LogPropagate(err)
+		}
+		data = &model.StreamByIdData{ID: audioID, Token: token}
+		from = actionData.From
+	default:
+		return s.logger.CriticalPropagate(
+			fmt.Errorf("'audio by id' strategy cannot handle the given data '%+v'", action.Data),
+		)
+	}
+
+	// user authentication
+	userID, err := s.tokenizer.Verify(data.Token)
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	// parse the given audio identifier
+	oid, err := primitive.ObjectIDFromHex(data.ID)
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	// find the target audio
+	q := dto.NewAudioGetRequestDTO(vo.NewID(oid), "", vo.ID{}, userID)
+	a, err := s.audioRepository.FindOneByID(s.ctx, q)
+	if err != nil {
+		if errtype.IsEntityNotFoundError(err) {
+			if err = s.communicator.Error(protomodel.NewControlFrame(action.Session.StreamID()), err, action.Conn); err != nil {
+				return s.logger.LogPropagate(err)
+			}
+		}
+		return s.logger.LogPropagate(err)
+	}
+
+	// the resource is being processed in background (or failed), its file is not playable yet
+	if !a.Resource.IsReady() {
+		err = errtype.NewResourceIsNotReadyError(a.Resource.GetName(), a.Resource.GetStatus())
+		if e := s.communicator.Error(protomodel.NewControlFrame(action.Session.StreamID()), err, action.Conn); e != nil {
+			return s.logger.LogPropagate(e)
+		}
+		return s.logger.LogPropagate(err)
+	}
+	s.logger.Info(fmt.Sprintf("[%v]: streaming audio 'resource':'%v'", action.Conn.RemoteAddr(), a.Resource.Name))
+
+	// audio resource streaming
+	action.Session.Run(session.AudioStream, data.ID, data.Token, "", func(ctx context.Context) {
+		err := s.guard.Run(ctx, data.Token, func(ctx context.Context) {
+			s.stream(ctx, action.Session, a.Resource, from, action.Conn)
+		})
+		// the stream of revoked token is interrupted
+		if err != nil {
+			if e := s.communicator.Error(protomodel.NewControlFrame(action.Session.StreamID()), err, action.Conn); e != nil {
+				s.logger.Error(fmt.Sprintf("[%v]: %v", action.Conn.RemoteAddr(), e.Error()))
+			}
+		}
+	})
+
+	return nil
+}
+
+// stream - reads the audio file by chunks from the given position and writes them to the connection.
+func (s *StreamAudioByIDActionStrategy) stream(
+	ctx context.Context,
+	sess *session.Session,
+	resource entity.Resource,
+	from float64,
+	conn *websocket.Conn,
+) {
+	streamID := sess.StreamID()
+	control := protomodel.NewControlFrame(streamID)
+
+	// detect the audio codec, the video one is dropped and the container is announced as audio
+	mediaType, err := s.codecInfo.Detect(resource)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		return
+	}
+
+	// the duration is used for estimate the length of chunks in seconds (flow control) and for validate the seek position
+	duration, err := s.durationInfo.Detect(resource)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		return
+	}
+	if from < 0 || (from > 0 && from >= duration) {
+		if err = s.communicator.Error(control, errtype.NewSeekIsOutOfRangeError(from, duration), conn); err != nil {
+			s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		}
+		return
+	}
+
+	// send the initializing message to client side
+	if err = s.communicator.Start(control, mediaType.AudioOnly(), nil, nil, conn); err != nil {
+		s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		return
+	}
+
+	// open the target resource file
+	file, err := s.storage.Open(resource.GetUserID(), resource.GetFilename())
+	if err != nil {
+		s.logger.Critical(fmt.Sprintf("[%v]: error resource opening: %v", conn.RemoteAddr(), err.Error()))
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	secondsPerByte := helper.SecondsPerByte(duration, file.Size())
+
+	// the stream starts from the fragment which contains the seek position
+	offset, position, initRange, err := s.seek(resource, file.Size(), from, duration)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		if err = s.communicator.Error(control, err, conn); err != nil {
+			s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		}
+		return
+	}
+
+	// the fragmented audio is decodable from the fragment only after the init segment
+	var seq int
+	if initRange != nil {
+		initChunk := readermodel.NewChunk(initRange.Length, initRange.Length)
+		if _, err = file.ReadAt(initChunk.Data, initRange.Offset); err != nil {
+			s.logger.Critical(fmt.Sprintf("[%v]: error init segment reading: %v", conn.RemoteAddr(), err.Error()))
+			return
+		}
+		if seq, err = sess.Acquire(ctx, 0); err != nil {
+			return
+		}
+		if err = s.communicator.Send(protomodel.NewInitFrame(streamID, seq, position), initChunk, conn); err != nil {
+			s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+			return
+		}
+	}
+
+	// read the target file by chunks from the seek offset
+	for chunk := range s.reader.ReadByChunks(ctx, file, offset) {
+		seconds := float64(chunk.GetLen()) * secondsPerByte
+
+		// wait while the stream is paused or too far ahead of the client
+		if err = sess.Await(ctx); err != nil {
+			break
+		}
+		if seq, err = sess.Acquire(ctx, seconds); err != nil {
+			break
+		}
+		if err = s.communicator.Send(protomodel.NewMediaFrame(streamID, seq, position), chunk, conn); err != nil {
+			s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err))
+			break
+		}
+		position += seconds
+	}
+
+	// the interrupted stream is replaced or stopped by the control action
+	if ctx.Err() != nil {
+		s.logger.Info(fmt.Sprintf("[%v]: streaming audio '%v' is interrupted", conn.RemoteAddr(), resource.Name))
+		return
+	}
+
+	// stop the streaming by sending appropriate message to client side
+	if err = s.communicator.Stop(control, conn); err != nil {
+		s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		return
+	}
+}
+
+// seek - finds the offset of the given position. The fragmented audio is started from the fragment which contains
+// the position and needs the init segment, the plain audio frames (mp3, adts) are resynced by the decoder, so their
+// offset is proportional to the position. Returns the zero offset without the init segment for the zero position.
+func (s *StreamAudioByIDActionStrategy) seek(
+	resource entity.Resource,
+	size int64,
+	from float64,
+	duration float64,
+) (offset int64, position float64, initRange *segmentermodel.Range, err error) {
+	if from == 0 {
+		return zeroOffset, 0, nil, nil
+	}
+
+	index, err := s.segmenter.Index(resource)
+	if err != nil {
+		if errtype.IsResourceIsNotSegmentableError(err) {
+			return int64(from / duration * float64(size)), from, nil, nil
+		}
+		return 0, 0, nil, s.logger.LogPropagate(err)
+	}
+
+	keyframe := index.KeyframeAt(from)
+	return keyframe.Offset, keyframe.Time, &index.Init, nil
+}
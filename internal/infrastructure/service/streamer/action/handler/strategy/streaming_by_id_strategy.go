@@ -7,30 +7,44 @@ import (
 	"github.com/Borislavv/video-streaming/internal/domain/entity"
 	"github.com/Borislavv/video-streaming/internal/domain/errtype"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
-	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
 	tokenizerinterface "github.com/Borislavv/video-streaming/internal/domain/service/tokenizer/interface"
+	videointerface "github.com/Borislavv/video-streaming/internal/domain/service/video/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/helper"
 	detectorinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/detector/interface"
+	manifestinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/manifest/interface"
 	readerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/reader/interface"
+	segmenterinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/segmenter/interface"
+	abrinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/abr/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
+	guardinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/guard/interface"
 	protointerface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
+	protomodel "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/model"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/session"
+	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
 	"github.com/gorilla/websocket"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"os"
 )
 
 const zeroOffset = 0
 
 type StreamByIDActionStrategy struct {
-	ctx             context.Context
-	logger          loggerinterface.Logger
-	videoRepository repositoryinterface.Video
-	reader          readerinterface.FileReader
-	codecInfo       detectorinterface.Codecs
-	communicator    protointerface.Communicator
-	tokenizer       tokenizerinterface.Tokenizer
+	ctx          context.Context
+	logger       loggerinterface.Logger
+	viewer       videointerface.Viewer
+	reader       readerinterface.FileReader
+	codecInfo    detectorinterface.Codecs
+	durationInfo detectorinterface.Duration
+	communicator protointerface.Communicator
+	tokenizer    tokenizerinterface.Tokenizer
+	guard        guardinterface.TokenGuard
+	adaptive     abrinterface.AdaptiveStreamer
+	storage      fileinterface.Storage
+	tracks       manifestinterface.TextTracks
+	audioTracks  detectorinterface.AudioTracks
+	demuxer      segmenterinterface.Demuxer
 }
 
 func NewStreamByIDActionStrategy(serviceContainer diinterface.ServiceContainer) (*StreamByIDActionStrategy, error) {
@@ -44,7 +58,7 @@ func NewStreamByIDActionStrategy(serviceContainer diinterface.ServiceContainer)
 		return nil, loggerService.LogPropagate(err)
 	}
 
-	videoRepository, err := serviceContainer.GetVideoRepository()
+	videoViewService, err := serviceContainer.GetVideoViewService()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
 	}
@@ -59,6 +73,11 @@ func NewStreamByIDActionStrategy(serviceContainer diinterface.ServiceContainer)
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	durationDetector, err := serviceContainer.GetDurationDetectorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	webSocketCommunicator, err := serviceContainer.GetWebSocketCommunicatorService()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
@@ -69,36 +88,102 @@ func NewStreamByIDActionStrategy(serviceContainer diinterface.ServiceContainer)
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	tokenGuard, err := serviceContainer.GetTokenGuardService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	adaptiveStreamer, err := serviceContainer.GetAdaptiveStreamerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	storageService, err := serviceContainer.GetFileStorageService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	textTracksService, err := serviceContainer.GetTextTracksService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	audioTracksDetector, err := serviceContainer.GetAudioTracksDetectorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	demuxerService, err := serviceContainer.GetDemuxerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	return &StreamByIDActionStrategy{
-		ctx:             ctx,
-		logger:          loggerService,
-		videoRepository: videoRepository,
-		reader:          fileReader,
-		codecInfo:       codecsDetector,
-		communicator:    webSocketCommunicator,
-		tokenizer:       tokenizerService,
+		ctx:          ctx,
+		logger:       loggerService,
+		viewer:       videoViewService,
+		reader:       fileReader,
+		codecInfo:    codecsDetector,
+		durationInfo: durationDetector,
+		communicator: webSocketCommunicator,
+		tokenizer:    tokenizerService,
+		guard:        tokenGuard,
+		adaptive:     adaptiveStreamer,
+		storage:      storageService,
+		tracks:       textTracksService,
+		audioTracks:  audioTracksDetector,
+		demuxer:      demuxerService,
 	}, nil
 }
 
 // IsAppropriate - method will tell the service architect that the strategy is acceptable.
 func (s *StreamByIDActionStrategy) IsAppropriate(action model.Action) bool {
-	return action.Do == enum.StreamByID
+	return action.Do == enum.StreamByID || action.Do == enum.Switch
 }
 
-// Do - will be streaming a target resource by ID.
+// Do - will be streaming a target resource by ID. The switch action replaces
+// the current stream of the connection by another video.
 func (s *StreamByIDActionStrategy) Do(action model.Action) error {
+	// the current stream will be replaced, so it must be stopped before anything is written to the connection
+	action.Session.Stop()
+
 	// check the data is eligible
-	data, ok := action.Data.(*model.StreamByIdData)
-	if !ok {
+	var data *model.StreamByIdData
+	switch actionData := action.Data.(type) {
+	case *model.StreamByIdData:
+		data = actionData
+		action.Session.SetFlowControl(data.FlowControl)
+		if hint := (vo.AudioHint{Index: data.Audio, Language: data.Language}); !hint.IsEmpty() {
+			action.Session.SetAudioHint(hint)
+		}
+	case *model.SwitchData:
+		// switching reuses the tokens of the current stream
+		_, token, share, ok := action.Session.Current()
+		if !ok {
+			err := errtype.NewNoActiveStreamError(action.Do.String())
+			if e := s.communicator.Error(protomodel.NewControlFrame(action.Session.StreamID()), err, action.Conn); e != nil {
+				return s.logger.LogPropagate(e)
+			}
+			return s.logger.LogPropagate(err)
+		}
+		data = &model.StreamByIdData{ID: actionData.ID, Token: token, Share: share}
+		// the index of track belongs to the previous video, only the preferred language is kept
+		action.Session.SetAudioHint(vo.AudioHint{Language: action.Session.AudioHint().Language})
+	default:
 		return s.logger.CriticalPropagate(
-			fmt.Errorf("'by id' strategy cannot handle the given data '%+v'", data),
+			fmt.Errorf("'by id' strategy cannot handle the given data '%+v'", action.Data),
 		)
 	}
 
-	// user authentication
-	userID, err := s.tokenizer.Verify(data.Token)
-	if err != nil {
-		return s.logger.LogPropagate(err)
+	// user authentication, the anonymous user is able to watch the shared and not private videos
+	var (
+		err    error
+		userID vo.ID
+	)
+	if data.Token != "" {
+		if userID, err = s.tokenizer.Verify(data.Token); err != nil {
+			return s.logger.LogPropagate(err)
+		}
 	}
 
 	// parse the given video resource identifier
@@ -108,46 +193,140 @@ func (s *StreamByIDActionStrategy) Do(action model.Action) error {
 	}
 
 	// find the target resource
-	q := dto.NewVideoGetRequestDTO(vo.NewID(oid), "", vo.ID{}, userID)
-	v, err := s.videoRepository.FindOneByID(s.ctx, q)
+	q := dto.NewVideoViewRequestDTO(vo.NewID(oid), userID, data.Share)
+	v, err := s.viewer.View(q)
 	if err != nil {
-		if errtype.IsEntityNotFoundError(err) {
-			if err = s.communicator.Error(err, action.Conn); err != nil {
+		if errtype.IsEntityNotFoundError(err) || errtype.IsAccessDeniedError(err) {
+			if err = s.communicator.Error(protomodel.NewControlFrame(action.Session.StreamID()), err, action.Conn); err != nil {
 				return s.logger.LogPropagate(err)
 			}
 		}
 		return s.logger.LogPropagate(err)
 	}
+
+	// the resource is being processed in background (or failed), its file is not playable yet
+	if !v.Resource.IsReady() {
+		err = errtype.NewResourceIsNotReadyError(v.Resource.GetName(), v.Resource.GetStatus())
+		if e := s.communicator.Error(protomodel.NewControlFrame(action.Session.StreamID()), err, action.Conn); e != nil {
+			return s.logger.LogPropagate(e)
+		}
+		return s.logger.LogPropagate(err)
+	}
 	s.logger.Info(fmt.Sprintf("[%v]: streaming 'resource':'%v'", action.Conn.RemoteAddr(), v.Resource.Name))
 
+	// the subtitle tracks are announced by the start message, the shared access is passed to their URLs
+	tracks, err := s.tracks.Tracks(v, data.Share)
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	// the audio track is selected by the client hint, the rest of audio tracks are not streamed
+	audio, err := s.selectAudio(action, v.Resource)
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
 	// video resource streaming
-	s.stream(v.Resource, action.Conn)
+	action.Session.Run(session.VideoStream, data.ID, data.Token, data.Share, func(ctx context.Context) {
+		err := s.guard.Run(ctx, data.Token, func(ctx context.Context) {
+			// the video which has the rendition ladder is streamed by segments of the selected renditions
+			if len(v.GetRenditions()) > 1 {
+				if err := s.adaptive.Stream(ctx, action.Session, v, zeroOffset, tracks, audio, action.Conn); err != nil {
+					s.logger.Error(fmt.Sprintf("[%v]: %v", action.Conn.RemoteAddr(), err.Error()))
+				}
+				return
+			}
+			s.stream(ctx, action.Session, v.Resource, tracks, audio, action.Conn)
+		})
+
+		// the token was revoked (logout) while streaming, the client is notified that the stream is interrupted
+		if err != nil {
+			if e := s.communicator.Error(protomodel.NewControlFrame(action.Session.StreamID()), err, action.Conn); e != nil {
+				s.logger.Error(fmt.Sprintf("[%v]: %v", action.Conn.RemoteAddr(), e.Error()))
+			}
+		}
+	})
 
 	return nil
 }
 
+// selectAudio - detects the audio tracks of the resource and marks the one which is requested by the client.
+func (s *StreamByIDActionStrategy) selectAudio(action model.Action, resource entity.Resource) ([]vo.AudioTrack, error) {
+	detected, err := s.audioTracks.Detect(resource)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	audio, err := s.audioTracks.Select(detected, action.Session.AudioHint())
+	if err != nil {
+		if errtype.IsAudioTrackNotFoundError(err) {
+			if e := s.communicator.Error(protomodel.NewControlFrame(action.Session.StreamID()), err, action.Conn); e != nil {
+				return nil, s.logger.LogPropagate(e)
+			}
+		}
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	return audio, nil
+}
+
 // stream - the method which composed all useful work of really streaming.
-func (s *StreamByIDActionStrategy) stream(resource entity.Resource, conn *websocket.Conn) {
-	// detect the audio and video codecs
-	audioCodec, videoCodec, err := s.codecInfo.Detect(resource)
+func (s *StreamByIDActionStrategy) stream(
+	ctx context.Context,
+	sess *session.Session,
+	resource entity.Resource,
+	tracks []vo.TextTrack,
+	audio []vo.AudioTrack,
+	conn *websocket.Conn,
+) {
+	streamID := sess.StreamID()
+	control := protomodel.NewControlFrame(streamID)
+
+	// detect the container and the audio and video codecs
+	mediaType, err := s.codecInfo.Detect(resource)
 	if err != nil {
 		s.logger.Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
 		return
 	}
 
-	// send the initializing message to client side
-	if err = s.communicator.Start(audioCodec, videoCodec, conn); err != nil {
-		s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+	// the duration is used for estimate the length of chunks in seconds (flow control)
+	duration, err := s.durationInfo.Detect(resource)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
 		return
 	}
 
+	// the codec of the selected audio track is announced instead of the first one
+	selected := vo.SelectedAudioTrack(audio)
+	if len(audio) > 0 && audio[selected].Codec != "" {
+		mediaType.AudioCodec = audio[selected].Codec
+	}
+
 	// open the target resource file
-	file, err := os.Open(resource.GetFilepath())
+	original, err := s.storage.Open(resource.GetUserID(), resource.GetFilename())
 	if err != nil {
 		s.logger.Critical(fmt.Sprintf("[%v]: error resource opening: %v", conn.RemoteAddr(), err.Error()))
 		return
 	}
-	defer func() { _ = file.Close() }()
+	defer func() { _ = original.Close() }()
+
+	// the file is read without the audio tracks which are not selected
+	file, err := s.demuxer.Demux(original, selected)
+	if err != nil {
+		if e := s.communicator.Error(control, err, conn); e != nil {
+			s.logger.Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), e.Error()))
+		}
+		s.logger.Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		return
+	}
+
+	// send the initializing message to client side
+	if err = s.communicator.Start(control, mediaType, tracks, audio, conn); err != nil {
+		s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		return
+	}
+
+	secondsPerByte := helper.SecondsPerByte(duration, file.Size())
 
 	// read the whole target file
 	//chunk := s.reader.ReadAll(file)
@@ -163,11 +342,25 @@ func (s *StreamByIDActionStrategy) stream(resource entity.Resource, conn *websoc
 	//)
 
 	// read the target file by chunks from zero offset
-	for chunk := range s.reader.ReadByChunks(file, zeroOffset) {
-		if err = s.communicator.Send(chunk, conn); err != nil {
+	var (
+		seq      int
+		position float64
+	)
+	for chunk := range s.reader.ReadByChunks(ctx, file, zeroOffset) {
+		seconds := float64(chunk.GetLen()) * secondsPerByte
+
+		// wait while the stream is paused or too far ahead of the client
+		if err = sess.Await(ctx); err != nil {
+			break
+		}
+		if seq, err = sess.Acquire(ctx, seconds); err != nil {
+			break
+		}
+		if err = s.communicator.Send(protomodel.NewMediaFrame(streamID, seq, position), chunk, conn); err != nil {
 			s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err))
 			break
 		}
+		position += seconds
 
 		s.logger.Info(
 			fmt.Sprintf("[%v]: wrote %d bytes of '%v' to websocket",
@@ -176,8 +369,14 @@ func (s *StreamByIDActionStrategy) stream(resource entity.Resource, conn *websoc
 		)
 	}
 
+	// the interrupted stream is replaced or stopped by the control action
+	if ctx.Err() != nil {
+		s.logger.Info(fmt.Sprintf("[%v]: streaming '%v' is interrupted", conn.RemoteAddr(), resource.Name))
+		return
+	}
+
 	// stop the streaming by sending appropriate message to client side
-	if err = s.communicator.Stop(conn); err != nil {
+	if err = s.communicator.Stop(control, conn); err != nil {
 		s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
 		return
 	}
@@ -3,76 +3,217 @@ package strategy
 import (
 	"context"
 	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
 	"github.com/Borislavv/video-streaming/internal/domain/dto"
 	"github.com/Borislavv/video-streaming/internal/domain/entity"
 	"github.com/Borislavv/video-streaming/internal/domain/errtype"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
-	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
 	tokenizerinterface "github.com/Borislavv/video-streaming/internal/domain/service/tokenizer/interface"
+	videointerface "github.com/Borislavv/video-streaming/internal/domain/service/video/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/helper"
 	detectorinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/detector/interface"
+	manifestinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/manifest/interface"
 	readerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/reader/interface"
+	readermodel "github.com/Borislavv/video-streaming/internal/infrastructure/service/reader/model"
+	segmenterinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/segmenter/interface"
+	segmentermodel "github.com/Borislavv/video-streaming/internal/infrastructure/service/segmenter/model"
+	abrinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/abr/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
+	guardinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/guard/interface"
 	protointerface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
+	protomodel "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/model"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/session"
+	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
 	"github.com/gorilla/websocket"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"math"
-	"os"
 )
 
 type StreamByIDWithOffsetActionStrategy struct {
-	ctx             context.Context
-	logger          loggerinterface.Logger
-	videoRepository repositoryinterface.Video
-	reader          readerinterface.FileReader
-	codecInfo       detectorinterface.Codecs
-	communicator    protointerface.Communicator
-	tokenizer       tokenizerinterface.Tokenizer
-	chunkSize       int
+	ctx          context.Context
+	logger       loggerinterface.Logger
+	viewer       videointerface.Viewer
+	reader       readerinterface.FileReader
+	codecInfo    detectorinterface.Codecs
+	durationInfo detectorinterface.Duration
+	segmenter    segmenterinterface.Segmenter
+	communicator protointerface.Communicator
+	tokenizer    tokenizerinterface.Tokenizer
+	guard        guardinterface.TokenGuard
+	adaptive     abrinterface.AdaptiveStreamer
+	storage      fileinterface.Storage
+	tracks       manifestinterface.TextTracks
+	audioTracks  detectorinterface.AudioTracks
+	demuxer      segmenterinterface.Demuxer
 }
 
 func NewStreamByIDWithOffsetActionStrategy(
-	ctx context.Context,
-	logger loggerinterface.Logger,
-	videoRepository repositoryinterface.Video,
-	reader readerinterface.FileReader,
-	codecInfo detectorinterface.Codecs,
-	communicator protointerface.Communicator,
-	tokenizer tokenizerinterface.Tokenizer,
-	chunkSize int,
-) *StreamByIDWithOffsetActionStrategy {
-	return &StreamByIDWithOffsetActionStrategy{
-		ctx:             ctx,
-		logger:          logger,
-		videoRepository: videoRepository,
-		reader:          reader,
-		codecInfo:       codecInfo,
-		communicator:    communicator,
-		tokenizer:       tokenizer,
-		chunkSize:       chunkSize,
+	serviceContainer diinterface.ServiceContainer,
+) (*StreamByIDWithOffsetActionStrategy, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	videoViewService, err := serviceContainer.GetVideoViewService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	fileReader, err := serviceContainer.GetFileReaderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	codecsDetector, err := serviceContainer.GetCodecsDetectorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	durationDetector, err := serviceContainer.GetDurationDetectorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	segmenterService, err := serviceContainer.GetSegmenterService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	webSocketCommunicator, err := serviceContainer.GetWebSocketCommunicatorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	tokenizerService, err := serviceContainer.GetTokenizerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	tokenGuard, err := serviceContainer.GetTokenGuardService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	adaptiveStreamer, err := serviceContainer.GetAdaptiveStreamerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
 	}
+
+	storageService, err := serviceContainer.GetFileStorageService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	textTracksService, err := serviceContainer.GetTextTracksService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	audioTracksDetector, err := serviceContainer.GetAudioTracksDetectorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	demuxerService, err := serviceContainer.GetDemuxerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &StreamByIDWithOffsetActionStrategy{
+		ctx:          ctx,
+		logger:       loggerService,
+		viewer:       videoViewService,
+		reader:       fileReader,
+		codecInfo:    codecsDetector,
+		durationInfo: durationDetector,
+		segmenter:    segmenterService,
+		communicator: webSocketCommunicator,
+		tokenizer:    tokenizerService,
+		guard:        tokenGuard,
+		adaptive:     adaptiveStreamer,
+		storage:      storageService,
+		tracks:       textTracksService,
+		audioTracks:  audioTracksDetector,
+		demuxer:      demuxerService,
+	}, nil
 }
 
 // IsAppropriate - method will tell the service architect that the strategy is acceptable.
 func (s *StreamByIDWithOffsetActionStrategy) IsAppropriate(action model.Action) bool {
-	return action.Do == enum.StreamByIDWithOffset
+	return action.Do == enum.StreamByIDWithOffset || action.Do == enum.Audio ||
+		(action.Do == enum.Seek && action.Session.Kind() == session.VideoStream)
 }
 
-// Do - will be streaming a target resource by ID from given offset.
+// Do - will be streaming a target resource by ID from given offset. The seek action restarts
+// the current stream of the connection from the requested position, the audio action restarts
+// it with the selected audio track.
 func (s *StreamByIDWithOffsetActionStrategy) Do(action model.Action) error {
+	// the audio track hint is kept by the session, so it may be sent before the stream is started
+	if actionData, ok := action.Data.(*model.AudioData); ok {
+		// the audio-only stream has no tracks to select
+		if _, _, _, ok = action.Session.Current(); ok && action.Session.Kind() == session.AudioStream {
+			err := errtype.NewUnsupportedActionError(action.Do.String())
+			if e := s.communicator.Error(protomodel.NewControlFrame(action.Session.StreamID()), err, action.Conn); e != nil {
+				return s.logger.LogPropagate(e)
+			}
+			return s.logger.LogPropagate(err)
+		}
+		action.Session.SetAudioHint(vo.AudioHint{Index: actionData.Audio, Language: actionData.Language})
+		if _, _, _, ok = action.Session.Current(); !ok {
+			s.logger.Info(fmt.Sprintf("[%v]: audio track is selected for the next stream", action.Conn.RemoteAddr()))
+			return nil
+		}
+	}
+
+	// the current stream will be replaced, so it must be stopped before anything is written to the connection
+	action.Session.Stop()
+
 	// check the data is eligible
-	data, ok := action.Data.(*model.StreamByIdWithOffsetData)
-	if !ok {
+	var data *model.StreamByIdWithOffsetData
+	switch actionData := action.Data.(type) {
+	case *model.StreamByIdWithOffsetData:
+		data = actionData
+		action.Session.SetFlowControl(data.FlowControl)
+		if hint := (vo.AudioHint{Index: data.Audio, Language: data.Language}); !hint.IsEmpty() {
+			action.Session.SetAudioHint(hint)
+		}
+	case *model.SeekData:
+		videoID, token, share, ok := action.Session.Current()
+		if !ok {
+			err := errtype.NewNoActiveStreamError(action.Do.String())
+			if e := s.communicator.Error(protomodel.NewControlFrame(action.Session.StreamID()), err, action.Conn); e != nil {
+				return s.logger.LogPropagate(e)
+			}
+			return s.logger.LogPropagate(err)
+		}
+		data = &model.StreamByIdWithOffsetData{ID: videoID, Token: token, Share: share, From: actionData.From}
+	case *model.AudioData:
+		// the current stream is restarted from the client position with the selected track
+		videoID, token, share, _ := action.Session.Current()
+		data = &model.StreamByIdWithOffsetData{ID: videoID, Token: token, Share: share, From: actionData.From}
+	default:
 		return s.logger.CriticalPropagate(
-			fmt.Errorf("'by id with offset' strategy cannot handle the given data '%+v'", data),
+			fmt.Errorf("'by id with offset' strategy cannot handle the given data '%+v'", action.Data),
 		)
 	}
 
-	// user authentication
-	userID, err := s.tokenizer.Verify(data.Token)
-	if err != nil {
-		return s.logger.LogPropagate(err)
+	// user authentication, the anonymous user is able to watch the shared and not private videos
+	var (
+		err    error
+		userID vo.ID
+	)
+	if data.Token != "" {
+		if userID, err = s.tokenizer.Verify(data.Token); err != nil {
+			return s.logger.LogPropagate(err)
+		}
 	}
 
 	// parse the given video resource identifier
@@ -82,66 +223,216 @@ func (s *StreamByIDWithOffsetActionStrategy) Do(action model.Action) error {
 	}
 
 	// searching the requested video resource
-	q := dto.NewVideoGetRequestDTO(vo.NewID(oid), "", vo.ID{}, userID)
-	v, err := s.videoRepository.FindOneByID(s.ctx, q)
+	q := dto.NewVideoViewRequestDTO(vo.NewID(oid), userID, data.Share)
+	v, err := s.viewer.View(q)
 	if err != nil {
-		if errtype.IsEntityNotFoundError(err) {
-			if err = s.communicator.Error(err, action.Conn); err != nil {
+		if errtype.IsEntityNotFoundError(err) || errtype.IsAccessDeniedError(err) {
+			if err = s.communicator.Error(protomodel.NewControlFrame(action.Session.StreamID()), err, action.Conn); err != nil {
 				return s.logger.LogPropagate(err)
 			}
 		}
 		return s.logger.LogPropagate(err)
 	}
+
+	// the resource is being processed in background (or failed), its file is not playable yet
+	if !v.Resource.IsReady() {
+		err = errtype.NewResourceIsNotReadyError(v.Resource.GetName(), v.Resource.GetStatus())
+		if e := s.communicator.Error(protomodel.NewControlFrame(action.Session.StreamID()), err, action.Conn); e != nil {
+			return s.logger.LogPropagate(e)
+		}
+		return s.logger.LogPropagate(err)
+	}
 	s.logger.Info(fmt.Sprintf("[%v]: streaming 'resource':'%v'", action.Conn.RemoteAddr(), v.Resource.Name))
 
+	// the subtitle tracks are announced by the start message, the shared access is passed to their URLs
+	tracks, err := s.tracks.Tracks(v, data.Share)
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	// the audio track is selected by the client hint, the rest of audio tracks are not streamed
+	audio, err := s.selectAudio(action, v.Resource)
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
 	// video resource streaming
-	s.stream(v.Resource, data, action.Conn)
+	action.Session.Run(session.VideoStream, data.ID, data.Token, data.Share, func(ctx context.Context) {
+		err := s.guard.Run(ctx, data.Token, func(ctx context.Context) {
+			s.stream(ctx, action.Session, v, tracks, audio, data, action.Conn)
+		})
+
+		// the token was revoked (logout) while streaming, the client is notified
+		if err != nil {
+			if e := s.communicator.Error(protomodel.NewControlFrame(action.Session.StreamID()), err, action.Conn); e != nil {
+				s.logger.Error(fmt.Sprintf("[%v]: %v", action.Conn.RemoteAddr(), e.Error()))
+			}
+		}
+	})
 
 	return nil
 }
 
-func (s *StreamByIDWithOffsetActionStrategy) stream(
+// selectAudio - detects the audio tracks of the resource and marks the one which is requested by the client.
+func (s *StreamByIDWithOffsetActionStrategy) selectAudio(
+	action model.Action,
 	resource entity.Resource,
+) ([]vo.AudioTrack, error) {
+	detected, err := s.audioTracks.Detect(resource)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	audio, err := s.audioTracks.Select(detected, action.Session.AudioHint())
+	if err != nil {
+		if errtype.IsAudioTrackNotFoundError(err) {
+			if e := s.communicator.Error(protomodel.NewControlFrame(action.Session.StreamID()), err, action.Conn); e != nil {
+				return nil, s.logger.LogPropagate(e)
+			}
+		}
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	return audio, nil
+}
+
+// stream - streams the resource from the nearest keyframe at or before the requested time. The position is taken
+// from the container index (mp4 fragments, moov sample tables or webm cues), so the init segment is sent first and
+// the client side SourceBuffer is able to decode the following fragments immediately.
+func (s *StreamByIDWithOffsetActionStrategy) stream(
+	ctx context.Context,
+	sess *session.Session,
+	video *agg.Video,
+	tracks []vo.TextTrack,
+	audio []vo.AudioTrack,
 	data *model.StreamByIdWithOffsetData,
 	conn *websocket.Conn,
 ) {
-	audioCodec, videoCodec, err := s.codecInfo.Detect(resource)
+	resource := video.Resource
+	streamID := sess.StreamID()
+	control := protomodel.NewControlFrame(streamID)
+
+	mediaType, err := s.codecInfo.Detect(resource)
 	if err != nil {
 		s.logger.Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
 		return
 	}
 
-	if err = s.communicator.Start(audioCodec, videoCodec, conn); err != nil {
-		s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+	// the seek position is validated by the probed duration, the client-supplied one is not trusted
+	duration, err := s.durationInfo.Detect(resource)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		if err = s.communicator.Error(control, err, conn); err != nil {
+			s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		}
+		return
+	}
+	if data.From < 0 || data.From >= duration {
+		s.logger.Warning(
+			fmt.Sprintf("[%v]: seek to %.3fs of '%v' is out of range, duration is %.3fs",
+				conn.RemoteAddr(), data.From, resource.Name, duration,
+			),
+		)
+		if err = s.communicator.Error(control, errtype.NewSeekIsOutOfRangeError(data.From, duration), conn); err != nil {
+			s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		}
 		return
 	}
 
-	file, err := os.Open(resource.GetFilepath())
+	// the video which has the rendition ladder is streamed from the segment which contains the seek position
+	if len(video.GetRenditions()) > 1 {
+		if err = s.adaptive.Stream(ctx, sess, video, data.From, tracks, audio, conn); err != nil {
+			s.logger.Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		}
+		return
+	}
+
+	// build the container index for determine the seek position, the resources which cannot be indexed
+	// (mpeg-ts of the legacy uploads) are streamed as is from the offset which is proportional to the position
+	var initRange *segmentermodel.Range
+	index, err := s.segmenter.SeekIndex(resource)
+	if err != nil && !errtype.IsResourceIsNotSegmentableError(err) {
+		s.logger.Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		if err = s.communicator.Error(control, err, conn); err != nil {
+			s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		}
+		return
+	}
+
+	// the codec of the selected audio track is announced instead of the first one
+	selected := vo.SelectedAudioTrack(audio)
+	if len(audio) > 0 && audio[selected].Codec != "" {
+		mediaType.AudioCodec = audio[selected].Codec
+	}
+
+	original, err := s.storage.Open(resource.GetUserID(), resource.GetFilename())
 	if err != nil {
 		s.logger.Critical(fmt.Sprintf("[%v]: error resource opening: %v", conn.RemoteAddr(), err.Error()))
 		return
 	}
-	defer func() { _ = file.Close() }()
+	defer func() { _ = original.Close() }()
 
-	stat, err := file.Stat()
+	// the file is read without the audio tracks which are not selected, the index offsets are translated
+	file, err := s.demuxer.Demux(original, selected)
 	if err != nil {
-		s.logger.Critical(fmt.Sprintf("[%v]: error receiving resource stat: %v", conn.RemoteAddr(), err.Error()))
+		s.logger.Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		if err = s.communicator.Error(control, err, conn); err != nil {
+			s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		}
 		return
 	}
+	secondsPerByte := helper.SecondsPerByte(duration, file.Size())
+
+	keyframe := segmentermodel.Keyframe{Offset: int64(data.From / duration * float64(file.Size())), Time: data.From}
+	if index != nil {
+		keyframe = index.KeyframeAt(data.From)
+		initRange = &index.Init
+	}
 
-	totalChunks := stat.Size() / int64(s.chunkSize)
+	if err = s.communicator.Start(control, mediaType, tracks, audio, conn); err != nil {
+		s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		return
+	}
 
-	chunkDuration := data.Duration / float64(totalChunks)
+	// send the fresh init segment
+	var seq int
+	if initRange != nil {
+		demuxedInit := file.Range(*initRange)
+		initChunk := readermodel.NewChunk(demuxedInit.Length, demuxedInit.Length)
+		if _, err = file.ReadAt(initChunk.Data, demuxedInit.Offset); err != nil {
+			s.logger.Critical(fmt.Sprintf("[%v]: error init segment reading: %v", conn.RemoteAddr(), err.Error()))
+			return
+		}
+		if seq, err = sess.Acquire(ctx, 0); err != nil {
+			return
+		}
+		if err = s.communicator.Send(protomodel.NewInitFrame(streamID, seq, keyframe.Time), initChunk, conn); err != nil {
+			s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+			return
+		}
+	}
 
-	targetChunk := math.Ceil(data.From / chunkDuration)
+	s.logger.Info(
+		fmt.Sprintf("[%v]: seeking '%v' to %.3fs (keyframe at %.3fs, offset %d)",
+			conn.RemoteAddr(), resource.Name, data.From, keyframe.Time, keyframe.Offset,
+		),
+	)
 
-	offset := int64((s.chunkSize * int(targetChunk)) - s.chunkSize)
+	position := keyframe.Time
+	for chunk := range s.reader.ReadByChunks(ctx, file, file.Offset(keyframe.Offset)) {
+		seconds := float64(chunk.GetLen()) * secondsPerByte
 
-	for chunk := range s.reader.ReadByChunks(file, offset) {
-		if err = s.communicator.Send(chunk, conn); err != nil {
+		if err = sess.Await(ctx); err != nil {
+			break
+		}
+		if seq, err = sess.Acquire(ctx, seconds); err != nil {
+			break
+		}
+		if err = s.communicator.Send(protomodel.NewMediaFrame(streamID, seq, position), chunk, conn); err != nil {
 			s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
 			break
 		}
+		position += seconds
 
 		s.logger.Info(
 			fmt.Sprintf("[%v]: wrote %d bytes of '%v' to websocket",
@@ -150,7 +441,13 @@ func (s *StreamByIDWithOffsetActionStrategy) stream(
 		)
 	}
 
-	if err = s.communicator.Stop(conn); err != nil {
+	// the interrupted stream is replaced or stopped by the control action
+	if ctx.Err() != nil {
+		s.logger.Info(fmt.Sprintf("[%v]: streaming '%v' is interrupted", conn.RemoteAddr(), resource.Name))
+		return
+	}
+
+	if err = s.communicator.Stop(control, conn); err != nil {
 		s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
 		return
 	}
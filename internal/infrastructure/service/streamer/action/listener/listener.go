@@ -1,25 +1,40 @@
 package listener
 
 import (
+	"context"
 	"fmt"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
 	protointerface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/session"
 	"github.com/gorilla/websocket"
 	"sync"
 )
 
 var (
 	supportedActionsMap = map[enum.Actions]struct{}{
-		enum.StreamByID: {},
+		enum.StreamByID:           {},
+		enum.StreamByIDWithOffset: {},
+		enum.StreamAudioByID:      {},
+		enum.Pause:                {},
+		enum.Resume:               {},
+		enum.Seek:                 {},
+		enum.Stop:                 {},
+		enum.Switch:               {},
+		enum.Ack:                  {},
+		enum.Buffer:               {},
+		enum.Rendition:            {},
+		enum.Audio:                {},
 	}
 )
 
 type WebSocketActionsListener struct {
-	logger       loggerinterface.Logger
-	communicator protointerface.Communicator
+	ctx              context.Context
+	logger           loggerinterface.Logger
+	communicator     protointerface.Communicator
+	maxBufferedAhead float64
 }
 
 func NewWebSocketActionsListener(serviceContainer diinterface.ServiceContainer) (*WebSocketActionsListener, error) {
@@ -28,24 +43,40 @@ func NewWebSocketActionsListener(serviceContainer diinterface.ServiceContainer)
 		return nil, err
 	}
 
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	webSocketCommunicatorService, err := serviceContainer.GetWebSocketCommunicatorService()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
 	}
 
 	return &WebSocketActionsListener{
-		logger:       loggerService,
-		communicator: webSocketCommunicatorService,
+		ctx:              ctx,
+		logger:           loggerService,
+		communicator:     webSocketCommunicatorService,
+		maxBufferedAhead: cfg.StreamingMaxBufferedAhead,
 	}, nil
 }
 
 func (l *WebSocketActionsListener) Listen(wg *sync.WaitGroup, conn *websocket.Conn) <-chan model.Action {
 	actionsCh := make(chan model.Action, 1)
 
+	// the session lives as long as the connection is read
+	sess := session.NewSession(l.ctx, l.maxBufferedAhead)
+
 	wg.Add(1)
 	go func() {
 		defer func() {
 			close(actionsCh)
+			sess.Close()
 			wg.Done()
 		}()
 
@@ -60,13 +91,13 @@ func (l *WebSocketActionsListener) Listen(wg *sync.WaitGroup, conn *websocket.Co
 				return
 			}
 			if t == websocket.TextMessage {
-				do, data, err := l.communicator.Parse(b)
+				do, data, err := l.communicator.Parse(b, conn)
 				if err != nil {
 					l.logger.Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
 					return
 				}
 				if _, isSupported := supportedActionsMap[do]; isSupported {
-					actionsCh <- model.Action{Do: do, Data: data, Conn: conn}
+					actionsCh <- model.Action{Do: do, Data: data, Conn: conn, Session: sess}
 					l.logger.Info(fmt.Sprintf("action '%v' with data '%v' received", do, data))
 				} else {
 					l.logger.Critical(fmt.Sprintf("do: %+v, data: %+v received unsupport action", do, data))
@@ -2,11 +2,13 @@ package model
 
 import (
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/session"
 	"github.com/gorilla/websocket"
 )
 
 type Action struct {
-	Do   enum.Actions
-	Data interface{}
-	Conn *websocket.Conn
+	Do      enum.Actions
+	Data    interface{}
+	Conn    *websocket.Conn
+	Session *session.Session
 }
@@ -1,13 +1,49 @@
 package model
 
 type StreamByIdData struct {
-	ID    string `json:"id"`
-	Token string `json:"token"`
+	ID          string `json:"id"`
+	Token       string `json:"token"`
+	Share       string `json:"share"`
+	FlowControl bool   `json:"flowControl"`
+	Audio       *int   `json:"audio"`
+	Language    string `json:"language"`
 }
 
 type StreamByIdWithOffsetData struct {
-	ID       string  `json:"id"`
-	Token    string  `json:"token"`
+	ID          string  `json:"id"`
+	Token       string  `json:"token"`
+	Share       string  `json:"share"`
+	From        float64 `json:"from"`
+	Duration    float64 `json:"duration"`
+	FlowControl bool    `json:"flowControl"`
+	Audio       *int    `json:"audio"`
+	Language    string  `json:"language"`
+}
+
+type SeekData struct {
+	From float64 `json:"from"`
+}
+
+type SwitchData struct {
+	ID string `json:"id"`
+}
+
+type AckData struct {
+	Seq int `json:"seq"`
+}
+
+type BufferData struct {
+	Ahead float64 `json:"ahead"`
+}
+
+type AudioData struct {
+	// Audio is a number of the audio track, it takes precedence over the Language.
+	Audio    *int    `json:"audio"`
+	Language string  `json:"language"`
 	From     float64 `json:"from"`
-	Duration float64 `json:"duration"`
+}
+
+type RenditionData struct {
+	// Height is a preferred rendition height, zero means the automatic selection.
+	Height int `json:"height"`
 }
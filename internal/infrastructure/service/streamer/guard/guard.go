@@ -0,0 +1,94 @@
+package guard
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	tokenizerinterface "github.com/Borislavv/video-streaming/internal/domain/service/tokenizer/interface"
+	"sync"
+	"time"
+)
+
+// TokenGuard - checks the token of the running stream periodically, the token was valid when the stream
+// was started, so only the revocation (logout, blocking) interrupts it, but not the expiration.
+type TokenGuard struct {
+	logger    loggerinterface.Logger
+	tokenizer tokenizerinterface.Tokenizer
+	interval  time.Duration
+}
+
+func NewTokenGuard(serviceContainer diinterface.ServiceContainer) (*TokenGuard, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	tokenizerService, err := serviceContainer.GetTokenizerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	interval, err := time.ParseDuration(cfg.StreamingTokenCheckInterval)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &TokenGuard{
+		logger:    loggerService,
+		tokenizer: tokenizerService,
+		interval:  interval,
+	}, nil
+}
+
+// Run - the stream of anonymous viewer (empty token) is not guarded.
+func (g *TokenGuard) Run(ctx context.Context, token string, stream func(ctx context.Context)) error {
+	if token == "" {
+		stream(ctx)
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu         = &sync.Mutex{}
+		revocation error
+	)
+
+	go func() {
+		ticker := time.NewTicker(g.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := g.tokenizer.IsRevoked(token); err != nil {
+					if !errtype.IsAccessTokenRevokedError(err) {
+						// the storage is not available, the stream is not interrupted by the check failure
+						g.logger.Log(err)
+						continue
+					}
+					mu.Lock()
+					revocation = err
+					mu.Unlock()
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	stream(ctx)
+
+	mu.Lock()
+	defer mu.Unlock()
+	return revocation
+}
@@ -0,0 +1,9 @@
+package guardinterface
+
+import "context"
+
+type TokenGuard interface {
+	// Run - runs the stream while its token is not revoked. The stream is interrupted by the context
+	// as soon as the revocation is detected and the revocation error is returned.
+	Run(ctx context.Context, token string, stream func(ctx context.Context)) error
+}
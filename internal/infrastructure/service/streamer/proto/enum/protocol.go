@@ -0,0 +1,20 @@
+package enum
+
+const (
+	// V0 - is a legacy protocol of plain text control messages and raw binary chunks,
+	// it's used when the client does not request any subprotocol.
+	V0 Protocol = "v0"
+	// V1 - is a protocol of binary frames with a header and JSON control messages.
+	V1 Protocol = "v1"
+)
+
+type Protocol string
+
+func (p Protocol) String() string {
+	return string(p)
+}
+
+// Subprotocols - returns the supported values of Sec-WebSocket-Protocol header in order of preference.
+func Subprotocols() []string {
+	return []string{V1.String(), V0.String()}
+}
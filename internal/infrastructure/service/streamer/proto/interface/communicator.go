@@ -2,14 +2,22 @@ package protointerface
 
 import (
 	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
+	protomodel "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/model"
 	"github.com/gorilla/websocket"
 )
 
 type Communicator interface {
-	Start(audioCodec string, videoCodec string, conn *websocket.Conn) error
-	Send(chunk dtointerface.Chunk, conn *websocket.Conn) error
-	Parse(bytes []byte) (action enum.Actions, data interface{}, err error)
-	Error(err error, conn *websocket.Conn) error
-	Stop(conn *websocket.Conn) error
+	Start(
+		frame protomodel.Frame,
+		mediaType vo.MediaType,
+		subtitles []vo.TextTrack,
+		audio []vo.AudioTrack,
+		conn *websocket.Conn,
+	) error
+	Send(frame protomodel.Frame, chunk dtointerface.Chunk, conn *websocket.Conn) error
+	Parse(bytes []byte, conn *websocket.Conn) (action enum.Actions, data interface{}, err error)
+	Error(frame protomodel.Frame, err error, conn *websocket.Conn) error
+	Stop(frame protomodel.Frame, conn *websocket.Conn) error
 }
@@ -0,0 +1,33 @@
+package model
+
+type FrameKind uint8
+
+const (
+	ControlFrame FrameKind = iota
+	InitFrame
+	MediaFrame
+)
+
+// Frame - is a metadata of the message which is written to the connection.
+type Frame struct {
+	Kind FrameKind
+	// StreamID is a number of the stream within the connection (each started stream takes the next one).
+	StreamID uint32
+	// Seq is a sequence number of the init/media frame within the stream, it's used by the client
+	// for acknowledgement of consumed chunks. The control frames are not numbered.
+	Seq uint32
+	// Timestamp is an estimated media position of the frame in seconds.
+	Timestamp float64
+}
+
+func NewControlFrame(streamID uint32) Frame {
+	return Frame{Kind: ControlFrame, StreamID: streamID}
+}
+
+func NewInitFrame(streamID uint32, seq int, timestamp float64) Frame {
+	return Frame{Kind: InitFrame, StreamID: streamID, Seq: uint32(seq), Timestamp: timestamp}
+}
+
+func NewMediaFrame(streamID uint32, seq int, timestamp float64) Frame {
+	return Frame{Kind: MediaFrame, StreamID: streamID, Seq: uint32(seq), Timestamp: timestamp}
+}
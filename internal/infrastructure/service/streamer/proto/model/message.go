@@ -0,0 +1,46 @@
+package model
+
+import (
+	"encoding/json"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+)
+
+type ControlType string
+
+const (
+	StartControl ControlType = "start"
+	StopControl  ControlType = "stop"
+	ErrorControl ControlType = "error"
+)
+
+// ControlMessage - is a server control message, for example:
+//
+//	{"type":"start","audioCodec":"mp4a.40.2","videoCodec":"avc1.64001F","mimeType":"video/mp4",
+//	 "contentType":"video/mp4; codecs=\"avc1.64001F, mp4a.40.2\"",
+//	 "subtitles":[{"id":"...","language":"en","label":"English","kind":"subtitles","url":"..."}],
+//	 "audioTracks":[{"index":0,"language":"eng","codec":"mp4a.40.2","channels":2,"selected":true}]}
+//	{"type":"error","error":{"message":"...","type":"..."}}
+//	{"type":"stop"}
+//
+// The codecs are RFC 6381 strings, the contentType is passed to the MediaSource as is.
+// The subtitles are WebVTT text tracks of the video which the client attaches to the player, the audio tracks
+// are announced if the video has several of them, only the selected one is streamed.
+type ControlMessage struct {
+	Type        ControlType     `json:"type"`
+	AudioCodec  string          `json:"audioCodec,omitempty"`
+	VideoCodec  string          `json:"videoCodec,omitempty"`
+	MimeType    string          `json:"mimeType,omitempty"`
+	ContentType string          `json:"contentType,omitempty"`
+	Subtitles   []vo.TextTrack  `json:"subtitles,omitempty"`
+	AudioTracks []vo.AudioTrack `json:"audioTracks,omitempty"`
+	Error       interface{}     `json:"error,omitempty"`
+}
+
+// ActionMessage - is a client action message of v1 protocol, for example:
+//
+//	{"action":"ID","data":{"id":"...","token":"..."}}
+//	{"action":"PAUSE"}
+type ActionMessage struct {
+	Action string          `json:"action"`
+	Data   json.RawMessage `json:"data,omitempty"`
+}
@@ -5,25 +5,21 @@ import (
 	"errors"
 	"fmt"
 	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	errtypeinterface "github.com/Borislavv/video-streaming/internal/domain/errtype/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
+	protoenum "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/enum"
+	protomodel "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/model"
 	"github.com/gorilla/websocket"
-	"strings"
-)
-
-const (
-	// message parts separator
-	protoSeparator string = "::"
-	// message prefixes
-	startMsgPref string = "start"
-	errMsgPref   string = "error"
-	stopMsgPref  string = "stop"
 )
 
 type Communicator struct {
-	logger loggerinterface.Logger
+	logger    loggerinterface.Logger
+	protocols map[protoenum.Protocol]protocol
 }
 
 func NewWebSocketCommunicator(serviceContainer diinterface.ServiceContainer) (*Communicator, error) {
@@ -34,80 +30,150 @@ func NewWebSocketCommunicator(serviceContainer diinterface.ServiceContainer) (*C
 
 	return &Communicator{
 		logger: loggerService,
+		protocols: map[protoenum.Protocol]protocol{
+			protoenum.V0: v0{},
+			protoenum.V1: v1{},
+		},
 	}, nil
 }
 
-func (w *Communicator) Start(audioCodec string, videoCodec string, conn *websocket.Conn) error {
-	b := strings.Builder{}
-	b.WriteString(startMsgPref)
-	b.WriteString(protoSeparator)
-	b.WriteString(audioCodec)
-	b.WriteString(protoSeparator)
-	b.WriteString(videoCodec)
-	initMessage := b.String()
+func (w *Communicator) Start(
+	frame protomodel.Frame,
+	mediaType vo.MediaType,
+	subtitles []vo.TextTrack,
+	audio []vo.AudioTrack,
+	conn *websocket.Conn,
+) error {
+	message := &protomodel.ControlMessage{
+		Type:        protomodel.StartControl,
+		AudioCodec:  mediaType.AudioCodec,
+		VideoCodec:  mediaType.VideoCodec,
+		MimeType:    mediaType.MimeType,
+		ContentType: mediaType.String(),
+		Subtitles:   subtitles,
+	}
+	// the single audio track has nothing to choose from
+	if len(audio) > 1 {
+		message.AudioTracks = audio
+	}
 
 	// writing the stream initialization message in a websocket connection
-	if err := conn.WriteMessage(websocket.TextMessage, []byte(initMessage)); err != nil {
+	if err := w.write(frame, message, nil, conn); err != nil {
 		return w.logger.ErrorPropagate(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
 	}
 
 	return nil
 }
 
-func (w *Communicator) Send(chunk dtointerface.Chunk, conn *websocket.Conn) error {
+func (w *Communicator) Send(frame protomodel.Frame, chunk dtointerface.Chunk, conn *websocket.Conn) error {
 	if chunk.GetError() != nil {
 		return w.logger.CriticalPropagate(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), chunk.GetError().Error()))
 	}
 
-	if err := conn.WriteMessage(websocket.BinaryMessage, chunk.GetData()); err != nil {
+	if err := w.write(frame, nil, chunk.GetData(), conn); err != nil {
 		return w.logger.CriticalPropagate(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
 	}
 
 	return nil
 }
 
-func (w *Communicator) Parse(bytes []byte) (action enum.Actions, data interface{}, err error) {
-	p := strings.Split(string(bytes), protoSeparator)
-	if len(p) < 2 {
-		return "", nil, errors.New("unable to parse message, bad websocket request received")
+func (w *Communicator) Parse(bytes []byte, conn *websocket.Conn) (action enum.Actions, data interface{}, err error) {
+	action, jsonBytes, err := w.protocol(conn).decode(bytes)
+	if err != nil {
+		return "", nil, w.logger.LogPropagate(err)
 	}
 
-	strategy := p[0]
-	jsonBytes := []byte(p[1])
-
-	switch enum.Actions(strategy) {
-	case enum.StreamByID:
+	switch action {
+	case enum.StreamByID, enum.StreamAudioByID:
 		data = &model.StreamByIdData{}
-		if err = json.Unmarshal(jsonBytes, data); err != nil {
-			return "", nil, w.logger.LogPropagate(err)
-		}
-		return enum.StreamByID, data, nil
 	case enum.StreamByIDWithOffset:
 		data = &model.StreamByIdWithOffsetData{}
-		if err = json.Unmarshal(jsonBytes, data); err != nil {
-			return "", nil, w.logger.LogPropagate(err)
-		}
-		return enum.StreamByIDWithOffset, data, nil
+	case enum.Seek:
+		data = &model.SeekData{}
+	case enum.Switch:
+		data = &model.SwitchData{}
+	case enum.Ack:
+		data = &model.AckData{}
+	case enum.Buffer:
+		data = &model.BufferData{}
+	case enum.Rendition:
+		data = &model.RenditionData{}
+	case enum.Audio:
+		data = &model.AudioData{}
+	case enum.Pause, enum.Resume, enum.Stop:
+		// control actions without payload
+		return action, nil, nil
 	default:
 		return "", nil, fmt.Errorf(
-			"unable to parse message because received unknown strategy '%v'", strategy,
+			"unable to parse message because received unknown strategy '%v'", action,
 		)
 	}
+
+	if len(jsonBytes) == 0 {
+		return "", nil, errors.New("unable to parse message, bad websocket request received")
+	}
+
+	if err = json.Unmarshal(jsonBytes, data); err != nil {
+		return "", nil, w.logger.LogPropagate(err)
+	}
+
+	return action, data, nil
 }
 
-func (w *Communicator) Error(err error, conn *websocket.Conn) error {
-	msg := []byte(fmt.Sprintf("%v:%v", errMsgPref, err.Error()))
+// Error - sends a structured error message. Only public errors are exposed as is,
+// the rest of them are replaced by the internal server error.
+func (w *Communicator) Error(frame protomodel.Frame, err error, conn *websocket.Conn) error {
+	var payload interface{} = errtype.NewInternalServerError()
+	if publicErr, ok := err.(errtypeinterface.PublicError); ok {
+		payload = publicErr
+	}
+
+	message := &protomodel.ControlMessage{
+		Type:  protomodel.ErrorControl,
+		Error: payload,
+	}
 
-	if e := conn.WriteMessage(websocket.TextMessage, msg); e != nil {
+	if e := w.write(frame, message, nil, conn); e != nil {
 		return w.logger.CriticalPropagate(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), e.Error()))
 	}
 
 	return nil
 }
 
-func (w *Communicator) Stop(conn *websocket.Conn) error {
-	if err := conn.WriteMessage(websocket.TextMessage, []byte(stopMsgPref)); err != nil {
+func (w *Communicator) Stop(frame protomodel.Frame, conn *websocket.Conn) error {
+	message := &protomodel.ControlMessage{
+		Type: protomodel.StopControl,
+	}
+
+	if err := w.write(frame, message, nil, conn); err != nil {
 		return w.logger.CriticalPropagate(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
 	}
 	return nil
 }
+
+// protocol - returns the protocol negotiated through the Sec-WebSocket-Protocol header,
+// the legacy one is used when the client did not request any.
+func (w *Communicator) protocol(conn *websocket.Conn) protocol {
+	if p, ok := w.protocols[protoenum.Protocol(conn.Subprotocol())]; ok {
+		return p
+	}
+	return w.protocols[protoenum.V0]
+}
+
+func (w *Communicator) write(
+	frame protomodel.Frame,
+	message *protomodel.ControlMessage,
+	payload []byte,
+	conn *websocket.Conn,
+) error {
+	if message != nil {
+		frame.Kind = protomodel.ControlFrame
+	}
+
+	messageType, data, err := w.protocol(conn).encode(frame, message, payload)
+	if err != nil {
+		return err
+	}
+
+	return conn.WriteMessage(messageType, data)
+}
@@ -0,0 +1,15 @@
+package ws
+
+import (
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/model"
+)
+
+// protocol - is a wire format of the one version of streaming protocol.
+type protocol interface {
+	// encode - makes a websocket message of the frame. The control frames are carrying the message,
+	// the init/media frames are carrying the payload.
+	encode(frame model.Frame, message *model.ControlMessage, payload []byte) (messageType int, data []byte, err error)
+	// decode - splits the client message into the action and its raw JSON data (may be empty).
+	decode(bytes []byte) (action enum.Actions, data []byte, err error)
+}
@@ -0,0 +1,68 @@
+package ws
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/model"
+	"github.com/gorilla/websocket"
+	"strings"
+)
+
+const (
+	// message parts separator
+	protoSeparator string = "::"
+)
+
+// v0 - is a legacy protocol: 'start::audioCodec::videoCodec::mimeType::contentType[::[subtitles json]]', 'error::{json}'
+// and 'stop' text messages and raw binary chunks without any header. The client actions look like 'ACTION::{json}' or just 'ACTION'.
+type v0 struct{}
+
+func (p v0) encode(frame model.Frame, message *model.ControlMessage, payload []byte) (int, []byte, error) {
+	if frame.Kind != model.ControlFrame {
+		return websocket.BinaryMessage, payload, nil
+	}
+
+	b := strings.Builder{}
+	b.WriteString(string(message.Type))
+	switch message.Type {
+	case model.StartControl:
+		b.WriteString(protoSeparator)
+		b.WriteString(message.AudioCodec)
+		b.WriteString(protoSeparator)
+		b.WriteString(message.VideoCodec)
+		b.WriteString(protoSeparator)
+		b.WriteString(message.MimeType)
+		b.WriteString(protoSeparator)
+		b.WriteString(message.ContentType)
+		if len(message.Subtitles) > 0 {
+			t, err := json.Marshal(message.Subtitles)
+			if err != nil {
+				return 0, nil, err
+			}
+			b.WriteString(protoSeparator)
+			b.Write(t)
+		}
+	case model.ErrorControl:
+		e, err := json.Marshal(message.Error)
+		if err != nil {
+			return 0, nil, err
+		}
+		b.WriteString(protoSeparator)
+		b.Write(e)
+	}
+
+	return websocket.TextMessage, []byte(b.String()), nil
+}
+
+func (p v0) decode(bytes []byte) (enum.Actions, []byte, error) {
+	parts := strings.SplitN(string(bytes), protoSeparator, 2)
+	if parts[0] == "" {
+		return "", nil, errors.New("unable to parse message, bad websocket request received")
+	}
+
+	if len(parts) < 2 {
+		return enum.Actions(parts[0]), nil, nil
+	}
+	return enum.Actions(parts[0]), []byte(parts[1]), nil
+}
@@ -0,0 +1,61 @@
+package ws
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/model"
+	"github.com/gorilla/websocket"
+	"math"
+)
+
+const (
+	v1Version    byte = 1
+	v1HeaderSize      = 20
+)
+
+// v1 - is a protocol of binary frames, each of them starts with the header (big-endian):
+//
+//	offset  size  field
+//	0       1     version (1)
+//	1       1     kind (0 - control, 1 - init, 2 - media)
+//	2       2     reserved
+//	4       4     stream id
+//	8       4     sequence number
+//	12      8     media timestamp in milliseconds
+//
+// The payload of control frames is a JSON model.ControlMessage, the payload of init/media frames is a raw chunk.
+// The client actions are text messages with JSON model.ActionMessage.
+type v1 struct{}
+
+func (p v1) encode(frame model.Frame, message *model.ControlMessage, payload []byte) (int, []byte, error) {
+	if frame.Kind == model.ControlFrame {
+		b, err := json.Marshal(message)
+		if err != nil {
+			return 0, nil, err
+		}
+		payload = b
+	}
+
+	data := make([]byte, v1HeaderSize, v1HeaderSize+len(payload))
+	data[0] = v1Version
+	data[1] = byte(frame.Kind)
+	binary.BigEndian.PutUint32(data[4:8], frame.StreamID)
+	binary.BigEndian.PutUint32(data[8:12], frame.Seq)
+	binary.BigEndian.PutUint64(data[12:20], uint64(math.Round(frame.Timestamp*1000)))
+
+	return websocket.BinaryMessage, append(data, payload...), nil
+}
+
+func (p v1) decode(bytes []byte) (enum.Actions, []byte, error) {
+	message := &model.ActionMessage{}
+	if err := json.Unmarshal(bytes, message); err != nil {
+		return "", nil, err
+	}
+	if message.Action == "" {
+		return "", nil, errors.New("unable to parse message, action is not specified")
+	}
+
+	return enum.Actions(message.Action), message.Data, nil
+}
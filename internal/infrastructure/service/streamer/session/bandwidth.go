@@ -0,0 +1,55 @@
+package session
+
+import "time"
+
+const (
+	// bandwidthSmoothing is a weight of the last measurement in the moving average
+	bandwidthSmoothing = 0.3
+	// minMeasuredElapsed protects the estimation from the writes which were just buffered by the kernel
+	minMeasuredElapsed = time.Millisecond
+)
+
+// Measure - accounts the throughput of a single write to the connection into the exponentially weighted
+// moving average. The estimation is kept for the whole session, so the next stream starts from the known one.
+func (s *Session) Measure(bytes int, elapsed time.Duration) {
+	if bytes <= 0 {
+		return
+	}
+	if elapsed < minMeasuredElapsed {
+		elapsed = minMeasuredElapsed
+	}
+	bandwidth := float64(bytes*8) / elapsed.Seconds()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.bandwidth == 0 {
+		s.bandwidth = bandwidth
+		return
+	}
+	s.bandwidth = bandwidthSmoothing*bandwidth + (1-bandwidthSmoothing)*s.bandwidth
+}
+
+// Bandwidth - returns the estimated throughput of the connection in bits per second, zero if it's unknown yet.
+func (s *Session) Bandwidth() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.bandwidth
+}
+
+// SetRenditionHint - pins the rendition by the preferred height, zero enables the automatic selection.
+func (s *Session) SetRenditionHint(height int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.renditionHint = height
+}
+
+// RenditionHint - returns the preferred rendition height, zero if the rendition is selected automatically.
+func (s *Session) RenditionHint() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.renditionHint
+}
@@ -0,0 +1,119 @@
+package session
+
+import "context"
+
+// flow - is a client-paced delivery state of the current stream. Each sent chunk is numbered (the sequence starts
+// from zero for each stream) and accounted by its duration. The client either acknowledges the consumed (played)
+// chunks or reports how many seconds it has buffered ahead of the playback position, so the server is able
+// to hold the stream while it's too far ahead of the client.
+type flow struct {
+	enabled bool
+	// maxAhead is a number of seconds which may be sent ahead of the client playback position
+	maxAhead float64
+	// sent is a cumulative duration of the sent chunks by their sequence number
+	sent []float64
+	// base is a cumulative duration which was already consumed by the client at the moment of the last report
+	base float64
+	// reported is a number of buffered-ahead seconds from the last client report
+	reported float64
+	// notify is closed (and replaced) on each client report for wake up the awaiting stream
+	notify chan struct{}
+}
+
+func newFlow(maxAhead float64) *flow {
+	return &flow{
+		maxAhead: maxAhead,
+		notify:   make(chan struct{}),
+	}
+}
+
+func (f *flow) reset() {
+	f.sent = f.sent[:0]
+	f.base = 0
+	f.reported = 0
+	f.wakeUp()
+}
+
+func (f *flow) total() float64 {
+	if len(f.sent) == 0 {
+		return 0
+	}
+	return f.sent[len(f.sent)-1]
+}
+
+// ahead - is an estimated number of seconds which the client has, but not played yet.
+// The chunks which are still on the fly at the moment of report are not counted.
+func (f *flow) ahead() float64 {
+	return f.reported + f.total() - f.base
+}
+
+func (f *flow) wakeUp() {
+	close(f.notify)
+	f.notify = make(chan struct{})
+}
+
+// SetFlowControl - enables or disables the client-paced delivery for the further streams of the session.
+func (s *Session) SetFlowControl(enabled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.flow.enabled = enabled
+}
+
+// Acquire - blocks while the stream is too far ahead of the client (in the flow control mode only) and
+// then accounts the chunk of given duration as sent. Returns the sequence number of the chunk or an error
+// if the stream was interrupted. A chunk is always allowed when the client has nothing buffered ahead,
+// so a chunk which is longer than the window will not stall the stream.
+func (s *Session) Acquire(ctx context.Context, seconds float64) (seq int, err error) {
+	for {
+		s.mu.Lock()
+		f := s.flow
+		if ahead := f.ahead(); !f.enabled || ahead <= 0 || ahead+seconds <= f.maxAhead {
+			f.sent = append(f.sent, f.total()+seconds)
+			seq = len(f.sent) - 1
+			s.mu.Unlock()
+			return seq, ctx.Err()
+		}
+		notify := f.notify
+		s.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-notify:
+		}
+	}
+}
+
+// Ack - handles the client acknowledgement of all the chunks up to the given sequence number inclusive.
+// Returns false if the chunk with such sequence number was not sent yet.
+func (s *Session) Ack(seq int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f := s.flow
+	if seq < 0 || seq >= len(f.sent) {
+		return false
+	}
+	f.base = f.sent[seq]
+	f.reported = 0
+	f.wakeUp()
+
+	return true
+}
+
+// Report - handles the client report of its buffered-ahead seconds.
+func (s *Session) Report(ahead float64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if ahead < 0 {
+		return false
+	}
+	f := s.flow
+	f.base = f.total()
+	f.reported = ahead
+	f.wakeUp()
+
+	return true
+}
@@ -0,0 +1,213 @@
+package session
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"sync"
+)
+
+// Kind - is a kind of the stream, the control actions of the current stream are handled by its kind.
+type Kind int
+
+const (
+	VideoStream Kind = iota
+	AudioStream
+)
+
+// Session - is a state of a single websocket connection which owns the in-flight stream, so the control
+// actions (pause, resume, seek, stop, switch) are able to affect it while the handler keeps reading actions.
+//
+// The stream goroutine is the only writer to the connection while it's running. Actions which replace
+// the stream must stop the current one first and only then write anything to the connection.
+type Session struct {
+	mu       *sync.Mutex
+	ctx      context.Context
+	cancel   context.CancelFunc
+	done     chan struct{}
+	resumeCh chan struct{}
+	closed   bool
+	streamID uint32
+	kind     Kind
+	videoID  string
+	token    string
+	share    string
+	flow     *flow
+	// bandwidth is an estimated throughput of the connection in bits per second
+	bandwidth float64
+	// renditionHint is a rendition height which was preferred by the client
+	renditionHint int
+	// audioHint is an audio track which was preferred by the client
+	audioHint vo.AudioHint
+}
+
+func NewSession(ctx context.Context, maxBufferedAhead float64) *Session {
+	return &Session{
+		mu:   &sync.Mutex{},
+		ctx:  ctx,
+		flow: newFlow(maxBufferedAhead),
+	}
+}
+
+// Run - stops the current stream and runs the given one in a separate goroutine. The passed context
+// will be canceled as soon as the stream is stopped, replaced or the session is closed. The ID is
+// a video or an audio one by the kind of stream.
+func (s *Session) Run(kind Kind, videoID string, token string, share string, stream func(ctx context.Context)) {
+	s.Stop()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	done := make(chan struct{})
+
+	s.cancel = cancel
+	s.done = done
+	s.streamID++
+	s.kind = kind
+	s.videoID = videoID
+	s.token = token
+	s.share = share
+	s.flow.reset()
+
+	go func() {
+		defer func() {
+			cancel()
+			close(done)
+		}()
+		stream(ctx)
+	}()
+}
+
+// Stop - interrupts the current stream and waits until it's finished.
+// Returns false if there was no running stream.
+func (s *Session) Stop() bool {
+	s.mu.Lock()
+	cancel, done := s.cancel, s.done
+	s.cancel, s.done = nil, nil
+	s.release()
+	s.mu.Unlock()
+
+	if cancel == nil {
+		return false
+	}
+	cancel()
+	<-done
+
+	return true
+}
+
+// Close - stops the current stream, further streams will not be started.
+func (s *Session) Close() {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+
+	s.Stop()
+}
+
+// Pause - suspends the current stream before sending the next chunk.
+// Returns false if there is no running stream.
+func (s *Session) Pause() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.isActive() {
+		return false
+	}
+	if s.resumeCh == nil {
+		s.resumeCh = make(chan struct{})
+	}
+	return true
+}
+
+// Resume - continues the paused stream. Returns false if there is no running stream.
+func (s *Session) Resume() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.isActive() {
+		return false
+	}
+	s.release()
+	return true
+}
+
+// Await - blocks while the stream is paused. Returns an error if the stream was interrupted.
+func (s *Session) Await(ctx context.Context) error {
+	s.mu.Lock()
+	resumeCh := s.resumeCh
+	s.mu.Unlock()
+
+	if resumeCh != nil {
+		select {
+		case <-ctx.Done():
+		case <-resumeCh:
+		}
+	}
+
+	return ctx.Err()
+}
+
+// StreamID - returns the number of the last started stream within the session.
+func (s *Session) StreamID() uint32 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.streamID
+}
+
+// Current - returns the video (or audio, see Kind) identifier, the token and the share token of the last started stream.
+func (s *Session) Current() (videoID string, token string, share string, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.videoID, s.token, s.share, s.videoID != ""
+}
+
+// Kind - returns the kind of the last started stream.
+func (s *Session) Kind() Kind {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.kind
+}
+
+// SetAudioHint - keeps the preferred audio track for the next streams of the connection.
+func (s *Session) SetAudioHint(hint vo.AudioHint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.audioHint = hint
+}
+
+// AudioHint - returns the preferred audio track, the empty hint means the default one.
+func (s *Session) AudioHint() vo.AudioHint {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.audioHint
+}
+
+func (s *Session) isActive() bool {
+	if s.done == nil {
+		return false
+	}
+	select {
+	case <-s.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// release - unblocks the stream which is awaiting for resume.
+func (s *Session) release() {
+	if s.resumeCh != nil {
+		close(s.resumeCh)
+		s.resumeCh = nil
+	}
+}
@@ -3,6 +3,8 @@ package tokenizer
 import (
 	"context"
 	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
 	"github.com/Borislavv/video-streaming/internal/domain/errtype"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
@@ -18,8 +20,8 @@ type JwtService struct {
 	ctx                     context.Context
 	logger                  loggerinterface.Logger
 	blockedTokenRepository  repositoryinterface.BlockedToken
+	keySet                  *keySet
 	jwtTokenAcceptedIssuers []string
-	jwtSecretSalt           []byte
 	jwtTokenIssuer          string
 	jwtTokenEncryptAlgo     string
 	jwtTokenExpiresAfter    int64
@@ -46,27 +48,39 @@ func NewJwtService(serviceContainer diinterface.ServiceContainer) (*JwtService,
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	keySet, err := newKeySet(cfg.JwtTokenEncryptAlgo, cfg.JwtSecretSalt, cfg.JwtKeysDir, cfg.JwtSigningKeyID)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	return &JwtService{
 		ctx:                     ctx,
 		logger:                  loggerService,
 		blockedTokenRepository:  blockedTokenRepository,
+		keySet:                  keySet,
 		jwtTokenAcceptedIssuers: strings.Split(cfg.JwtTokenAcceptedIssuers, ","),
-		jwtSecretSalt:           []byte(cfg.JwtSecretSalt),
 		jwtTokenIssuer:          cfg.JwtTokenIssuer,
 		jwtTokenEncryptAlgo:     cfg.JwtTokenEncryptAlgo,
 		jwtTokenExpiresAfter:    cfg.JwtTokenExpiresAfter,
 	}, nil
 }
 
-// New will generate a new JWT.
-func (s *JwtService) New(user *agg.User) (token string, err error) {
-	tkn := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"sub": user.ID.Value.Hex(),
-		"iss": s.jwtTokenIssuer,
-		"exp": &jwt.NumericDate{Time: time.Now().Add(time.Second * time.Duration(s.jwtTokenExpiresAfter))},
+// New will generate a new JWT. The session is a family of refresh tokens which the access token is issued with,
+// the access token is revoked together with its session. The token is signed by the signing key of the key set
+// and refers to it by the `kid` header, so the verifier picks the same key after the signing key was rotated.
+func (s *JwtService) New(user *agg.User, session string) (token string, err error) {
+	tkn := jwt.NewWithClaims(s.keySet.method, jwt.MapClaims{
+		"sub":   user.ID.Value.Hex(),
+		"iss":   s.jwtTokenIssuer,
+		"sid":   session,
+		"roles": user.GetRoles(),
+		"exp":   &jwt.NumericDate{Time: time.Now().Add(time.Second * time.Duration(s.jwtTokenExpiresAfter))},
 	})
+	if s.keySet.signing.id != "" {
+		tkn.Header["kid"] = s.keySet.signing.id
+	}
 
-	if token, err = tkn.SignedString(s.jwtSecretSalt); err != nil {
+	if token, err = tkn.SignedString(s.keySet.signing.private); err != nil {
 		return "", s.logger.LogPropagate(err)
 	} else {
 		return token, nil
@@ -75,32 +89,23 @@ func (s *JwtService) New(user *agg.User) (token string, err error) {
 
 // Verify will decode the token and return a user ID or error, if it was occurred.
 func (s *JwtService) Verify(token string) (userID vo.ID, err error) {
-	parsedToken, err := jwt.Parse(token, func(decodedToken *jwt.Token) (interface{}, error) {
-		if decodedToken.Header["alg"] != s.jwtTokenEncryptAlgo {
-			// user must be banned here because the algo wasn't matched
-			return nil, errtype.NewTokenAlgoWasNotMatchedInternalError(token)
-		}
-		// cast to the configured givenToken signature type (stored in `s.jwtTokenEncryptAlgo`)
-		if _, success := decodedToken.Method.(*jwt.SigningMethodHMAC); !success {
-			return nil, errtype.NewTokenUnexpectedSigningMethodInternalError(token, decodedToken.Header["alg"])
-		}
-		// jwtSecretSalt is a string containing your secret, but you need pass the []byte
-		return s.jwtSecretSalt, nil
-	})
+	userID, _, err = s.VerifyWithRoles(token)
+	return userID, err
+}
+
+// VerifyWithRoles will decode the token and return a user ID with the user roles or error, if it was occurred.
+func (s *JwtService) VerifyWithRoles(token string) (userID vo.ID, roles []string, err error) {
+	parsedToken, err := jwt.Parse(token, s.keyFunc(token))
 	if err != nil {
 		// parsing givenToken error occurred
 		s.logger.Log(err)
 		// return a token invalid error
-		return vo.ID{}, s.logger.LogPropagate(errtype.NewAccessTokenIsInvalidError())
+		return vo.ID{}, nil, s.logger.LogPropagate(errtype.NewAccessTokenIsInvalidError())
 	}
 
-	// checking that token is not blocked
-	found, err := s.blockedTokenRepository.Has(s.ctx, parsedToken.Raw)
-	if err != nil {
-		return vo.ID{}, s.logger.LogPropagate(err)
-	}
-	if found {
-		return vo.ID{}, s.logger.LogPropagate(errtype.NewAccessTokenWasBlockedError())
+	// checking that token is not blocked and its session is not revoked
+	if err = s.checkRevocation(parsedToken); err != nil {
+		return vo.ID{}, nil, s.logger.LogPropagate(err)
 	}
 
 	// extracting claims of the givenToken payload
@@ -109,68 +114,181 @@ func (s *JwtService) Verify(token string) (userID vo.ID, err error) {
 			// the issuer is not valid, log it
 			s.logger.Log(err)
 			// return a token invalid error
-			return vo.ID{}, s.logger.LogPropagate(errtype.NewAccessTokenIsInvalidError())
+			return vo.ID{}, nil, s.logger.LogPropagate(errtype.NewAccessTokenIsInvalidError())
 		}
 
 		userID, err = s.getUserID(claims)
 		if err != nil {
-			return vo.ID{}, s.logger.LogPropagate(err)
+			return vo.ID{}, nil, s.logger.LogPropagate(err)
 		}
 
-		return userID, nil
+		return userID, s.getRoles(claims), nil
 	} else {
 		// error occurred while extracting claims from givenToken or givenToken is not valid
 		s.logger.Log(errtype.NewTokenInvalidInternalError(token))
 		// return a token invalid error
-		return vo.ID{}, s.logger.LogPropagate(errtype.NewAccessTokenIsInvalidError())
+		return vo.ID{}, nil, s.logger.LogPropagate(errtype.NewAccessTokenIsInvalidError())
 	}
 }
 
-// Block will mark the token as blocked into the storage.
-func (s *JwtService) Block(token string, reason string) error {
-	userID, err := s.parseUserID(token)
+// IsRevoked will check the token was not blocked and its session was not revoked. The expiration is not checked,
+// so it's used for the long operations which were started by the valid token (streaming).
+func (s *JwtService) IsRevoked(token string) error {
+	parsedToken, err := jwt.Parse(token, s.keyFunc(token), jwt.WithoutClaimsValidation())
 	if err != nil {
-		// block token anyway and log message,
-		// because the token may be is invalid but must be blocked.
-		// in this case a user will be undetermined
+		// parsing givenToken error occurred
 		s.logger.Log(err)
+		// return a token invalid error
+		return s.logger.LogPropagate(errtype.NewAccessTokenIsInvalidError())
 	}
 
-	if err = s.blockedTokenRepository.Insert(s.ctx, agg.NewBlockedToken(token, reason, userID)); err != nil {
+	if err = s.checkRevocation(parsedToken); err != nil {
 		return s.logger.LogPropagate(err)
 	}
 	return nil
 }
 
-func (s *JwtService) parseUserID(token string) (userID vo.ID, err error) {
-	parsedToken, err := jwt.Parse(token, func(decodedToken *jwt.Token) (interface{}, error) {
+// GetSession will decode the token and return the session which the token was issued with.
+// The token which was issued before the sessions were introduced has an empty one.
+func (s *JwtService) GetSession(token string) (session string, err error) {
+	parsedToken, err := jwt.Parse(token, s.keyFunc(token))
+	if err != nil {
+		// parsing givenToken error occurred
+		s.logger.Log(err)
+		// return a token invalid error
+		return "", s.logger.LogPropagate(errtype.NewAccessTokenIsInvalidError())
+	}
+
+	claims, success := parsedToken.Claims.(jwt.MapClaims)
+	if !success {
+		return "", s.logger.LogPropagate(errtype.NewAccessTokenIsInvalidError())
+	}
+
+	return s.getSession(claims), nil
+}
+
+// Block will mark the token as blocked into the storage until the token is expired. The token which signature
+// is not verified or which is expired is rejected by verification anyway, so it's not stored (otherwise, any
+// garbage sent by clients would be persisted).
+func (s *JwtService) Block(token string, reason string) error {
+	userID, expiresAt, err := s.parseClaims(token)
+	if err != nil {
+		// the error was logged while parsing
+		return nil
+	}
+	if expiresAt.IsZero() {
+		// the token which was issued by this service cannot live longer
+		expiresAt = time.Now().Add(time.Second * time.Duration(s.jwtTokenExpiresAfter))
+	}
+
+	blockedToken := agg.NewBlockedToken(token, reason, userID, expiresAt)
+	if blockedToken.IsExpired(time.Now()) {
+		return nil
+	}
+
+	// the blocked token fails each next verification, so it's not stored twice
+	found, err := s.blockedTokenRepository.Has(s.ctx, token)
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+	if found {
+		return nil
+	}
+
+	if err = s.blockedTokenRepository.Insert(s.ctx, blockedToken); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+	return nil
+}
+
+// BlockSession will mark the session as blocked into the storage, so all access tokens which were issued with it
+// are rejected. The session is blocked until the last of them is expired, the new ones are not issued because
+// the refresh tokens of the session are revoked.
+func (s *JwtService) BlockSession(session string, userID vo.ID, reason string) error {
+	expiresAt := time.Now().Add(time.Second * time.Duration(s.jwtTokenExpiresAfter))
+
+	if err := s.blockedTokenRepository.Insert(s.ctx, agg.NewBlockedSession(session, reason, userID, expiresAt)); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+	return nil
+}
+
+// parseClaims - verifies the signature and extracts the user ID and the expiration, the expired token is parsed too.
+// The user ID is empty if the token has no valid subject.
+func (s *JwtService) parseClaims(token string) (userID vo.ID, expiresAt time.Time, err error) {
+	parsedToken, err := jwt.Parse(token, s.keyFunc(token), jwt.WithoutClaimsValidation())
+	if err != nil {
+		// parsing givenToken error occurred
+		s.logger.Log(err)
+		// return a token invalid error
+		return vo.ID{}, time.Time{}, s.logger.LogPropagate(errtype.NewAccessTokenIsInvalidError())
+	}
+
+	claims, success := parsedToken.Claims.(jwt.MapClaims)
+	if !success {
+		return vo.ID{}, time.Time{}, s.logger.LogPropagate(errtype.NewAccessTokenIsInvalidError())
+	}
+
+	if exp, eerr := claims.GetExpirationTime(); eerr == nil && exp != nil {
+		expiresAt = exp.Time
+	}
+
+	// the error is logged by the getter, the verified token is blocked with the undetermined user anyway
+	userID, _ = s.getUserID(claims)
+	return userID, expiresAt, nil
+}
+
+func (s *JwtService) keyFunc(token string) jwt.Keyfunc {
+	return func(decodedToken *jwt.Token) (interface{}, error) {
 		if decodedToken.Header["alg"] != s.jwtTokenEncryptAlgo {
 			// user must be banned here because the algo wasn't matched
 			return nil, errtype.NewTokenAlgoWasNotMatchedInternalError(token)
 		}
-		// cast to the configured givenToken signature type (stored in `s.jwtTokenEncryptAlgo`)
-		if _, success := decodedToken.Method.(*jwt.SigningMethodHMAC); !success {
+		// the signing method must be the same as the configured one (stored in `s.jwtTokenEncryptAlgo`)
+		if decodedToken.Method != s.keySet.method {
 			return nil, errtype.NewTokenUnexpectedSigningMethodInternalError(token, decodedToken.Header["alg"])
 		}
-		// jwtSecretSalt is a string containing your secret, but you need pass the []byte
-		return s.jwtSecretSalt, nil
-	})
+		// the key is chosen by `kid`, so the tokens signed by the previous key are valid while it's into the set
+		kid, _ := decodedToken.Header["kid"].(string)
+		k, found := s.keySet.verifying(kid)
+		if !found {
+			return nil, errtype.NewTokenUnexpectedSigningKeyInternalError(token, kid)
+		}
+		return k.public, nil
+	}
+}
+
+// Jwks will return the public keys which verify the issued tokens, it's empty for the HMAC algo.
+func (s *JwtService) Jwks() *dto.JwksResponseDTO {
+	return s.keySet.jwks()
+}
+
+// checkRevocation - checks the token was not blocked and the session of token was not revoked by logout.
+func (s *JwtService) checkRevocation(parsedToken *jwt.Token) error {
+	found, err := s.blockedTokenRepository.Has(s.ctx, parsedToken.Raw)
 	if err != nil {
-		// parsing givenToken error occurred
-		s.logger.Log(err)
-		// return a token invalid error
-		return vo.ID{}, s.logger.LogPropagate(errtype.NewAccessTokenIsInvalidError())
+		return s.logger.LogPropagate(err)
+	}
+	if found {
+		return errtype.NewAccessTokenWasBlockedError()
 	}
 
-	if claims, success := parsedToken.Claims.(jwt.MapClaims); success {
-		if userID, err = s.getUserID(claims); err != nil {
-			return vo.ID{}, s.logger.LogPropagate(err)
-		} else {
-			return userID, nil
+	claims, success := parsedToken.Claims.(jwt.MapClaims)
+	if !success {
+		return errtype.NewAccessTokenIsInvalidError()
+	}
+
+	if session := s.getSession(claims); session != "" {
+		blocked, berr := s.blockedTokenRepository.HasSession(s.ctx, session)
+		if berr != nil {
+			return s.logger.LogPropagate(berr)
+		}
+		if blocked {
+			return errtype.NewAccessTokenWasBlockedError()
 		}
-	} else {
-		return vo.ID{}, s.logger.LogPropagate(errtype.NewAccessTokenIsInvalidError())
 	}
+
+	return nil
 }
 
 func (s *JwtService) isValidIssuer(token string, claims jwt.Claims) error {
@@ -208,3 +326,26 @@ func (s *JwtService) getUserID(claims jwt.Claims) (userID vo.ID, err error) {
 	// returning a success response
 	return vo.ID{Value: oID}, nil
 }
+
+// getRoles - extracts the user roles, the token which was issued before the roles were introduced
+// belongs to the regular user.
+func (s *JwtService) getRoles(claims jwt.MapClaims) (roles []string) {
+	if values, ok := claims["roles"].([]interface{}); ok {
+		for _, value := range values {
+			if role, isString := value.(string); isString {
+				roles = append(roles, role)
+			}
+		}
+	}
+	if len(roles) == 0 {
+		return []string{enum.UserRole}
+	}
+	return roles
+}
+
+func (s *JwtService) getSession(claims jwt.MapClaims) string {
+	if session, ok := claims["sid"].(string); ok {
+		return session
+	}
+	return ""
+}
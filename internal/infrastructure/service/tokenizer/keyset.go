@@ -0,0 +1,243 @@
+package tokenizer
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/golang-jwt/jwt/v5"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	keyExt       = ".pem"
+	publicKeyExt = ".pub"
+)
+
+// key - is a key of the key set, the public only key has a nil private part and verifies tokens only.
+type key struct {
+	id      string
+	private crypto.PrivateKey
+	public  crypto.PublicKey
+}
+
+// keySet - is a set of keys of the configured algo, the signing key signs new tokens, all keys verify them.
+type keySet struct {
+	method  jwt.SigningMethod
+	keys    map[string]*key
+	signing *key
+}
+
+// newKeySet will build the key set of the given algo. The HMAC algo uses the salt as a single key, it must be shared
+// by all instances, so the empty one is an error. The asymmetric algo loads keys from the dir.
+func newKeySet(algo string, salt string, dir string, signingKeyID string) (*keySet, error) {
+	method := jwt.GetSigningMethod(algo)
+	if method == nil {
+		return nil, fmt.Errorf("jwt algo '%v' is not supported", algo)
+	}
+
+	if _, isHMAC := method.(*jwt.SigningMethodHMAC); isHMAC {
+		// the tokens signed by a key of one instance would be rejected by the others and after the restart
+		secret := []byte(salt)
+		if len(secret) == 0 {
+			return nil, fmt.Errorf("jwt secret salt must be specified for the '%v' algo", algo)
+		}
+		k := &key{private: secret, public: secret}
+		return &keySet{method: method, keys: map[string]*key{"": k}, signing: k}, nil
+	}
+
+	if dir == "" {
+		return nil, fmt.Errorf("jwt keys dir must be specified for the '%v' algo", algo)
+	}
+
+	keys, err := loadKeys(method, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	ks := &keySet{method: method, keys: keys}
+	if signingKeyID != "" {
+		k, found := keys[signingKeyID]
+		if !found || k.private == nil {
+			return nil, fmt.Errorf("jwt signing private key '%v' was not found into '%v'", signingKeyID, dir)
+		}
+		ks.signing = k
+	} else {
+		for id, k := range keys {
+			if k.private != nil && (ks.signing == nil || id > ks.signing.id) {
+				ks.signing = k
+			}
+		}
+		if ks.signing == nil {
+			return nil, fmt.Errorf("jwt signing private key was not found into '%v'", dir)
+		}
+	}
+
+	return ks, nil
+}
+
+// loadKeys - loads the PEM encoded keys from the dir, the private key overrides the public one with the same ID.
+func loadKeys(method jwt.SigningMethod, dir string) (map[string]*key, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]*key, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != keyExt {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		id := strings.TrimSuffix(entry.Name(), keyExt)
+		if strings.HasSuffix(id, publicKeyExt) {
+			id = strings.TrimSuffix(id, publicKeyExt)
+			if _, found := keys[id]; found {
+				continue
+			}
+			public, err := parsePublicKey(method, data)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse jwt public key '%v': %w", entry.Name(), err)
+			}
+			keys[id] = &key{id: id, public: public}
+			continue
+		}
+
+		private, public, err := parsePrivateKey(method, data)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse jwt private key '%v': %w", entry.Name(), err)
+		}
+		keys[id] = &key{id: id, private: private, public: public}
+	}
+
+	return keys, nil
+}
+
+func parsePrivateKey(method jwt.SigningMethod, data []byte) (crypto.PrivateKey, crypto.PublicKey, error) {
+	switch m := method.(type) {
+	case *jwt.SigningMethodRSA:
+		k, err := jwt.ParseRSAPrivateKeyFromPEM(data)
+		if err != nil {
+			return nil, nil, err
+		}
+		return k, &k.PublicKey, nil
+	case *jwt.SigningMethodECDSA:
+		k, err := jwt.ParseECPrivateKeyFromPEM(data)
+		if err != nil {
+			return nil, nil, err
+		}
+		if k.Curve.Params().BitSize != m.CurveBits {
+			return nil, nil, fmt.Errorf("curve '%v' doesn't match the '%v' algo", k.Curve.Params().Name, m.Alg())
+		}
+		return k, &k.PublicKey, nil
+	case *jwt.SigningMethodEd25519:
+		k, err := jwt.ParseEdPrivateKeyFromPEM(data)
+		if err != nil {
+			return nil, nil, err
+		}
+		private, ok := k.(ed25519.PrivateKey)
+		if !ok {
+			return nil, nil, fmt.Errorf("key is not an ed25519 key")
+		}
+		return private, private.Public(), nil
+	default:
+		return nil, nil, fmt.Errorf("'%v' is not an asymmetric algo", method.Alg())
+	}
+}
+
+func parsePublicKey(method jwt.SigningMethod, data []byte) (crypto.PublicKey, error) {
+	switch m := method.(type) {
+	case *jwt.SigningMethodRSA:
+		return jwt.ParseRSAPublicKeyFromPEM(data)
+	case *jwt.SigningMethodECDSA:
+		k, err := jwt.ParseECPublicKeyFromPEM(data)
+		if err != nil {
+			return nil, err
+		}
+		if k.Curve.Params().BitSize != m.CurveBits {
+			return nil, fmt.Errorf("curve '%v' doesn't match the '%v' algo", k.Curve.Params().Name, m.Alg())
+		}
+		return k, nil
+	case *jwt.SigningMethodEd25519:
+		return jwt.ParseEdPublicKeyFromPEM(data)
+	default:
+		return nil, fmt.Errorf("'%v' is not an asymmetric algo", method.Alg())
+	}
+}
+
+// verifying - returns the key which verifies the token with given `kid`, the token without `kid`
+// (issued before the key set was introduced) is verified by the signing key.
+func (ks *keySet) verifying(id string) (*key, bool) {
+	if id == "" {
+		return ks.signing, true
+	}
+	k, found := ks.keys[id]
+	return k, found
+}
+
+// jwks - returns the public keys of the set, the HMAC key is a secret, so it's never published.
+func (ks *keySet) jwks() *dto.JwksResponseDTO {
+	ids := make([]string, 0, len(ks.keys))
+	for id := range ks.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	set := &dto.JwksResponseDTO{Keys: []*dto.JwkResponseDTO{}}
+	for _, id := range ids {
+		if jwk := ks.keys[id].jwk(ks.method.Alg()); jwk != nil {
+			set.Keys = append(set.Keys, jwk)
+		}
+	}
+	return set
+}
+
+func (k *key) jwk(alg string) *dto.JwkResponseDTO {
+	encode := base64.RawURLEncoding.EncodeToString
+
+	switch public := k.public.(type) {
+	case *rsa.PublicKey:
+		return &dto.JwkResponseDTO{
+			Kty: "RSA",
+			Kid: k.id,
+			Use: "sig",
+			Alg: alg,
+			N:   encode(public.N.Bytes()),
+			E:   encode(big.NewInt(int64(public.E)).Bytes()),
+		}
+	case *ecdsa.PublicKey:
+		size := (public.Curve.Params().BitSize + 7) / 8
+		return &dto.JwkResponseDTO{
+			Kty: "EC",
+			Kid: k.id,
+			Use: "sig",
+			Alg: alg,
+			Crv: public.Curve.Params().Name,
+			X:   encode(public.X.FillBytes(make([]byte, size))),
+			Y:   encode(public.Y.FillBytes(make([]byte, size))),
+		}
+	case ed25519.PublicKey:
+		return &dto.JwkResponseDTO{
+			Kty: "OKP",
+			Kid: k.id,
+			Use: "sig",
+			Alg: alg,
+			Crv: "Ed25519",
+			X:   encode(public),
+		}
+	default:
+		return nil
+	}
+}
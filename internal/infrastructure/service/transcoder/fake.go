@@ -0,0 +1,117 @@
+package transcoder
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const FakeTranscoderType = "fake"
+
+const (
+	fakeSourceWidth   = 1920
+	fakeSourceHeight  = 1080
+	fakeSourceBitrate = 5_000_000
+	fakeAudioCodec    = "mp4a.40.2"
+	fakeVideoCodec    = "avc1.42E01E"
+)
+
+// FakeTranscoder is a deterministic transcoder which does not require the ffmpeg. Each rendition is a copy
+// of the original file which is described by the requested profile.
+type FakeTranscoder struct {
+	logger  loggerinterface.Logger
+	storage fileinterface.Storage
+}
+
+func NewFakeTranscoder(serviceContainer diinterface.ServiceContainer) (*FakeTranscoder, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	storageService, err := serviceContainer.GetFileStorageService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &FakeTranscoder{
+		logger:  loggerService,
+		storage: storageService,
+	}, nil
+}
+
+// Probe - describes any resource as the 1080p one.
+func (t *FakeTranscoder) Probe(resource entity.Resource) (entity.Rendition, error) {
+	return entity.Rendition{
+		Resource:   resource,
+		Width:      fakeSourceWidth,
+		Height:     fakeSourceHeight,
+		Bitrate:    fakeSourceBitrate,
+		AudioCodec: fakeAudioCodec,
+		VideoCodec: fakeVideoCodec,
+	}, nil
+}
+
+// Transcode - copies the original file and describes it by the profile.
+func (t *FakeTranscoder) Transcode(resource entity.Resource, profile vo.RenditionProfile) (entity.Rendition, error) {
+	file, err := t.storage.Open(resource.GetUserID(), resource.GetFilename())
+	if err != nil {
+		return entity.Rendition{}, t.logger.LogPropagate(err)
+	}
+	defer func() { _ = file.Close() }()
+
+	filename := renditionFilename(resource, profile)
+	length, path, err := t.storage.Store(resource.GetUserID(), filename, file)
+	if err != nil {
+		return entity.Rendition{}, t.logger.LogPropagate(err)
+	}
+
+	// 16:9 width rounded to the even number as the scale=-2 does
+	width := profile.Height * 16 / 9
+	width -= width % 2
+
+	return entity.Rendition{
+		Resource: entity.Resource{
+			ID:       vo.NewID(primitive.NewObjectID()),
+			UserID:   resource.GetUserID(),
+			Name:     resource.GetName(),
+			Filename: filename,
+			Filepath: path,
+			Filetype: resource.GetFiletype(),
+			Filesize: length,
+		},
+		Width:      width,
+		Height:     profile.Height,
+		Bitrate:    profile.Bitrate,
+		AudioCodec: fakeAudioCodec,
+		VideoCodec: fakeVideoCodec,
+	}, nil
+}
+
+// Fragment - copies the original file under the mp4 name.
+func (t *FakeTranscoder) Fragment(resource entity.Resource, progress func(float64)) (entity.Resource, error) {
+	fragmented := resource
+	fragmented.Filetype = "video/mp4"
+
+	file, err := t.storage.Open(resource.GetUserID(), resource.GetFilename())
+	if err != nil {
+		return entity.Resource{}, t.logger.LogPropagate(err)
+	}
+	defer func() { _ = file.Close() }()
+
+	filename := fragmentedFilename(resource)
+	length, path, err := t.storage.Store(resource.GetUserID(), filename, file)
+	if err != nil {
+		return entity.Resource{}, t.logger.LogPropagate(err)
+	}
+
+	fragmented.Filename = filename
+	fragmented.Filepath = path
+	fragmented.Filesize = length
+	progress(1)
+
+	return fragmented, nil
+}
@@ -0,0 +1,322 @@
+package transcoder
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/detector"
+	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"gopkg.in/vansante/go-ffprobe.v2"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const FFmpegTranscoderType = "ffmpeg"
+
+// FFmpegTranscoder is a service which produces the renditions by the ffmpeg binary.
+type FFmpegTranscoder struct {
+	ctx             context.Context
+	logger          loggerinterface.Logger
+	storage         fileinterface.Storage
+	segmentDuration float64
+}
+
+func NewFFmpegTranscoder(serviceContainer diinterface.ServiceContainer) (*FFmpegTranscoder, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	storageService, err := serviceContainer.GetFileStorageService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &FFmpegTranscoder{
+		ctx:             ctx,
+		logger:          loggerService,
+		storage:         storageService,
+		segmentDuration: cfg.SegmentTargetDuration,
+	}, nil
+}
+
+// Probe - describes the given resource by the ffprobe.
+func (t *FFmpegTranscoder) Probe(resource entity.Resource) (entity.Rendition, error) {
+	data, err := t.probe(resource)
+	if err != nil {
+		return entity.Rendition{}, t.logger.LogPropagate(err)
+	}
+
+	// the codecs are stored as RFC 6381 strings, so the manifests and the MediaSource take them as is
+	rendition := entity.Rendition{Resource: resource}
+	if stream := data.FirstAudioStream(); stream != nil {
+		rendition.AudioCodec = detector.CodecString(vo.MediaStream{
+			Codec:    stream.CodecName,
+			CodecTag: stream.CodecTagString,
+			Profile:  stream.Profile,
+		})
+	}
+	if stream := data.FirstVideoStream(); stream != nil {
+		rendition.VideoCodec = detector.CodecString(vo.MediaStream{
+			Codec:       stream.CodecName,
+			CodecTag:    stream.CodecTagString,
+			Profile:     stream.Profile,
+			Level:       stream.Level,
+			Width:       stream.Width,
+			Height:      stream.Height,
+			PixelFormat: stream.PixFmt,
+		})
+		rendition.Width = stream.Width
+		rendition.Height = stream.Height
+	}
+	if data.Format != nil {
+		// the overall bitrate is used because the streams bitrate is often omitted in the fragmented containers
+		if bitrate, err := strconv.ParseInt(data.Format.BitRate, 10, 64); err == nil {
+			rendition.Bitrate = bitrate
+		}
+	}
+
+	return rendition, nil
+}
+
+// Transcode - makes a fragmented mp4 rendition of the given resource by the profile. The keyframes are forced
+// at the segment target duration, so the renditions can be switched at the same segment boundaries. All audio
+// tracks are kept, so the selected one is streamed by any rendition.
+func (t *FFmpegTranscoder) Transcode(resource entity.Resource, profile vo.RenditionProfile) (entity.Rendition, error) {
+	source, release, err := t.storage.Local(resource.GetUserID(), resource.GetFilename())
+	if err != nil {
+		return entity.Rendition{}, t.logger.LogPropagate(err)
+	}
+	defer release()
+
+	tmp, err := os.CreateTemp("", "rendition-*.mp4")
+	if err != nil {
+		return entity.Rendition{}, t.logger.LogPropagate(err)
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	stderr := &bytes.Buffer{}
+	cmd := exec.CommandContext(t.ctx, "ffmpeg",
+		"-y", "-loglevel", "error",
+		"-i", source,
+		"-map", "0:v:0", "-map", "0:a?",
+		"-vf", fmt.Sprintf("scale=-2:%d", profile.Height),
+		"-c:v", "libx264",
+		"-b:v", strconv.FormatInt(profile.Bitrate, 10),
+		"-force_key_frames", fmt.Sprintf("expr:gte(t,n_forced*%v)", t.segmentDuration),
+		"-c:a", "aac",
+		"-movflags", "frag_keyframe+empty_moov+default_base_moof",
+		"-f", "mp4",
+		tmp.Name(),
+	)
+	cmd.Stderr = stderr
+	if err = cmd.Run(); err != nil {
+		return entity.Rendition{}, t.logger.LogPropagate(
+			errtype.NewTranscodingFailedError(resource.GetName(), reason(stderr, err)),
+		)
+	}
+
+	return t.store(resource, profile, tmp)
+}
+
+// Fragment - repacks the resource into the fragmented mp4. The streams are copied as is when their codecs
+// are supported by the browsers, otherwise they are transcoded into h264/aac. The first video stream and all
+// audio streams are taken (the audio track is selected while streaming), the others (subtitles, data) cannot
+// be muxed into the mp4 anyway.
+func (t *FFmpegTranscoder) Fragment(resource entity.Resource, progress func(float64)) (entity.Resource, error) {
+	data, err := t.probe(resource)
+	if err != nil {
+		return entity.Resource{}, t.logger.LogPropagate(err)
+	}
+
+	videoCodec := "libx264"
+	if stream := data.FirstVideoStream(); stream != nil && stream.CodecName == "h264" {
+		videoCodec = "copy"
+	}
+	// each audio stream is copied or transcoded on its own
+	var audioCodecs []string
+	for i, stream := range data.StreamType(ffprobe.StreamAudio) {
+		audioCodec := "aac"
+		if stream.CodecName == "aac" {
+			audioCodec = "copy"
+		}
+		audioCodecs = append(audioCodecs, fmt.Sprintf("-c:a:%d", i), audioCodec)
+	}
+	duration := 0.
+	if data.Format != nil {
+		duration = data.Format.DurationSeconds
+	}
+
+	source, release, err := t.storage.Local(resource.GetUserID(), resource.GetFilename())
+	if err != nil {
+		return entity.Resource{}, t.logger.LogPropagate(err)
+	}
+	defer release()
+
+	tmp, err := os.CreateTemp("", "fragmented-*.mp4")
+	if err != nil {
+		return entity.Resource{}, t.logger.LogPropagate(err)
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	args := []string{
+		"-y", "-loglevel", "error", "-nostats",
+		"-progress", "pipe:1",
+		"-i", source,
+		"-map", "0:v:0?", "-map", "0:a?",
+		"-c:v", videoCodec,
+	}
+	args = append(args, audioCodecs...)
+	args = append(args,
+		"-movflags", "frag_keyframe+empty_moov+default_base_moof",
+		"-f", "mp4",
+		tmp.Name(),
+	)
+
+	stderr := &bytes.Buffer{}
+	cmd := exec.CommandContext(t.ctx, "ffmpeg", args...)
+	cmd.Stderr = stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return entity.Resource{}, t.logger.LogPropagate(err)
+	}
+	if err = cmd.Start(); err != nil {
+		return entity.Resource{}, t.logger.LogPropagate(err)
+	}
+
+	// the progress is reported by ffmpeg as 'key=value' lines, the processed time is in microseconds
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		key, value, found := strings.Cut(scanner.Text(), "=")
+		if !found || (key != "out_time_us" && key != "out_time_ms") || duration <= 0 {
+			continue
+		}
+		if us, perr := strconv.ParseInt(value, 10, 64); perr == nil && us > 0 {
+			progress(math.Min(float64(us)/1e6/duration, 1))
+		}
+	}
+
+	if err = cmd.Wait(); err != nil {
+		return entity.Resource{}, t.logger.LogPropagate(
+			errtype.NewTranscodingFailedError(resource.GetName(), reason(stderr, err)),
+		)
+	}
+	if _, err = tmp.Seek(0, 0); err != nil {
+		return entity.Resource{}, t.logger.LogPropagate(err)
+	}
+
+	// the source file is not needed anymore, so it may be overwritten when the names are matched
+	filename := fragmentedFilename(resource)
+	length, path, err := t.storage.Store(resource.GetUserID(), filename, tmp)
+	if err != nil {
+		return entity.Resource{}, t.logger.LogPropagate(err)
+	}
+	progress(1)
+
+	fragmented := resource
+	fragmented.Filename = filename
+	fragmented.Filepath = path
+	fragmented.Filetype = "video/mp4"
+	fragmented.Filesize = length
+	// the content was changed, so it must be probed again
+	fragmented.MediaInfo = nil
+
+	return fragmented, nil
+}
+
+func (t *FFmpegTranscoder) probe(resource entity.Resource) (*ffprobe.ProbeData, error) {
+	file, err := t.storage.Open(resource.GetUserID(), resource.GetFilename())
+	if err != nil {
+		return nil, t.logger.LogPropagate(err)
+	}
+	defer func() { _ = file.Close() }()
+
+	data, err := ffprobe.ProbeReader(t.ctx, file)
+	if err != nil {
+		return nil, t.logger.LogPropagate(err)
+	}
+
+	return data, nil
+}
+
+func (t *FFmpegTranscoder) store(
+	resource entity.Resource,
+	profile vo.RenditionProfile,
+	file *os.File,
+) (entity.Rendition, error) {
+	if _, err := file.Seek(0, 0); err != nil {
+		return entity.Rendition{}, t.logger.LogPropagate(err)
+	}
+
+	filename := renditionFilename(resource, profile)
+	length, path, err := t.storage.Store(resource.GetUserID(), filename, file)
+	if err != nil {
+		return entity.Rendition{}, t.logger.LogPropagate(err)
+	}
+
+	rendition, err := t.Probe(entity.Resource{
+		ID:       vo.NewID(primitive.NewObjectID()),
+		UserID:   resource.GetUserID(),
+		Name:     resource.GetName(),
+		Filename: filename,
+		Filepath: path,
+		Filetype: "video/mp4",
+		Filesize: length,
+	})
+	if err != nil {
+		_ = t.storage.Remove(resource.GetUserID(), filename)
+		return entity.Rendition{}, t.logger.LogPropagate(err)
+	}
+
+	return rendition, nil
+}
+
+// fragmentedFilename - makes a filename of the fragmented mp4 from the original one, e.g. 'a1b2c3_6f1d.mp4'.
+// The file of the same content is shared by the resources, so the resource id prevents the collision
+// of the files which are written by parallel jobs.
+func fragmentedFilename(resource entity.Resource) string {
+	base := strings.TrimSuffix(resource.GetFilename(), filepath.Ext(resource.GetFilename()))
+	return fmt.Sprintf("%v_%v.mp4", base, resource.ID.Value.Hex())
+}
+
+// reason - takes the ffmpeg error output, the exit status is used if it's empty.
+func reason(stderr *bytes.Buffer, err error) string {
+	if r := strings.TrimSpace(stderr.String()); r != "" {
+		return r
+	}
+	return err.Error()
+}
+
+// renditionFilename - makes a filename of the rendition from the original one, e.g. 'a1b2c3_6f1d_720p.mp4'.
+// The renditions are owned by the resource, even if its file is shared with others.
+func renditionFilename(resource entity.Resource, profile vo.RenditionProfile) string {
+	base := strings.TrimSuffix(resource.GetFilename(), filepath.Ext(resource.GetFilename()))
+	return fmt.Sprintf("%v_%v_%dp.mp4", base, resource.ID.Value.Hex(), profile.Height)
+}
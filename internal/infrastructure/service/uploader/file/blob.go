@@ -0,0 +1,197 @@
+package file
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/mongodb"
+	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"hash"
+	"io"
+	"path/filepath"
+	"sync"
+)
+
+// receivingBlobExt - the content is stored under the temporary name until its hash is computed
+const receivingBlobExt = ".part"
+
+// BlobStorageService stores the files of the user by the SHA-256 of their content, so the same bytes uploaded
+// under different names are stored once and the different files with the same name do not collide.
+// The references are counted in the database and the file is removed when the last one is released.
+type BlobStorageService struct {
+	ctx        context.Context
+	logger     loggerinterface.Logger
+	storage    fileinterface.Storage
+	repository repositoryinterface.Blob
+	// mu - the reference and the file are changed together, so the released blob cannot be removed
+	// right after the same content was stored again
+	mu *sync.Mutex
+}
+
+func NewBlobStorageService(serviceContainer diinterface.ServiceContainer) (*BlobStorageService, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	storageService, err := serviceContainer.GetFileStorageService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	blobRepository, err := serviceContainer.GetBlobRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &BlobStorageService{
+		ctx:        ctx,
+		logger:     loggerService,
+		storage:    storageService,
+		repository: blobRepository,
+		mu:         &sync.Mutex{},
+	}, nil
+}
+
+// Store is saving the content and computing its hash at the same pass.
+func (s *BlobStorageService) Store(
+	userID vo.ID,
+	ext string,
+	reader io.Reader,
+) (
+	filename string,
+	length int64,
+	path string,
+	err error,
+) {
+	receiving := primitive.NewObjectID().Hex() + receivingBlobExt
+
+	sum := sha256.New()
+	length, _, err = s.storage.Store(userID, receiving, io.TeeReader(reader, sum))
+	if err != nil {
+		return "", 0, "", s.logger.LogPropagate(err)
+	}
+
+	filename, path, err = s.commit(userID, receiving, blobFilename(sum, ext))
+	if err != nil {
+		// the receiving file is left when it was not moved or was moved back
+		if received, herr := s.storage.Has(userID, receiving); herr != nil {
+			s.logger.Log(herr)
+		} else if received {
+			if rerr := s.storage.Remove(userID, receiving); rerr != nil {
+				s.logger.Log(rerr)
+			}
+		}
+		return "", 0, "", s.logger.LogPropagate(err)
+	}
+
+	return filename, length, path, nil
+}
+
+// Adopt is reading the stored file for compute its hash.
+func (s *BlobStorageService) Adopt(userID vo.ID, name string) (filename string, path string, err error) {
+	file, err := s.storage.Open(userID, name)
+	if err != nil {
+		return "", "", s.logger.LogPropagate(err)
+	}
+
+	sum := sha256.New()
+	_, err = io.Copy(sum, file)
+	_ = file.Close()
+	if err != nil {
+		return "", "", s.logger.LogPropagate(err)
+	}
+
+	filename, path, err = s.commit(userID, name, blobFilename(sum, filepath.Ext(name)))
+	if err != nil {
+		return "", "", s.logger.LogPropagate(err)
+	}
+
+	return filename, path, nil
+}
+
+// Retain is adding a reference of the already stored blob.
+func (s *BlobStorageService) Retain(userID vo.ID, filename string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.repository.Retain(s.ctx, userID, filename); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	return nil
+}
+
+// Release is removing the file when the blob is not referenced anymore. The file which was stored before
+// the blobs were introduced has no references and is owned by the single resource, so it's removed at once.
+func (s *BlobStorageService) Release(userID vo.ID, filename string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	blob, err := s.repository.Release(s.ctx, userID, filename)
+	if err != nil {
+		if errors.Is(err, mongodb.BlobNotFoundByFilenameError) {
+			return s.storage.Remove(userID, filename)
+		}
+		return s.logger.LogPropagate(err)
+	}
+	if blob.References > 0 {
+		return nil
+	}
+
+	removed, err := s.repository.Remove(s.ctx, blob)
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+	if removed {
+		if err = s.storage.Remove(userID, filename); err != nil {
+			return s.logger.LogPropagate(err)
+		}
+	}
+
+	return nil
+}
+
+// commit is moving the file under the content addressed name (the identical content is just replaced)
+// and adding the reference. The new blob which reference was not added is moved back, so it's not left
+// unreferenced under the content addressed name.
+func (s *BlobStorageService) commit(userID vo.ID, name string, filename string) (string, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existed, err := s.storage.Has(userID, filename)
+	if err != nil {
+		return "", "", s.logger.LogPropagate(err)
+	}
+
+	path, err := s.storage.Move(userID, name, filename)
+	if err != nil {
+		return "", "", s.logger.LogPropagate(err)
+	}
+
+	if _, err = s.repository.Retain(s.ctx, userID, filename); err != nil {
+		if !existed {
+			if _, merr := s.storage.Move(userID, filename, name); merr != nil {
+				s.logger.Log(merr)
+			}
+		}
+		return "", "", s.logger.LogPropagate(err)
+	}
+
+	return filename, path, nil
+}
+
+func blobFilename(sum hash.Hash, ext string) string {
+	return hex.EncodeToString(sum.Sum(nil)) + ext
+}
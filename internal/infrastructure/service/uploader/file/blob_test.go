@@ -0,0 +1,215 @@
+package file
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/di/ditest"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/mongodb"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/filetest"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+var errTestRetain = errors.New("retain failed")
+
+// fakeBlobRepository counts the references in memory as the mongo repository does.
+type fakeBlobRepository struct {
+	mu         sync.Mutex
+	blobs      map[string]*agg.Blob
+	failRetain bool
+}
+
+func (r *fakeBlobRepository) Retain(_ context.Context, userID vo.ID, filename string) (*agg.Blob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.failRetain {
+		return nil, errTestRetain
+	}
+	blob, ok := r.blobs[userID.Hex()+filename]
+	if !ok {
+		blob = &agg.Blob{Blob: entity.Blob{ID: vo.NewID(primitive.NewObjectID()), UserID: userID, Filename: filename}}
+		r.blobs[userID.Hex()+filename] = blob
+	}
+	blob.References++
+	return blob, nil
+}
+
+func (r *fakeBlobRepository) Release(_ context.Context, userID vo.ID, filename string) (*agg.Blob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	blob, ok := r.blobs[userID.Hex()+filename]
+	if !ok {
+		return nil, mongodb.BlobNotFoundByFilenameError
+	}
+	blob.References--
+	return blob, nil
+}
+
+func (r *fakeBlobRepository) Remove(_ context.Context, blob *agg.Blob) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if blob.References > 0 {
+		return false, nil
+	}
+	delete(r.blobs, blob.UserID.Hex()+blob.Filename)
+	return true, nil
+}
+
+func (r *fakeBlobRepository) references(userID vo.ID, filename string) int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if blob, ok := r.blobs[userID.Hex()+filename]; ok {
+		return blob.References
+	}
+	return 0
+}
+
+func newTestBlobStorage(t *testing.T) (*BlobStorageService, *filetest.MemoryStorage, *fakeBlobRepository, vo.ID) {
+	t.Helper()
+
+	container, storage := ditest.NewStorageContainer(t, nil)
+	repository := &fakeBlobRepository{blobs: make(map[string]*agg.Blob)}
+	container.
+		Set(repository, reflect.TypeOf((*repositoryinterface.Blob)(nil)))
+
+	blobs, err := NewBlobStorageService(container)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return blobs, storage, repository, vo.NewID(primitive.NewObjectID())
+}
+
+func TestBlobStorageService_StoreAndRelease(t *testing.T) {
+	blobs, storage, repository, userID := newTestBlobStorage(t)
+	content := testContent(1000, 3)
+
+	first, length, _, err := blobs.Store(userID, ".mp4", bytes.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if length != int64(len(content)) {
+		t.Errorf("expected %d bytes are stored, got %d", len(content), length)
+	}
+	second, _, _, err := blobs.Store(userID, ".mp4", bytes.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first != second {
+		t.Fatalf("expected the identical content has the same name, got '%v' and '%v'", first, second)
+	}
+	if names := storage.Names(userID); len(names) != 1 || names[0] != first {
+		t.Fatalf("expected the single file '%v', got %v", first, names)
+	}
+	if refs := repository.references(userID, first); refs != 2 {
+		t.Fatalf("expected 2 references, got %d", refs)
+	}
+	if stored, _ := storage.Get(userID, first); !bytes.Equal(stored, content) {
+		t.Fatal("expected the stored content is equal to the uploaded one")
+	}
+
+	if err = blobs.Release(userID, first); err != nil {
+		t.Fatal(err)
+	}
+	if has, _ := storage.Has(userID, first); !has {
+		t.Fatal("expected the file is kept while it's referenced")
+	}
+
+	if err = blobs.Release(userID, first); err != nil {
+		t.Fatal(err)
+	}
+	if has, _ := storage.Has(userID, first); has {
+		t.Fatal("expected the file is removed by the last release")
+	}
+	if refs := repository.references(userID, first); refs != 0 {
+		t.Errorf("expected the blob is removed, got %d references", refs)
+	}
+}
+
+func TestBlobStorageService_ReleaseLegacyFile(t *testing.T) {
+	blobs, storage, _, userID := newTestBlobStorage(t)
+	storage.Put(userID, "legacy.mp4", testContent(10, 0))
+
+	if err := blobs.Release(userID, "legacy.mp4"); err != nil {
+		t.Fatal(err)
+	}
+	if has, _ := storage.Has(userID, "legacy.mp4"); has {
+		t.Error("expected the file without the blob is removed at once")
+	}
+}
+
+func TestBlobStorageService_StoreCleansUpOnFailure(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing bool
+	}{
+		{name: "new blob"},
+		{name: "existing blob", existing: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			blobs, storage, repository, userID := newTestBlobStorage(t)
+			content := testContent(100, 1)
+
+			var existing string
+			if test.existing {
+				var err error
+				if existing, _, _, err = blobs.Store(userID, ".mp4", bytes.NewReader(content)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			repository.failRetain = true
+			if _, _, _, err := blobs.Store(userID, ".mp4", bytes.NewReader(content)); !errors.Is(err, errTestRetain) {
+				t.Fatalf("expected the retain error, got %v", err)
+			}
+
+			// neither the receiving file nor the unreferenced new blob are left
+			names := storage.Names(userID)
+			if test.existing {
+				if len(names) != 1 || names[0] != existing {
+					t.Fatalf("expected only the existing blob '%v', got %v", existing, names)
+				}
+				if refs := repository.references(userID, existing); refs != 1 {
+					t.Errorf("expected the existing blob keeps 1 reference, got %d", refs)
+				}
+				return
+			}
+			if len(names) != 0 {
+				t.Errorf("expected no files, got %v", names)
+			}
+		})
+	}
+}
+
+func TestBlobStorageService_AdoptKeepsFileOnFailure(t *testing.T) {
+	blobs, storage, repository, userID := newTestBlobStorage(t)
+	storage.Put(userID, "upload.mp4", testContent(100, 2))
+
+	repository.failRetain = true
+	if _, _, err := blobs.Adopt(userID, "upload.mp4"); !errors.Is(err, errTestRetain) {
+		t.Fatalf("expected the retain error, got %v", err)
+	}
+
+	// the adopted file is moved back, so the adoption may be retried
+	if names := storage.Names(userID); len(names) != 1 || names[0] != "upload.mp4" {
+		t.Fatalf("expected the file is kept under its name, got %v", names)
+	}
+
+	repository.failRetain = false
+	filename, _, err := blobs.Adopt(userID, "upload.mp4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := storage.Names(userID); len(names) != 1 || names[0] != filename {
+		t.Errorf("expected the single blob '%v', got %v", filename, names)
+	}
+}
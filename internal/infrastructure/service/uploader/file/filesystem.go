@@ -3,12 +3,15 @@ package file
 import (
 	"context"
 	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/vo"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/helper"
+	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
 	"io"
 	"os"
+	"time"
 )
 
 type FilesystemStorageService struct {
@@ -117,6 +120,11 @@ func (s *FilesystemStorageService) Store(
 	// moving the data in to the created file from tmp
 	length, err = io.Copy(createdFile, reader)
 	if err != nil {
+		// the partially stored file is not valid (the reader was interrupted or exceeded the limit)
+		_ = createdFile.Close()
+		if rerr := os.Remove(path); rerr != nil {
+			s.logger.Log(rerr)
+		}
 		return 0, "", s.logger.LogPropagate(err)
 	}
 
@@ -124,6 +132,112 @@ func (s *FilesystemStorageService) Store(
 	return length, path, nil
 }
 
+// Append is writing the data at the end of the partially received file. The tail which was written but not
+// accounted by the offset (the previous chunk was interrupted) will be dropped. Written length is returned
+// even on error, so the received part of the chunk is not lost.
+func (s *FilesystemStorageService) Append(
+	userID vo.ID,
+	filename string,
+	offset int64,
+	reader io.Reader,
+) (
+	written int64,
+	path string,
+	err error,
+) {
+	// full qualified filepath
+	path, err = s.filepath(userID, filename)
+	if err != nil {
+		return 0, "", s.logger.LogPropagate(err)
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0644)
+	if err != nil {
+		return 0, "", s.logger.LogPropagate(err)
+	}
+	defer func() { _ = file.Close() }()
+
+	info, err := file.Stat()
+	if err != nil {
+		return 0, "", s.logger.LogPropagate(err)
+	}
+	if info.Size() < offset {
+		return 0, "", s.logger.LogPropagate(errtype.NewUploadOffsetMismatchError(info.Size(), offset))
+	} else if info.Size() > offset {
+		if err = file.Truncate(offset); err != nil {
+			return 0, "", s.logger.LogPropagate(err)
+		}
+	}
+
+	if _, err = file.Seek(offset, io.SeekStart); err != nil {
+		return 0, "", s.logger.LogPropagate(err)
+	}
+
+	written, err = io.Copy(file, reader)
+	if err != nil {
+		return written, path, s.logger.LogPropagate(err)
+	}
+
+	return written, path, nil
+}
+
+// Open is opening the stored file for reading.
+func (s *FilesystemStorageService) Open(userID vo.ID, name string) (fileinterface.File, error) {
+	// full qualified filepath
+	path, err := s.filepath(userID, name)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	return &filesystemFile{File: file, info: info}, nil
+}
+
+// Local is providing the path of the stored file as is, there is nothing to release.
+func (s *FilesystemStorageService) Local(userID vo.ID, name string) (path string, release func(), err error) {
+	// full qualified filepath
+	path, err = s.filepath(userID, name)
+	if err != nil {
+		return "", nil, s.logger.LogPropagate(err)
+	}
+
+	if _, err = os.Stat(path); err != nil {
+		return "", nil, s.logger.LogPropagate(err)
+	}
+
+	return path, func() {}, nil
+}
+
+// Move is renaming the stored file, the existing file with the target name is atomically replaced,
+// so the opened one is still readable till it's closed.
+func (s *FilesystemStorageService) Move(userID vo.ID, from string, to string) (path string, err error) {
+	// full qualified filepaths
+	fromPath, err := s.filepath(userID, from)
+	if err != nil {
+		return "", s.logger.LogPropagate(err)
+	}
+	path, err = s.filepath(userID, to)
+	if err != nil {
+		return "", s.logger.LogPropagate(err)
+	}
+
+	if err = os.Rename(fromPath, path); err != nil {
+		return "", s.logger.LogPropagate(err)
+	}
+
+	return path, nil
+}
+
 func (s *FilesystemStorageService) Remove(userID vo.ID, name string) error {
 	// full qualified filepath
 	path, err := s.filepath(userID, name)
@@ -163,3 +277,21 @@ func (s *FilesystemStorageService) filepath(userID vo.ID, filename string) (path
 
 	return fmt.Sprintf("%v/%v", dir, filename), nil
 }
+
+// filesystemFile is the opened file which is described by the stat taken at the opening.
+type filesystemFile struct {
+	*os.File
+	info os.FileInfo
+}
+
+func (f *filesystemFile) Name() string {
+	return f.info.Name()
+}
+
+func (f *filesystemFile) Size() int64 {
+	return f.info.Size()
+}
+
+func (f *filesystemFile) ModTime() time.Time {
+	return f.info.ModTime()
+}
@@ -0,0 +1,154 @@
+package filetest
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryStorage is the in-memory storage for tests, the files are kept by the user ID and name.
+type MemoryStorage struct {
+	mu    sync.Mutex
+	files map[string][]byte
+}
+
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{files: make(map[string][]byte)}
+}
+
+// Put is saving the file content as is.
+func (s *MemoryStorage) Put(userID vo.ID, name string, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.files[key(userID, name)] = data
+}
+
+// Get is returning the file content.
+func (s *MemoryStorage) Get(userID vo.ID, name string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.files[key(userID, name)]
+	return data, ok
+}
+
+// Names is returning the sorted names of the user files.
+func (s *MemoryStorage) Names(userID vo.ID) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prefix := key(userID, "")
+	names := make([]string, 0, len(s.files))
+	for k := range s.files {
+		if strings.HasPrefix(k, prefix) {
+			names = append(names, strings.TrimPrefix(k, prefix))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s *MemoryStorage) Has(userID vo.ID, name string) (bool, error) {
+	_, ok := s.Get(userID, name)
+	return ok, nil
+}
+
+func (s *MemoryStorage) Store(userID vo.ID, name string, reader io.Reader) (int64, string, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return 0, "", err
+	}
+	s.Put(userID, name, data)
+	return int64(len(data)), key(userID, name), nil
+}
+
+func (s *MemoryStorage) Append(userID vo.ID, name string, offset int64, reader io.Reader) (int64, string, error) {
+	data, _ := s.Get(userID, name)
+	if int64(len(data)) != offset {
+		return 0, "", errtype.NewUploadOffsetMismatchError(int64(len(data)), offset)
+	}
+	chunk, err := io.ReadAll(reader)
+	if err != nil {
+		return 0, "", err
+	}
+	s.Put(userID, name, append(append([]byte{}, data...), chunk...))
+	return int64(len(chunk)), key(userID, name), nil
+}
+
+func (s *MemoryStorage) Open(userID vo.ID, name string) (fileinterface.File, error) {
+	data, ok := s.Get(userID, name)
+	if !ok {
+		return nil, errtype.NewStorageObjectNotFoundError(key(userID, name))
+	}
+	return &memoryFile{Reader: bytes.NewReader(data), name: name, size: int64(len(data))}, nil
+}
+
+// Local is writing the file into the temporary one which is removed by the release func.
+func (s *MemoryStorage) Local(userID vo.ID, name string) (string, func(), error) {
+	data, ok := s.Get(userID, name)
+	if !ok {
+		return "", nil, errtype.NewStorageObjectNotFoundError(key(userID, name))
+	}
+	tmp, err := os.CreateTemp("", "memory-*-"+name)
+	if err != nil {
+		return "", nil, err
+	}
+	defer func() { _ = tmp.Close() }()
+	if _, err = tmp.Write(data); err != nil {
+		_ = os.Remove(tmp.Name())
+		return "", nil, err
+	}
+	return tmp.Name(), func() { _ = os.Remove(tmp.Name()) }, nil
+}
+
+func (s *MemoryStorage) Move(userID vo.ID, from string, to string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.files[key(userID, from)]
+	if !ok {
+		return "", errtype.NewStorageObjectNotFoundError(key(userID, from))
+	}
+	delete(s.files, key(userID, from))
+	s.files[key(userID, to)] = data
+	return key(userID, to), nil
+}
+
+func (s *MemoryStorage) Remove(userID vo.ID, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.files, key(userID, name))
+	return nil
+}
+
+func key(userID vo.ID, name string) string {
+	return fmt.Sprintf("%v/%v", userID.Hex(), name)
+}
+
+// memoryFile is the opened file of the memory storage.
+type memoryFile struct {
+	*bytes.Reader
+	name string
+	size int64
+}
+
+func (f *memoryFile) Close() error {
+	return nil
+}
+
+func (f *memoryFile) Name() string {
+	return f.name
+}
+
+func (f *memoryFile) Size() int64 {
+	return f.size
+}
+
+func (f *memoryFile) ModTime() time.Time {
+	return time.Time{}
+}
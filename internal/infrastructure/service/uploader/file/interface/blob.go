@@ -0,0 +1,18 @@
+package fileinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"io"
+)
+
+type BlobStorage interface {
+	// Store is saving the content under the name which is computed from its SHA-256 while streaming,
+	// the identical content of the user is stored once. Each call adds a reference to the blob.
+	Store(userID vo.ID, ext string, reader io.Reader) (filename string, length int64, filepath string, err error)
+	// Adopt is making the blob from the already stored file, the file is moved under the content addressed name.
+	Adopt(userID vo.ID, name string) (filename string, filepath string, err error)
+	// Retain is adding a reference of the already stored blob.
+	Retain(userID vo.ID, filename string) error
+	// Release is dropping a reference of the blob, the file is removed with the last one.
+	Release(userID vo.ID, filename string) error
+}
@@ -0,0 +1,9 @@
+package fileinterface
+
+import "io"
+
+type Sniffer interface {
+	// Sniff - detects the filetype by the first bytes of the content. The returned reader must be used
+	// instead of the given one because the sniffed bytes are already read from it.
+	Sniff(name string, reader io.Reader) (filetype string, content io.Reader, err error)
+}
@@ -3,6 +3,7 @@ package fileinterface
 import (
 	"github.com/Borislavv/video-streaming/internal/domain/vo"
 	"io"
+	"time"
 )
 
 type Storage interface {
@@ -10,6 +11,27 @@ type Storage interface {
 	Has(userID vo.ID, filename string) (has bool, err error)
 	// Store is saving file and calculating new hashed name.
 	Store(userID vo.ID, name string, reader io.Reader) (length int64, filepath string, err error)
+	// Append is writing the data at the end of the partially received file which must be of the offset length.
+	Append(userID vo.ID, name string, offset int64, reader io.Reader) (written int64, filepath string, err error)
+	// Open is opening the stored file for reading, the file may be read sequentially and at the given offsets.
+	Open(userID vo.ID, name string) (file File, err error)
+	// Local is providing a path of the stored file on the local filesystem for the external tools (e.g. ffmpeg).
+	// The release func must be called when the path is not needed anymore.
+	Local(userID vo.ID, name string) (path string, release func(), err error)
+	// Move is renaming the stored file, the existing file with the target name is replaced.
+	Move(userID vo.ID, from string, to string) (filepath string, err error)
 	// Remove is delete the file by name from resources directory.
 	Remove(userID vo.ID, name string) (err error)
 }
+
+// File is the opened stored file. The ReadAt is safe for concurrent use.
+type File interface {
+	io.ReadSeekCloser
+	io.ReaderAt
+	// Name is the name of the stored file.
+	Name() string
+	// Size is the length of the file in bytes.
+	Size() int64
+	// ModTime is the last modification time of the file.
+	ModTime() time.Time
+}
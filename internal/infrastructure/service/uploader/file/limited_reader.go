@@ -0,0 +1,38 @@
+package file
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"io"
+)
+
+// LimitedReader is a reader which fails as soon as the read data exceeds the limit, so the body of unknown
+// length is checked while streaming and the partially stored file is removed by the storage.
+type LimitedReader struct {
+	reader io.Reader
+	limit  int64
+	read   int64
+}
+
+func NewLimitedReader(reader io.Reader, limit int64) *LimitedReader {
+	return &LimitedReader{
+		reader: reader,
+		limit:  limit,
+	}
+}
+
+// Read - reads not more than one byte over the limit, so the exceeding is detected while the bytes over
+// the limit are never returned.
+func (r *LimitedReader) Read(p []byte) (n int, err error) {
+	if r.read > r.limit {
+		return 0, errtype.NewFilesizeThresholdExceededError(r.limit)
+	}
+	if remaining := r.limit - r.read + 1; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+
+	n, err = r.reader.Read(p)
+	if r.read += int64(n); r.read > r.limit {
+		return n - int(r.read-r.limit), errtype.NewFilesizeThresholdExceededError(r.limit)
+	}
+	return n, err
+}
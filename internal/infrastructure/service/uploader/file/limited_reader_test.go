@@ -0,0 +1,71 @@
+package file
+
+import (
+	"bytes"
+	"errors"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"io"
+	"testing"
+	"testing/iotest"
+)
+
+func TestLimitedReader(t *testing.T) {
+	tests := []struct {
+		name     string
+		size     int
+		limit    int64
+		exceeded bool
+	}{
+		{name: "less than limit", size: 99, limit: 100},
+		{name: "exactly limit", size: 100, limit: 100},
+		{name: "limit and one byte", size: 101, limit: 100, exceeded: true},
+		{name: "much more than limit", size: 10_000, limit: 100, exceeded: true},
+		{name: "zero limit", size: 1, limit: 0, exceeded: true},
+	}
+	for _, test := range tests {
+		for _, reader := range []struct {
+			name string
+			wrap func(io.Reader) io.Reader
+		}{
+			{name: "whole", wrap: func(r io.Reader) io.Reader { return r }},
+			{name: "by bytes", wrap: iotest.OneByteReader},
+			{name: "by halves", wrap: iotest.HalfReader},
+		} {
+			t.Run(test.name+" "+reader.name, func(t *testing.T) {
+				content := testContent(test.size, 0)
+				written := bytes.Buffer{}
+
+				n, err := io.Copy(&written, NewLimitedReader(reader.wrap(bytes.NewReader(content)), test.limit))
+
+				exceeded := &errtype.FilesizeThresholdExceededError{}
+				if test.exceeded != errors.As(err, &exceeded) {
+					t.Fatalf("expected exceeding %v, got error %v", test.exceeded, err)
+				}
+				if !test.exceeded && err != nil {
+					t.Fatal(err)
+				}
+
+				// the bytes over the limit are never passed to the writer
+				expected := content
+				if int64(len(expected)) > test.limit {
+					expected = expected[:test.limit]
+				}
+				if n != int64(len(expected)) || !bytes.Equal(written.Bytes(), expected) {
+					t.Errorf("expected %d bytes are written, got %d", len(expected), n)
+				}
+			})
+		}
+	}
+}
+
+func TestLimitedReader_FailsAfterExceeding(t *testing.T) {
+	reader := NewLimitedReader(bytes.NewReader(testContent(20, 0)), 10)
+
+	p := make([]byte, 20)
+	if n, err := reader.Read(p); n != 10 || err == nil {
+		t.Fatalf("expected 10 bytes with the error, got %d and %v", n, err)
+	}
+	if n, err := reader.Read(p); n != 0 || err == nil {
+		t.Fatalf("expected the error without bytes, got %d and %v", n, err)
+	}
+}
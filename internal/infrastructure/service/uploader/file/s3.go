@@ -0,0 +1,516 @@
+package file
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const S3StorageType = "s3"
+
+const (
+	// s3MinPartSize - the parts except the last one cannot be less than 5mb
+	s3MinPartSize = 5 << 20
+	// s3ReadAheadSize - the small reads (e.g. the boxes headers while indexing) are served from the block
+	// of this size, so each of them is not a separate request
+	s3ReadAheadSize = 64 << 10
+)
+
+// S3StorageService stores the files into the S3-compatible object storage under the '<userID>/<filename>' keys.
+// The files are read by ranges, so any node is able to stream them without the local copy.
+type S3StorageService struct {
+	ctx      context.Context
+	logger   loggerinterface.Logger
+	client   *s3Client
+	partSize int64
+}
+
+func NewS3StorageService(serviceContainer diinterface.ServiceContainer) (*S3StorageService, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	if cfg.S3PartSize < s3MinPartSize {
+		return nil, loggerService.LogPropagate(
+			fmt.Errorf("s3 part size %d is less than min. %d bytes", cfg.S3PartSize, s3MinPartSize),
+		)
+	}
+
+	client, err := newS3Client(
+		cfg.S3Endpoint, cfg.S3Region, cfg.S3Bucket, cfg.S3AccessKey, cfg.S3SecretKey, cfg.S3UsePathStyle,
+	)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	if err = client.ensureBucket(ctx); err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &S3StorageService{
+		ctx:      ctx,
+		logger:   loggerService,
+		client:   client,
+		partSize: cfg.S3PartSize,
+	}, nil
+}
+
+// Has is checking whether the object already exists.
+func (s *S3StorageService) Has(userID vo.ID, filename string) (has bool, err error) {
+	if _, err = s.client.head(s.ctx, s.key(userID, filename)); err != nil {
+		if errtype.IsStorageObjectNotFoundError(err) {
+			return false, nil
+		}
+		return true, s.logger.LogPropagate(err)
+	}
+	return true, nil
+}
+
+// Store is saving the content of unknown length. The content which fits into the single part is stored
+// by one request, otherwise it's uploaded by parts and the received parts are dropped on failure.
+func (s *S3StorageService) Store(userID vo.ID, name string, reader io.Reader) (length int64, path string, err error) {
+	key := s.key(userID, name)
+	part := make([]byte, s.partSize)
+
+	n, err := io.ReadFull(reader, part)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return 0, "", s.logger.LogPropagate(err)
+	}
+	if err != nil {
+		// the whole content is read
+		if err = s.client.put(s.ctx, key, bytes.NewReader(part[:n]), int64(n)); err != nil {
+			return 0, "", s.logger.LogPropagate(err)
+		}
+		return int64(n), s.client.location(key), nil
+	}
+
+	uploadID, err := s.client.createMultipartUpload(s.ctx, key)
+	if err != nil {
+		return 0, "", s.logger.LogPropagate(err)
+	}
+
+	length, err = s.storeParts(key, uploadID, part, reader)
+	if err != nil {
+		if aerr := s.client.abortMultipartUpload(s.ctx, key, uploadID); aerr != nil {
+			s.logger.Log(aerr)
+		}
+		return 0, "", s.logger.LogPropagate(err)
+	}
+
+	return length, s.client.location(key), nil
+}
+
+// storeParts - uploads the parts one by one, the first one is already read into the buffer.
+func (s *S3StorageService) storeParts(key string, uploadID string, part []byte, reader io.Reader) (int64, error) {
+	var (
+		length int64
+		parts  []s3Part
+		n      = len(part)
+		err    error
+	)
+	for number := 1; n > 0; number++ {
+		uploaded, uerr := s.client.uploadPart(s.ctx, key, uploadID, number, part[:n])
+		if uerr != nil {
+			return 0, uerr
+		}
+		parts = append(parts, uploaded)
+		length += int64(n)
+
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+		if n, err = io.ReadFull(reader, part); err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return 0, err
+		}
+	}
+
+	if err = s.client.completeMultipartUpload(s.ctx, key, uploadID, parts); err != nil {
+		return 0, err
+	}
+
+	return length, nil
+}
+
+// Append is writing the chunk at the end of the object. The objects are immutable, so the object is replaced by
+// the multipart upload of the stored content and the chunk: the stored content which may be a part (at least 5mb)
+// is copied on the server side, the smaller one is prepended to the first part of the chunk. No state is kept
+// between the calls, so the next chunk may be received by any node. The received part of the interrupted chunk
+// is saved as on the filesystem.
+func (s *S3StorageService) Append(
+	userID vo.ID,
+	name string,
+	offset int64,
+	reader io.Reader,
+) (
+	written int64,
+	path string,
+	err error,
+) {
+	key := s.key(userID, name)
+
+	var size int64
+	if object, herr := s.client.head(s.ctx, key); herr == nil {
+		size = object.size
+	} else if !errtype.IsStorageObjectNotFoundError(herr) {
+		return 0, "", s.logger.LogPropagate(herr)
+	}
+	if offset != size {
+		return 0, "", s.logger.LogPropagate(errtype.NewUploadOffsetMismatchError(size, offset))
+	}
+
+	part := make([]byte, s.partSize)
+
+	// the stored content which is less than the min. part is read into the beginning of the first part
+	var prefix int
+	if size > 0 && size < s3MinPartSize {
+		if prefix, err = s.readAll(key, part[:size]); err != nil {
+			return 0, "", s.logger.LogPropagate(err)
+		}
+	}
+
+	n, rerr := io.ReadFull(reader, part[prefix:])
+	written = int64(n)
+
+	// the end of chunk is not an error, the failed reading is returned after the received part is saved
+	var readErr error
+	if rerr != nil && !errors.Is(rerr, io.EOF) && !errors.Is(rerr, io.ErrUnexpectedEOF) {
+		readErr = rerr
+	}
+	if n == 0 && rerr != nil {
+		return 0, s.client.location(key), s.propagate(readErr)
+	}
+
+	// the whole content fits into the single request
+	if rerr != nil && size < s3MinPartSize {
+		if err = s.client.put(s.ctx, key, bytes.NewReader(part[:prefix+n]), int64(prefix+n)); err != nil {
+			return 0, "", s.logger.LogPropagate(err)
+		}
+		return written, s.client.location(key), s.propagate(readErr)
+	}
+
+	uploadID, err := s.client.createMultipartUpload(s.ctx, key)
+	if err != nil {
+		return 0, "", s.logger.LogPropagate(err)
+	}
+
+	appended, readErr, err := s.appendParts(key, uploadID, size >= s3MinPartSize, part, prefix+n, rerr, reader)
+	if err != nil {
+		if aerr := s.client.abortMultipartUpload(s.ctx, key, uploadID); aerr != nil {
+			s.logger.Log(aerr)
+		}
+		return 0, "", s.logger.LogPropagate(err)
+	}
+
+	return written + appended, s.client.location(key), s.propagate(readErr)
+}
+
+// appendParts - uploads the parts of the replacing object: the stored one (if it's copied) and the chunk,
+// the first part of which is already read into the buffer. Returns the number of bytes which were read
+// from the reader after the first part and the error of the reading.
+func (s *S3StorageService) appendParts(
+	key string,
+	uploadID string,
+	copyStored bool,
+	part []byte,
+	n int,
+	rerr error,
+	reader io.Reader,
+) (appended int64, readErr error, err error) {
+	var (
+		parts  []s3Part
+		number = 1
+	)
+	if copyStored {
+		copied, cerr := s.client.uploadPartCopy(s.ctx, key, uploadID, number, key)
+		if cerr != nil {
+			return 0, nil, cerr
+		}
+		parts = append(parts, copied)
+		number++
+	}
+
+	for {
+		if n > 0 {
+			uploaded, uerr := s.client.uploadPart(s.ctx, key, uploadID, number, part[:n])
+			if uerr != nil {
+				return 0, nil, uerr
+			}
+			parts = append(parts, uploaded)
+			number++
+		}
+		if rerr != nil {
+			if !errors.Is(rerr, io.EOF) && !errors.Is(rerr, io.ErrUnexpectedEOF) {
+				readErr = rerr
+			}
+			break
+		}
+
+		n, rerr = io.ReadFull(reader, part)
+		appended += int64(n)
+	}
+
+	if err = s.client.completeMultipartUpload(s.ctx, key, uploadID, parts); err != nil {
+		return 0, nil, err
+	}
+
+	return appended, readErr, nil
+}
+
+// readAll - reads the beginning of the object into the buffer.
+func (s *S3StorageService) readAll(key string, p []byte) (int, error) {
+	body, err := s.client.get(s.ctx, key, 0, int64(len(p)))
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = body.Close() }()
+
+	return io.ReadFull(body, p)
+}
+
+// propagate - logs the error if it's not nil.
+func (s *S3StorageService) propagate(err error) error {
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+	return nil
+}
+
+// Open is describing the object, the content is requested by ranges while reading.
+func (s *S3StorageService) Open(userID vo.ID, name string) (fileinterface.File, error) {
+	key := s.key(userID, name)
+
+	object, err := s.client.head(s.ctx, key)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	return &s3File{
+		ctx:     s.ctx,
+		client:  s.client,
+		key:     key,
+		name:    name,
+		size:    object.size,
+		modTime: object.modTime,
+		mu:      &sync.Mutex{},
+	}, nil
+}
+
+// Local is downloading the object into the temporary file which is removed on release.
+func (s *S3StorageService) Local(userID vo.ID, name string) (path string, release func(), err error) {
+	body, err := s.client.get(s.ctx, s.key(userID, name), 0, -1)
+	if err != nil {
+		return "", nil, s.logger.LogPropagate(err)
+	}
+	defer func() { _ = body.Close() }()
+
+	tmp, err := os.CreateTemp("", "object-*"+filepath.Ext(name))
+	if err != nil {
+		return "", nil, s.logger.LogPropagate(err)
+	}
+	defer func() { _ = tmp.Close() }()
+
+	release = func() {
+		if rerr := os.Remove(tmp.Name()); rerr != nil {
+			s.logger.Log(rerr)
+		}
+	}
+
+	if _, err = io.Copy(tmp, body); err != nil {
+		release()
+		return "", nil, s.logger.LogPropagate(err)
+	}
+
+	return tmp.Name(), release, nil
+}
+
+// Move is copying the object on the server side and removing the source one.
+func (s *S3StorageService) Move(userID vo.ID, from string, to string) (path string, err error) {
+	key := s.key(userID, to)
+
+	if err = s.client.copy(s.ctx, s.key(userID, from), key); err != nil {
+		return "", s.logger.LogPropagate(err)
+	}
+	if err = s.client.delete(s.ctx, s.key(userID, from)); err != nil {
+		return "", s.logger.LogPropagate(err)
+	}
+
+	return s.client.location(key), nil
+}
+
+// Remove is deleting the object, the missing one is not an error.
+func (s *S3StorageService) Remove(userID vo.ID, name string) error {
+	if err := s.client.delete(s.ctx, s.key(userID, name)); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+	return nil
+}
+
+func (s *S3StorageService) key(userID vo.ID, name string) string {
+	return fmt.Sprintf("%v/%v", userID.Hex(), name)
+}
+
+// s3File is the opened object. The sequential reading is served by one ranged request from the current
+// offset, it's reopened after the seek. The ReadAt makes a request per call, except the small ones.
+type s3File struct {
+	ctx     context.Context
+	client  *s3Client
+	key     string
+	name    string
+	size    int64
+	modTime time.Time
+
+	// sequential reading state
+	offset int64
+	body   io.ReadCloser
+
+	// mu - the read-ahead block is shared by the concurrent ReadAt calls
+	mu         *sync.Mutex
+	block      []byte
+	blockStart int64
+}
+
+func (f *s3File) Read(p []byte) (n int, err error) {
+	if f.offset >= f.size {
+		return 0, io.EOF
+	}
+	if f.body == nil {
+		if f.body, err = f.client.get(f.ctx, f.key, f.offset, -1); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err = f.body.Read(p)
+	f.offset += int64(n)
+	return n, err
+}
+
+func (f *s3File) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += f.size
+	}
+	if offset < 0 {
+		return 0, fmt.Errorf("seek of '%v' to the negative position %d", f.key, offset)
+	}
+
+	// the opened body reads from the previous offset
+	if offset != f.offset && f.body != nil {
+		_ = f.body.Close()
+		f.body = nil
+	}
+	f.offset = offset
+
+	return offset, nil
+}
+
+func (f *s3File) ReadAt(p []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, fmt.Errorf("read of '%v' at the negative offset %d", f.key, off)
+	}
+	if off >= f.size {
+		return 0, io.EOF
+	}
+
+	want := int64(len(p))
+	if want > f.size-off {
+		want = f.size - off
+	}
+
+	if want <= s3ReadAheadSize {
+		if n, err = f.readAhead(p[:want], off); err != nil {
+			return n, err
+		}
+	} else {
+		body, gerr := f.client.get(f.ctx, f.key, off, want)
+		if gerr != nil {
+			return 0, gerr
+		}
+		n, err = io.ReadFull(body, p[:want])
+		_ = body.Close()
+		if err != nil {
+			return n, err
+		}
+	}
+
+	// the ReaderAt must explain why the buffer is not filled
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// readAhead - serves the read from the cached block, the block is fetched if it does not contain the range.
+func (f *s3File) readAhead(p []byte, off int64) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	end := off + int64(len(p))
+	if off < f.blockStart || end > f.blockStart+int64(len(f.block)) {
+		length := int64(s3ReadAheadSize)
+		if length > f.size-off {
+			length = f.size - off
+		}
+
+		body, err := f.client.get(f.ctx, f.key, off, length)
+		if err != nil {
+			return 0, err
+		}
+		defer func() { _ = body.Close() }()
+
+		block := make([]byte, length)
+		if _, err = io.ReadFull(body, block); err != nil {
+			return 0, err
+		}
+		f.block, f.blockStart = block, off
+	}
+
+	return copy(p, f.block[off-f.blockStart:]), nil
+}
+
+func (f *s3File) Close() error {
+	if f.body != nil {
+		err := f.body.Close()
+		f.body = nil
+		return err
+	}
+	return nil
+}
+
+func (f *s3File) Name() string {
+	return f.name
+}
+
+func (f *s3File) Size() int64 {
+	return f.size
+}
+
+func (f *s3File) ModTime() time.Time {
+	return f.modTime
+}
@@ -0,0 +1,272 @@
+package file
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeS3 is an in-memory S3-compatible API (path style) which covers the operations of the s3Client. As the real
+// storage, it rejects the parts except the last one which are less than the min. part size.
+type fakeS3 struct {
+	mu      sync.Mutex
+	buckets map[string]bool
+	objects map[string][]byte
+	uploads map[string]map[int][]byte
+	nextID  int
+	// requests - the number of requests by the method, the tests check the operations which were used
+	requests map[string]int
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *httptest.Server) {
+	t.Helper()
+
+	fake := &fakeS3{
+		buckets:  make(map[string]bool),
+		objects:  make(map[string][]byte),
+		uploads:  make(map[string]map[int][]byte),
+		requests: make(map[string]int),
+	}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	return fake, server
+}
+
+func (f *fakeS3) object(bucket string, key string) ([]byte, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	data, ok := f.objects[bucket+"/"+key]
+	return data, ok
+}
+
+func (f *fakeS3) pendingUploads() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.uploads)
+}
+
+func (f *fakeS3) requested(operation string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.requests[operation]
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), s3SigningAlgo+" Credential=") {
+		f.error(w, http.StatusForbidden, "AccessDenied")
+		return
+	}
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	query := r.URL.Query()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if key == "" {
+		f.serveBucket(w, r, bucket)
+		return
+	}
+	if !f.buckets[bucket] {
+		f.error(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+	name := bucket + "/" + key
+
+	switch {
+	case r.Method == http.MethodPost && query.Has("uploads"):
+		f.requests["create"]++
+		f.nextID++
+		uploadID := strconv.Itoa(f.nextID)
+		f.uploads[uploadID] = make(map[int][]byte)
+		f.xml(w, struct {
+			XMLName  xml.Name `xml:"InitiateMultipartUploadResult"`
+			UploadID string   `xml:"UploadId"`
+		}{UploadID: uploadID})
+	case r.Method == http.MethodPut && query.Has("uploadId"):
+		parts, ok := f.uploads[query.Get("uploadId")]
+		if !ok {
+			f.error(w, http.StatusNotFound, "NoSuchUpload")
+			return
+		}
+		number, _ := strconv.Atoi(query.Get("partNumber"))
+		if source := r.Header.Get("X-Amz-Copy-Source"); source != "" {
+			f.requests["copy part"]++
+			data, found := f.source(source)
+			if !found {
+				f.error(w, http.StatusNotFound, "NoSuchKey")
+				return
+			}
+			parts[number] = data
+			f.xml(w, struct {
+				XMLName xml.Name `xml:"CopyPartResult"`
+				ETag    string   `xml:"ETag"`
+			}{ETag: etag(data)})
+			return
+		}
+		f.requests["part"]++
+		data, _ := io.ReadAll(r.Body)
+		parts[number] = data
+		w.Header().Set("ETag", etag(data))
+	case r.Method == http.MethodPost && query.Has("uploadId"):
+		f.requests["complete"]++
+		f.complete(w, r, name, query.Get("uploadId"))
+	case r.Method == http.MethodDelete && query.Has("uploadId"):
+		f.requests["abort"]++
+		delete(f.uploads, query.Get("uploadId"))
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPut && r.Header.Get("X-Amz-Copy-Source") != "":
+		f.requests["copy"]++
+		data, found := f.source(r.Header.Get("X-Amz-Copy-Source"))
+		if !found {
+			f.error(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		f.objects[name] = data
+		f.xml(w, struct {
+			XMLName xml.Name `xml:"CopyObjectResult"`
+			ETag    string   `xml:"ETag"`
+		}{ETag: etag(data)})
+	case r.Method == http.MethodPut:
+		f.requests["put"]++
+		data, _ := io.ReadAll(r.Body)
+		f.objects[name] = data
+		w.Header().Set("ETag", etag(data))
+	case r.Method == http.MethodDelete:
+		f.requests["delete"]++
+		delete(f.objects, name)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodHead || r.Method == http.MethodGet:
+		f.requests[r.Method]++
+		f.serveObject(w, r, name)
+	default:
+		f.error(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
+
+func (f *fakeS3) serveBucket(w http.ResponseWriter, r *http.Request, bucket string) {
+	switch r.Method {
+	case http.MethodHead:
+		if !f.buckets[bucket] {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	case http.MethodPut:
+		f.buckets[bucket] = true
+	default:
+		f.error(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
+
+func (f *fakeS3) serveObject(w http.ResponseWriter, r *http.Request, name string) {
+	data, ok := f.objects[name]
+	if !ok {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		f.error(w, http.StatusNotFound, "NoSuchKey")
+		return
+	}
+
+	w.Header().Set("Last-Modified", time.Unix(0, 0).UTC().Format(http.TimeFormat))
+	if r.Method == http.MethodHead {
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		return
+	}
+
+	start, end := 0, len(data)-1
+	if ranged := r.Header.Get("Range"); ranged != "" {
+		from, to, _ := strings.Cut(strings.TrimPrefix(ranged, "bytes="), "-")
+		start, _ = strconv.Atoi(from)
+		if to != "" {
+			end, _ = strconv.Atoi(to)
+		}
+		if start >= len(data) {
+			f.error(w, http.StatusRequestedRangeNotSatisfiable, "InvalidRange")
+			return
+		}
+		if end >= len(data) {
+			end = len(data) - 1
+		}
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, len(data)))
+		w.Header().Set("Content-Length", strconv.Itoa(end-start+1))
+		w.WriteHeader(http.StatusPartialContent)
+	}
+	_, _ = w.Write(data[start : end+1])
+}
+
+func (f *fakeS3) complete(w http.ResponseWriter, r *http.Request, name string, uploadID string) {
+	parts, ok := f.uploads[uploadID]
+	if !ok {
+		f.error(w, http.StatusNotFound, "NoSuchUpload")
+		return
+	}
+
+	completed := struct {
+		Parts []s3Part `xml:"Part"`
+	}{}
+	if err := xml.NewDecoder(r.Body).Decode(&completed); err != nil || len(completed.Parts) == 0 {
+		f.error(w, http.StatusBadRequest, "MalformedXML")
+		return
+	}
+
+	content := bytes.Buffer{}
+	for i, part := range completed.Parts {
+		data, found := parts[part.Number]
+		if !found || etag(data) != part.ETag {
+			f.error(w, http.StatusBadRequest, "InvalidPart")
+			return
+		}
+		if i < len(completed.Parts)-1 && len(data) < s3MinPartSize {
+			f.error(w, http.StatusBadRequest, "EntityTooSmall")
+			return
+		}
+		content.Write(data)
+	}
+
+	f.objects[name] = content.Bytes()
+	delete(f.uploads, uploadID)
+	f.xml(w, struct {
+		XMLName xml.Name `xml:"CompleteMultipartUploadResult"`
+		Key     string   `xml:"Key"`
+	}{Key: name})
+}
+
+// source - takes the object by the copy source header '/bucket/key'.
+func (f *fakeS3) source(header string) ([]byte, bool) {
+	source, err := url.PathUnescape(strings.TrimPrefix(header, "/"))
+	if err != nil {
+		return nil, false
+	}
+	data, ok := f.objects[source]
+	return data, ok
+}
+
+func (f *fakeS3) xml(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/xml")
+	_ = xml.NewEncoder(w).Encode(v)
+}
+
+func (f *fakeS3) error(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_ = xml.NewEncoder(w).Encode(struct {
+		XMLName xml.Name `xml:"Error"`
+		s3Error
+	}{s3Error: s3Error{Code: code, Message: code}})
+}
+
+func etag(data []byte) string {
+	return fmt.Sprintf(`"%x"`, md5.Sum(data))
+}
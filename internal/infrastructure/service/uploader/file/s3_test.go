@@ -0,0 +1,369 @@
+package file
+
+import (
+	"bytes"
+	"errors"
+	"github.com/Borislavv/video-streaming/internal/app"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/di/ditest"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"io"
+	"testing"
+)
+
+const testS3Bucket = "resources"
+
+func newTestS3Storage(t *testing.T) (*S3StorageService, *fakeS3, vo.ID) {
+	t.Helper()
+
+	fake, server := newFakeS3(t)
+	container := ditest.NewContainer(t, &app.Config{
+		S3Endpoint:     server.URL,
+		S3Region:       "us-east-1",
+		S3Bucket:       testS3Bucket,
+		S3AccessKey:    "access",
+		S3SecretKey:    "secret",
+		S3UsePathStyle: true,
+		S3PartSize:     s3MinPartSize,
+	})
+
+	storage, err := NewS3StorageService(container)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return storage, fake, vo.NewID(primitive.NewObjectID())
+}
+
+// testContent - makes the content which differs by the position, so the misplaced ranges are detected.
+func testContent(size int, seed byte) []byte {
+	content := make([]byte, size)
+	for i := range content {
+		content[i] = byte(i%251) + seed
+	}
+	return content
+}
+
+// interruptedReader - returns the content and the error instead of the io.EOF, as the broken connection.
+type interruptedReader struct {
+	io.Reader
+}
+
+func (r interruptedReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if errors.Is(err, io.EOF) {
+		return n, io.ErrClosedPipe
+	}
+	return n, err
+}
+
+func TestS3StorageService_Store(t *testing.T) {
+	tests := []struct {
+		name      string
+		size      int
+		multipart bool
+	}{
+		{name: "empty content is stored by one request", size: 0},
+		{name: "small content is stored by one request", size: 1000},
+		{name: "content of the single part is stored by parts", size: s3MinPartSize, multipart: true},
+		{name: "large content is stored by parts", size: 2*s3MinPartSize + 1000, multipart: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			storage, fake, userID := newTestS3Storage(t)
+			content := testContent(test.size, 1)
+
+			length, path, err := storage.Store(userID, "video.mp4", bytes.NewReader(content))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if length != int64(test.size) {
+				t.Errorf("expected length %d, got %d", test.size, length)
+			}
+			if expected := "s3://" + testS3Bucket + "/" + userID.Hex() + "/video.mp4"; path != expected {
+				t.Errorf("expected path '%v', got '%v'", expected, path)
+			}
+
+			stored, ok := fake.object(testS3Bucket, userID.Hex()+"/video.mp4")
+			if !ok || !bytes.Equal(stored, content) {
+				t.Fatalf("expected the stored content of %d bytes, got %d bytes (stored: %v)", test.size, len(stored), ok)
+			}
+			if multipart := fake.requested("complete") > 0; multipart != test.multipart {
+				t.Errorf("expected multipart upload %v, got %v", test.multipart, multipart)
+			}
+			if fake.pendingUploads() != 0 {
+				t.Errorf("expected no pending uploads, got %d", fake.pendingUploads())
+			}
+			if has, _ := storage.Has(userID, "video.mp4"); !has {
+				t.Error("expected the object exists")
+			}
+		})
+	}
+}
+
+func TestS3StorageService_Store_InterruptedReader(t *testing.T) {
+	storage, fake, userID := newTestS3Storage(t)
+
+	content := testContent(2*s3MinPartSize, 1)
+	if _, _, err := storage.Store(userID, "video.mp4", interruptedReader{bytes.NewReader(content)}); err == nil {
+		t.Fatal("expected the reading error")
+	}
+	if has, _ := storage.Has(userID, "video.mp4"); has {
+		t.Error("expected the object is not stored")
+	}
+	if fake.pendingUploads() != 0 {
+		t.Errorf("expected the upload is aborted, got %d pending uploads", fake.pendingUploads())
+	}
+}
+
+func TestS3StorageService_Open(t *testing.T) {
+	storage, fake, userID := newTestS3Storage(t)
+
+	size := 3*s3ReadAheadSize + 100
+	content := testContent(size, 1)
+	if _, _, err := storage.Store(userID, "video.mp4", bytes.NewReader(content)); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := storage.Open(userID, "video.mp4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = file.Close() }()
+
+	if file.Size() != int64(size) {
+		t.Fatalf("expected size %d, got %d", size, file.Size())
+	}
+	if file.Name() != "video.mp4" {
+		t.Fatalf("expected name 'video.mp4', got '%v'", file.Name())
+	}
+
+	t.Run("ranges", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			offset   int64
+			length   int
+			expected int
+			eof      bool
+		}{
+			{name: "head", offset: 0, length: 8, expected: 8},
+			{name: "small range inside the block", offset: 100, length: 1000, expected: 1000},
+			{name: "small range across the block", offset: s3ReadAheadSize - 10, length: 20, expected: 20},
+			{name: "large range", offset: 10, length: 2*s3ReadAheadSize + 5, expected: 2*s3ReadAheadSize + 5},
+			{name: "small range at the end", offset: int64(size) - 10, length: 100, expected: 10, eof: true},
+			{name: "large range at the end", offset: 1, length: size, expected: size - 1, eof: true},
+			{name: "last byte", offset: int64(size) - 1, length: 1, expected: 1},
+			{name: "beyond the end", offset: int64(size), length: 10, expected: 0, eof: true},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				p := make([]byte, test.length)
+				n, rerr := file.ReadAt(p, test.offset)
+				if test.eof && !errors.Is(rerr, io.EOF) {
+					t.Fatalf("expected io.EOF, got %v", rerr)
+				}
+				if !test.eof && rerr != nil {
+					t.Fatal(rerr)
+				}
+				if n != test.expected {
+					t.Fatalf("expected %d bytes, got %d", test.expected, n)
+				}
+				if !bytes.Equal(p[:n], content[test.offset:test.offset+int64(n)]) {
+					t.Fatalf("the range %d-%d differs from the content", test.offset, test.offset+int64(n))
+				}
+			})
+		}
+	})
+
+	t.Run("small reads are served by the block", func(t *testing.T) {
+		p := make([]byte, 16)
+		if _, rerr := file.ReadAt(p, 2*s3ReadAheadSize); rerr != nil {
+			t.Fatal(rerr)
+		}
+		requested := fake.requested("GET")
+		for off := int64(2*s3ReadAheadSize + 16); off < 3*s3ReadAheadSize-16; off += 4096 {
+			if _, rerr := file.ReadAt(p, off); rerr != nil {
+				t.Fatal(rerr)
+			}
+			if !bytes.Equal(p, content[off:off+16]) {
+				t.Fatalf("the range at %d differs from the content", off)
+			}
+		}
+		if fake.requested("GET") != requested {
+			t.Errorf("expected no requests, got %d", fake.requested("GET")-requested)
+		}
+	})
+
+	t.Run("negative offset", func(t *testing.T) {
+		if _, rerr := file.ReadAt(make([]byte, 1), -1); rerr == nil || errors.Is(rerr, io.EOF) {
+			t.Fatalf("expected the negative offset error, got %v", rerr)
+		}
+	})
+
+	t.Run("read and seek", func(t *testing.T) {
+		read, rerr := io.ReadAll(file)
+		if rerr != nil {
+			t.Fatal(rerr)
+		}
+		if !bytes.Equal(read, content) {
+			t.Fatalf("expected the whole content of %d bytes, got %d bytes", size, len(read))
+		}
+
+		offset, rerr := file.Seek(-100, io.SeekEnd)
+		if rerr != nil {
+			t.Fatal(rerr)
+		}
+		if offset != int64(size)-100 {
+			t.Fatalf("expected offset %d, got %d", size-100, offset)
+		}
+		if read, rerr = io.ReadAll(file); rerr != nil {
+			t.Fatal(rerr)
+		}
+		if !bytes.Equal(read, content[size-100:]) {
+			t.Fatalf("expected the last 100 bytes, got %d bytes", len(read))
+		}
+
+		if _, rerr = file.Seek(-1, io.SeekStart); rerr == nil {
+			t.Fatal("expected the negative position error")
+		}
+	})
+}
+
+func TestS3StorageService_Open_NotFound(t *testing.T) {
+	storage, _, userID := newTestS3Storage(t)
+
+	if _, err := storage.Open(userID, "missing.mp4"); !errtype.IsStorageObjectNotFoundError(err) {
+		t.Fatalf("expected the not found error, got %v", err)
+	}
+}
+
+func TestS3StorageService_Remove(t *testing.T) {
+	storage, _, userID := newTestS3Storage(t)
+
+	if _, _, err := storage.Store(userID, "video.mp4", bytes.NewReader(testContent(1000, 1))); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := storage.Store(userID, "kept.mp4", bytes.NewReader(testContent(1000, 2))); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := storage.Remove(userID, "video.mp4"); err != nil {
+		t.Fatal(err)
+	}
+	if has, err := storage.Has(userID, "video.mp4"); err != nil || has {
+		t.Fatalf("expected the object is removed, got has %v (err: %v)", has, err)
+	}
+	if has, _ := storage.Has(userID, "kept.mp4"); !has {
+		t.Fatal("expected the other object is kept")
+	}
+
+	// the missing object is not an error
+	if err := storage.Remove(userID, "video.mp4"); err != nil {
+		t.Fatalf("expected removing of the missing object succeeds, got %v", err)
+	}
+}
+
+func TestS3StorageService_Append(t *testing.T) {
+	storage, fake, userID := newTestS3Storage(t)
+
+	chunks := []struct {
+		name   string
+		size   int
+		copied bool
+	}{
+		{name: "first chunk creates the object", size: 1000},
+		{name: "small object is prepended to the chunk", size: 2000},
+		{name: "small object is prepended to the first part of the large chunk", size: s3MinPartSize + 500},
+		{name: "large object is copied on the server side", size: 10, copied: true},
+		{name: "large object is copied before the large chunk", size: 2*s3MinPartSize + 1, copied: true},
+	}
+
+	var expected []byte
+	for i, chunk := range chunks {
+		t.Run(chunk.name, func(t *testing.T) {
+			content := testContent(chunk.size, byte(i))
+			copied := fake.requested("copy part")
+
+			written, path, err := storage.Append(userID, "upload", int64(len(expected)), bytes.NewReader(content))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if written != int64(chunk.size) {
+				t.Fatalf("expected %d written bytes, got %d", chunk.size, written)
+			}
+			if path != "s3://"+testS3Bucket+"/"+userID.Hex()+"/upload" {
+				t.Fatalf("unexpected path '%v'", path)
+			}
+			expected = append(expected, content...)
+
+			stored, _ := fake.object(testS3Bucket, userID.Hex()+"/upload")
+			if !bytes.Equal(stored, expected) {
+				t.Fatalf("expected the object of %d bytes, got %d bytes", len(expected), len(stored))
+			}
+			if isCopied := fake.requested("copy part") > copied; isCopied != chunk.copied {
+				t.Errorf("expected the stored content is copied %v, got %v", chunk.copied, isCopied)
+			}
+			if fake.pendingUploads() != 0 {
+				t.Errorf("expected no pending uploads, got %d", fake.pendingUploads())
+			}
+		})
+	}
+
+	t.Run("empty chunk keeps the object", func(t *testing.T) {
+		written, _, err := storage.Append(userID, "upload", int64(len(expected)), bytes.NewReader(nil))
+		if err != nil || written != 0 {
+			t.Fatalf("expected nothing is written, got %d bytes (err: %v)", written, err)
+		}
+		if stored, _ := fake.object(testS3Bucket, userID.Hex()+"/upload"); !bytes.Equal(stored, expected) {
+			t.Fatal("expected the object is not changed")
+		}
+	})
+
+	t.Run("offset mismatch", func(t *testing.T) {
+		_, _, err := storage.Append(userID, "upload", int64(len(expected))-1, bytes.NewReader([]byte("chunk")))
+		mismatch := &errtype.UploadOffsetMismatchError{}
+		if !errors.As(err, &mismatch) {
+			t.Fatalf("expected the offset mismatch error, got %v", err)
+		}
+		if stored, _ := fake.object(testS3Bucket, userID.Hex()+"/upload"); !bytes.Equal(stored, expected) {
+			t.Fatal("expected the object is not changed")
+		}
+	})
+}
+
+func TestS3StorageService_Append_InterruptedReader(t *testing.T) {
+	tests := []struct {
+		name   string
+		stored int
+		chunk  int
+	}{
+		{name: "small chunk", stored: 100, chunk: 1000},
+		{name: "large chunk", stored: 100, chunk: s3MinPartSize + 1000},
+		{name: "large object", stored: s3MinPartSize, chunk: 1000},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			storage, fake, userID := newTestS3Storage(t)
+
+			stored := testContent(test.stored, 1)
+			if _, _, err := storage.Append(userID, "upload", 0, bytes.NewReader(stored)); err != nil {
+				t.Fatal(err)
+			}
+
+			// the received part of the chunk is saved, so the client resumes from the returned offset
+			chunk := testContent(test.chunk, 2)
+			written, _, err := storage.Append(userID, "upload", int64(test.stored), interruptedReader{bytes.NewReader(chunk)})
+			if !errors.Is(err, io.ErrClosedPipe) {
+				t.Fatalf("expected the reading error, got %v", err)
+			}
+			if written != int64(test.chunk) {
+				t.Fatalf("expected %d written bytes, got %d", test.chunk, written)
+			}
+			object, _ := fake.object(testS3Bucket, userID.Hex()+"/upload")
+			if !bytes.Equal(object, append(stored, chunk...)) {
+				t.Fatalf("expected the object of %d bytes, got %d bytes", test.stored+test.chunk, len(object))
+			}
+		})
+	}
+}
@@ -0,0 +1,450 @@
+package file
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	s3SigningAlgo   = "AWS4-HMAC-SHA256"
+	s3Service       = "s3"
+	s3DefaultRegion = "us-east-1"
+	// s3UnsignedPayload - the body is not hashed, so the large parts are streamed without buffering twice
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+)
+
+// s3Object is a description of the stored object.
+type s3Object struct {
+	size    int64
+	modTime time.Time
+}
+
+// s3Part is an uploaded part of the multipart upload.
+type s3Part struct {
+	Number int    `xml:"PartNumber"`
+	ETag   string `xml:"ETag"`
+}
+
+// s3Error is an error response body.
+type s3Error struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+// s3Client is a minimal client of the S3-compatible API (AWS S3, MinIO, Ceph RGW and others) which covers only
+// the operations needed by the storage. The requests are signed by the AWS Signature Version 4.
+type s3Client struct {
+	http      *http.Client
+	endpoint  *url.URL
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	// pathStyle - the bucket is addressed by the path instead of the subdomain (required by MinIO by default)
+	pathStyle bool
+}
+
+func newS3Client(endpoint, region, bucket, accessKey, secretKey string, pathStyle bool) (*s3Client, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("s3 endpoint '%v' must contain the scheme and host", endpoint)
+	}
+	if region == "" {
+		region = s3DefaultRegion
+	}
+
+	return &s3Client{
+		http:      &http.Client{},
+		endpoint:  u,
+		region:    region,
+		bucket:    bucket,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		pathStyle: pathStyle,
+	}, nil
+}
+
+// location - is a full qualified name of the object.
+func (c *s3Client) location(key string) string {
+	return fmt.Sprintf("s3://%v/%v", c.bucket, key)
+}
+
+// ensureBucket - creates the bucket if it does not exist yet.
+func (c *s3Client) ensureBucket(ctx context.Context) error {
+	resp, err := c.do(ctx, http.MethodHead, "", nil, nil, nil, 0)
+	if err == nil {
+		_ = resp.Body.Close()
+		return nil
+	} else if !errtype.IsStorageObjectNotFoundError(err) {
+		return err
+	}
+
+	var body []byte
+	if c.region != s3DefaultRegion {
+		body = []byte(fmt.Sprintf(
+			`<CreateBucketConfiguration><LocationConstraint>%v</LocationConstraint></CreateBucketConfiguration>`,
+			c.region,
+		))
+	}
+	resp, err = c.do(ctx, http.MethodPut, "", nil, nil, bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// head - describes the object.
+func (c *s3Client) head(ctx context.Context, key string) (s3Object, error) {
+	resp, err := c.do(ctx, http.MethodHead, key, nil, nil, nil, 0)
+	if err != nil {
+		return s3Object{}, err
+	}
+	_ = resp.Body.Close()
+
+	modTime, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+	return s3Object{size: resp.ContentLength, modTime: modTime}, nil
+}
+
+// get - reads the given range of the object, the negative length means up to the end.
+func (c *s3Client) get(ctx context.Context, key string, offset int64, length int64) (io.ReadCloser, error) {
+	header := http.Header{}
+	if length < 0 {
+		header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	} else {
+		header.Set("Range", fmt.Sprintf("bytes=%d-%d", offset, offset+length-1))
+	}
+
+	resp, err := c.do(ctx, http.MethodGet, key, nil, header, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// put - stores the object of the known length by the single request.
+func (c *s3Client) put(ctx context.Context, key string, body io.Reader, length int64) error {
+	resp, err := c.do(ctx, http.MethodPut, key, nil, nil, body, length)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// copy - makes a server side copy of the object.
+func (c *s3Client) copy(ctx context.Context, from string, to string) error {
+	header := http.Header{}
+	header.Set("X-Amz-Copy-Source", "/"+c.bucket+"/"+s3EncodePath(from))
+
+	resp, err := c.do(ctx, http.MethodPut, to, nil, header, nil, 0)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// the copying may fail after the response status was sent, then the error is in the body
+	return c.bodyError(resp, "copy", to)
+}
+
+// delete - removes the object, the missing object is not an error.
+func (c *s3Client) delete(ctx context.Context, key string) error {
+	resp, err := c.do(ctx, http.MethodDelete, key, nil, nil, nil, 0)
+	if err != nil {
+		if errtype.IsStorageObjectNotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// createMultipartUpload - starts the upload of the object by parts, the upload id is returned.
+func (c *s3Client) createMultipartUpload(ctx context.Context, key string) (string, error) {
+	resp, err := c.do(ctx, http.MethodPost, key, url.Values{"uploads": {""}}, nil, nil, 0)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	result := struct {
+		UploadID string `xml:"UploadId"`
+	}{}
+	if err = xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", err
+	}
+	return result.UploadID, nil
+}
+
+// uploadPart - uploads the part of the multipart upload, each part except the last one must be at least 5mb.
+func (c *s3Client) uploadPart(ctx context.Context, key string, uploadID string, number int, data []byte) (s3Part, error) {
+	query := url.Values{"partNumber": {strconv.Itoa(number)}, "uploadId": {uploadID}}
+
+	resp, err := c.do(ctx, http.MethodPut, key, query, nil, bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return s3Part{}, err
+	}
+	_ = resp.Body.Close()
+
+	return s3Part{Number: number, ETag: resp.Header.Get("ETag")}, nil
+}
+
+// uploadPartCopy - copies the whole source object as the part of the multipart upload on the server side,
+// the source may be the object which is replaced by the upload.
+func (c *s3Client) uploadPartCopy(
+	ctx context.Context,
+	key string,
+	uploadID string,
+	number int,
+	source string,
+) (s3Part, error) {
+	query := url.Values{"partNumber": {strconv.Itoa(number)}, "uploadId": {uploadID}}
+	header := http.Header{}
+	header.Set("X-Amz-Copy-Source", "/"+c.bucket+"/"+s3EncodePath(source))
+
+	resp, err := c.do(ctx, http.MethodPut, key, query, header, nil, 0)
+	if err != nil {
+		return s3Part{}, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return s3Part{}, err
+	}
+
+	// the copying may fail after the response status was sent, then the error is in the body
+	result := struct {
+		s3Error
+		ETag string `xml:"ETag"`
+	}{}
+	if err = xml.Unmarshal(data, &result); err != nil {
+		return s3Part{}, err
+	}
+	if result.Code != "" {
+		return s3Part{}, errtype.NewStorageRequestFailedError(
+			"copy part", c.location(key), resp.StatusCode, result.Code+": "+result.Message,
+		)
+	}
+
+	return s3Part{Number: number, ETag: result.ETag}, nil
+}
+
+// completeMultipartUpload - assembles the object from the uploaded parts.
+func (c *s3Client) completeMultipartUpload(ctx context.Context, key string, uploadID string, parts []s3Part) error {
+	body, err := xml.Marshal(struct {
+		XMLName xml.Name `xml:"CompleteMultipartUpload"`
+		Parts   []s3Part `xml:"Part"`
+	}{Parts: parts})
+	if err != nil {
+		return err
+	}
+
+	query := url.Values{"uploadId": {uploadID}}
+	resp, err := c.do(ctx, http.MethodPost, key, query, nil, bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// the assembling may fail after the response status was sent, then the error is in the body
+	return c.bodyError(resp, "complete", key)
+}
+
+// abortMultipartUpload - drops the uploaded parts.
+func (c *s3Client) abortMultipartUpload(ctx context.Context, key string, uploadID string) error {
+	resp, err := c.do(ctx, http.MethodDelete, key, url.Values{"uploadId": {uploadID}}, nil, nil, 0)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// do - makes the signed request, the response is returned only with the successful status.
+func (c *s3Client) do(
+	ctx context.Context,
+	method string,
+	key string,
+	query url.Values,
+	header http.Header,
+	body io.Reader,
+	length int64,
+) (*http.Response, error) {
+	u := *c.endpoint
+	path := strings.TrimSuffix(u.Path, "/")
+	if c.pathStyle {
+		path += "/" + c.bucket
+	} else {
+		u.Host = c.bucket + "." + u.Host
+	}
+	if key != "" {
+		path += "/" + key
+	}
+	if path == "" {
+		path = "/"
+	}
+	u.Path = path
+	u.RawPath = s3EncodePath(path)
+	u.RawQuery = s3CanonicalQuery(query)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	if body != nil {
+		req.ContentLength = length
+		if length == 0 {
+			req.Body = http.NoBody
+		}
+	}
+	c.sign(req, u.RawPath, time.Now())
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer func() { _ = resp.Body.Close() }()
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, errtype.NewStorageObjectNotFoundError(c.location(key))
+		}
+		return nil, errtype.NewStorageRequestFailedError(method, c.location(key), resp.StatusCode, s3Reason(resp))
+	}
+
+	return resp, nil
+}
+
+// bodyError - checks the successful response does not contain an error.
+func (c *s3Client) bodyError(resp *http.Response, operation string, key string) error {
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	e := s3Error{}
+	if xml.Unmarshal(data, &e) == nil && e.Code != "" {
+		return errtype.NewStorageRequestFailedError(operation, c.location(key), resp.StatusCode, e.Code+": "+e.Message)
+	}
+	return nil
+}
+
+// sign - signs the request by the AWS Signature Version 4.
+func (c *s3Client) sign(req *http.Request, canonicalURI string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", s3UnsignedPayload)
+
+	// the host and the amz headers are signed
+	names := []string{"host"}
+	for name := range req.Header {
+		if lower := strings.ToLower(name); strings.HasPrefix(lower, "x-amz-") {
+			names = append(names, lower)
+		}
+	}
+	sort.Strings(names)
+
+	canonicalHeaders := strings.Builder{}
+	for _, name := range names {
+		value := req.URL.Host
+		if name != "host" {
+			value = strings.TrimSpace(strings.Join(req.Header.Values(name), ","))
+		}
+		canonicalHeaders.WriteString(name + ":" + value + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI,
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		s3UnsignedPayload,
+	}, "\n")
+
+	scope := strings.Join([]string{date, c.region, s3Service, "aws4_request"}, "/")
+	hashed := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{s3SigningAlgo, amzDate, scope, hex.EncodeToString(hashed[:])}, "\n")
+
+	key := s3HMAC([]byte("AWS4"+c.secretKey), date)
+	key = s3HMAC(key, c.region)
+	key = s3HMAC(key, s3Service)
+	key = s3HMAC(key, "aws4_request")
+	signature := hex.EncodeToString(s3HMAC(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"%v Credential=%v/%v, SignedHeaders=%v, Signature=%v",
+		s3SigningAlgo, c.accessKey, scope, signedHeaders, signature,
+	))
+}
+
+func s3HMAC(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// s3Encode - encodes the string as the signature requires: everything except the unreserved characters.
+func s3Encode(s string, keepSlash bool) string {
+	b := strings.Builder{}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' || (keepSlash && c == '/') {
+			b.WriteByte(c)
+		} else {
+			b.WriteString(fmt.Sprintf("%%%02X", c))
+		}
+	}
+	return b.String()
+}
+
+func s3EncodePath(path string) string {
+	return s3Encode(path, true)
+}
+
+// s3CanonicalQuery - encodes the query sorted by the keys, it's used as is for the request and the signature.
+func s3CanonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		for _, v := range query[k] {
+			pairs = append(pairs, s3Encode(k, false)+"="+s3Encode(v, false))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// s3Reason - takes the error code and message from the response, the status is used if the body is empty.
+func s3Reason(resp *http.Response) string {
+	e := s3Error{}
+	if err := xml.NewDecoder(resp.Body).Decode(&e); err != nil || e.Code == "" {
+		return resp.Status
+	}
+	return e.Code + ": " + e.Message
+}
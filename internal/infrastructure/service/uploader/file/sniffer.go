@@ -0,0 +1,85 @@
+package file
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+const (
+	// sniffLen - the number of bytes which are considered by the http.DetectContentType
+	sniffLen = 512
+	// mpegTSPacketLen - the transport stream is detected by the sync byte at the start of two packets
+	mpegTSPacketLen   = 188
+	mpegTSSyncByte    = 0x47
+	unknownFiletype   = "application/octet-stream"
+	oggFiletype       = "application/ogg"
+	mp4Filetype       = "video/mp4"
+	m4aFiletype       = "audio/mp4"
+	quickTimeFiletype = "video/quicktime"
+	mpegTSFiletype    = "video/mp2t"
+	flacFiletype      = "audio/flac"
+)
+
+// ContentSnifferService detects the filetype by the content instead of trusting the client's header, so the
+// stored filetype is always the real one and a not media content is rejected before it's stored.
+type ContentSnifferService struct {
+}
+
+func NewContentSnifferService() *ContentSnifferService {
+	return &ContentSnifferService{}
+}
+
+// Sniff - detects the filetype of the content, the not media content is rejected.
+func (s *ContentSnifferService) Sniff(name string, reader io.Reader) (filetype string, content io.Reader, err error) {
+	buffered := bufio.NewReaderSize(reader, sniffLen)
+
+	head, err := buffered.Peek(sniffLen)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", nil, err
+	}
+
+	filetype = s.detect(head)
+	if !strings.HasPrefix(filetype, "video/") && !strings.HasPrefix(filetype, "audio/") && filetype != oggFiletype {
+		return "", nil, errtype.NewUnsupportedMediaContentError(name, filetype)
+	}
+
+	return filetype, buffered, nil
+}
+
+// detect - the containers which are not known by the http.DetectContentType are checked first.
+func (s *ContentSnifferService) detect(head []byte) string {
+	if len(head) >= 12 && bytes.Equal(head[4:8], []byte("ftyp")) {
+		// the iso base media files are described by the major brand
+		switch brand := string(head[8:12]); {
+		case brand == "qt  ":
+			return quickTimeFiletype
+		case brand == "M4A " || brand == "M4B ":
+			return m4aFiletype
+		case binary.BigEndian.Uint32(head[:4]) >= 8:
+			return mp4Filetype
+		}
+	}
+	if len(head) > mpegTSPacketLen && head[0] == mpegTSSyncByte && head[mpegTSPacketLen] == mpegTSSyncByte {
+		return mpegTSFiletype
+	}
+	if bytes.HasPrefix(head, []byte("fLaC")) {
+		return flacFiletype
+	}
+	if len(head) == 0 {
+		return unknownFiletype
+	}
+
+	// the parameters are not a part of the stored filetype
+	filetype, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil {
+		return unknownFiletype
+	}
+	return filetype
+}
@@ -0,0 +1,76 @@
+package file
+
+import (
+	"bytes"
+	"errors"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"io"
+	"testing"
+)
+
+func testFtyp(brand string) []byte {
+	return append([]byte{0, 0, 0, 24, 'f', 't', 'y', 'p'}, []byte(brand+"\x00\x00\x02\x00isommp41")...)
+}
+
+func testMPEGTS(packets int) []byte {
+	content := make([]byte, packets*mpegTSPacketLen)
+	for i := 0; i < packets; i++ {
+		content[i*mpegTSPacketLen] = mpegTSSyncByte
+	}
+	return content
+}
+
+func TestContentSnifferService_Sniff(t *testing.T) {
+	tests := []struct {
+		name     string
+		head     []byte
+		filetype string // empty means the content is rejected
+	}{
+		{name: "quicktime brand", head: testFtyp("qt  "), filetype: quickTimeFiletype},
+		{name: "m4a brand", head: testFtyp("M4A "), filetype: m4aFiletype},
+		{name: "m4b brand", head: testFtyp("M4B "), filetype: m4aFiletype},
+		{name: "isom brand", head: testFtyp("isom"), filetype: mp4Filetype},
+		{name: "mp42 brand", head: testFtyp("mp42"), filetype: mp4Filetype},
+		{name: "mpeg-ts of two packets", head: testMPEGTS(2), filetype: mpegTSFiletype},
+		{name: "flac", head: []byte("fLaC\x00\x00\x00\x22"), filetype: flacFiletype},
+		{name: "webm", head: []byte("\x1A\x45\xDF\xA3\x9F\x42\x86\x81\x01\x42\x82\x84webm"), filetype: "video/webm"},
+		{name: "ogg", head: []byte("OggS\x00\x02\x00\x00"), filetype: oggFiletype},
+		{name: "mp3 with id3", head: []byte("ID3\x04\x00\x00\x00\x00\x00\x00"), filetype: "audio/mpeg"},
+		{name: "html", head: []byte("<!DOCTYPE html><html><body>video</body></html>")},
+		{name: "text", head: []byte("just a text")},
+		{name: "mpeg-ts sync byte of single packet", head: testMPEGTS(1)},
+		{name: "empty", head: []byte{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// the content is longer than the sniffed part, so it must be passed through as is
+			content := append(append([]byte{}, test.head...), testContent(2*sniffLen, 1)...)
+			if len(test.head) == 0 {
+				content = test.head
+			}
+
+			filetype, reader, err := NewContentSnifferService().Sniff("file", bytes.NewReader(content))
+			if test.filetype == "" {
+				unsupported := &errtype.UnsupportedMediaContentError{}
+				if !errors.As(err, &unsupported) {
+					t.Fatalf("expected the content is rejected, got '%v' and %v", filetype, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if filetype != test.filetype {
+				t.Errorf("expected '%v', got '%v'", test.filetype, filetype)
+			}
+			passed, err := io.ReadAll(reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(passed, content) {
+				t.Errorf("expected the whole content of %d bytes is passed, got %d", len(content), len(passed))
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package file
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// subRipTiming - the timing line of SubRip cue, e.g. '00:01:02,500 --> 00:01:04,000 X1:40 X2:600 Y1:20 Y2:50'
+	subRipTiming = regexp.MustCompile(
+		`^\s*(\d+):(\d{1,2}):(\d{1,2})[,.](\d{1,3})\s*-->\s*(\d+):(\d{1,2}):(\d{1,2})[,.](\d{1,3})`,
+	)
+	// subRipNumber - the number line which starts the SubRip cue
+	subRipNumber = regexp.MustCompile(`^\s*\d+\s*$`)
+)
+
+// subRipState - is a position into the SubRip cue, only the line which follows the cue number (or starts the cue
+// without it) is the timing one, so the text which looks like a timing is not converted.
+type subRipState int
+
+const (
+	subRipCueStart   subRipState = iota // after the blank line, the number or the timing is expected
+	subRipTimingLine                    // after the number, the timing is expected
+	subRipText                          // after the timing till the blank line
+)
+
+// SubRipReader converts the SubRip subtitles to the WebVTT line by line while reading, so the file is not loaded
+// into the memory. The cue numbers are kept as the cue identifiers, the timings are written in the WebVTT format
+// and the SubRip coordinates are dropped.
+type SubRipReader struct {
+	reader  *bufio.Reader
+	buffer  bytes.Buffer
+	started bool
+	state   subRipState
+	err     error
+}
+
+func NewSubRipReader(reader *bufio.Reader) *SubRipReader {
+	return &SubRipReader{reader: reader}
+}
+
+func (r *SubRipReader) Read(p []byte) (n int, err error) {
+	for r.buffer.Len() == 0 && r.err == nil {
+		r.next()
+	}
+	if r.buffer.Len() > 0 {
+		return r.buffer.Read(p)
+	}
+	return 0, r.err
+}
+
+// next - converts the next line into the buffer, the WebVTT header is written first.
+func (r *SubRipReader) next() {
+	line, err := r.reader.ReadString('\n')
+	if !r.started {
+		r.started = true
+		r.buffer.WriteString(webVTTSignature + "\n\n")
+		line = strings.TrimPrefix(line, string(utf8BOM))
+	}
+	if line != "" {
+		r.buffer.WriteString(r.convert(strings.TrimRight(line, "\r\n")) + "\n")
+	}
+	r.err = err
+}
+
+// convert - converts the line by the position into the cue and moves to the next one.
+func (r *SubRipReader) convert(line string) string {
+	if strings.TrimSpace(line) == "" {
+		r.state = subRipCueStart
+		return ""
+	}
+
+	switch r.state {
+	case subRipCueStart:
+		if subRipNumber.MatchString(line) {
+			r.state = subRipTimingLine
+			return strings.TrimSpace(line)
+		}
+		fallthrough
+	case subRipTimingLine:
+		r.state = subRipText
+		if m := subRipTiming.FindStringSubmatch(line); m != nil {
+			return webVTTTimestamp(m[1:5]) + " --> " + webVTTTimestamp(m[5:9])
+		}
+	}
+
+	// the arrow is not allowed in the WebVTT cue text
+	return strings.ReplaceAll(line, "-->", "->")
+}
+
+// webVTTTimestamp - formats the hours, minutes, seconds and fraction of SubRip timestamp in WebVTT format,
+// the fraction is a part of second, so '5' means 500 milliseconds.
+func webVTTTimestamp(parts []string) string {
+	hours, _ := strconv.Atoi(parts[0])
+	minutes, _ := strconv.Atoi(parts[1])
+	seconds, _ := strconv.Atoi(parts[2])
+	fraction := parts[3] + strings.Repeat("0", 3-len(parts[3]))
+	return fmt.Sprintf("%02d:%02d:%02d.%s", hours, minutes, seconds, fraction)
+}
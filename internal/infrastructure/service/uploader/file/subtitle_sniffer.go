@@ -0,0 +1,66 @@
+package file
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"io"
+	"regexp"
+)
+
+const (
+	WebVTTFiletype = "text/vtt"
+	WebVTTExt      = ".vtt"
+	// webVTTSignature - the WebVTT file starts with the signature followed by a whitespace or the end of line
+	webVTTSignature = "WEBVTT"
+)
+
+var (
+	utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+	// subRipCue - the SubRip file starts with the cue number followed by the timing line
+	subRipCue = regexp.MustCompile(`^\s*\d+[ \t]*\r?\n[ \t]*\d+:\d{1,2}:\d{1,2}[,.]\d{1,3}[ \t]*-->`)
+)
+
+// SubtitleSnifferService detects the subtitle format by the content. The SubRip subtitles are converted to the
+// WebVTT while reading, so the stored subtitles are always the WebVTT ones and the players need no conversion.
+type SubtitleSnifferService struct {
+}
+
+func NewSubtitleSnifferService() *SubtitleSnifferService {
+	return &SubtitleSnifferService{}
+}
+
+// Sniff - detects the subtitle format of the content, the content which is not a subtitle is rejected.
+func (s *SubtitleSnifferService) Sniff(name string, reader io.Reader) (filetype string, content io.Reader, err error) {
+	buffered := bufio.NewReaderSize(reader, sniffLen)
+
+	head, err := buffered.Peek(sniffLen)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", nil, err
+	}
+	head = bytes.TrimPrefix(head, utf8BOM)
+
+	if isWebVTT(head) {
+		return WebVTTFiletype, buffered, nil
+	}
+	if subRipCue.Match(head) {
+		return WebVTTFiletype, NewSubRipReader(buffered), nil
+	}
+
+	return "", nil, errtype.NewUnsupportedSubtitleContentError(name)
+}
+
+func isWebVTT(head []byte) bool {
+	if !bytes.HasPrefix(head, []byte(webVTTSignature)) {
+		return false
+	}
+	if len(head) == len(webVTTSignature) {
+		return true
+	}
+	switch head[len(webVTTSignature)] {
+	case ' ', '\t', '\r', '\n':
+		return true
+	}
+	return false
+}
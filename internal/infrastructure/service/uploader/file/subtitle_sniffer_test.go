@@ -0,0 +1,147 @@
+package file
+
+import (
+	"bytes"
+	"errors"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestSubtitleSnifferService_Sniff(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string // empty means the content is rejected
+	}{
+		{
+			name:     "webvtt",
+			content:  "WEBVTT\n\n00:00:01.000 --> 00:00:02.000\nHello\n",
+			expected: "WEBVTT\n\n00:00:01.000 --> 00:00:02.000\nHello\n",
+		},
+		{
+			name:     "webvtt with header text",
+			content:  "WEBVTT - Movie\n\n00:00:01.000 --> 00:00:02.000\nHello\n",
+			expected: "WEBVTT - Movie\n\n00:00:01.000 --> 00:00:02.000\nHello\n",
+		},
+		{
+			name:     "webvtt signature only",
+			content:  "WEBVTT",
+			expected: "WEBVTT",
+		},
+		{
+			name:    "signature followed by other chars",
+			content: "WEBVTTX\n\n00:00:01.000 --> 00:00:02.000\nHello\n",
+		},
+		{
+			name:     "subrip",
+			content:  "1\n00:00:01,000 --> 00:00:02,000\nHello\n\n2\n00:00:03,000 --> 00:00:04,000\nWorld\n",
+			expected: "WEBVTT\n\n1\n00:00:01.000 --> 00:00:02.000\nHello\n\n2\n00:00:03.000 --> 00:00:04.000\nWorld\n",
+		},
+		{
+			name:     "subrip with bom and crlf",
+			content:  "\xEF\xBB\xBF1\r\n00:00:01,000 --> 00:00:02,000\r\nHello\r\n\r\n2\r\n00:00:03,000 --> 00:00:04,000\r\nWorld\r\n",
+			expected: "WEBVTT\n\n1\n00:00:01.000 --> 00:00:02.000\nHello\n\n2\n00:00:03.000 --> 00:00:04.000\nWorld\n",
+		},
+		{
+			name:     "subrip short fraction is padded",
+			content:  "1\n0:0:1,5 --> 0:00:02,25\nHello\n",
+			expected: "WEBVTT\n\n1\n00:00:01.500 --> 00:00:02.250\nHello\n",
+		},
+		{
+			name:     "subrip coordinates are dropped",
+			content:  "1\n00:00:01,000 --> 00:00:02,000 X1:40 X2:600 Y1:20 Y2:50\nHello\n",
+			expected: "WEBVTT\n\n1\n00:00:01.000 --> 00:00:02.000\nHello\n",
+		},
+		{
+			name:     "subrip arrow in text",
+			content:  "1\n00:00:01,000 --> 00:00:02,000\nHe said --> go\n",
+			expected: "WEBVTT\n\n1\n00:00:01.000 --> 00:00:02.000\nHe said -> go\n",
+		},
+		{
+			name:     "subrip timing in text is not converted",
+			content:  "1\n00:00:01,000 --> 00:00:02,000\n00:00:05,000 --> 00:00:06,000\n12\n",
+			expected: "WEBVTT\n\n1\n00:00:01.000 --> 00:00:02.000\n00:00:05,000 -> 00:00:06,000\n12\n",
+		},
+		{
+			name:     "subrip without the last line break",
+			content:  "1\n00:00:01,000 --> 00:00:02,000\nHello",
+			expected: "WEBVTT\n\n1\n00:00:01.000 --> 00:00:02.000\nHello\n",
+		},
+		{
+			name:    "text",
+			content: "just a text\n",
+		},
+		{
+			name:    "empty",
+			content: "",
+		},
+	}
+	for _, test := range tests {
+		for _, reader := range []struct {
+			name string
+			wrap func(io.Reader) io.Reader
+		}{
+			{name: "whole", wrap: func(r io.Reader) io.Reader { return r }},
+			// the lines are split between the reads of the source and of the converted content
+			{name: "by bytes", wrap: iotest.OneByteReader},
+		} {
+			t.Run(test.name+" "+reader.name, func(t *testing.T) {
+				filetype, content, err := NewSubtitleSnifferService().Sniff("subtitles", strings.NewReader(test.content))
+				if test.expected == "" {
+					unsupported := &errtype.UnsupportedSubtitleContentError{}
+					if !errors.As(err, &unsupported) {
+						t.Fatalf("expected the content is rejected, got '%v' and %v", filetype, err)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if filetype != WebVTTFiletype {
+					t.Errorf("expected '%v', got '%v'", WebVTTFiletype, filetype)
+				}
+				converted, err := io.ReadAll(reader.wrap(content))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(converted, []byte(test.expected)) {
+					t.Errorf("expected %q, got %q", test.expected, converted)
+				}
+			})
+		}
+	}
+}
+
+func TestSubRipReader_ChunkBoundaryMidLine(t *testing.T) {
+	content := "1\n00:00:01,000 --> 00:00:02,000\nHello\n"
+	// the source returns the content by bytes, so each line is received by many reads
+	filetype, converted, err := NewSubtitleSnifferService().Sniff("subtitles", iotest.OneByteReader(strings.NewReader(content)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filetype != WebVTTFiletype {
+		t.Fatalf("expected '%v', got '%v'", WebVTTFiletype, filetype)
+	}
+
+	var written []byte
+	p := make([]byte, 7)
+	for {
+		n, rerr := converted.Read(p)
+		written = append(written, p[:n]...)
+		if errors.Is(rerr, io.EOF) {
+			break
+		}
+		if rerr != nil {
+			t.Fatal(rerr)
+		}
+	}
+
+	expected := "WEBVTT\n\n1\n00:00:01.000 --> 00:00:02.000\nHello\n"
+	if string(written) != expected {
+		t.Errorf("expected %q, got %q", expected, written)
+	}
+}
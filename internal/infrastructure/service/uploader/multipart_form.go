@@ -1,15 +1,22 @@
 package uploader
 
 import (
+	"errors"
 	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/errtype"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file"
 	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
+	"net/http"
+	"path"
 )
 
 const MultipartFormUploadingType = "multipart_form"
 
+// formOverhead - the max. size of the form without the file (fields, boundaries and part headers)
+const formOverhead = 1 << 20
+
 // MultipartFormUploader is a service which represents functionality
 // for uploader a full file from *http.Request into fileStorage.
 // This approach of uploading takes a much more RAM but works more fast than MultipartPartUploader.
@@ -17,11 +24,13 @@ const MultipartFormUploadingType = "multipart_form"
 // the appropriate value of 'inMemoryFileSizeThreshold' through env. configuration.
 type MultipartFormUploader struct {
 	logger                    loggerinterface.Logger
-	fileStorage               fileinterface.Storage
-	fileNameComputer          fileinterface.NameComputer
+	blobs                     fileinterface.BlobStorage
+	sniffer                   fileinterface.Sniffer
 	formFilename              string
 	maxFilesize               int64
 	inMemoryFileSizeThreshold int64
+	// ext is an extension of the stored files, the extension of uploaded file is kept if it's empty
+	ext string
 }
 
 func NewNativeUploader(serviceContainer diinterface.ServiceContainer) (*MultipartFormUploader, error) {
@@ -30,12 +39,12 @@ func NewNativeUploader(serviceContainer diinterface.ServiceContainer) (*Multipar
 		return nil, err
 	}
 
-	storageService, err := serviceContainer.GetFileStorageService()
+	blobStorageService, err := serviceContainer.GetBlobStorageService()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
 	}
 
-	fileNameComputer, err := serviceContainer.GetFileNameComputerService()
+	snifferService, err := serviceContainer.GetFileSnifferService()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
 	}
@@ -47,18 +56,56 @@ func NewNativeUploader(serviceContainer diinterface.ServiceContainer) (*Multipar
 
 	return &MultipartFormUploader{
 		logger:                    loggerService,
-		fileStorage:               storageService,
-		fileNameComputer:          fileNameComputer,
+		blobs:                     blobStorageService,
+		sniffer:                   snifferService,
 		formFilename:              config.ResourceFormFilename,
+		maxFilesize:               config.ResourceMaxFilesizeThreshold,
 		inMemoryFileSizeThreshold: config.ResourceInMemoryFileSizeThreshold,
 	}, nil
 }
 
+// NewSubtitleUploader - makes the uploader which accepts the subtitles only, the SubRip ones are converted
+// to the WebVTT while storing.
+func NewSubtitleUploader(serviceContainer diinterface.ServiceContainer) (*MultipartFormUploader, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	blobStorageService, err := serviceContainer.GetBlobStorageService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	config, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &MultipartFormUploader{
+		logger:                    loggerService,
+		blobs:                     blobStorageService,
+		sniffer:                   file.NewSubtitleSnifferService(),
+		formFilename:              config.SubtitleFormFilename,
+		maxFilesize:               config.SubtitleMaxFilesizeThreshold,
+		inMemoryFileSizeThreshold: config.SubtitleMaxFilesizeThreshold,
+		ext:                       file.WebVTTExt,
+	}, nil
+}
+
 // Upload method will be store a file on the disk and calculate a new hashed name. Request DTO mutation!
 func (u *MultipartFormUploader) Upload(reqDTO dtointerface.UploadResourceRequest) (err error) {
+	// the form is read before the file is stored, so the body must be limited while parsing
+	// (the form fields and boundaries are allowed to take the overhead over the file)
+	reqDTO.GetRequest().Body = http.MaxBytesReader(nil, reqDTO.GetRequest().Body, u.maxFilesize+formOverhead)
+
 	// request will be parsed and stored in the memory if it is under the RAM threshold,
 	// otherwise last parts of parsed file will be stored in the tmp files on the disk space
 	if err = reqDTO.GetRequest().ParseMultipartForm(u.inMemoryFileSizeThreshold); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return u.logger.LogPropagate(errtype.NewFilesizeThresholdExceededError(u.maxFilesize))
+		}
 		return u.logger.LogPropagate(err)
 	}
 
@@ -70,25 +117,19 @@ func (u *MultipartFormUploader) Upload(reqDTO dtointerface.UploadResourceRequest
 	}
 	defer func() { _ = formFile.Close() }()
 
-	// TODO must be added filesize for check uniqueness
-	filename, err := u.fileNameComputer.Get(
-		reqDTO.GetUserID(),
-		header.Filename,
-		header.Header.Get("Content-Type"),
-		header.Header.Get("Content-Disposition"),
-	)
-
-	// checking whether the being uploaded resource already exists
-	has, err := u.fileStorage.Has(reqDTO.GetUserID(), filename)
+	// the filesize is checked while storing and the filetype is detected by the content, not by the header
+	filetype, content, err := u.sniffer.Sniff(header.Filename, file.NewLimitedReader(formFile, u.maxFilesize))
 	if err != nil {
 		return u.logger.LogPropagate(err)
 	}
-	if has { // if being uploading resource is already exists, then throw an error
-		return u.logger.LogPropagate(errtype.NewResourceAlreadyExistsError(header.Filename))
+
+	ext := u.ext
+	if ext == "" {
+		ext = path.Ext(header.Filename)
 	}
 
-	// saving a file on disk and calculating new hashed name with full qualified path
-	length, filepath, err := u.fileStorage.Store(reqDTO.GetUserID(), filename, formFile)
+	// saving a file on disk under the name computed from its content, the identical file is stored once
+	filename, length, filepath, err := u.blobs.Store(reqDTO.GetUserID(), ext, content)
 	if err != nil {
 		return u.logger.LogPropagate(err)
 	}
@@ -98,7 +139,7 @@ func (u *MultipartFormUploader) Upload(reqDTO dtointerface.UploadResourceRequest
 	reqDTO.SetUploadedFilename(filename)
 	reqDTO.SetUploadedFilepath(filepath)
 	reqDTO.SetUploadedFilesize(length)
-	reqDTO.SetUploadedFiletype(header.Header.Get("Content-Type"))
+	reqDTO.SetUploadedFiletype(filetype)
 
 	return nil
 }
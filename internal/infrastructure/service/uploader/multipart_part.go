@@ -5,10 +5,11 @@ import (
 	"github.com/Borislavv/video-streaming/internal/domain/errtype"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
-	storagerinterface "github.com/Borislavv/video-streaming/internal/domain/service/storager/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file"
 	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
 	"io"
 	"mime/multipart"
+	"path"
 )
 
 const MultipartPartUploadingType = "multipart_part"
@@ -18,8 +19,8 @@ const MultipartPartUploadingType = "multipart_part"
 // Approximately, to upload a 50MB file you will need only 10MB of RAM.
 type MultipartPartUploader struct {
 	logger      loggerinterface.Logger
-	storage     storagerinterface.Storage
-	filename    fileinterface.NameComputer
+	blobs       fileinterface.BlobStorage
+	sniffer     fileinterface.Sniffer
 	maxFilesize int64
 }
 
@@ -29,20 +30,26 @@ func NewPartsUploader(serviceContainer diinterface.ServiceContainer) (*Multipart
 		return nil, err
 	}
 
-	storageService, err := serviceContainer.GetFileStorageService()
+	blobStorageService, err := serviceContainer.GetBlobStorageService()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
 	}
 
-	filenameService, err := serviceContainer.GetFileNameComputerService()
+	snifferService, err := serviceContainer.GetFileSnifferService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	config, err := serviceContainer.GetConfig()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
 	}
 
 	return &MultipartPartUploader{
-		logger:   loggerService,
-		storage:  storageService,
-		filename: filenameService,
+		logger:      loggerService,
+		blobs:       blobStorageService,
+		sniffer:     snifferService,
+		maxFilesize: config.ResourceMaxFilesizeThreshold,
 	}, nil
 }
 
@@ -52,25 +59,14 @@ func (u *MultipartPartUploader) Upload(reqDTO dtointerface.UploadResourceRequest
 		return u.logger.LogPropagate(err)
 	}
 
-	// TODO must be added filesize for check uniqueness
-	filename, err := u.filename.Get(
-		reqDTO.GetUserID(),
-		part.FileName(),
-		part.Header.Get("Content-Type"),
-		part.Header.Get("Content-Disposition"),
-	)
-
-	// checking whether the being uploaded resource already exists
-	has, err := u.storage.Has(reqDTO.GetUserID(), filename)
+	// the filesize is checked while streaming and the filetype is detected by the content, not by the header
+	filetype, content, err := u.sniffer.Sniff(part.FileName(), file.NewLimitedReader(part, u.maxFilesize))
 	if err != nil {
 		return u.logger.LogPropagate(err)
 	}
-	if has { // if being uploading resource is already exists, then throw an error
-		return u.logger.LogPropagate(errtype.NewResourceAlreadyExistsError(part.FileName()))
-	}
 
-	// saving a file on disk and calculating new hashed name with full qualified path
-	length, filepath, err := u.storage.Store(reqDTO.GetUserID(), filename, part)
+	// saving a file on disk under the name computed from its content, the identical file is stored once
+	filename, length, filepath, err := u.blobs.Store(reqDTO.GetUserID(), path.Ext(part.FileName()), content)
 	if err != nil {
 		return u.logger.LogPropagate(err)
 	}
@@ -80,7 +76,7 @@ func (u *MultipartPartUploader) Upload(reqDTO dtointerface.UploadResourceRequest
 	reqDTO.SetUploadedFilename(filename)
 	reqDTO.SetUploadedFilepath(filepath)
 	reqDTO.SetUploadedFilesize(length)
-	reqDTO.SetUploadedFiletype(part.Header.Get("Content-Type"))
+	reqDTO.SetUploadedFiletype(filetype)
 
 	return nil
 }
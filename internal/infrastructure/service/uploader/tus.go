@@ -0,0 +1,344 @@
+package uploader
+
+import (
+	"context"
+	"errors"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	extractorinterface "github.com/Borislavv/video-streaming/internal/domain/service/extractor/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/mongodb"
+	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"io"
+	"sync"
+	"time"
+)
+
+const TusUploadingType = "tus"
+
+const (
+	// uploadIDParameter - is the route parameter of the upload which completion is handled by the Upload method
+	uploadIDParameter = "id"
+	// expiredUploadsRemovingInterval - how often the expired uploads are looked for
+	expiredUploadsRemovingInterval = time.Minute
+)
+
+// TusUploader is a service which represents functionality of the resumable uploading by the tus protocol.
+// The file is appended by chunks into the fileStorage and the state of the upload is saved into the database,
+// so the uploading may be continued from the received offset, even after the server restart.
+type TusUploader struct {
+	ctx              context.Context
+	logger           loggerinterface.Logger
+	fileStorage      fileinterface.Storage
+	blobs            fileinterface.BlobStorage
+	fileNameComputer fileinterface.NameComputer
+	sniffer          fileinterface.Sniffer
+	repository       repositoryinterface.Upload
+	extractor        extractorinterface.RequestParams
+	expiration       time.Duration
+	// locks - the uploads which are receiving a chunk at now
+	locks *sync.Map
+}
+
+func NewTusUploader(serviceContainer diinterface.ServiceContainer) (*TusUploader, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	storageService, err := serviceContainer.GetFileStorageService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	blobStorageService, err := serviceContainer.GetBlobStorageService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	fileNameComputer, err := serviceContainer.GetFileNameComputerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	snifferService, err := serviceContainer.GetFileSnifferService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	uploadRepository, err := serviceContainer.GetUploadRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	requestParametersExtractor, err := serviceContainer.GetRequestParametersExtractorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	config, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	expiration, err := time.ParseDuration(config.ResourceUploadExpiration)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &TusUploader{
+		ctx:              ctx,
+		logger:           loggerService,
+		fileStorage:      storageService,
+		blobs:            blobStorageService,
+		fileNameComputer: fileNameComputer,
+		sniffer:          snifferService,
+		repository:       uploadRepository,
+		extractor:        requestParametersExtractor,
+		expiration:       expiration,
+		locks:            &sync.Map{},
+	}, nil
+}
+
+// Create - registers a new upload, the file will be created by the first chunk.
+func (u *TusUploader) Create(req dtointerface.CreateUploadRequest) (*agg.Upload, error) {
+	// the upload id is a part of the filename, so the same file may be uploaded twice in parallel
+	id := vo.NewID(primitive.NewObjectID())
+
+	filename, err := u.fileNameComputer.Get(req.GetUserID(), req.GetName(), req.GetFiletype(), id.Hex())
+	if err != nil {
+		return nil, u.logger.LogPropagate(err)
+	}
+
+	upload, err := u.repository.Insert(u.ctx, &agg.Upload{
+		Upload: entity.Upload{
+			ID:        id,
+			UserID:    req.GetUserID(),
+			Name:      req.GetName(),
+			Filename:  filename,
+			Filetype:  req.GetFiletype(),
+			Length:    req.GetLength(),
+			ExpiresAt: time.Now().Add(u.expiration),
+		},
+		Timestamp: vo.Timestamp{
+			CreatedAt: time.Now(),
+		},
+	})
+	if err != nil {
+		return nil, u.logger.LogPropagate(err)
+	}
+
+	return upload, nil
+}
+
+// Find - fetches the upload, the expired one is considered as not found even if it's not removed yet.
+func (u *TusUploader) Find(req dtointerface.GetUploadRequest) (*agg.Upload, error) {
+	upload, err := u.repository.FindOneByID(u.ctx, req)
+	if err != nil {
+		return nil, u.logger.LogPropagate(err)
+	}
+
+	if !upload.IsCompleted() && upload.ExpiresAt.Before(time.Now()) {
+		return nil, u.logger.LogPropagate(mongodb.UploadNotFoundByIdError)
+	}
+
+	return upload, nil
+}
+
+// Append - writes the chunk at the upload offset. The chunk which goes out of the declared length is rejected.
+func (u *TusUploader) Append(req dtointerface.PatchUploadRequest) (*agg.Upload, error) {
+	// parallel chunks of the same upload are rejected, the client must wait for the previous one
+	if _, locked := u.locks.LoadOrStore(req.GetID().Value.Hex(), struct{}{}); locked {
+		return nil, u.logger.LogPropagate(errtype.NewUploadIsLockedError())
+	}
+	defer u.locks.Delete(req.GetID().Value.Hex())
+
+	upload, err := u.Find(req)
+	if err != nil {
+		return nil, u.logger.LogPropagate(err)
+	}
+
+	if req.GetOffset() != upload.Offset {
+		return nil, u.logger.LogPropagate(errtype.NewUploadOffsetMismatchError(upload.Offset, req.GetOffset()))
+	}
+	if contentLength := req.GetRequest().ContentLength; contentLength > upload.Length-upload.Offset {
+		return nil, u.logger.LogPropagate(errtype.NewUploadLengthExceededError(upload.Offset+contentLength, upload.Length))
+	}
+	if upload.IsCompleted() {
+		return upload, nil
+	}
+
+	content := io.LimitReader(req.GetBody(), upload.Length-upload.Offset)
+
+	// the filetype declared by the metadata is replaced by the detected one from the first chunk
+	if upload.Offset == 0 {
+		if upload.Filetype, content, err = u.sniffer.Sniff(upload.Name, content); err != nil {
+			return nil, u.logger.LogPropagate(err)
+		}
+	}
+
+	written, filepath, err := u.fileStorage.Append(upload.UserID, upload.Filename, upload.Offset, content)
+	if written > 0 { // the received part is saved even if the chunk was interrupted
+		upload.Offset += written
+		upload.Filepath = filepath
+		upload.ExpiresAt = time.Now().Add(u.expiration)
+
+		if upload, err = u.repository.Update(u.ctx, upload); err != nil {
+			return nil, u.logger.LogPropagate(err)
+		}
+	}
+	if err != nil {
+		return nil, u.logger.LogPropagate(err)
+	}
+
+	return upload, nil
+}
+
+// Upload method will be take the completed upload by the request route and describe its file. Request DTO mutation!
+func (u *TusUploader) Upload(reqDTO dtointerface.UploadResourceRequest) error {
+	hexID, err := u.extractor.GetParameter(uploadIDParameter, reqDTO.GetRequest())
+	if err != nil {
+		return u.logger.LogPropagate(err)
+	}
+	oID, err := primitive.ObjectIDFromHex(hexID)
+	if err != nil {
+		return u.logger.LogPropagate(err)
+	}
+
+	upload, err := u.Find(dto.NewUploadGetRequestDTO(vo.NewID(oID), reqDTO.GetUserID()))
+	if err != nil {
+		return u.logger.LogPropagate(err)
+	}
+
+	if !upload.IsCompleted() {
+		return u.logger.LogPropagate(errtype.NewUploadIsNotCompletedError(upload.Offset, upload.Length))
+	}
+	if !upload.ResourceID.Value.IsZero() {
+		return u.logger.LogPropagate(errtype.NewResourceAlreadyExistsError(upload.Name))
+	}
+
+	// the received file is moved under the name computed from its content once (the identical file is stored once),
+	// the upload refers to the moved file and holds its reference, so the failed resource creation may be retried
+	if !upload.Adopted {
+		if upload.Filename, upload.Filepath, err = u.blobs.Adopt(upload.UserID, upload.Filename); err != nil {
+			return u.logger.LogPropagate(err)
+		}
+		upload.Adopted = true
+
+		if upload, err = u.repository.Update(u.ctx, upload); err != nil {
+			return u.logger.LogPropagate(err)
+		}
+	}
+
+	// the resource holds its own reference, it's released if the resource creation fails
+	if err = u.blobs.Retain(upload.UserID, upload.Filename); err != nil {
+		return u.logger.LogPropagate(err)
+	}
+
+	// mutate request reqDTO
+	reqDTO.SetOriginFilename(upload.Name)
+	reqDTO.SetUploadedFilename(upload.Filename)
+	reqDTO.SetUploadedFilepath(upload.Filepath)
+	reqDTO.SetUploadedFilesize(upload.Length)
+	reqDTO.SetUploadedFiletype(upload.Filetype)
+
+	return nil
+}
+
+// Complete - links the upload with the created resource, so the file will not be removed on expiration.
+// The reference of the upload is dropped after that, the file is kept by the resource one.
+func (u *TusUploader) Complete(upload *agg.Upload, resource *agg.Resource) (*agg.Upload, error) {
+	upload.ResourceID = resource.ID
+
+	upload, err := u.repository.Update(u.ctx, upload)
+	if err != nil {
+		return nil, u.logger.LogPropagate(err)
+	}
+
+	if upload.Adopted {
+		if err = u.blobs.Release(upload.UserID, upload.Filename); err != nil {
+			u.logger.Log(err)
+		}
+	}
+
+	return upload, nil
+}
+
+// Terminate - removes the upload, the file is removed too if it's not owned by the resource.
+func (u *TusUploader) Terminate(req dtointerface.DeleteUploadRequest) error {
+	upload, err := u.repository.FindOneByID(u.ctx, req)
+	if err != nil {
+		return u.logger.LogPropagate(err)
+	}
+
+	return u.remove(upload)
+}
+
+// Run - starts removing of the expired uploads until the app. context is done.
+func (u *TusUploader) Run(wg *sync.WaitGroup) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(expiredUploadsRemovingInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-u.ctx.Done():
+				return
+			case <-ticker.C:
+				u.removeExpired()
+			}
+		}
+	}()
+}
+
+func (u *TusUploader) removeExpired() {
+	uploads, err := u.repository.FindExpired(u.ctx, time.Now())
+	if err != nil {
+		u.logger.Log(err)
+		return
+	}
+
+	for _, upload := range uploads {
+		// the upload which is receiving a chunk at now is prolonged by it
+		if _, locked := u.locks.Load(upload.ID.Hex()); locked {
+			continue
+		}
+		if err = u.remove(upload); err != nil && !errors.Is(err, mongodb.UploadWasNotDeletedError) {
+			u.logger.Log(err)
+		}
+	}
+}
+
+func (u *TusUploader) remove(upload *agg.Upload) error {
+	if upload.ResourceID.Value.IsZero() {
+		// the adopted file may be shared with the other resources of the same content
+		remove := u.fileStorage.Remove
+		if upload.Adopted {
+			remove = u.blobs.Release
+		}
+		if err := remove(upload.UserID, upload.Filename); err != nil {
+			return u.logger.LogPropagate(err)
+		}
+	}
+
+	if err := u.repository.Remove(u.ctx, upload); err != nil {
+		return u.logger.LogPropagate(err)
+	}
+
+	return nil
+}
@@ -71,7 +71,8 @@ function handleResponse(data) {
         showErrorMessage(data.error.message || "A server error occurred");
         setTimeout(clearErrorMessage, 5000); // Автоматическое скрытие сообщения об ошибке через 5 секунд
     } else {
-        document.cookie = `x-access-token=`+data.data;
+        document.cookie = `x-access-token=`+data.data.accessToken;
+        document.cookie = `x-refresh-token=`+data.data.refreshToken;
         window.location.replace("/");
     }
 }
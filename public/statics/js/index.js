@@ -82,7 +82,15 @@ function renderList(data) {
         data.list.forEach(video => {
             const listItem = document.createElement('li');
             listItem.className = 'list-item';
-            listItem.textContent = video.name;
+            // the poster is made when the uploaded video is processed, the cookie authorizes the image request
+            if (video.posterURL) {
+                const poster = document.createElement('img');
+                poster.className = 'poster';
+                poster.src = video.posterURL;
+                poster.alt = '';
+                listItem.appendChild(poster);
+            }
+            listItem.appendChild(document.createTextNode(video.name));
             listItem.id = video.id.value
             videoList.appendChild(listItem);
         });
@@ -1,8 +1,15 @@
-const websocket = new WebSocket('ws://0.0.0.0:9988/');
+// the protocol version is negotiated by the server, v0 is a legacy one
+const websocket = new WebSocket('ws://0.0.0.0:9988/', ['v1', 'v0']);
+
+// v1 frame header: version (1b), kind (1b), reserved (2b), stream id (4b), seq (4b), timestamp ms (8b)
+const FRAME_HEADER_SIZE  = 20;
+const FRAME_KIND_CONTROL = 0;
 
 const videoPlayer = document.getElementById('videoPlayer');
 const nextBtn = document.getElementById('next-btn');
 const prevBtn = document.getElementById('prev-btn');
+const renditionSelect = document.getElementById('rendition-select');
+const audioSelect = document.getElementById('audio-select');
 
 websocket.binaryType = 'arraybuffer';
 
@@ -11,6 +18,7 @@ let mediaSource;
 let chunks;
 let mediaSourceReady;
 let token;
+let currentStreamID;
 
 // ws event: open
 websocket.onopen = (event) => {
@@ -36,6 +44,11 @@ websocket.onmessage = (event) => {
 
     console.log("Some data received...", data)
 
+    if (websocket.protocol === 'v1') {
+        handleFrame(data)
+        return;
+    }
+
     if (typeof data === 'string' && (
         data.startsWith('start') ||
         data.startsWith('error') ||
@@ -43,18 +56,21 @@ websocket.onmessage = (event) => {
     )) {
         console.log('Data is action: ' + data)
 
+        let dataParts = data.split('::')
         if (data.startsWith('start')) {
-            console.log("Starting new video...")
-            let dataParts = data.split('::')
-            console.log(dataParts)
-            makeMediaResource(dataParts[1], dataParts[2])
+            handleControl({
+                type: 'start',
+                audioCodec: dataParts[1],
+                videoCodec: dataParts[2],
+                mimeType: dataParts[3],
+                contentType: dataParts[4],
+                // the subtitles are the optional json array, its labels may contain the separator
+                subtitles: dataParts.length > 5 ? JSON.parse(dataParts.slice(5).join('::')) : [],
+            })
         } else if (data.startsWith('error')) {
-            let dataParts = data.split('::')
-            console.log("Server error occurred: " + dataParts[1])
-            showAlert(dataParts[1])
+            handleControl({ type: 'error', error: JSON.parse(dataParts[1]) })
         } else if (data === 'stop') {
-            console.log("Stopping playing...")
-            closeMediaResource()
+            handleControl({ type: 'stop' })
         }
 
         return;
@@ -67,20 +83,92 @@ websocket.onmessage = (event) => {
     }
 };
 
+// handles the v1 frame (see the header layout above)
+function handleFrame(data) {
+    const header   = new DataView(data, 0, FRAME_HEADER_SIZE)
+    const kind     = header.getUint8(1)
+    const streamID = header.getUint32(4)
+    const payload  = data.slice(FRAME_HEADER_SIZE)
+
+    if (kind === FRAME_KIND_CONTROL) {
+        const message = JSON.parse(new TextDecoder().decode(payload))
+        if (message.type === 'start') {
+            currentStreamID = streamID
+        }
+        handleControl(message)
+        return
+    }
+
+    // the frames of replaced streams are skipped
+    if (streamID !== currentStreamID) {
+        console.log('Skipping the frame of stream', streamID)
+        return
+    }
+
+    console.log('Data is chunk (seq: ' + header.getUint32(8) + '), adding to buffer...')
+    chunks.push(payload)
+    addNextChunk()
+}
+
+function handleControl(message) {
+    console.log('Control message: ', message)
+
+    switch (message.type) {
+        case 'start':
+            console.log("Starting new video...")
+            makeMediaResource(message)
+            break
+        case 'error':
+            console.log("Server error occurred: ", message.error)
+            showAlert(message.error.message)
+            break
+        case 'stop':
+            console.log("Stopping playing...")
+            closeMediaResource()
+            break
+    }
+}
+
 videoPlayer.addEventListener('seeking', function (event) {
-    console.log("---> REQUEST FROM: ", event.currentTarget.currentTime, event.currentTarget.duration)
+    const from = event.currentTarget.currentTime
+    console.log("---> REQUEST FROM: ", from, event.currentTarget.duration)
+
+    // the position is already buffered, nothing to request
+    for (let i = 0; i < videoPlayer.buffered.length; i++) {
+        if (videoPlayer.buffered.start(i) <= from && from < videoPlayer.buffered.end(i)) {
+            return
+        }
+    }
 
-    // socket.send("ID:"+currentVideoID+":FROM:"+event.currentTarget.currentTime+":TO:"+event.currentTarget.duration)
+    sendAction('SEEK', { from: from })
 });
 
-// todo need to make a ticker which will count the awaiting time if it's more than N then send decrease buffer action
 videoPlayer.addEventListener('waiting', function() {
     console.warn('Video playback is waiting for data (buffering)');
 
-    // requesting decrease the buffer
-    // socket.send("decrBuff") // not implemented yet
+    // the server holds the stream while it's too far ahead, so tell it immediately that the buffer is drained
+    reportBufferedAhead()
 });
 
+// flow control: the server keeps at most N seconds ahead of the playback position
+setInterval(reportBufferedAhead, 1000)
+
+function reportBufferedAhead() {
+    if (websocket.readyState !== WebSocket.OPEN || !mediaSourceReady) {
+        return
+    }
+
+    let ahead = 0
+    const position = videoPlayer.currentTime
+    for (let i = 0; i < videoPlayer.buffered.length; i++) {
+        if (videoPlayer.buffered.start(i) <= position && position < videoPlayer.buffered.end(i)) {
+            ahead = videoPlayer.buffered.end(i) - position
+        }
+    }
+
+    sendAction('BUFFER', { ahead: ahead })
+}
+
 let currentVideoID = ''
 // initialization function
 function waitForVideoListWillBeRendered(selector, callback) {
@@ -191,11 +279,32 @@ document.addEventListener('click', function (event) {
     }
 });
 
+// rendition hint: the preferred height, zero means the server selects the rendition by the measured bandwidth
+renditionSelect.addEventListener('change', function () {
+    sendAction('RENDITION', { height: parseInt(renditionSelect.value, 10) })
+});
+
+// audio track: the server restarts the stream from the current position with the selected track
+audioSelect.addEventListener('change', function () {
+    sendAction('AUDIO', { audio: parseInt(audioSelect.value, 10), from: videoPlayer.currentTime })
+});
+
 function requestByID(strategy, id) {
-    let data = `${strategy}::{ "id": "${id}", "token": "${token}" }`
-    console.log("websocket request: " + data);
     console.log(id)
-    websocket.send(data)
+    sendAction(strategy, { id: id, token: token, flowControl: true })
+}
+
+// sends the action, for example the control one (PAUSE, RESUME, SEEK, STOP, SWITCH, ACK, BUFFER, RENDITION, AUDIO)
+// which affects the current stream
+function sendAction(action, data) {
+    let message
+    if (websocket.protocol === 'v1') {
+        message = JSON.stringify({ action: action, data: data })
+    } else {
+        message = data === undefined ? action : `${action}::${JSON.stringify(data)}`
+    }
+    console.log("websocket request: " + message);
+    websocket.send(message)
 }
 
 function addNextChunk() {
@@ -259,7 +368,59 @@ async function awaitingClose() {
     }
 }
 
-function makeMediaResource(audioCodec, videoCodec) {
+// contentType - returns the full MIME type with the RFC 6381 codecs, the old server sends the codecs only
+function contentType(message) {
+    if (message.contentType) {
+        return message.contentType
+    }
+
+    const codecs = [message.videoCodec, message.audioCodec].filter(codec => codec).join(', ')
+    if (codecs === '') {
+        console.error('Codecs string a empty! Unable to play video!')
+    }
+
+    // the audio stream has no video codec
+    const mime = message.mimeType || (message.videoCodec ? 'video/mp4' : 'audio/mp4')
+    return mime + '; codecs="' + codecs + '"'
+}
+
+// setTextTracks - replaces the subtitle tracks of the player by the tracks announced in the start message
+function setTextTracks(subtitles) {
+    videoPlayer.querySelectorAll('track').forEach(track => track.remove())
+
+    for (const subtitle of subtitles || []) {
+        const track = document.createElement('track')
+        track.kind = subtitle.kind
+        track.label = subtitle.label
+        track.srclang = subtitle.language
+        track.src = subtitle.url
+        track.default = !!subtitle.default
+        videoPlayer.appendChild(track)
+    }
+}
+
+// setAudioTracks - fills the audio track select by the tracks announced in the start message, the select
+// is hidden if the video has the single track
+function setAudioTracks(audioTracks) {
+    audioSelect.replaceChildren()
+    audioSelect.hidden = !audioTracks || audioTracks.length < 2
+    if (audioSelect.hidden) {
+        return
+    }
+
+    for (const audioTrack of audioTracks) {
+        const option = document.createElement('option')
+        option.value = audioTrack.index
+        option.textContent = audioTrack.language || 'Track ' + (audioTrack.index + 1)
+        option.selected = !!audioTrack.selected
+        audioSelect.appendChild(option)
+    }
+}
+
+function makeMediaResource(message) {
+    setTextTracks(message.subtitles)
+    setAudioTracks(message.audioTracks)
+
     mediaSource = new MediaSource();
     mediaSourceReady = false;
     videoPlayer.src = URL.createObjectURL(mediaSource);
@@ -273,25 +434,7 @@ function makeMediaResource(audioCodec, videoCodec) {
         console.log("MediaSource sourceopen event is open");
 
         try {
-            let codecsMap = []
-            codecsMap['avc1'] = 'avc1.42E01E'
-            codecsMap['mp4a'] = 'mp4a.40.2'
-
-            let codecsStr = ''
-            if (videoCodec !== '' && codecsMap[videoCodec]) {
-                codecsStr += codecsMap[videoCodec]
-            }
-            if (audioCodec !== '' && codecsMap[audioCodec]) {
-                if (videoCodec !== '') {
-                    codecsStr += ', '
-                }
-                codecsStr += codecsMap[audioCodec]
-            }
-            if (codecsStr === '') {
-                console.error('Codecs string a empty! Unable to play video!')
-            }
-
-            const codec = 'video/mp4; codecs="' + codecsStr +'"';
+            const codec = contentType(message)
 
             console.log("CODEC: ", codec)
 